@@ -0,0 +1,65 @@
+// Command enrich processes queued summarise and embed jobs outside the API server.
+//
+// By default it drains the due jobs once and exits, which suits a cron job. With -watch it
+// keeps polling like the server's in-process workers. When run as a Lambda function, e.g. on
+// an EventBridge schedule, each invocation drains the queue.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/server"
+	"github.com/talent-fit/backend/internal/services"
+)
+
+func main() {
+	watch := flag.Bool("watch", false, "Keep polling for jobs instead of exiting once the queue is drained")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	container, err := server.NewContainer(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize container: %v", err)
+	}
+	defer container.Close()
+
+	hostname, _ := os.Hostname()
+	workerID := fmt.Sprintf("enrich-%s-%d", hostname, os.Getpid())
+
+	if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" {
+		lambda.Start(func(ctx context.Context) error {
+			processed, err := services.DrainEnrichmentQueue(ctx, container.EnrichmentService, workerID)
+			log.Printf("Processed %d enrichment jobs", processed)
+			return err
+		})
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if *watch {
+		pollInterval := time.Duration(cfg.Enrichment.PollIntervalSeconds) * time.Second
+		services.RunEnrichmentWorker(ctx, container.EnrichmentService, workerID, pollInterval)
+		return
+	}
+
+	processed, err := services.DrainEnrichmentQueue(ctx, container.EnrichmentService, workerID)
+	if err != nil {
+		log.Fatalf("Enrichment failed after %d jobs: %v", processed, err)
+	}
+	log.Printf("Processed %d enrichment jobs", processed)
+}
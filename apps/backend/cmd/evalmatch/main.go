@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/evaluation"
+	"github.com/talent-fit/backend/internal/prompts"
+	"github.com/talent-fit/backend/internal/services"
+)
+
+func main() {
+	var (
+		fixtures      = flag.String("fixtures", "evaluation/fixtures/matching.yaml", "Labelled fixture set (.json, .yaml or .yml)")
+		provider      = flag.String("provider", "fake", "Model provider: fake (offline, deterministic) or real (configured OpenAI/Grok)")
+		k             = flag.Int("k", 5, "Cut-off for precision@k, nDCG@k and bad@k")
+		baseline      = flag.String("baseline", "", "Baseline report to diff against")
+		writeBaseline = flag.Bool("write-baseline", false, "Write this run to -baseline instead of diffing")
+		output        = flag.String("out", "", "Optional path to write the full JSON report")
+		tolerance     = flag.Float64("tolerance", 0.02, "Allowed drop per metric before it is reported as a regression")
+	)
+	flag.Parse()
+
+	set, err := evaluation.LoadFixtures(*fixtures)
+	if err != nil {
+		log.Fatalf("Failed to load fixtures: %v", err)
+	}
+
+	// Only AI settings are needed, so the database and OAuth configuration is not validated
+	cfg := config.FromEnv()
+
+	var embeddingService domain.EmbeddingService
+	switch *provider {
+	case "fake":
+		embeddingService = evaluation.NewFakeProvider(set)
+		cfg.AI.GrokModel = "fake"
+	case "real":
+		embeddingService = services.NewOpenAIEmbeddingService(cfg, nil, nil)
+	default:
+		fmt.Printf("Unknown provider: %s\n", *provider)
+		fmt.Println("Available providers: fake, real")
+		os.Exit(1)
+	}
+
+	// Embedded templates only: the harness evaluates the prompts in this checkout
+	promptRegistry, err := prompts.NewRegistry(nil)
+	if err != nil {
+		log.Fatalf("Failed to load prompt templates: %v", err)
+	}
+
+	runner := evaluation.NewRunner(embeddingService, promptRegistry, cfg, *provider, *k)
+	report, err := runner.Run(context.Background(), set)
+	if err != nil {
+		log.Fatalf("Evaluation failed: %v", err)
+	}
+
+	evaluation.PrintReport(os.Stdout, report)
+
+	if *output != "" {
+		if err := evaluation.WriteReport(*output, report); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+	}
+
+	if *baseline == "" {
+		return
+	}
+
+	if *writeBaseline {
+		if err := evaluation.WriteReport(*baseline, report); err != nil {
+			log.Fatalf("Failed to write baseline: %v", err)
+		}
+		fmt.Printf("\nBaseline written to %s\n", *baseline)
+		return
+	}
+
+	previous, err := evaluation.LoadReport(*baseline)
+	if err != nil {
+		log.Fatalf("Failed to load baseline: %v", err)
+	}
+	if previous.Provider != report.Provider || previous.K != report.K {
+		log.Printf("Warning: baseline was recorded with provider %s and k=%d; this run uses provider %s and k=%d",
+			previous.Provider, previous.K, report.Provider, report.K)
+	}
+
+	fmt.Printf("\nChanges against baseline %s:\n", *baseline)
+	deltas := evaluation.Compare(previous, report, *tolerance)
+	evaluation.PrintDeltas(os.Stdout, deltas)
+	if evaluation.HasRegression(deltas) {
+		os.Exit(1)
+	}
+}
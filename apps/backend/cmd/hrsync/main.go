@@ -0,0 +1,66 @@
+// Command hrsync syncs employees from HR exports dropped in HRIS_DROP_DIR.
+//
+// By default it reads the waiting files once and exits, which suits a cron job. With -watch it
+// keeps polling every HRIS_POLL_INTERVAL_SECONDS (60 when unset). When run as a Lambda function,
+// e.g. on an EventBridge schedule, each invocation reads the waiting files.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/server"
+	"github.com/talent-fit/backend/internal/services"
+)
+
+// defaultPollInterval is used with -watch when no interval is configured
+const defaultPollInterval = time.Minute
+
+func main() {
+	watch := flag.Bool("watch", false, "Keep polling for dropped files instead of exiting")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.HRIS.DropDir == "" {
+		log.Fatalf("HRIS_DROP_DIR is not set")
+	}
+
+	container, err := server.NewContainer(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize container: %v", err)
+	}
+	defer container.Close()
+
+	if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" {
+		lambda.Start(func(ctx context.Context) error {
+			return container.HRSyncService.Poll(ctx)
+		})
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if *watch {
+		interval := time.Duration(cfg.HRIS.PollIntervalSeconds) * time.Second
+		if interval <= 0 {
+			interval = defaultPollInterval
+		}
+		services.RunHRSyncPoller(ctx, container.HRSyncService, interval)
+		return
+	}
+
+	if err := container.HRSyncService.Poll(ctx); err != nil {
+		log.Fatalf("HR sync failed: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+// Command import loads users, employee profiles, projects and allocations from CSV or XLSX files.
+//
+// Files are validated and applied in one transaction, so either every row is imported or none is.
+// Rows are imported into the tenant given by -tenant, the default tenant unless set.
+// With -dry-run the files are only validated. The report is printed as JSON and the command exits
+// non-zero when any row has an error.
+//
+//	go run ./cmd/import -users users.csv -profiles profiles.xlsx -dry-run
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/internal/server"
+)
+
+func main() {
+	paths := make(map[models.ImportKind]*string, len(models.ImportKinds))
+	for _, kind := range models.ImportKinds {
+		paths[kind] = flag.String(string(kind), "", "CSV or XLSX file of "+string(kind))
+	}
+	dryRun := flag.Bool("dry-run", false, "Validate the files and report what would change without writing")
+	tenantID := flag.Uint("tenant", uint(domain.DefaultTenantID), "ID of the tenant to import into")
+	flag.Parse()
+
+	var files []*domain.ImportFile
+	for _, kind := range models.ImportKinds {
+		path := *paths[kind]
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", path, err)
+		}
+		files = append(files, &domain.ImportFile{Kind: kind, Filename: filepath.Base(path), Data: data})
+	}
+	if len(files) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	container, err := server.NewContainer(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize container: %v", err)
+	}
+	defer container.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	report, err := container.ImportService.Import(domain.WithTenant(ctx, *tenantID), files, *dryRun)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+	if len(report.Errors) > 0 {
+		log.Printf("Import has %d row errors; nothing was written", len(report.Errors))
+		os.Exit(1)
+	}
+}
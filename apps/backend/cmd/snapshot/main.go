@@ -0,0 +1,79 @@
+// Command snapshot stores today's dashboard metrics for the trend charts, organisation-wide and
+// per department and geo, for every tenant, replacing any snapshot already taken today. Today is the current date
+// in the database's time zone. Run it daily from cron, or
+// as a Lambda function on an EventBridge schedule.
+//
+// With -backfill-from it first fills in every day from that date up to yesterday, computed from the
+// allocation and employment dates on record. Profiles and availability flags are taken as they are
+// now, so backfilled days approximate what the dashboard would have shown.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/server"
+	"github.com/talent-fit/backend/internal/services"
+)
+
+func main() {
+	backfillFrom := flag.String("backfill-from", "", "Also snapshot every day from this date (YYYY-MM-DD) up to yesterday")
+	flag.Parse()
+
+	var from time.Time
+	if *backfillFrom != "" {
+		var err error
+		if from, err = time.Parse("2006-01-02", *backfillFrom); err != nil {
+			log.Fatalf("-backfill-from must be a date in YYYY-MM-DD format")
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	container, err := server.NewContainer(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize container: %v", err)
+	}
+	defer container.Close()
+
+	if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" {
+		lambda.Start(func(ctx context.Context) error {
+			return services.ForEachTenant(ctx, container.Tenants, func(ctx context.Context) error {
+				return container.DashboardService.SnapshotToday(ctx, true)
+			})
+		})
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	days := 0
+	for day := from; !from.IsZero() && day.Before(today); day = day.AddDate(0, 0, 1) {
+		err := services.ForEachTenant(ctx, container.Tenants, func(ctx context.Context) error {
+			return container.DashboardService.SnapshotMetrics(ctx, day)
+		})
+		if err != nil {
+			log.Fatalf("Snapshot for %s failed: %v", day.Format("2006-01-02"), err)
+		}
+		days++
+	}
+	err = services.ForEachTenant(ctx, container.Tenants, func(ctx context.Context) error {
+		return container.DashboardService.SnapshotToday(ctx, true)
+	})
+	if err != nil {
+		log.Fatalf("Snapshot failed: %v", err)
+	}
+	log.Printf("Stored metrics snapshots for %d day(s)", days+1)
+}
@@ -0,0 +1,59 @@
+// Command tenant creates a tenant together with its first admin, who signs in with their email
+// through any configured login provider and then manages the tenant's users and settings.
+//
+//	go run ./cmd/tenant -name "Acme UK" -slug acme-uk -admin-email ops@acme.co.uk -signup-domains acme.co.uk
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/internal/server"
+)
+
+func main() {
+	var request models.CreateTenantRequest
+	flag.StringVar(&request.Name, "name", "", "Tenant name")
+	flag.StringVar(&request.Slug, "slug", "", "Short lowercase identifier, e.g. acme-uk")
+	flag.StringVar(&request.AdminEmail, "admin-email", "", "Email of the tenant's first admin")
+	flag.StringVar(&request.AdminFirstName, "admin-first-name", "", "First name of the admin")
+	flag.StringVar(&request.AdminLastName, "admin-last-name", "", "Last name of the admin")
+	flag.StringVar(&request.SignUpDomains, "signup-domains", "", "Comma-separated email domains that may sign up to the tenant without an invitation")
+	flag.Parse()
+	if request.Name == "" || request.Slug == "" || request.AdminEmail == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	container, err := server.NewContainer(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize container: %v", err)
+	}
+	defer container.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	tenant, err := container.Tenants.CreateTenant(ctx, &request)
+	if err != nil {
+		log.Fatalf("Failed to create tenant: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(tenant); err != nil {
+		log.Fatalf("Failed to write tenant: %v", err)
+	}
+}
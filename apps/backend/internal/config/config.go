@@ -1,8 +1,14 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -13,6 +19,10 @@ type Config struct {
 	Database DatabaseConfig
 	Auth     AuthConfig
 	AI       AIConfig
+	Enrichment EnrichmentConfig
+	Storage  StorageConfig
+	HRIS     HRISConfig
+	Metrics  MetricsConfig
     Slack    SlackConfig
 	Logging  LoggingConfig
 }
@@ -22,6 +32,8 @@ type ServerConfig struct {
 	Port        string
 	Host        string
 	Environment string
+	// Lambda is set when running under AWS Lambda, where responses are buffered and cannot stream
+	Lambda bool
 }
 
 // DatabaseConfig holds database configuration
@@ -41,7 +53,35 @@ type AuthConfig struct {
 	GoogleClientSecret string
 	GoogleRedirectURL  string
 	JWTSecret          string
-	JWTExpiry          string
+	// JWTExpiry is the access token lifetime, e.g. 15m
+	JWTExpiry string
+	// RefreshTokenExpiry is how long a session lasts without being used to refresh, e.g. 720h
+	RefreshTokenExpiry string
+	// OIDCProviders is a JSON array of further OpenID Connect providers, e.g. Azure AD or Okta,
+	// see identity.ProviderConfig; Google is configured by GoogleClientID
+	OIDCProviders string
+	// SignUpDomains is a comma-separated list of email domains that may sign up to the default tenant
+	// without an invitation; other tenants' domains are set with cmd/tenant
+	SignUpDomains string
+	// DevLogin enables signing in as any user without a login provider, and impersonation.
+	// It is only allowed in development; see DevLoginEnabled.
+	DevLogin bool
+}
+
+// Defaults for the token lifetimes, also used when a configured lifetime does not parse
+const (
+	defaultJWTExpiry          = 15 * time.Minute
+	defaultRefreshTokenExpiry = 30 * 24 * time.Hour
+)
+
+// AccessTokenTTL returns the parsed JWTExpiry
+func (a AuthConfig) AccessTokenTTL() time.Duration {
+	return parseDuration(a.JWTExpiry, defaultJWTExpiry)
+}
+
+// RefreshTokenTTL returns the parsed RefreshTokenExpiry
+func (a AuthConfig) RefreshTokenTTL() time.Duration {
+	return parseDuration(a.RefreshTokenExpiry, defaultRefreshTokenExpiry)
 }
 
 // AIConfig holds AI service configuration
@@ -52,6 +92,81 @@ type AIConfig struct {
 	GrokAPIKey string
 	GrokAPIBaseURL string
 	GrokModel string
+	// ScoringResponseFormat is one of json_object (default), json_schema or text. json_schema is only
+	// honoured by some providers; the other modes rely on the scorer validating the output.
+	ScoringResponseFormat string
+	// ScoringMaxAttempts bounds the validate-and-repair loop for scoring output
+	ScoringMaxAttempts int
+	// Pricing is the USD price per million tokens by model, used to cost recorded usage
+	Pricing map[string]ModelPrice
+	// MonthlyBudgetUSD caps each tenant's AI spend per calendar month; 0 disables the cap.
+	// Once spent, candidate scoring uses the similarity-based fallback instead of the model.
+	MonthlyBudgetUSD float64
+	// RedactionSecret keys the pseudonymous tokens that replace personal data in prompts
+	RedactionSecret string
+	// PromptFieldAllowlist lists, per prompt template, the fields that may be sent to the model as-is
+	PromptFieldAllowlist map[string][]string
+}
+
+// ModelPrice is the USD price per million prompt and completion tokens
+type ModelPrice struct {
+	PromptPerMillion     float64
+	CompletionPerMillion float64
+}
+
+// defaultPromptFieldAllowlist keeps candidate names out of scoring prompts; override with AI_PROMPT_FIELD_ALLOWLIST
+const defaultPromptFieldAllowlist = "score_candidates=skills|geo|experience|industry|availability|status|similarity;summarize_project=description|roles;parse_talent_query=query;extract_cv_profile=cv_text"
+
+// defaultAIPricing covers the default models; override with AI_PRICING
+const defaultAIPricing = "text-embedding-3-small=0.02/0,grok-4-fast=0.20/0.50"
+
+// EnrichmentConfig holds configuration for the background summarise and embed jobs
+type EnrichmentConfig struct {
+	// Workers is the number of in-process workers started by the API server; 0 disables them,
+	// e.g. on Lambda where the queue is drained by cmd/enrich on a schedule instead
+	Workers int
+	// PollIntervalSeconds is how long an idle worker waits before polling the queue again
+	PollIntervalSeconds int
+	// MaxAttempts bounds retries before a job and its record are marked failed
+	MaxAttempts int
+	// RetryBaseSeconds is the first retry delay; it doubles with every attempt
+	RetryBaseSeconds int
+	// LockTimeoutSeconds after which a running job is assumed abandoned and reclaimed
+	LockTimeoutSeconds int
+}
+
+// StorageConfig holds configuration for uploaded files
+type StorageConfig struct {
+	// BlobStore selects where uploaded CVs are kept when the employee asks to keep the original:
+	// "local" for a directory on disk, or "none" to keep nothing
+	BlobStore string
+	// LocalDir is the root directory of the local blob store
+	LocalDir string
+	// MaxUploadMB bounds the size of an uploaded CV
+	MaxUploadMB int
+	// MaxImportMB bounds the combined size of the files in one bulk import
+	MaxImportMB int
+}
+
+// HRISConfig holds configuration for syncing employees from the HR system
+type HRISConfig struct {
+	// WebhookSecret signs inbound webhook deliveries; the webhook is disabled while it is empty
+	WebhookSecret string
+	// DropDir is a directory, e.g. an SFTP upload target, polled for CSV or XLSX exports; empty disables it
+	DropDir string
+	// PollIntervalSeconds is how often the API server polls DropDir; 0 leaves polling to cmd/hrsync
+	PollIntervalSeconds int
+	// FieldMap maps record fields to the HR system's names, e.g. "external_id=Employee Number;hire_date=Start Date"
+	FieldMap string
+	// TenantID is the tenant whose employees the HR system holds
+	TenantID uint
+}
+
+// MetricsConfig holds configuration for the daily dashboard metrics snapshots
+type MetricsConfig struct {
+	// SnapshotCheckMinutes is how often the API server checks whether today's snapshot has been
+	// taken; 0 leaves snapshots to cmd/snapshot
+	SnapshotCheckMinutes int
 }
 
 // SlackConfig holds Slack integration configuration
@@ -68,6 +183,29 @@ type LoggingConfig struct {
 
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
+	config := FromEnv()
+
+	// Local development with dev login needs no JWT_SECRET; a random one ends sessions on restart
+	if config.DevLoginEnabled() && config.Auth.JWTSecret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate development jwt secret: %w", err)
+		}
+		config.Auth.JWTSecret = hex.EncodeToString(secret)
+		log.Printf("Warning: JWT_SECRET is not set, using a random development secret")
+	}
+
+	// Validate required configuration
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	return config, nil
+}
+
+// FromEnv reads configuration from environment variables without validating it.
+// Offline tools that need no database or OAuth settings use this instead of Load.
+func FromEnv() *Config {
 	// Load .env file if it exists (for local development)
 	_ = godotenv.Load()
 
@@ -77,6 +215,7 @@ func Load() (*Config, error) {
 			Port:        getEnv("SERVER_PORT", "8080"),
 			Host:        getEnv("SERVER_HOST", "localhost"),
 			Environment: getEnv("ENV", "development"),
+			Lambda:      getEnv("AWS_LAMBDA_FUNCTION_NAME", "") != "",
 		},
 		Database: DatabaseConfig{
 			URL:      getEnv("DB_URL", ""),
@@ -92,7 +231,11 @@ func Load() (*Config, error) {
 			GoogleClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
 			GoogleRedirectURL:  getEnv("GOOGLE_REDIRECT_URL", ""),
 			JWTSecret:          getEnv("JWT_SECRET", ""),
-			JWTExpiry:          getEnv("JWT_EXPIRY", "24h"),
+			JWTExpiry:          getEnv("JWT_EXPIRY", "15m"),
+			RefreshTokenExpiry: getEnv("REFRESH_TOKEN_EXPIRY", "720h"),
+			OIDCProviders:      getEnv("OIDC_PROVIDERS", ""),
+			SignUpDomains:      getEnv("SIGNUP_ALLOWED_DOMAINS", ""),
+			DevLogin:           getEnvBool("DEV_LOGIN", false),
 		},
 		AI: AIConfig{
 			OpenAIAPIKey: getEnv("OPENAI_API_KEY", ""),
@@ -101,6 +244,35 @@ func Load() (*Config, error) {
 			GrokAPIKey: getEnv("GROKK_API_KEY", ""),
 			GrokAPIBaseURL: getEnv("GROKK_BASE_URL", "https://api.x.ai/v1"),
 			GrokModel: getEnv("GROKK_MODEL", "grok-4-fast"),
+			ScoringResponseFormat: getEnv("AI_SCORING_RESPONSE_FORMAT", "json_object"),
+			ScoringMaxAttempts: getEnvInt("AI_SCORING_MAX_ATTEMPTS", 3),
+			Pricing: parsePricing(getEnv("AI_PRICING", defaultAIPricing)),
+			MonthlyBudgetUSD: getEnvFloat("AI_MONTHLY_BUDGET_USD", 0),
+			RedactionSecret: getEnv("AI_REDACTION_SECRET", getEnv("JWT_SECRET", "")),
+			PromptFieldAllowlist: parseAllowlist(getEnv("AI_PROMPT_FIELD_ALLOWLIST", defaultPromptFieldAllowlist)),
+		},
+		Enrichment: EnrichmentConfig{
+			Workers:             getEnvInt("ENRICHMENT_WORKERS", 2),
+			PollIntervalSeconds: getEnvInt("ENRICHMENT_POLL_INTERVAL_SECONDS", 5),
+			MaxAttempts:         getEnvInt("ENRICHMENT_MAX_ATTEMPTS", 5),
+			RetryBaseSeconds:    getEnvInt("ENRICHMENT_RETRY_BASE_SECONDS", 30),
+			LockTimeoutSeconds:  getEnvInt("ENRICHMENT_LOCK_TIMEOUT_SECONDS", 300),
+		},
+		Storage: StorageConfig{
+			BlobStore:   getEnv("BLOB_STORE", "none"),
+			LocalDir:    getEnv("BLOB_STORE_DIR", "./data/blobs"),
+			MaxUploadMB: getEnvInt("CV_MAX_UPLOAD_MB", 5),
+			MaxImportMB: getEnvInt("IMPORT_MAX_UPLOAD_MB", 20),
+		},
+		HRIS: HRISConfig{
+			WebhookSecret:       getEnv("HRIS_WEBHOOK_SECRET", ""),
+			DropDir:             getEnv("HRIS_DROP_DIR", ""),
+			PollIntervalSeconds: getEnvInt("HRIS_POLL_INTERVAL_SECONDS", 0),
+			FieldMap:            getEnv("HRIS_FIELD_MAP", ""),
+			TenantID:            uint(getEnvInt("HRIS_TENANT_ID", 1)),
+		},
+		Metrics: MetricsConfig{
+			SnapshotCheckMinutes: getEnvInt("METRICS_SNAPSHOT_CHECK_MINUTES", 0),
 		},
         Slack: SlackConfig{
             BotToken: getEnv("SLACK_BOT_TOKEN", ""),
@@ -112,12 +284,7 @@ func Load() (*Config, error) {
 		},
 	}
 
-	// Validate required configuration
-	if err := config.Validate(); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
-	}
-
-	return config, nil
+	return config
 }
 
 // Validate validates the configuration
@@ -130,8 +297,18 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("jwt secret is required")
 	}
 
-	if c.Auth.GoogleClientID == "" || c.Auth.GoogleClientSecret == "" {
-		return fmt.Errorf("google oauth configuration is incomplete")
+	for name, value := range map[string]string{"JWT_EXPIRY": c.Auth.JWTExpiry, "REFRESH_TOKEN_EXPIRY": c.Auth.RefreshTokenExpiry} {
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			return fmt.Errorf("%s must be a positive duration such as 15m or 720h", name)
+		}
+	}
+
+	if c.Auth.DevLogin && !c.IsDevelopment() {
+		return fmt.Errorf("DEV_LOGIN is only allowed with ENV=development")
+	}
+
+	if c.Auth.GoogleClientID == "" && c.Auth.OIDCProviders == "" && !c.DevLoginEnabled() {
+		return fmt.Errorf("no login provider configured: set GOOGLE_CLIENT_ID or OIDC_PROVIDERS, or DEV_LOGIN=true in development")
 	}
 
 	return nil
@@ -165,6 +342,88 @@ func (c *Config) IsDevelopment() bool {
 	return c.Server.Environment == "development"
 }
 
+// DevLoginEnabled reports whether dev login and impersonation are on: DEV_LOGIN=true in development
+func (c *Config) DevLoginEnabled() bool {
+	return c.IsDevelopment() && c.Auth.DevLogin
+}
+
+// getEnvBool gets a boolean environment variable with a fallback value
+func getEnvBool(key string, fallback bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+// getEnvInt gets an integer environment variable with a fallback value
+func getEnvInt(key string, fallback int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+// getEnvFloat gets a float environment variable with a fallback value
+func getEnvFloat(key string, fallback float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+// parseDuration parses a positive duration, returning fallback if value is empty or invalid
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// parsePricing parses "model=prompt/completion,..." with prices in USD per million tokens.
+// Malformed entries are skipped.
+func parsePricing(value string) map[string]ModelPrice {
+	pricing := make(map[string]ModelPrice)
+	for _, entry := range strings.Split(value, ",") {
+		model, prices, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			continue
+		}
+		promptPrice, completionPrice, _ := strings.Cut(prices, "/")
+		prompt, err := strconv.ParseFloat(strings.TrimSpace(promptPrice), 64)
+		if err != nil {
+			continue
+		}
+		completion, _ := strconv.ParseFloat(strings.TrimSpace(completionPrice), 64)
+		pricing[strings.TrimSpace(model)] = ModelPrice{PromptPerMillion: prompt, CompletionPerMillion: completion}
+	}
+	return pricing
+}
+
+// parseAllowlist parses "prompt=field|field;prompt=field" into fields per prompt
+func parseAllowlist(value string) map[string][]string {
+	allowlist := make(map[string][]string)
+	for _, entry := range strings.Split(value, ";") {
+		prompt, fields, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			continue
+		}
+		prompt = strings.TrimSpace(prompt)
+		allowlist[prompt] = []string{}
+		for _, field := range strings.Split(fields, "|") {
+			if field = strings.TrimSpace(field); field != "" {
+				allowlist[prompt] = append(allowlist[prompt], field)
+			}
+		}
+	}
+	return allowlist
+}
+
 // getEnv gets an environment variable with a fallback value
 func getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// AIUsageRepository implements the domain.AIUsageRepository interface
+type AIUsageRepository struct {
+	db *gorm.DB
+}
+
+// NewAIUsageRepository creates a new AI usage repository
+func NewAIUsageRepository(db *gorm.DB) domain.AIUsageRepository {
+	return &AIUsageRepository{
+		db: db,
+	}
+}
+
+// Create records an AI provider call
+func (r *AIUsageRepository) Create(ctx context.Context, usage *entities.AIUsage) error {
+	return r.db.WithContext(ctx).Create(usage).Error
+}
+
+// DailyByFeature aggregates usage per UTC day and feature for calls in [from, to)
+func (r *AIUsageRepository) DailyByFeature(ctx context.Context, from time.Time, to time.Time) ([]domain.AIUsageAggregate, error) {
+	var rows []domain.AIUsageAggregate
+	result := r.db.WithContext(ctx).
+		Model(&entities.AIUsage{}).
+		Select(`date_trunc('day', created_at AT TIME ZONE 'UTC') AS day,
+			feature,
+			COUNT(*) AS calls,
+			COUNT(*) FILTER (WHERE NOT success) AS failures,
+			COALESCE(SUM(prompt_tokens), 0) AS prompt_tokens,
+			COALESCE(SUM(completion_tokens), 0) AS completion_tokens,
+			COALESCE(SUM(cost_usd), 0) AS cost_usd,
+			COALESCE(AVG(latency_ms), 0) AS avg_latency_ms`).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("day, feature").
+		Order("day, feature").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rows, nil
+}
+
+// TotalCost sums the cost of calls in [from, to)
+func (r *AIUsageRepository) TotalCost(ctx context.Context, from time.Time, to time.Time) (float64, error) {
+	var total float64
+	result := r.db.WithContext(ctx).
+		Model(&entities.AIUsage{}).
+		Select("COALESCE(SUM(cost_usd), 0)").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Scan(&total)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return total, nil
+}
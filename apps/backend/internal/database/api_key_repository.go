@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository implements domain.APIKeyRepository
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *gorm.DB) domain.APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// CreateServiceAccount stores a service account, or returns domain.ErrServiceAccountExists
+func (r *APIKeyRepository) CreateServiceAccount(ctx context.Context, account *entities.ServiceAccount) error {
+	err := r.db.WithContext(ctx).Create(account).Error
+	if isUniqueViolation(err) {
+		return domain.ErrServiceAccountExists
+	}
+	return err
+}
+
+// GetServiceAccount returns a service account with its keys, newest first
+func (r *APIKeyRepository) GetServiceAccount(ctx context.Context, id uint) (*entities.ServiceAccount, error) {
+	var account entities.ServiceAccount
+	err := r.db.WithContext(ctx).Preload("APIKeys", r.keysNewestFirst).First(&account, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrServiceAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// ListServiceAccounts returns every service account with its keys, by name
+func (r *APIKeyRepository) ListServiceAccounts(ctx context.Context) ([]*entities.ServiceAccount, error) {
+	var accounts []*entities.ServiceAccount
+	err := r.db.WithContext(ctx).Preload("APIKeys", r.keysNewestFirst).Order("name").Find(&accounts).Error
+	return accounts, err
+}
+
+// DisableServiceAccount disables an account and revokes its keys in one transaction
+func (r *APIKeyRepository) DisableServiceAccount(ctx context.Context, id uint, now time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.ServiceAccount{}).
+			Where("id = ? AND disabled_at IS NULL", id).
+			Update("disabled_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var count int64
+			if err := tx.Model(&entities.ServiceAccount{}).Where("id = ?", id).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return domain.ErrServiceAccountNotFound
+			}
+		}
+		return tx.Model(&entities.APIKey{}).
+			Where("service_account_id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", now).Error
+	})
+}
+
+// CreateKey stores a new key
+func (r *APIKeyRepository) CreateKey(ctx context.Context, key *entities.APIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+// GetKey returns a key by ID
+func (r *APIKeyRepository) GetKey(ctx context.Context, id uint) (*entities.APIKey, error) {
+	var key entities.APIKey
+	err := r.db.WithContext(ctx).First(&key, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetKeyByHash returns a key with its service account by the hash of the raw key
+func (r *APIKeyRepository) GetKeyByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	var key entities.APIKey
+	err := r.db.WithContext(ctx).Preload("ServiceAccount").Where("key_hash = ?", keyHash).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RotateKey stores next and cuts old's expiry, revoking old when no grace period is left
+func (r *APIKeyRepository) RotateKey(ctx context.Context, old *entities.APIKey, next *entities.APIKey, oldExpiresAt time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"expires_at": oldExpiresAt}
+		if !oldExpiresAt.After(next.CreatedAt) {
+			updates["revoked_at"] = next.CreatedAt
+		}
+		result := tx.Model(&entities.APIKey{}).
+			Where("id = ? AND revoked_at IS NULL AND expires_at > ?", old.ID, next.CreatedAt).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Revoked or expired meanwhile
+			return domain.ErrInvalidAPIKey
+		}
+		return tx.Create(next).Error
+	})
+}
+
+// RevokeKey revokes a key; revoking a revoked key is a no-op
+func (r *APIKeyRepository) RevokeKey(ctx context.Context, id uint, now time.Time) error {
+	if _, err := r.GetKey(ctx, id); err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Model(&entities.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", now).Error
+}
+
+// TouchKey records when a key was last used
+func (r *APIKeyRepository) TouchKey(ctx context.Context, id uint, now time.Time) error {
+	return r.db.WithContext(ctx).Model(&entities.APIKey{}).Where("id = ?", id).Update("last_used_at", now).Error
+}
+
+// keysNewestFirst orders preloaded keys
+func (r *APIKeyRepository) keysNewestFirst(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at DESC, id DESC")
+}
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"context"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// AuditRepository implements domain.AuditRepository
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new audit repository
+func NewAuditRepository(db *gorm.DB) domain.AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Record appends an event to the audit log
+func (r *AuditRepository) Record(ctx context.Context, event *entities.AuditEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+// List returns events newest first, filtered by action and email when given
+func (r *AuditRepository) List(ctx context.Context, action, email string, limit int) ([]*entities.AuditEvent, error) {
+	query := r.db.WithContext(ctx)
+	if action != "" {
+		query = query.Where("action = ?", action)
+	}
+	if email != "" {
+		query = query.Where("email = ?", email)
+	}
+	var events []*entities.AuditEvent
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&events).Error
+	return events, err
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AuthTokenRepository implements domain.AuthTokenRepository
+type AuthTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewAuthTokenRepository creates a new auth token repository
+func NewAuthTokenRepository(db *gorm.DB) domain.AuthTokenRepository {
+	return &AuthTokenRepository{db: db}
+}
+
+// CreateRefreshToken stores a new refresh token
+func (r *AuthTokenRepository) CreateRefreshToken(ctx context.Context, token *entities.RefreshToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+// GetRefreshToken returns a refresh token by its hash, revoked or not
+func (r *AuthTokenRepository) GetRefreshToken(ctx context.Context, tokenHash string) (*entities.RefreshToken, error) {
+	var token entities.RefreshToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken revokes old and stores next in one transaction. Only one of two concurrent
+// rotations of the same token revokes it; the other gets domain.ErrInvalidRefreshToken.
+func (r *AuthTokenRepository) RotateRefreshToken(ctx context.Context, old *entities.RefreshToken, next *entities.RefreshToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", old.ID).
+			Update("revoked_at", next.CreatedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrInvalidRefreshToken
+		}
+		return tx.Create(next).Error
+	})
+}
+
+// RevokeRefreshTokenFamily revokes the unrevoked tokens of a session
+func (r *AuthTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	return r.db.WithContext(ctx).Model(&entities.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAccessToken adds an access token to the revocation list; revoking it twice is a no-op
+func (r *AuthTokenRepository) RevokeAccessToken(ctx context.Context, token *entities.RevokedToken) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+// IsAccessTokenRevoked reports whether a jti is on the revocation list
+func (r *AuthTokenRepository) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	return count > 0, err
+}
+
+// RevokeUserSessions sets the user's sessions_revoked_at and revokes their refresh tokens
+func (r *AuthTokenRepository) RevokeUserSessions(ctx context.Context, userID uint, now time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.User{}).Where("id = ?", userID).Update("sessions_revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrUserNotFound
+		}
+		return tx.Model(&entities.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", now).Error
+	})
+}
+
+// DeleteExpired removes refresh tokens and revocation entries that expired before a time
+func (r *AuthTokenRepository) DeleteExpired(ctx context.Context, before time.Time) error {
+	db := r.db.WithContext(ctx)
+	if err := db.Where("expires_at < ?", before).Delete(&entities.RefreshToken{}).Error; err != nil {
+		return err
+	}
+	return db.Where("expires_at < ?", before).Delete(&entities.RevokedToken{}).Error
+}
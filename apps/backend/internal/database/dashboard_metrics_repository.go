@@ -0,0 +1,201 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Windows in days for the "rolling off soon" metric
+const (
+	rollOffEmploymentDays = 60
+	rollOffAllocationDays = 30
+)
+
+// dashboardMetricsQuery computes the metrics per group in one pass. {{group}} is the grouping
+// expression over employee_profiles p and {{projects}} the active project count per group g.
+// {{staff_scope}} and {{project_scope}} limit employees and projects to org units, if scoped.
+// Every table is limited to @tenant, or read across tenants when it is NULL, see tenantParam.
+// Timestamps are compared as dates in the session time zone, the same zone CURRENT_DATE uses.
+const dashboardMetricsQuery = `
+WITH params AS (
+    SELECT COALESCE(CAST(@day AS date), CURRENT_DATE) AS today, CAST(@tenant AS integer) AS tenant
+),
+staff AS (
+    SELECT p.user_id, {{group}} AS grp, p.availability_flag, p.end_date, p.notice_date
+    FROM employee_profiles p CROSS JOIN params
+    WHERE p.deleted_at IS NULL
+      AND p.tenant_id = COALESCE(params.tenant, p.tenant_id)
+      AND (p.date_of_joining IS NULL OR p.date_of_joining::date <= params.today)
+      AND (p.end_date IS NULL OR p.end_date::date >= params.today)
+      AND NOT EXISTS (
+          SELECT 1 FROM users u
+          WHERE u.id = p.user_id AND u.deactivated_at::date <= params.today
+      )
+      {{staff_scope}}
+),
+live_allocations AS (
+    SELECT a.employee_id, a.project_id, a.allocation_type, a.start_date, a.end_date
+    FROM project_allocations a
+    JOIN projects pr ON pr.id = a.project_id AND pr.deleted_at IS NULL
+    CROSS JOIN params
+    WHERE a.deleted_at IS NULL
+      AND a.tenant_id = COALESCE(params.tenant, a.tenant_id)
+),
+active AS (
+    SELECT a.employee_id, a.project_id, a.allocation_type
+    FROM live_allocations a CROSS JOIN params
+    WHERE a.start_date::date <= params.today
+      AND (a.end_date IS NULL OR a.end_date::date >= params.today)
+),
+load AS (
+    SELECT employee_id,
+           SUM(CASE allocation_type WHEN @full_time THEN 1.0 WHEN @part_time THEN 0.5 ELSE 0 END) AS weight
+    FROM active
+    GROUP BY employee_id
+),
+rolling AS (
+    SELECT s.user_id
+    FROM staff s CROSS JOIN params
+    WHERE (s.end_date::date > params.today AND s.end_date::date <= params.today + @employment_days)
+       OR (s.notice_date::date > params.today AND s.notice_date::date <= params.today + @employment_days)
+    UNION
+    SELECT a.employee_id
+    FROM live_allocations a CROSS JOIN params
+    WHERE a.end_date::date > params.today AND a.end_date::date <= params.today + @allocation_days
+),
+active_projects AS (
+    SELECT pr.id
+    FROM projects pr CROSS JOIN params
+    WHERE pr.deleted_at IS NULL
+      AND pr.tenant_id = COALESCE(params.tenant, pr.tenant_id)
+      AND pr.start_date::date <= params.today
+      AND pr.end_date::date >= params.today
+      {{project_scope}}
+),
+group_projects AS (
+    SELECT s.grp, COUNT(DISTINCT a.project_id) AS active_projects
+    FROM active a
+    JOIN staff s ON s.user_id = a.employee_id
+    JOIN active_projects ap ON ap.id = a.project_id
+    GROUP BY s.grp
+),
+metrics AS (
+    SELECT s.grp,
+           COUNT(*) AS headcount,
+           COUNT(*) FILTER (WHERE s.availability_flag OR l.employee_id IS NULL) AS available_engineers,
+           COUNT(*) FILTER (WHERE l.employee_id IS NULL) AS bench_resources,
+           COUNT(*) FILTER (WHERE l.employee_id IS NOT NULL) AS allocated_engineers,
+           COUNT(*) FILTER (WHERE r.user_id IS NOT NULL) AS rolling_off_soon,
+           COALESCE(SUM(l.weight), 0) AS allocated_weight
+    FROM staff s
+    LEFT JOIN load l ON l.employee_id = s.user_id
+    LEFT JOIN rolling r ON r.user_id = s.user_id
+    GROUP BY s.grp
+)
+SELECT g.grp AS group_value, g.headcount, g.available_engineers, g.bench_resources, g.allocated_engineers,
+       g.rolling_off_soon, {{projects}} AS active_projects,
+       ROUND(g.allocated_weight * 100.0 / g.headcount, 1) AS utilisation_pct
+FROM metrics g
+ORDER BY g.grp`
+
+// dashboardGroupings maps each grouping to its grouping expression and active project count
+var dashboardGroupings = map[string][2]string{
+	entities.MetricsGroupAll:        {"''", "(SELECT COUNT(*) FROM active_projects)"},
+	entities.MetricsGroupDepartment: {"COALESCE(p.department, '')", "COALESCE((SELECT gp.active_projects FROM group_projects gp WHERE gp.grp = g.grp), 0)"},
+	entities.MetricsGroupGeo:        {"COALESCE(p.geo, '')", "COALESCE((SELECT gp.active_projects FROM group_projects gp WHERE gp.grp = g.grp), 0)"},
+}
+
+// DashboardMetricsRepository implements domain.DashboardMetricsRepository
+type DashboardMetricsRepository struct {
+	db *gorm.DB
+}
+
+// NewDashboardMetricsRepository creates a new dashboard metrics repository
+func NewDashboardMetricsRepository(db *gorm.DB) domain.DashboardMetricsRepository {
+	return &DashboardMetricsRepository{db: db}
+}
+
+// Today returns CURRENT_DATE in the database's time zone
+func (r *DashboardMetricsRepository) Today(ctx context.Context) (time.Time, error) {
+	var today string
+	if err := r.db.WithContext(ctx).Raw("SELECT CAST(CURRENT_DATE AS text)").Scan(&today).Error; err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse("2006-01-02", today)
+}
+
+// Compute runs the metrics query for a grouping; an organisation with no employees yields one empty group
+func (r *DashboardMetricsRepository) Compute(ctx context.Context, groupBy string, day *time.Time, orgUnitIDs []uint) ([]*domain.GroupMetrics, error) {
+	grouping, ok := dashboardGroupings[groupBy]
+	if !ok {
+		return nil, domain.ErrInvalidMetricsGroup
+	}
+	tenant, err := tenantParam(ctx)
+	if err != nil {
+		return nil, err
+	}
+	staffScope, projectScope := "", ""
+	if orgUnitIDs != nil {
+		staffScope, projectScope = "AND p.org_unit_id IN @org_units", "AND pr.org_unit_id IN @org_units"
+	}
+	query := strings.NewReplacer(
+		"{{group}}", grouping[0],
+		"{{projects}}", grouping[1],
+		"{{staff_scope}}", staffScope,
+		"{{project_scope}}", projectScope,
+	).Replace(dashboardMetricsQuery)
+
+	var dayParam interface{}
+	if day != nil {
+		dayParam = day.Format("2006-01-02")
+	}
+	var rows []struct {
+		GroupValue         string
+		Headcount          int
+		AvailableEngineers int
+		BenchResources     int
+		AllocatedEngineers int
+		RollingOffSoon     int
+		ActiveProjects     int
+		UtilisationPct     float64
+	}
+	err = r.db.WithContext(ctx).Raw(query, map[string]interface{}{
+		"day":             dayParam,
+		"full_time":       string(models.AllocationFullTime),
+		"part_time":       string(models.AllocationPartTime),
+		"employment_days": rollOffEmploymentDays,
+		"allocation_days": rollOffAllocationDays,
+		"org_units":       orgUnitIDs,
+		"tenant":          tenant,
+	}).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute dashboard metrics: %w", err)
+	}
+
+	groups := make([]*domain.GroupMetrics, len(rows))
+	for i, row := range rows {
+		groups[i] = &domain.GroupMetrics{
+			Group:     row.GroupValue,
+			Headcount: row.Headcount,
+			ManagerDashboardMetrics: domain.ManagerDashboardMetrics{
+				AvailableEngineers: row.AvailableEngineers,
+				ActiveProjects:     row.ActiveProjects,
+				RollingOffSoon:     row.RollingOffSoon,
+				BenchResources:     row.BenchResources,
+				AllocatedEngineers: row.AllocatedEngineers,
+			},
+			UtilisationPct: row.UtilisationPct,
+		}
+	}
+	if len(groups) == 0 && groupBy == entities.MetricsGroupAll {
+		groups = append(groups, &domain.GroupMetrics{})
+	}
+	return groups, nil
+}
@@ -45,6 +45,11 @@ func NewDatabase(cfg *config.Config) (*Database, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Every repository is scoped to the tenant of its context
+	if err := db.Use(TenantScope{}); err != nil {
+		return nil, fmt.Errorf("failed to register tenant scope: %w", err)
+	}
+
 	// Get underlying SQL DB for connection configuration
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/pgvector/pgvector-go"
 	"github.com/talent-fit/backend/internal/domain"
 	"github.com/talent-fit/backend/internal/entities"
 	"gorm.io/gorm"
@@ -32,16 +34,21 @@ func (r *EmployeeProfileRepository) GetAll(ctx context.Context) ([]*entities.Emp
 	return profiles, nil
 }
 
-// GetFiltered retrieves employee profiles filtered by skills, geos and availability
-func (r *EmployeeProfileRepository) GetFiltered(ctx context.Context, skills []string, geos []string, availableOnly bool) ([]*entities.EmployeeProfile, error) {
+// GetFiltered retrieves employee profiles filtered by skills, geos, availability and org units
+func (r *EmployeeProfileRepository) GetFiltered(ctx context.Context, skills []string, geos []string, availableOnly bool, orgUnitIDs []uint) ([]*entities.EmployeeProfile, error) {
     dbq := r.db.WithContext(ctx).Model(&entities.EmployeeProfile{}).Preload("User")
 
 	if len(geos) > 0 {
 		dbq = dbq.Where("geo IN ?", geos)
 	}
 
+	if orgUnitIDs != nil {
+		dbq = dbq.Where("org_unit_id IN ?", orgUnitIDs)
+	}
+
 	if availableOnly {
-		dbq = dbq.Where("availability_flag = ?", true)
+		dbq = dbq.Where("availability_flag = ?", true).
+			Where("NOT EXISTS (SELECT 1 FROM users u WHERE u.id = employee_profiles.user_id AND u.deactivated_at IS NOT NULL)")
 	}
 
 	if len(skills) > 0 {
@@ -88,7 +95,12 @@ func (r *EmployeeProfileRepository) GetByUserID(ctx context.Context, userID stri
 
 // Create creates a new employee profile in database
 func (r *EmployeeProfileRepository) Create(ctx context.Context, profile *entities.EmployeeProfile) (*entities.EmployeeProfile, error) {
-	result := r.db.WithContext(ctx).Create(profile)
+	query := r.db.WithContext(ctx)
+	if len(profile.Embedding.Slice()) == 0 {
+		// The embedding is generated later by an enrichment job; store NULL rather than an empty vector
+		query = query.Omit("embedding")
+	}
+	result := query.Create(profile)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -104,6 +116,12 @@ func (r *EmployeeProfileRepository) Update(ctx context.Context, userID string, p
 	return profile, nil
 }
 
+// UpdateEnrichment writes only the given enrichment columns, including zero values,
+// so a background job never overwrites fields edited while it ran
+func (r *EmployeeProfileRepository) UpdateEnrichment(ctx context.Context, userID string, profile *entities.EmployeeProfile, columns ...string) error {
+	return r.db.WithContext(ctx).Model(&entities.EmployeeProfile{}).Where("user_id = ?", userID).Select(columns).Updates(profile).Error
+}
+
 // GetAvailableEmployees retrieves available employees from database
 func (r *EmployeeProfileRepository) GetAvailableEmployees(ctx context.Context) ([]*entities.EmployeeProfile, error) {
     var profiles []*entities.EmployeeProfile
@@ -114,7 +132,8 @@ func (r *EmployeeProfileRepository) GetAvailableEmployees(ctx context.Context) (
 	return profiles, nil
 }
 
-// GetSimilarAvailableProfiles finds the most similar available employee profiles to a project using vector similarity
+// GetSimilarAvailableProfiles finds the most similar available employee profiles to a project using vector similarity.
+// Candidates come from the project's tenant only, which must be the context's tenant if it has one.
 // Availability Logic:
 // - Either: Not currently allocated to any project
 // - Or: Marked as available for extra work (availability_flag = true) AND not already working on the same project
@@ -125,9 +144,10 @@ func (r *EmployeeProfileRepository) GetSimilarAvailableProfiles(ctx context.Cont
 
 	query := `
 		WITH proj AS (
-			SELECT embedding AS e
+			SELECT embedding AS e, tenant_id
 			FROM projects
 			WHERE id = ? AND embedding IS NOT NULL
+				AND tenant_id = COALESCE(CAST(? AS integer), tenant_id)
 		)
 		SELECT
 			ep.user_id,
@@ -147,6 +167,8 @@ func (r *EmployeeProfileRepository) GetSimilarAvailableProfiles(ctx context.Cont
 		FROM employee_profiles ep, proj
 		WHERE ep.embedding IS NOT NULL
 			AND ep.deleted_at IS NULL
+			AND ep.tenant_id = proj.tenant_id
+			AND NOT EXISTS (SELECT 1 FROM users u WHERE u.id = ep.user_id AND u.deactivated_at IS NOT NULL)
 			AND (
 				NOT EXISTS (
 					SELECT 1
@@ -174,8 +196,14 @@ func (r *EmployeeProfileRepository) GetSimilarAvailableProfiles(ctx context.Cont
 		Similarity float64 `gorm:"column:similarity"`
 	}
 
+	tenant, err := tenantParam(ctx)
+	if err != nil {
+		return nil, err
+	}
 	var results []QueryResult
-	err := r.db.WithContext(ctx).Raw(query, projectID, projectID, limit).Scan(&results).Error
+	err = withTenantSession(ctx, r.db, func(tx *gorm.DB) error {
+		return tx.Raw(query, projectID, tenant, projectID, limit).Scan(&results).Error
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute similarity search: %w", err)
 	}
@@ -194,7 +222,8 @@ func (r *EmployeeProfileRepository) GetSimilarAvailableProfiles(ctx context.Cont
 	return matches, nil
 }
 
-// GetSimilarAvailableProfilesWithUser finds similar profiles and includes user information
+// GetSimilarAvailableProfilesWithUser finds similar profiles and includes user information, from the
+// project's tenant only like GetSimilarAvailableProfiles
 // Availability Logic:
 // - Either: Not currently allocated to any project
 // - Or: Marked as available for extra work (availability_flag = true) AND not already working on the same project
@@ -205,9 +234,10 @@ func (r *EmployeeProfileRepository) GetSimilarAvailableProfilesWithUser(ctx cont
 
 	query := `
 		WITH proj AS (
-    SELECT embedding AS e, start_date
+    SELECT embedding AS e, start_date, tenant_id
     FROM projects
     WHERE id = ? AND embedding IS NOT NULL
+      AND tenant_id = COALESCE(CAST(? AS integer), tenant_id)
 )
 SELECT
     ep.user_id,
@@ -268,6 +298,9 @@ SELECT
       WHERE ep.embedding IS NOT NULL
         AND ep.deleted_at IS NULL
         AND u.deleted_at IS NULL
+        AND u.deactivated_at IS NULL
+        AND ep.tenant_id = proj.tenant_id
+        AND u.tenant_id = proj.tenant_id
         AND (
           NOT EXISTS (
               SELECT 1
@@ -313,8 +346,14 @@ SELECT
 		Status     string  `gorm:"column:status"`
 	}
 
+	tenant, err := tenantParam(ctx)
+	if err != nil {
+		return nil, err
+	}
 	var results []QueryResultWithUser
-	err := r.db.WithContext(ctx).Raw(query, projectID, projectID, projectID, limit).Scan(&results).Error
+	err = withTenantSession(ctx, r.db, func(tx *gorm.DB) error {
+		return tx.Raw(query, projectID, tenant, projectID, projectID, limit).Scan(&results).Error
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute similarity search with user data: %w", err)
 	}
@@ -343,3 +382,135 @@ SELECT
 
 	return matches, nil
 }
+
+// Search finds profiles matching structured filters, ranked by matched skills and then by
+// similarity to the search embedding. Profiles still waiting for an embedding rank last but
+// are not excluded. Availability follows the matching rules: free when no allocation runs
+// past the requested date, or when marked available for extra work.
+func (r *EmployeeProfileRepository) Search(ctx context.Context, search *domain.ProfileSearch) ([]*domain.ProfileSearchMatch, error) {
+	tenant, err := tenantParam(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limit := search.Limit
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	similarity := "0::float8"
+	var selectArgs []interface{}
+	if len(search.Embedding) > 0 {
+		similarity = "COALESCE(1 - (ep.embedding <=> ?), 0)"
+		selectArgs = append(selectArgs, pgvector.NewVector(search.Embedding))
+	}
+	matchedSkills := "0"
+	if len(search.Skills) > 0 {
+		matchedSkills = `(SELECT COUNT(*) FROM jsonb_array_elements_text(ep.skills) s WHERE LOWER(s) IN ?)`
+		selectArgs = append(selectArgs, search.Skills)
+	}
+
+	query := r.db.WithContext(ctx).
+		Table("employee_profiles ep").
+		Select(`ep.*,
+			u.first_name,
+			u.last_name,
+			u.email,
+			u.role,
+			`+similarity+` AS similarity,
+			`+matchedSkills+` AS matched_skills,
+			CASE
+				WHEN EXISTS (
+					SELECT 1
+					FROM project_allocations pa
+					WHERE pa.employee_id = ep.user_id
+						AND pa.deleted_at IS NULL
+						AND pa.end_date IS NULL
+				) THEN NULL
+				ELSE GREATEST(now(), COALESCE((
+					SELECT MAX(pa.end_date)
+					FROM project_allocations pa
+					WHERE pa.employee_id = ep.user_id
+						AND pa.deleted_at IS NULL
+				), now()))
+			END AS available_from`, selectArgs...).
+		Joins("INNER JOIN users u ON ep.user_id = u.id").
+		Where("ep.deleted_at IS NULL AND u.deleted_at IS NULL AND u.deactivated_at IS NULL").
+		// The aliased table has no model for the tenant scope to act on
+		Where("ep.tenant_id = COALESCE(CAST(? AS integer), ep.tenant_id)", tenant)
+
+	if len(search.Skills) > 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM jsonb_array_elements_text(ep.skills) s WHERE LOWER(s) IN ?)", search.Skills)
+	}
+	if len(search.Types) > 0 {
+		query = query.Where("LOWER(ep.type) IN ?", search.Types)
+	}
+	if len(search.Geos) > 0 {
+		query = query.Where("LOWER(ep.geo) IN ?", search.Geos)
+	}
+	if len(search.Industries) > 0 {
+		conditions := make([]string, 0, len(search.Industries))
+		args := make([]interface{}, 0, len(search.Industries))
+		for _, industry := range search.Industries {
+			conditions = append(conditions, "LOWER(ep.industry) LIKE ?")
+			args = append(args, "%"+industry+"%")
+		}
+		query = query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+	if search.MinExperienceYears != nil {
+		query = query.Where("ep.years_of_experience >= ?", *search.MinExperienceYears)
+	}
+	if search.MaxExperienceYears != nil {
+		query = query.Where("ep.years_of_experience <= ?", *search.MaxExperienceYears)
+	}
+	if search.AvailableBy != nil {
+		query = query.Where(`(ep.end_date IS NULL OR ep.end_date > ?)
+			AND (
+				ep.availability_flag = true
+				OR NOT EXISTS (
+					SELECT 1
+					FROM project_allocations pa
+					WHERE pa.employee_id = ep.user_id
+						AND pa.deleted_at IS NULL
+						AND (pa.end_date IS NULL OR pa.end_date > ?)
+				)
+			)`, *search.AvailableBy, *search.AvailableBy)
+	}
+
+	type QueryResultWithUser struct {
+		entities.EmployeeProfile
+		// User fields
+		FirstName     string     `gorm:"column:first_name"`
+		LastName      string     `gorm:"column:last_name"`
+		Email         string     `gorm:"column:email"`
+		Role          string     `gorm:"column:role"`
+		Similarity    float64    `gorm:"column:similarity"`
+		MatchedSkills int        `gorm:"column:matched_skills"`
+		AvailableFrom *time.Time `gorm:"column:available_from"`
+	}
+
+	var results []QueryResultWithUser
+	err = query.Order("matched_skills DESC, similarity DESC, ep.user_id").Limit(limit).Scan(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute profile search: %w", err)
+	}
+
+	matches := make([]*domain.ProfileSearchMatch, len(results))
+	for i, result := range results {
+		profile := result.EmployeeProfile
+		profile.User = entities.User{
+			ID:        profile.UserID,
+			FirstName: result.FirstName,
+			LastName:  result.LastName,
+			Email:     result.Email,
+			Role:      result.Role,
+		}
+		matches[i] = &domain.ProfileSearchMatch{
+			Profile:       &profile,
+			Similarity:    result.Similarity,
+			MatchedSkills: result.MatchedSkills,
+			AvailableFrom: result.AvailableFrom,
+		}
+	}
+
+	return matches, nil
+}
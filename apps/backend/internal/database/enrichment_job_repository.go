@@ -0,0 +1,224 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EnrichmentJobRepository implements the domain.EnrichmentJobRepository interface
+type EnrichmentJobRepository struct {
+	db *gorm.DB
+}
+
+// NewEnrichmentJobRepository creates a new enrichment job repository
+func NewEnrichmentJobRepository(db *gorm.DB) domain.EnrichmentJobRepository {
+	return &EnrichmentJobRepository{
+		db: db,
+	}
+}
+
+// Enqueue adds a pending job; the partial unique index on pending jobs makes a duplicate a no-op
+func (r *EnrichmentJobRepository) Enqueue(ctx context.Context, job *entities.EnrichmentJob) error {
+	job.Status = entities.JobStatusPending
+	if job.RunAfter.IsZero() {
+		job.RunAfter = time.Now()
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(job).Error
+}
+
+// Claim locks the oldest due job with FOR UPDATE SKIP LOCKED so concurrent workers never share a job.
+// Workers claim jobs of every tenant with a domain.AllTenants context; a context scoped to a tenant
+// only claims that tenant's.
+func (r *EnrichmentJobRepository) Claim(ctx context.Context, workerID string, staleBefore time.Time) (*entities.EnrichmentJob, error) {
+	tenant, err := tenantParam(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var job entities.EnrichmentJob
+	result := r.db.WithContext(ctx).Raw(`
+		UPDATE enrichment_jobs
+		SET status = ?, attempts = attempts + 1, locked_at = NOW(), locked_by = ?, updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM enrichment_jobs
+			WHERE ((status = ? AND run_after <= NOW())
+				OR (status = ? AND locked_at < ?))
+				AND tenant_id = COALESCE(CAST(? AS integer), tenant_id)
+			ORDER BY run_after, id
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING *`,
+		entities.JobStatusRunning, workerID,
+		entities.JobStatusPending,
+		entities.JobStatusRunning, staleBefore,
+		tenant,
+	).Scan(&job)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 || job.ID == 0 {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+// MarkSucceeded completes a job
+func (r *EnrichmentJobRepository) MarkSucceeded(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Model(&entities.EnrichmentJob{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     entities.JobStatusSucceeded,
+		"last_error": "",
+		"locked_at":  nil,
+		"locked_by":  "",
+	}).Error
+}
+
+// MarkFailed schedules a retry at retryAt, or fails the job permanently when retryAt is nil
+func (r *EnrichmentJobRepository) MarkFailed(ctx context.Context, id int, lastError string, retryAt *time.Time) error {
+	updates := map[string]interface{}{
+		"status":     entities.JobStatusFailed,
+		"last_error": lastError,
+		"locked_at":  nil,
+		"locked_by":  "",
+	}
+	if retryAt != nil {
+		updates["status"] = entities.JobStatusPending
+		updates["run_after"] = *retryAt
+	}
+	result := r.db.WithContext(ctx).Model(&entities.EnrichmentJob{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil && retryAt != nil && isUniqueViolation(result.Error) {
+		// A newer job for the same entity was queued while this one ran; it supersedes the retry
+		return r.db.WithContext(ctx).Model(&entities.EnrichmentJob{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":     entities.JobStatusSucceeded,
+			"last_error": "superseded by a newer job: " + lastError,
+			"locked_at":  nil,
+			"locked_by":  "",
+		}).Error
+	}
+	return result.Error
+}
+
+// GetByID retrieves a job by ID
+func (r *EnrichmentJobRepository) GetByID(ctx context.Context, id int) (*entities.EnrichmentJob, error) {
+	var job entities.EnrichmentJob
+	result := r.db.WithContext(ctx).First(&job, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &job, nil
+}
+
+// List retrieves the most recently updated jobs, optionally filtered by status
+func (r *EnrichmentJobRepository) List(ctx context.Context, status string, limit int) ([]*entities.EnrichmentJob, error) {
+	var jobs []*entities.EnrichmentJob
+	query := r.db.WithContext(ctx).Order("updated_at DESC, id DESC").Limit(limit)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// CountByStatus counts jobs in each status
+func (r *EnrichmentJobRepository) CountByStatus(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	result := r.db.WithContext(ctx).
+		Model(&entities.EnrichmentJob{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// Retry moves a failed job back to pending with a fresh attempt budget
+func (r *EnrichmentJobRepository) Retry(ctx context.Context, id int) (*entities.EnrichmentJob, error) {
+	result := r.db.WithContext(ctx).Model(&entities.EnrichmentJob{}).
+		Where("id = ? AND status = ?", id, entities.JobStatusFailed).
+		Updates(map[string]interface{}{
+			"status":    entities.JobStatusPending,
+			"attempts":  0,
+			"run_after": time.Now(),
+		})
+	if result.Error != nil {
+		if isUniqueViolation(result.Error) {
+			return nil, errors.New("a job for this entity is already pending")
+		}
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.New("only failed jobs can be retried")
+	}
+	return r.GetByID(ctx, id)
+}
+
+// EnqueueMissing queues projects and profiles that are pending enrichment but have no pending or
+// running job, e.g. because queueing failed after the record was saved
+func (r *EnrichmentJobRepository) EnqueueMissing(ctx context.Context, maxAttempts int) (int64, error) {
+	tenant, err := tenantParam(ctx)
+	if err != nil {
+		return 0, err
+	}
+	projects := r.db.WithContext(ctx).Exec(`
+		INSERT INTO enrichment_jobs (job_type, entity_type, entity_id, max_attempts, tenant_id)
+		SELECT CASE WHEN COALESCE(p.description, '') <> '' AND COALESCE(p.summary, '') = '' THEN ? ELSE ? END, ?, p.id, ?, p.tenant_id
+		FROM projects p
+		WHERE p.enrichment_status = ? AND p.deleted_at IS NULL
+			AND p.tenant_id = COALESCE(CAST(? AS integer), p.tenant_id)
+			AND NOT EXISTS (
+				SELECT 1 FROM enrichment_jobs j
+				WHERE j.entity_type = ? AND j.entity_id = p.id AND j.status IN (?, ?)
+			)
+		ON CONFLICT DO NOTHING`,
+		entities.JobTypeSummarizeProject, entities.JobTypeEmbedProject, domain.EntityTypeProject, maxAttempts,
+		entities.EnrichmentStatusPending, tenant,
+		domain.EntityTypeProject, entities.JobStatusPending, entities.JobStatusRunning,
+	)
+	if projects.Error != nil {
+		return 0, projects.Error
+	}
+
+	profiles := r.db.WithContext(ctx).Exec(`
+		INSERT INTO enrichment_jobs (job_type, entity_type, entity_id, max_attempts, tenant_id)
+		SELECT ?, ?, ep.user_id, ?, ep.tenant_id
+		FROM employee_profiles ep
+		WHERE ep.enrichment_status = ? AND ep.deleted_at IS NULL
+			AND ep.tenant_id = COALESCE(CAST(? AS integer), ep.tenant_id)
+			AND NOT EXISTS (
+				SELECT 1 FROM enrichment_jobs j
+				WHERE j.entity_type = ? AND j.entity_id = ep.user_id AND j.status IN (?, ?)
+			)
+		ON CONFLICT DO NOTHING`,
+		entities.JobTypeEmbedProfile, domain.EntityTypeProfile, maxAttempts,
+		entities.EnrichmentStatusPending, tenant,
+		domain.EntityTypeProfile, entities.JobStatusPending, entities.JobStatusRunning,
+	)
+	if profiles.Error != nil {
+		return projects.RowsAffected, profiles.Error
+	}
+	return projects.RowsAffected + profiles.RowsAffected, nil
+}
+
+// isUniqueViolation reports whether err is a Postgres unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// openQueueDB is openTestDB with the partial unique index migration 008 adds, which AutoMigrate cannot express
+func openQueueDB(t *testing.T) (*gorm.DB, domain.EnrichmentJobRepository) {
+	t.Helper()
+	db := openTestDB(t)
+	if err := db.Exec(`CREATE UNIQUE INDEX idx_enrichment_jobs_one_pending ON enrichment_jobs(job_type, entity_type, entity_id) WHERE status = 'pending'`).Error; err != nil {
+		t.Fatal(err)
+	}
+	return db, NewEnrichmentJobRepository(db)
+}
+
+func profileJob(userID int, status string) *entities.EnrichmentJob {
+	return &entities.EnrichmentJob{
+		JobType: entities.JobTypeEmbedProfile, EntityType: domain.EntityTypeProfile, EntityID: userID,
+		Status: status, MaxAttempts: 3, RunAfter: time.Now().Add(-time.Minute),
+	}
+}
+
+func loadJob(t *testing.T, db *gorm.DB, id int) *entities.EnrichmentJob {
+	t.Helper()
+	var job entities.EnrichmentJob
+	if err := db.First(&job, id).Error; err != nil {
+		t.Fatal(err)
+	}
+	return &job
+}
+
+func TestEnrichmentQueueClaim(t *testing.T) {
+	db, repo := openQueueDB(t)
+	// Workers claim across tenants
+	ctx := domain.AllTenants(context.Background())
+	now := time.Now()
+	stale, fresh := now.Add(-2*time.Hour), now
+
+	notDue := profileJob(1, entities.JobStatusPending)
+	notDue.RunAfter = now.Add(time.Hour)
+	crashed := profileJob(2, entities.JobStatusRunning)
+	crashed.Attempts, crashed.LockedAt, crashed.LockedBy = 1, &stale, "crashed-worker"
+	busy := profileJob(3, entities.JobStatusRunning)
+	busy.Attempts, busy.LockedAt, busy.LockedBy = 1, &fresh, "busy-worker"
+	if err := db.Create([]*entities.EnrichmentJob{notDue, crashed, busy}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := repo.Claim(ctx, "worker", now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job == nil || job.ID != crashed.ID {
+		t.Fatalf("Claim() = %+v, want the stale job %d reclaimed", job, crashed.ID)
+	}
+	if job.Attempts != 2 || job.LockedBy != "worker" || job.Status != entities.JobStatusRunning {
+		t.Errorf("claimed job = attempts %d locked by %q %s, want attempt 2 running for worker", job.Attempts, job.LockedBy, job.Status)
+	}
+
+	// The future job and the job locked by a live worker stay where they are
+	if job, err := repo.Claim(ctx, "worker", now.Add(-time.Hour)); job != nil || err != nil {
+		t.Errorf("second Claim() = %+v, %v, want nothing due", job, err)
+	}
+	if got := loadJob(t, db, busy.ID); got.LockedBy != "busy-worker" || got.Attempts != 1 {
+		t.Errorf("running job was taken over: locked by %q after %d attempts", got.LockedBy, got.Attempts)
+	}
+}
+
+func TestEnrichmentQueueUniquePendingJob(t *testing.T) {
+	db, repo := openQueueDB(t)
+	ctx := domain.WithTenant(context.Background(), domain.DefaultTenantID)
+
+	t.Run("enqueue", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			if err := repo.Enqueue(ctx, profileJob(1, "")); err != nil {
+				t.Fatalf("Enqueue() #%d error = %v", i+1, err)
+			}
+		}
+		var count int64
+		db.Model(&entities.EnrichmentJob{}).Where("entity_id = ?", 1).Count(&count)
+		if count != 1 {
+			t.Errorf("enqueueing twice stored %d pending jobs, want 1", count)
+		}
+	})
+
+	t.Run("retry superseded by a newer job", func(t *testing.T) {
+		running := profileJob(2, entities.JobStatusRunning)
+		if err := db.Create(running).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Enqueue(ctx, profileJob(2, "")); err != nil {
+			t.Fatal(err)
+		}
+		retryAt := time.Now().Add(time.Minute)
+		if err := repo.MarkFailed(ctx, running.ID, "provider unavailable", &retryAt); err != nil {
+			t.Fatalf("MarkFailed() error = %v", err)
+		}
+		if got := loadJob(t, db, running.ID); got.Status != entities.JobStatusSucceeded || got.LastError != "superseded by a newer job: provider unavailable" {
+			t.Errorf("superseded job = %s %q, want it succeeded as superseded", got.Status, got.LastError)
+		}
+	})
+
+	t.Run("manual retry", func(t *testing.T) {
+		failed := profileJob(3, entities.JobStatusFailed)
+		failed.Attempts = 3
+		if err := db.Create(failed).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Enqueue(ctx, profileJob(3, "")); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.Retry(ctx, failed.ID); err == nil || err.Error() != "a job for this entity is already pending" {
+			t.Errorf("Retry() with a pending job error = %v, want already pending", err)
+		}
+
+		db.Where("entity_id = ? AND status = ?", 3, entities.JobStatusPending).Delete(&entities.EnrichmentJob{})
+		job, err := repo.Retry(ctx, failed.ID)
+		if err != nil {
+			t.Fatalf("Retry() error = %v", err)
+		}
+		if job.Status != entities.JobStatusPending || job.Attempts != 0 {
+			t.Errorf("retried job = %s after %d attempts, want pending with none", job.Status, job.Attempts)
+		}
+		if _, err := repo.Retry(ctx, failed.ID); err == nil || err.Error() != "only failed jobs can be retried" {
+			t.Errorf("Retry() of a pending job error = %v, want only failed jobs", err)
+		}
+	})
+}
+
+func TestEnrichmentQueueEnqueueMissing(t *testing.T) {
+	db, repo := openQueueDB(t)
+	ctx := domain.AllTenants(context.Background())
+
+	var users []*entities.User
+	var profiles []*entities.EmployeeProfile
+	for i, status := range []string{entities.EnrichmentStatusPending, entities.EnrichmentStatusPending, entities.EnrichmentStatusPending, entities.EnrichmentStatusReady} {
+		id := uint(i + 1)
+		users = append(users, &entities.User{ID: id, FirstName: "Test", Email: fmt.Sprintf("user%d@example.com", id), Role: "Employee"})
+		profiles = append(profiles, &entities.EmployeeProfile{UserID: id, Type: "Backend", EnrichmentStatus: status})
+	}
+	// User 2 already has a pending job, user 3 a running one; user 4 is enriched
+	for _, batch := range []interface{}{
+		users, profiles,
+		[]*entities.EnrichmentJob{profileJob(2, entities.JobStatusPending), profileJob(3, entities.JobStatusRunning)},
+	} {
+		if err := db.Omit("Embedding").Create(batch).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	queued, err := repo.EnqueueMissing(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queued != 1 {
+		t.Errorf("EnqueueMissing() queued %d jobs, want 1 for user 1", queued)
+	}
+	var job entities.EnrichmentJob
+	if err := db.Where("entity_id = ? AND status = ?", 1, entities.JobStatusPending).First(&job).Error; err != nil || job.JobType != entities.JobTypeEmbedProfile {
+		t.Errorf("user 1's job = %+v, %v, want a pending embed_profile job", job, err)
+	}
+
+	if queued, err := repo.EnqueueMissing(ctx, 3); queued != 0 || err != nil {
+		t.Errorf("second EnqueueMissing() = %d, %v, want nothing left to queue", queued, err)
+	}
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// HRSyncRepository implements the domain.HRSyncRepository interface
+type HRSyncRepository struct {
+	db *gorm.DB
+}
+
+// NewHRSyncRepository creates a new HR sync repository
+func NewHRSyncRepository(db *gorm.DB) domain.HRSyncRepository {
+	return &HRSyncRepository{
+		db: db,
+	}
+}
+
+// Create stores a run and its entries
+func (r *HRSyncRepository) Create(ctx context.Context, run *entities.HRSyncRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+// GetByID retrieves a run with its entries
+func (r *HRSyncRepository) GetByID(ctx context.Context, id uint) (*entities.HRSyncRun, error) {
+	var run entities.HRSyncRun
+	result := r.db.WithContext(ctx).Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).First(&run, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &run, nil
+}
+
+// GetByReference returns the latest run for a delivery, or nil when there is none
+func (r *HRSyncRepository) GetByReference(ctx context.Context, source, reference string) (*entities.HRSyncRun, error) {
+	var run entities.HRSyncRun
+	result := r.db.WithContext(ctx).Where("source = ? AND reference = ?", source, reference).Order("id DESC").First(&run)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &run, nil
+}
+
+// List retrieves the most recent runs without their entries
+func (r *HRSyncRepository) List(ctx context.Context, limit int) ([]*entities.HRSyncRun, error) {
+	var runs []*entities.HRSyncRun
+	result := r.db.WithContext(ctx).Order("started_at DESC, id DESC").Limit(limit).Find(&runs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return runs, nil
+}
@@ -0,0 +1,296 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// errImportRolledBack ends the import transaction without writing anything
+var errImportRolledBack = errors.New("import rolled back")
+
+// profileEmbeddingColumns are the profile columns that feed its embedding
+var profileEmbeddingColumns = map[string]bool{
+	"type": true, "skills": true, "years_of_experience": true, "experience_level": true, "industry": true, "geo": true,
+}
+
+// ImportRepository implements the domain.ImportRepository interface
+type ImportRepository struct {
+	db *gorm.DB
+}
+
+// NewImportRepository creates a new import repository
+func NewImportRepository(db *gorm.DB) domain.ImportRepository {
+	return &ImportRepository{
+		db: db,
+	}
+}
+
+// Apply upserts users by external ID then email, profiles by user, projects by external ID and
+// allocations by employee, project and start date. Rows are applied in that order so later kinds
+// can refer to records created earlier in the same import; a dry run rolls all of it back.
+func (r *ImportRepository) Apply(ctx context.Context, batch *domain.ImportBatch, dryRun bool) (*domain.ImportResult, error) {
+	result := &domain.ImportResult{
+		Created:  make(map[models.ImportKind]int),
+		Updated:  make(map[models.ImportKind]int),
+		Projects: make(map[int]bool),
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range batch.Users {
+			if err := applyImportUser(tx, &batch.Users[i], result); err != nil {
+				return importRowFailure(batch.Users[i].ImportSource, err)
+			}
+		}
+		for i := range batch.Profiles {
+			if err := applyImportProfile(tx, &batch.Profiles[i], result); err != nil {
+				return importRowFailure(batch.Profiles[i].ImportSource, err)
+			}
+		}
+		for i := range batch.Projects {
+			if err := applyImportProject(tx, &batch.Projects[i], result); err != nil {
+				return importRowFailure(batch.Projects[i].ImportSource, err)
+			}
+		}
+		for i := range batch.Allocations {
+			if err := applyImportAllocation(tx, &batch.Allocations[i], result); err != nil {
+				return importRowFailure(batch.Allocations[i].ImportSource, err)
+			}
+		}
+		if dryRun || len(result.Errors) > 0 {
+			return errImportRolledBack
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRolledBack) {
+		return nil, err
+	}
+	return result, nil
+}
+
+func applyImportUser(tx *gorm.DB, item *domain.ImportUser, result *domain.ImportResult) error {
+	var existing entities.User
+	found, byExternalID := false, false
+	var err error
+	if item.User.ExternalID != nil {
+		if found, err = findOne(tx, &existing, "external_id = ?", *item.User.ExternalID); err != nil {
+			return err
+		}
+		byExternalID = found
+	}
+	if !found {
+		if found, err = findOne(tx, &existing, "email = ?", item.User.Email); err != nil {
+			return err
+		}
+		if found && existing.ExternalID != nil && item.User.ExternalID != nil {
+			addImportError(result, item.ImportSource, "external_id", "%s already has external ID %s", item.User.Email, *existing.ExternalID)
+			return nil
+		}
+	}
+
+	if !found {
+		if err := tx.Create(item.User).Error; err != nil {
+			return err
+		}
+		result.Created[models.ImportUsers]++
+		return nil
+	}
+
+	// A user matched by external ID takes the file's email, unless another user has it
+	if byExternalID && existing.Email != item.User.Email {
+		var other entities.User
+		taken, err := findOne(tx, &other, "email = ?", item.User.Email)
+		if err != nil {
+			return err
+		}
+		if taken {
+			addImportError(result, item.ImportSource, "email", "%s belongs to another user", item.User.Email)
+			return nil
+		}
+	}
+	if err := tx.Model(&entities.User{}).Where("id = ?", existing.ID).Select(item.Columns).Updates(item.User).Error; err != nil {
+		return err
+	}
+	item.User.ID = existing.ID
+	result.Updated[models.ImportUsers]++
+	return nil
+}
+
+func applyImportProfile(tx *gorm.DB, item *domain.ImportProfile, result *domain.ImportResult) error {
+	var user entities.User
+	found, err := findOne(tx, &user, "email = ?", item.Email)
+	if err != nil {
+		return err
+	}
+	if !found {
+		addImportError(result, item.ImportSource, "email", "no user with email %s", item.Email)
+		return nil
+	}
+
+	item.Profile.UserID = user.ID
+	item.Profile.EnrichmentStatus = entities.EnrichmentStatusPending
+	var existing entities.EmployeeProfile
+	if found, err = findOne(tx, &existing, "user_id = ?", user.ID); err != nil {
+		return err
+	}
+	if !found {
+		if item.Profile.Type == "" {
+			addImportError(result, item.ImportSource, "type", "is required for a new profile")
+			return nil
+		}
+		if err := tx.Omit("embedding").Create(item.Profile).Error; err != nil {
+			return err
+		}
+		result.Created[models.ImportProfiles]++
+		result.ProfileUserIDs = append(result.ProfileUserIDs, user.ID)
+		return nil
+	}
+
+	columns := item.Columns
+	reembed := false
+	for _, column := range columns {
+		reembed = reembed || profileEmbeddingColumns[column]
+	}
+	if reembed {
+		columns = append(columns, "enrichment_status")
+	}
+	if len(columns) > 0 {
+		if err := tx.Model(&entities.EmployeeProfile{}).Where("user_id = ?", user.ID).Select(columns).Updates(item.Profile).Error; err != nil {
+			return err
+		}
+	}
+	result.Updated[models.ImportProfiles]++
+	if reembed {
+		result.ProfileUserIDs = append(result.ProfileUserIDs, user.ID)
+	}
+	return nil
+}
+
+func applyImportProject(tx *gorm.DB, item *domain.ImportProject, result *domain.ImportResult) error {
+	var existing entities.Project
+	found, err := findOne(tx, &existing, "external_id = ?", *item.Project.ExternalID)
+	if err != nil {
+		return err
+	}
+	item.Project.EnrichmentStatus = entities.EnrichmentStatusPending
+
+	if !found {
+		if err := tx.Omit("embedding").Create(item.Project).Error; err != nil {
+			return err
+		}
+		result.Created[models.ImportProjects]++
+		result.Projects[item.Project.ID] = item.Project.Description != ""
+		return nil
+	}
+
+	// Like a project edit, only a new description or seat mix needs a new summary and embedding
+	columns := item.Columns
+	summarize := false
+	description := existing.Description
+	for _, column := range columns {
+		switch column {
+		case "description":
+			description = item.Project.Description
+			summarize = summarize || description != existing.Description
+		case "seats_by_type":
+			summarize = summarize || !seatsEqual(item.Project.SeatsByType, existing.SeatsByType)
+		}
+	}
+	summarize = summarize && description != ""
+	if summarize {
+		columns = append(columns, "enrichment_status")
+	}
+	if err := tx.Model(&entities.Project{}).Where("id = ?", existing.ID).Select(columns).Updates(item.Project).Error; err != nil {
+		return err
+	}
+	item.Project.ID = existing.ID
+	result.Updated[models.ImportProjects]++
+	if summarize {
+		result.Projects[existing.ID] = true
+	}
+	return nil
+}
+
+func applyImportAllocation(tx *gorm.DB, item *domain.ImportAllocation, result *domain.ImportResult) error {
+	var user entities.User
+	found, err := findOne(tx, &user, "email = ?", item.EmployeeEmail)
+	if err != nil {
+		return err
+	}
+	if !found {
+		addImportError(result, item.ImportSource, "employee_email", "no user with email %s", item.EmployeeEmail)
+		return nil
+	}
+	var project entities.Project
+	if found, err = findOne(tx, &project, "external_id = ?", item.ProjectExternalID); err != nil {
+		return err
+	}
+	if !found {
+		addImportError(result, item.ImportSource, "project_external_id", "no project with external ID %s", item.ProjectExternalID)
+		return nil
+	}
+
+	allocation := item.Allocation
+	allocation.EmployeeID = int(user.ID)
+	allocation.ProjectID = project.ID
+	var existing entities.ProjectAllocation
+	found, err = findOne(tx, &existing, "employee_id = ? AND project_id = ? AND start_date = ?",
+		allocation.EmployeeID, allocation.ProjectID, allocation.StartDate)
+	if err != nil {
+		return err
+	}
+	if !found {
+		if err := tx.Create(allocation).Error; err != nil {
+			return err
+		}
+		result.Created[models.ImportAllocations]++
+		return nil
+	}
+	if err := tx.Model(&entities.ProjectAllocation{}).Where("id = ?", existing.ID).
+		Select("allocation_type", "end_date").Updates(allocation).Error; err != nil {
+		return err
+	}
+	allocation.ID = existing.ID
+	result.Updated[models.ImportAllocations]++
+	return nil
+}
+
+// findOne loads the first record matching the condition, reporting false when there is none
+func findOne(tx *gorm.DB, dest interface{}, query string, args ...interface{}) (bool, error) {
+	err := tx.Where(query, args...).First(dest).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func addImportError(result *domain.ImportResult, source domain.ImportSource, column, format string, args ...interface{}) {
+	result.Errors = append(result.Errors, models.ImportRowError{
+		File:    source.File,
+		Line:    source.Line,
+		Column:  column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// importRowFailure names the row a database error happened on; the error aborts the whole import
+func importRowFailure(source domain.ImportSource, err error) error {
+	return fmt.Errorf("failed to import %s line %d: %w", source.File, source.Line, err)
+}
+
+func seatsEqual(a, b entities.SeatsByType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, seats := range a {
+		if b[name] != seats {
+			return false
+		}
+	}
+	return true
+}
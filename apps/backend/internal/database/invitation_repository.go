@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// InvitationRepository implements domain.InvitationRepository
+type InvitationRepository struct {
+	db *gorm.DB
+}
+
+// NewInvitationRepository creates a new invitation repository
+func NewInvitationRepository(db *gorm.DB) domain.InvitationRepository {
+	return &InvitationRepository{db: db}
+}
+
+// Create stores a new invitation. An expired one for the same email is revoked first, so it
+// does not hold the email's pending slot.
+func (r *InvitationRepository) Create(ctx context.Context, invitation *entities.Invitation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entities.Invitation{}).
+			Where("email = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at <= ?", invitation.Email, invitation.CreatedAt).
+			Update("revoked_at", invitation.CreatedAt).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(invitation).Error
+	})
+}
+
+// GetByID returns an invitation, or domain.ErrInvitationNotFound
+func (r *InvitationRepository) GetByID(ctx context.Context, id uint) (*entities.Invitation, error) {
+	var invitation entities.Invitation
+	err := r.db.WithContext(ctx).First(&invitation, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrInvitationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetPending returns the pending invitation for an email, or domain.ErrInvitationNotFound
+func (r *InvitationRepository) GetPending(ctx context.Context, email string, now time.Time) (*entities.Invitation, error) {
+	var invitation entities.Invitation
+	err := r.pending(r.db.WithContext(ctx), now).Where("email = ?", email).First(&invitation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrInvitationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// List returns invitations newest first
+func (r *InvitationRepository) List(ctx context.Context, pendingOnly bool, now time.Time, limit int) ([]*entities.Invitation, error) {
+	query := r.db.WithContext(ctx)
+	if pendingOnly {
+		query = r.pending(query, now)
+	}
+	var invitations []*entities.Invitation
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&invitations).Error
+	return invitations, err
+}
+
+// Accept creates the user and marks the invitation accepted in one transaction
+func (r *InvitationRepository) Accept(ctx context.Context, invitation *entities.Invitation, user *entities.User, now time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&entities.Invitation{}).
+			Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", invitation.ID).
+			Updates(map[string]interface{}{"accepted_at": now, "user_id": user.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrInvitationNotActive
+		}
+		return nil
+	})
+}
+
+// Revoke marks a pending invitation revoked
+func (r *InvitationRepository) Revoke(ctx context.Context, id uint, now time.Time) error {
+	result := r.db.WithContext(ctx).Model(&entities.Invitation{}).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", id).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return err
+		}
+		return domain.ErrInvitationNotActive
+	}
+	return nil
+}
+
+// pending filters to invitations neither accepted, revoked nor expired
+func (r *InvitationRepository) pending(query *gorm.DB, now time.Time) *gorm.DB {
+	return query.Where("accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", now)
+}
@@ -0,0 +1,40 @@
+package database
+
+import (
+	"context"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// MatchRunRepository implements the domain.MatchRunRepository interface
+type MatchRunRepository struct {
+	db *gorm.DB
+}
+
+// NewMatchRunRepository creates a new match run repository
+func NewMatchRunRepository(db *gorm.DB) domain.MatchRunRepository {
+	return &MatchRunRepository{
+		db: db,
+	}
+}
+
+// Create records a match run
+func (r *MatchRunRepository) Create(ctx context.Context, run *entities.MatchRun) (*entities.MatchRun, error) {
+	result := r.db.WithContext(ctx).Create(run)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return run, nil
+}
+
+// GetByProjectID retrieves match runs for a project, newest first
+func (r *MatchRunRepository) GetByProjectID(ctx context.Context, projectID int) ([]*entities.MatchRun, error) {
+	var runs []*entities.MatchRun
+	result := r.db.WithContext(ctx).Where("project_id = ?", projectID).Order("created_at DESC").Find(&runs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return runs, nil
+}
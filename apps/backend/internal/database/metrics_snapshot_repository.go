@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MetricsSnapshotRepository implements domain.MetricsSnapshotRepository
+type MetricsSnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewMetricsSnapshotRepository creates a new metrics snapshot repository
+func NewMetricsSnapshotRepository(db *gorm.DB) domain.MetricsSnapshotRepository {
+	return &MetricsSnapshotRepository{db: db}
+}
+
+// Save upserts snapshots on their tenant, date and group
+func (r *MetricsSnapshotRepository) Save(ctx context.Context, snapshots []*entities.MetricsSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "tenant_id"}, {Name: "date"}, {Name: "group_by"}, {Name: "group_value"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"headcount", "available_engineers", "active_projects", "rolling_off_soon",
+			"bench_resources", "allocated_engineers", "utilisation_pct", "created_at",
+		}),
+	}).Create(&snapshots).Error
+}
+
+// Exists reports whether the organisation-wide snapshot for a date has been taken
+func (r *MetricsSnapshotRepository) Exists(ctx context.Context, date time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.MetricsSnapshot{}).
+		Where("date = ? AND group_by = ?", date.Format("2006-01-02"), entities.MetricsGroupAll).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// List returns a grouping's snapshots between two dates ordered by date and group value
+func (r *MetricsSnapshotRepository) List(ctx context.Context, groupBy string, from, to time.Time) ([]*entities.MetricsSnapshot, error) {
+	var snapshots []*entities.MetricsSnapshot
+	result := r.db.WithContext(ctx).
+		Where("group_by = ? AND date BETWEEN ? AND ?", groupBy, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("date, group_value").
+		Find(&snapshots)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return snapshots, nil
+}
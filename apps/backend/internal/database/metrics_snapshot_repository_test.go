@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+func TestMetricsSnapshotRepository(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewMetricsSnapshotRepository(db)
+	ctx := domain.WithTenant(context.Background(), domain.DefaultTenantID)
+	day := func(d int) time.Time { return time.Date(2026, time.October, d, 0, 0, 0, 0, time.UTC) }
+
+	var snapshots []*entities.MetricsSnapshot
+	for _, d := range []int{3, 1, 2} {
+		snapshots = append(snapshots,
+			&entities.MetricsSnapshot{Date: day(d), GroupBy: entities.MetricsGroupDepartment, GroupValue: "Sales", Headcount: d},
+			&entities.MetricsSnapshot{Date: day(d), GroupBy: entities.MetricsGroupDepartment, GroupValue: "", Headcount: 10 + d},
+		)
+	}
+	snapshots = append(snapshots, &entities.MetricsSnapshot{Date: day(2), GroupBy: entities.MetricsGroupAll, Headcount: 5})
+	if err := repo.Save(ctx, snapshots); err != nil {
+		t.Fatal(err)
+	}
+
+	// Retaking a day's snapshot replaces its rows
+	if err := repo.Save(ctx, []*entities.MetricsSnapshot{{Date: day(2), GroupBy: entities.MetricsGroupDepartment, GroupValue: "Sales", Headcount: 20}}); err != nil {
+		t.Fatalf("Save() of an existing snapshot error = %v", err)
+	}
+
+	listed, err := repo.List(ctx, entities.MetricsGroupDepartment, day(2), day(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, snapshot := range listed {
+		got = append(got, fmt.Sprintf("%s/%s:%d", snapshot.Date.Format("01-02"), snapshot.GroupValue, snapshot.Headcount))
+	}
+	if want := "10-02/:12 10-02/Sales:20 10-03/:13 10-03/Sales:3"; strings.Join(got, " ") != want {
+		t.Errorf("List() = %s, want %s", strings.Join(got, " "), want)
+	}
+
+	// Only the organisation-wide row marks a day's snapshot as taken
+	for d, want := range map[int]bool{1: false, 2: true, 3: false} {
+		if exists, err := repo.Exists(ctx, day(d)); err != nil || exists != want {
+			t.Errorf("Exists(2026-10-%02d) = %v, %v, want %v", d, exists, err, want)
+		}
+	}
+}
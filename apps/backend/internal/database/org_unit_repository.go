@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// subtreeQuery walks down the org unit tree from the given units of the tenant, see tenantParam
+const subtreeQuery = `
+WITH RECURSIVE subtree AS (
+    SELECT id FROM org_units WHERE id IN @ids AND tenant_id = COALESCE(CAST(@tenant AS integer), tenant_id)
+    UNION
+    SELECT o.id FROM org_units o JOIN subtree s ON o.parent_id = s.id
+)
+SELECT id FROM subtree ORDER BY id`
+
+// OrgUnitRepository implements domain.OrgUnitRepository
+type OrgUnitRepository struct {
+	db *gorm.DB
+}
+
+// NewOrgUnitRepository creates a new org unit repository
+func NewOrgUnitRepository(db *gorm.DB) domain.OrgUnitRepository {
+	return &OrgUnitRepository{db: db}
+}
+
+// List returns every org unit, companies first, then by name
+func (r *OrgUnitRepository) List(ctx context.Context) ([]*entities.OrgUnit, error) {
+	var units []*entities.OrgUnit
+	err := r.db.WithContext(ctx).
+		Order("CASE kind WHEN 'company' THEN 0 WHEN 'practice' THEN 1 ELSE 2 END, name, id").
+		Find(&units).Error
+	return units, err
+}
+
+// GetByID returns an org unit, or domain.ErrOrgUnitNotFound
+func (r *OrgUnitRepository) GetByID(ctx context.Context, id uint) (*entities.OrgUnit, error) {
+	var unit entities.OrgUnit
+	err := r.db.WithContext(ctx).First(&unit, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrOrgUnitNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &unit, nil
+}
+
+// Create stores an org unit
+func (r *OrgUnitRepository) Create(ctx context.Context, unit *entities.OrgUnit) error {
+	err := r.db.WithContext(ctx).Create(unit).Error
+	if isUniqueViolation(err) {
+		return domain.ErrOrgUnitExists
+	}
+	return err
+}
+
+// Update saves every field of an org unit, including cleared parent and head
+func (r *OrgUnitRepository) Update(ctx context.Context, unit *entities.OrgUnit) error {
+	err := r.db.WithContext(ctx).Save(unit).Error
+	if isUniqueViolation(err) {
+		return domain.ErrOrgUnitExists
+	}
+	return err
+}
+
+// Delete removes an org unit with no child units, employees or projects. Soft-deleted profiles
+// and projects are detached from it first.
+func (r *OrgUnitRepository) Delete(ctx context.Context, id uint) error {
+	tenant, err := tenantParam(ctx)
+	if err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var members int64
+		err := tx.Raw(`
+			SELECT (SELECT COUNT(*) FROM org_units WHERE parent_id = @id AND tenant_id = COALESCE(CAST(@tenant AS integer), tenant_id))
+			     + (SELECT COUNT(*) FROM employee_profiles WHERE org_unit_id = @id AND deleted_at IS NULL AND tenant_id = COALESCE(CAST(@tenant AS integer), tenant_id))
+			     + (SELECT COUNT(*) FROM projects WHERE org_unit_id = @id AND deleted_at IS NULL AND tenant_id = COALESCE(CAST(@tenant AS integer), tenant_id))`,
+			map[string]interface{}{"id": id, "tenant": tenant}).Scan(&members).Error
+		if err != nil {
+			return err
+		}
+		if members > 0 {
+			return domain.ErrOrgUnitInUse
+		}
+
+		for _, model := range []interface{}{&entities.EmployeeProfile{}, &entities.Project{}} {
+			if err := tx.Unscoped().Model(model).Where("org_unit_id = ?", id).Update("org_unit_id", nil).Error; err != nil {
+				return err
+			}
+		}
+		result := tx.Delete(&entities.OrgUnit{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrOrgUnitNotFound
+		}
+		return nil
+	})
+}
+
+// SubtreeIDs returns the given units and every unit below them
+func (r *OrgUnitRepository) SubtreeIDs(ctx context.Context, ids []uint) ([]uint, error) {
+	var subtree []uint
+	if len(ids) == 0 {
+		return subtree, nil
+	}
+	tenant, err := tenantParam(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.WithContext(ctx).Raw(subtreeQuery, map[string]interface{}{"ids": ids, "tenant": tenant}).Scan(&subtree).Error
+	return subtree, err
+}
+
+// GetHeadedBy returns the org units a user heads
+func (r *OrgUnitRepository) GetHeadedBy(ctx context.Context, userID uint) ([]*entities.OrgUnit, error) {
+	var units []*entities.OrgUnit
+	err := r.db.WithContext(ctx).Where("head_id = ?", userID).Order("id").Find(&units).Error
+	return units, err
+}
+
+// GetMemberUnitID returns the org unit of a user's employee profile, or nil
+func (r *OrgUnitRepository) GetMemberUnitID(ctx context.Context, userID uint) (*uint, error) {
+	var profiles []entities.EmployeeProfile
+	err := r.db.WithContext(ctx).Select("org_unit_id").Where("user_id = ?", userID).Limit(1).Find(&profiles).Error
+	if err != nil || len(profiles) == 0 {
+		return nil, err
+	}
+	return profiles[0].OrgUnitID, nil
+}
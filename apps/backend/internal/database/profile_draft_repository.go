@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// ProfileDraftRepository implements the domain.ProfileDraftRepository interface
+type ProfileDraftRepository struct {
+	db *gorm.DB
+}
+
+// NewProfileDraftRepository creates a new profile draft repository
+func NewProfileDraftRepository(db *gorm.DB) domain.ProfileDraftRepository {
+	return &ProfileDraftRepository{
+		db: db,
+	}
+}
+
+// Create stores a new draft
+func (r *ProfileDraftRepository) Create(ctx context.Context, draft *entities.ProfileDraft) (*entities.ProfileDraft, error) {
+	result := r.db.WithContext(ctx).Create(draft)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return draft, nil
+}
+
+// GetByID retrieves a draft by ID
+func (r *ProfileDraftRepository) GetByID(ctx context.Context, id uint) (*entities.ProfileDraft, error) {
+	var draft entities.ProfileDraft
+	result := r.db.WithContext(ctx).First(&draft, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &draft, nil
+}
+
+// UpdateStatus closes a pending draft. The status condition makes a double confirm a no-op
+// rather than a second profile update.
+func (r *ProfileDraftRepository) UpdateStatus(ctx context.Context, draft *entities.ProfileDraft, status string) (bool, error) {
+	updates := map[string]interface{}{"status": status}
+	if status == entities.DraftStatusConfirmed {
+		now := time.Now()
+		updates["confirmed_at"] = now
+		draft.ConfirmedAt = &now
+	}
+	result := r.db.WithContext(ctx).Model(&entities.ProfileDraft{}).
+		Where("id = ? AND status = ?", draft.ID, entities.DraftStatusPending).
+		Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	draft.Status = status
+	return true, nil
+}
@@ -20,10 +20,14 @@ func NewProjectRepository(db *gorm.DB) domain.ProjectRepository {
 	}
 }
 
-// GetAll retrieves all projects from database
-func (r *ProjectRepository) GetAll(ctx context.Context) ([]*entities.Project, error) {
+// GetAll retrieves the projects in the given org units from database, all when nil
+func (r *ProjectRepository) GetAll(ctx context.Context, orgUnitIDs []uint) ([]*entities.Project, error) {
 	var projects []*entities.Project
-	result := r.db.WithContext(ctx).Preload("ProjectAllocations").Find(&projects)
+	query := r.db.WithContext(ctx).Preload("ProjectAllocations")
+	if orgUnitIDs != nil {
+		query = query.Where("org_unit_id IN ?", orgUnitIDs)
+	}
+	result := query.Find(&projects)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -42,7 +46,12 @@ func (r *ProjectRepository) GetByID(ctx context.Context, id int) (*entities.Proj
 
 // Create creates a new project in database
 func (r *ProjectRepository) Create(ctx context.Context, project *entities.Project) (*entities.Project, error) {
-	result := r.db.WithContext(ctx).Create(project)
+	query := r.db.WithContext(ctx)
+	if len(project.Embedding.Slice()) == 0 {
+		// The embedding is generated later by an enrichment job; store NULL rather than an empty vector
+		query = query.Omit("embedding")
+	}
+	result := query.Create(project)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -58,6 +67,12 @@ func (r *ProjectRepository) Update(ctx context.Context, id int, project *entitie
 	return project, nil
 }
 
+// UpdateEnrichment writes only the given enrichment columns, including zero values,
+// so a background job never overwrites fields edited while it ran
+func (r *ProjectRepository) UpdateEnrichment(ctx context.Context, id int, project *entities.Project, columns ...string) error {
+	return r.db.WithContext(ctx).Model(&entities.Project{}).Where("id = ?", id).Select(columns).Updates(project).Error
+}
+
 // Delete deletes a project from database
 func (r *ProjectRepository) Delete(ctx context.Context, id int) error {
 	result := r.db.WithContext(ctx).Delete(&entities.Project{}, "id = ?", id)
@@ -0,0 +1,26 @@
+package database
+
+import (
+	"context"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// PromptAuditRepository implements the domain.PromptAuditRepository interface
+type PromptAuditRepository struct {
+	db *gorm.DB
+}
+
+// NewPromptAuditRepository creates a new prompt audit repository
+func NewPromptAuditRepository(db *gorm.DB) domain.PromptAuditRepository {
+	return &PromptAuditRepository{
+		db: db,
+	}
+}
+
+// Create records a prompt as sent to an external model
+func (r *PromptAuditRepository) Create(ctx context.Context, log *entities.PromptAuditLog) error {
+	return r.db.WithContext(ctx).Create(log).Error
+}
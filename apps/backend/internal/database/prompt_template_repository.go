@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PromptTemplateRepository implements the domain.PromptTemplateRepository interface. A context with
+// a tenant reads that tenant's versions ahead of the deployment defaults and writes only its own;
+// a context marked by domain.AllTenants reads and writes the defaults.
+type PromptTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewPromptTemplateRepository creates a new prompt template repository
+func NewPromptTemplateRepository(db *gorm.DB) domain.PromptTemplateRepository {
+	return &PromptTemplateRepository{
+		db: db,
+	}
+}
+
+// GetActive retrieves the active override for a template, the tenant's before the default
+func (r *PromptTemplateRepository) GetActive(ctx context.Context, name string) (*entities.PromptTemplate, error) {
+	query, err := r.visible(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var template entities.PromptTemplate
+	result := query.Where("name = ? AND active = ?", name, true).Order("tenant_id IS NULL").First(&template)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &template, nil
+}
+
+// GetByVersion retrieves a specific stored version of a template, the tenant's before the default
+func (r *PromptTemplateRepository) GetByVersion(ctx context.Context, name string, version string) (*entities.PromptTemplate, error) {
+	query, err := r.visible(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var template entities.PromptTemplate
+	result := query.Where("name = ? AND version = ?", name, version).Order("tenant_id IS NULL").First(&template)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &template, nil
+}
+
+// GetByName retrieves the stored versions of a template a tenant sees: its own, and the defaults it
+// has not replaced. Defaults are listed inactive once the tenant has an active version of its own.
+func (r *PromptTemplateRepository) GetByName(ctx context.Context, name string) ([]*entities.PromptTemplate, error) {
+	query, err := r.visible(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var stored []*entities.PromptTemplate
+	result := query.Where("name = ?", name).Order("created_at").Find(&stored)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	owned := make(map[string]bool)
+	ownActive := false
+	for _, t := range stored {
+		if t.TenantID != nil {
+			owned[t.Version] = true
+			ownActive = ownActive || t.Active
+		}
+	}
+	templates := make([]*entities.PromptTemplate, 0, len(stored))
+	for _, t := range stored {
+		if t.TenantID == nil {
+			if owned[t.Version] {
+				continue
+			}
+			t.Active = t.Active && !ownActive
+		}
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+// Save creates a template version or replaces the body of an existing one, for the context's tenant
+func (r *PromptTemplateRepository) Save(ctx context.Context, template *entities.PromptTemplate) (*entities.PromptTemplate, error) {
+	tenantID, err := promptTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	template.TenantID = tenantID
+
+	// Tenant versions and defaults have separate partial unique indexes, see migration 023
+	onConflict := clause.OnConflict{
+		Columns:     []clause.Column{{Name: "tenant_id"}, {Name: "name"}, {Name: "version"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "tenant_id IS NOT NULL"}}},
+		DoUpdates:   clause.AssignmentColumns([]string{"body", "created_by", "updated_at"}),
+	}
+	if tenantID == nil {
+		onConflict.Columns = []clause.Column{{Name: "name"}, {Name: "version"}}
+		onConflict.TargetWhere = clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "tenant_id IS NULL"}}}
+	}
+	result := r.db.WithContext(ctx).Clauses(onConflict).Omit("active").Create(template)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.GetByVersion(ctx, template.Name, template.Version)
+}
+
+// Activate makes one of the context tenant's stored versions its active override; an empty version
+// deactivates all of its overrides, so the default applies again
+func (r *PromptTemplateRepository) Activate(ctx context.Context, name string, version string) error {
+	tenantID, err := promptTenant(ctx)
+	if err != nil {
+		return err
+	}
+	owned := func(tx *gorm.DB) *gorm.DB {
+		if tenantID == nil {
+			return tx.Model(&entities.PromptTemplate{}).Where("tenant_id IS NULL AND name = ?", name)
+		}
+		return tx.Model(&entities.PromptTemplate{}).Where("tenant_id = ? AND name = ?", *tenantID, name)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := owned(tx).Update("active", false).Error; err != nil {
+			return err
+		}
+		if version == "" {
+			return nil
+		}
+		result := owned(tx).Where("version = ?", version).Update("active", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// visible scopes a query to the context tenant's versions and the deployment defaults
+func (r *PromptTemplateRepository) visible(ctx context.Context) (*gorm.DB, error) {
+	tenantID, err := promptTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := r.db.WithContext(ctx)
+	if tenantID == nil {
+		return query.Where("tenant_id IS NULL"), nil
+	}
+	return query.Where("(tenant_id = ? OR tenant_id IS NULL)", *tenantID), nil
+}
+
+// promptTenant returns the context's tenant, or nil for the deployment defaults under domain.AllTenants
+func promptTenant(ctx context.Context) (*uint, error) {
+	if tenantID, ok := domain.TenantFromContext(ctx); ok {
+		return &tenantID, nil
+	}
+	if domain.SpansTenants(ctx) {
+		return nil, nil
+	}
+	return nil, domain.ErrTenantRequired
+}
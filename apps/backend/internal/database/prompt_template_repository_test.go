@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+func TestPromptTemplateRepositoryPerTenant(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewPromptTemplateRepository(db)
+	defaults := domain.AllTenants(context.Background())
+	acme := domain.WithTenant(context.Background(), 2)
+	globex := domain.WithTenant(context.Background(), 3)
+	const name = "summarize_project"
+
+	save := func(ctx context.Context, version string, body string) {
+		t.Helper()
+		if _, err := repo.Save(ctx, &entities.PromptTemplate{Name: name, Version: version, Body: body}); err != nil {
+			t.Fatalf("Save(%s) error = %v", version, err)
+		}
+	}
+	save(defaults, "v3", "default")
+	save(acme, "v3", "acme")
+	save(acme, "v3", "acme edited")
+	if err := repo.Activate(defaults, name, "v3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Activate(acme, name, "v3"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		tenant string
+		ctx    context.Context
+		body   string
+	}{
+		{"acme", acme, "acme edited"},
+		{"globex", globex, "default"},
+		{"defaults", defaults, "default"},
+	} {
+		active, err := repo.GetActive(tc.ctx, name)
+		if err != nil {
+			t.Fatalf("%s: GetActive() error = %v", tc.tenant, err)
+		}
+		if active.Body != tc.body {
+			t.Errorf("%s: active body = %q, want %q", tc.tenant, active.Body, tc.body)
+		}
+	}
+
+	// Acme's own v3 replaces the default one in its listing
+	listed, err := repo.GetByName(acme, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 1 || listed[0].Body != "acme edited" || !listed[0].Active {
+		t.Errorf("GetByName() for Acme = %+v, want only its active v3", listed)
+	}
+
+	// Globex cannot activate a version it has not stored
+	if err := repo.Activate(globex, name, "v3"); err == nil {
+		t.Error("Activate() of another tenant's version succeeded, want an error")
+	}
+	if _, err := repo.GetActive(context.Background(), name); err != domain.ErrTenantRequired {
+		t.Errorf("GetActive() without a tenant error = %v, want %v", err, domain.ErrTenantRequired)
+	}
+}
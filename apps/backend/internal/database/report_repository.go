@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// reportBatchSize is how many records are loaded at a time while streaming a report
+const reportBatchSize = 500
+
+// ReportRepository implements domain.ReportRepository
+type ReportRepository struct {
+	db *gorm.DB
+}
+
+// NewReportRepository creates a new report repository
+func NewReportRepository(db *gorm.DB) domain.ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// EachProfile streams profiles of employees who joined before the period ends and had not left before it starts
+func (r *ReportRepository) EachProfile(ctx context.Context, period domain.ReportPeriod, fn func(*entities.EmployeeProfile) error) error {
+	var batch []*entities.EmployeeProfile
+	return r.db.WithContext(ctx).
+		Preload("User").
+		Where("date_of_joining IS NULL OR date_of_joining < ?", period.To.AddDate(0, 0, 1)).
+		Where("end_date IS NULL OR end_date >= ?", period.From).
+		FindInBatches(&batch, reportBatchSize, func(tx *gorm.DB, _ int) error {
+			for _, profile := range batch {
+				if err := fn(profile); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+// EachAllocation streams allocations that start before the period ends and end on or after its first day
+func (r *ReportRepository) EachAllocation(ctx context.Context, period domain.ReportPeriod, fn func(*entities.ProjectAllocation) error) error {
+	var batch []*entities.ProjectAllocation
+	return r.db.WithContext(ctx).
+		Preload("Project").
+		Preload("Employee").
+		Where("start_date < ?", period.To.AddDate(0, 0, 1)).
+		Where("end_date IS NULL OR end_date >= ?", period.From).
+		FindInBatches(&batch, reportBatchSize, func(tx *gorm.DB, _ int) error {
+			for _, allocation := range batch {
+				if err := fn(allocation); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
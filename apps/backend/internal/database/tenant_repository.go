@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// TenantRepository implements domain.TenantRepository. Tenants themselves are not tenant-scoped.
+type TenantRepository struct {
+	db *gorm.DB
+}
+
+// NewTenantRepository creates a new tenant repository
+func NewTenantRepository(db *gorm.DB) domain.TenantRepository {
+	return &TenantRepository{db: db}
+}
+
+// List returns every tenant by ID
+func (r *TenantRepository) List(ctx context.Context) ([]*entities.Tenant, error) {
+	var tenants []*entities.Tenant
+	err := r.db.WithContext(ctx).Order("id").Find(&tenants).Error
+	return tenants, err
+}
+
+// GetByID returns a tenant, or domain.ErrTenantNotFound
+func (r *TenantRepository) GetByID(ctx context.Context, id uint) (*entities.Tenant, error) {
+	var tenant entities.Tenant
+	err := r.db.WithContext(ctx).First(&tenant, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrTenantNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+// GetBySignUpDomain returns the tenant whose signup_domains list an email domain, or domain.ErrTenantNotFound
+func (r *TenantRepository) GetBySignUpDomain(ctx context.Context, emailDomain string) (*entities.Tenant, error) {
+	var tenant entities.Tenant
+	err := r.db.WithContext(ctx).Where("? = ANY(string_to_array(signup_domains, ','))", emailDomain).Order("id").First(&tenant).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrTenantNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+// Create stores a tenant, or returns domain.ErrTenantExists
+func (r *TenantRepository) Create(ctx context.Context, tenant *entities.Tenant) error {
+	err := r.db.WithContext(ctx).Create(tenant).Error
+	if isUniqueViolation(err) {
+		return domain.ErrTenantExists
+	}
+	return err
+}
+
+// Update saves every field of a tenant, including cleared settings
+func (r *TenantRepository) Update(ctx context.Context, tenant *entities.Tenant) error {
+	return r.db.WithContext(ctx).Save(tenant).Error
+}
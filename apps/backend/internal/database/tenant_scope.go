@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// TenantScope is a GORM plugin that isolates tenants in every repository. For models with a
+// TenantID, statements run with a context scoped by domain.WithTenant only read, update and delete
+// that tenant's rows, and new rows are stamped with it. Statements on those models fail with
+// domain.ErrTenantRequired unless the context has a tenant or is marked by domain.AllTenants.
+// Raw SQL is not rewritten: raw queries filter on tenantParam themselves. A nullable TenantID marks
+// rows shared with every tenant, which the plugin leaves to the model's repository.
+type TenantScope struct{}
+
+// Name returns the plugin's name
+func (TenantScope) Name() string {
+	return "tenant_scope"
+}
+
+// Initialize registers the plugin's callbacks ahead of GORM's own
+func (TenantScope) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("tenant_scope:create", assignTenant); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Before("gorm:query").Register("tenant_scope:query", scopeToTenant); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("tenant_scope:row", scopeToTenant); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("tenant_scope:update", scopeToTenant); err != nil {
+		return err
+	}
+	return db.Callback().Delete().Before("gorm:delete").Register("tenant_scope:delete", scopeToTenant)
+}
+
+// tenantField returns the statement model's TenantID field and the context's tenant, if both exist.
+// A tenant-owned model without a tenant adds domain.ErrTenantRequired unless the context spans tenants.
+func tenantField(db *gorm.DB) (*schema.Field, uint, bool) {
+	if db.Statement.Schema == nil {
+		return nil, 0, false
+	}
+	field := db.Statement.Schema.LookUpField("TenantID")
+	if field == nil || field.FieldType.Kind() == reflect.Ptr {
+		return nil, 0, false
+	}
+	tenantID, ok := domain.TenantFromContext(db.Statement.Context)
+	if !ok {
+		if !domain.SpansTenants(db.Statement.Context) {
+			db.AddError(domain.ErrTenantRequired)
+		}
+		return nil, 0, false
+	}
+	return field, tenantID, true
+}
+
+// scopeToTenant adds a tenant_id condition on the statement's own table
+func scopeToTenant(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	field, tenantID, ok := tenantField(db)
+	if !ok {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: tenantID},
+	}})
+}
+
+// assignTenant stamps new rows with the context's tenant and rejects rows set to another one
+func assignTenant(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	field, tenantID, ok := tenantField(db)
+	if !ok {
+		return
+	}
+	assign := func(record reflect.Value) {
+		current, isZero := field.ValueOf(db.Statement.Context, record)
+		if isZero {
+			if err := field.Set(db.Statement.Context, record, tenantID); err != nil {
+				db.AddError(err)
+			}
+			return
+		}
+		if id, _ := current.(uint); id != tenantID {
+			db.AddError(domain.ErrTenantMismatch)
+		}
+	}
+
+	switch value := reflect.Indirect(db.Statement.ReflectValue); value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			assign(reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		assign(value)
+	}
+}
+
+// tenantParam returns the context's tenant as a raw SQL parameter, or NULL for a context marked by
+// domain.AllTenants. Raw queries compare it as tenant_id = COALESCE(CAST(? AS integer), tenant_id),
+// so only that marker sees every tenant; any other context without a tenant is refused.
+func tenantParam(ctx context.Context) (interface{}, error) {
+	if tenantID, ok := domain.TenantFromContext(ctx); ok {
+		return tenantID, nil
+	}
+	if domain.SpansTenants(ctx) {
+		return nil, nil
+	}
+	return nil, domain.ErrTenantRequired
+}
+
+// withTenantSession runs fn in a transaction with app.tenant_id set to the context's tenant, which
+// the optional row-level security policies in migrations/optional/tenant_rls.sql check. Without a
+// tenant fn runs directly.
+func withTenantSession(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return fn(db.WithContext(ctx))
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT set_config('app.tenant_id', ?, true)", strconv.FormatUint(uint64(tenantID), 10)).Error; err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
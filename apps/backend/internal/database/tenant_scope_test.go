@@ -0,0 +1,247 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pgvector/pgvector-go"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// tenantVector returns an embedding that is most similar to the one with the same seed
+func tenantVector(seed int) []float32 {
+	vector := make([]float32, 1536)
+	for i := range vector {
+		vector[i] = 1
+	}
+	vector[seed] = 2
+	return vector
+}
+
+// insertTenantFixture stores two tenants with two available employees and one project each.
+// Tenant 2's employees are the most similar to both projects, so a leak would rank them first.
+func insertTenantFixture(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	var users []*entities.User
+	var profiles []*entities.EmployeeProfile
+	for i := 1; i <= 4; i++ {
+		tenantID := uint(1 + (i-1)/2)
+		users = append(users, &entities.User{
+			ID: uint(i), TenantID: tenantID, FirstName: "Test", LastName: fmt.Sprint(i),
+			Email: fmt.Sprintf("user%d@example.com", i), Role: "Employee",
+		})
+		profiles = append(profiles, &entities.EmployeeProfile{
+			UserID: uint(i), TenantID: tenantID, Type: "Software Engineer", Skills: entities.Skills{"go"},
+			Embedding: pgvector.NewVector(tenantVector(i + 10*int(tenantID))),
+		})
+	}
+	projects := []*entities.Project{
+		{ID: 1, TenantID: 1, Name: "Tenant 1 project", RequiredSeats: 1, Embedding: pgvector.NewVector(tenantVector(23))},
+		{ID: 2, TenantID: 2, Name: "Tenant 2 project", RequiredSeats: 1, Embedding: pgvector.NewVector(tenantVector(24))},
+	}
+	for _, batch := range []interface{}{
+		[]*entities.Tenant{{ID: 1, Name: "One", Slug: "one"}, {ID: 2, Name: "Two", Slug: "two"}},
+		users, profiles, projects,
+	} {
+		if err := db.Create(batch).Error; err != nil {
+			t.Fatalf("failed to insert fixture: %v", err)
+		}
+	}
+}
+
+func matchedUserIDs(matches []*domain.SimilarityMatch) []uint {
+	ids := make([]uint, len(matches))
+	for i, match := range matches {
+		ids[i] = match.Profile.UserID
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func TestVectorSearchIsTenantScoped(t *testing.T) {
+	db := openTestDB(t)
+	insertTenantFixture(t, db)
+	repo := NewEmployeeProfileRepository(db)
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		projectID string
+		want      []uint
+	}{
+		{"own project", domain.WithTenant(context.Background(), 1), "1", []uint{1, 2}},
+		{"other tenant's project", domain.WithTenant(context.Background(), 1), "2", []uint{}},
+		{"all tenants use the project's tenant", domain.AllTenants(context.Background()), "2", []uint{3, 4}},
+		{"second tenant", domain.WithTenant(context.Background(), 2), "2", []uint{3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, search := range map[string]func(context.Context, string, int) ([]*domain.SimilarityMatch, error){
+				"GetSimilarAvailableProfiles":         repo.GetSimilarAvailableProfiles,
+				"GetSimilarAvailableProfilesWithUser": repo.GetSimilarAvailableProfilesWithUser,
+			} {
+				matches, err := search(tt.ctx, tt.projectID, 10)
+				if err != nil {
+					t.Fatalf("%s() error = %v", name, err)
+				}
+				if got := matchedUserIDs(matches); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+					t.Errorf("%s() = users %v, want %v", name, got, tt.want)
+				}
+			}
+		})
+	}
+
+	t.Run("talent search", func(t *testing.T) {
+		matches, err := repo.Search(domain.WithTenant(context.Background(), 1), &domain.ProfileSearch{Embedding: tenantVector(23), Limit: 10})
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		var got []uint
+		for _, match := range matches {
+			got = append(got, match.Profile.UserID)
+		}
+		sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+		if fmt.Sprint(got) != "[1 2]" {
+			t.Errorf("Search() = users %v, want [1 2]", got)
+		}
+	})
+
+	t.Run("no tenant", func(t *testing.T) {
+		if _, err := repo.GetSimilarAvailableProfiles(context.Background(), "1", 10); !errors.Is(err, domain.ErrTenantRequired) {
+			t.Errorf("GetSimilarAvailableProfiles() without a tenant error = %v, want %v", err, domain.ErrTenantRequired)
+		}
+		if _, err := repo.Search(context.Background(), &domain.ProfileSearch{Embedding: tenantVector(23), Limit: 10}); !errors.Is(err, domain.ErrTenantRequired) {
+			t.Errorf("Search() without a tenant error = %v, want %v", err, domain.ErrTenantRequired)
+		}
+	})
+}
+
+func TestTenantScopePlugin(t *testing.T) {
+	db := openTestDB(t)
+	insertTenantFixture(t, db)
+	repo := NewEmployeeProfileRepository(db)
+	tenant2 := domain.WithTenant(context.Background(), 2)
+
+	profiles, err := repo.GetAll(tenant2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 2 || profiles[0].TenantID != 2 || profiles[1].TenantID != 2 {
+		t.Errorf("GetAll() in tenant 2 returned %d profiles, want tenant 2's 2", len(profiles))
+	}
+	if _, err := repo.GetByUserID(tenant2, "1"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetByUserID() of another tenant's profile error = %v, want not found", err)
+	}
+
+	// Updates and deletes cannot reach another tenant's rows
+	if result := db.WithContext(tenant2).Model(&entities.User{}).Where("id = ?", 1).Update("first_name", "Leaked"); result.Error != nil || result.RowsAffected != 0 {
+		t.Errorf("cross-tenant update affected %d rows, error %v", result.RowsAffected, result.Error)
+	}
+	if result := db.WithContext(tenant2).Delete(&entities.Project{}, 1); result.Error != nil || result.RowsAffected != 0 {
+		t.Errorf("cross-tenant delete affected %d rows, error %v", result.RowsAffected, result.Error)
+	}
+
+	// New rows are stamped with the context's tenant and cannot be created in another
+	user := &entities.User{ID: 5, FirstName: "New", Email: "new@example.com", Role: "Employee"}
+	if err := db.WithContext(tenant2).Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	if user.TenantID != 2 {
+		t.Errorf("created user in tenant %d, want 2", user.TenantID)
+	}
+	other := &entities.User{ID: 6, TenantID: 1, FirstName: "Other", Email: "other@example.com", Role: "Employee"}
+	if err := db.WithContext(tenant2).Create(other).Error; !errors.Is(err, domain.ErrTenantMismatch) {
+		t.Errorf("Create() in another tenant error = %v, want %v", err, domain.ErrTenantMismatch)
+	}
+
+	// Without a tenant or the AllTenants marker, tenant-owned rows are refused rather than all returned
+	if _, err := repo.GetAll(context.Background()); !errors.Is(err, domain.ErrTenantRequired) {
+		t.Errorf("GetAll() without a tenant error = %v, want %v", err, domain.ErrTenantRequired)
+	}
+	unscoped := &entities.User{ID: 7, FirstName: "Nobody", Email: "nobody@example.com", Role: "Employee"}
+	if err := db.WithContext(context.Background()).Create(unscoped).Error; !errors.Is(err, domain.ErrTenantRequired) {
+		t.Errorf("Create() without a tenant error = %v, want %v", err, domain.ErrTenantRequired)
+	}
+	var tenants []*entities.Tenant
+	if err := db.WithContext(context.Background()).Find(&tenants).Error; err != nil || len(tenants) != 2 {
+		t.Errorf("tenants are not tenant-owned, but Find() returned %d, %v", len(tenants), err)
+	}
+	all, err := repo.GetAll(domain.AllTenants(context.Background()))
+	if err != nil || len(all) != 4 {
+		t.Errorf("GetAll() across tenants = %d profiles, %v, want all 4", len(all), err)
+	}
+}
+
+// TestTenantScopeFailsClosed builds statements without running them, so it needs no database
+func TestTenantScopeFailsClosed(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Use(TenantScope{}); err != nil {
+		t.Fatal(err)
+	}
+
+	unscoped := context.Background()
+	statements := map[string]func(ctx context.Context) error{
+		"query": func(ctx context.Context) error { return db.WithContext(ctx).Find(&[]entities.User{}).Error },
+		"count": func(ctx context.Context) error {
+			return db.WithContext(ctx).Model(&entities.Project{}).Count(new(int64)).Error
+		},
+		"create": func(ctx context.Context) error {
+			return db.WithContext(ctx).Create(&entities.User{Email: "new@example.com"}).Error
+		},
+		"update": func(ctx context.Context) error {
+			return db.WithContext(ctx).Model(&entities.User{}).Where("id = ?", 1).Update("first_name", "Changed").Error
+		},
+		"delete": func(ctx context.Context) error { return db.WithContext(ctx).Delete(&entities.Project{}, 1).Error },
+	}
+	for name, run := range statements {
+		if err := run(unscoped); !errors.Is(err, domain.ErrTenantRequired) {
+			t.Errorf("%s without a tenant error = %v, want %v", name, err, domain.ErrTenantRequired)
+		}
+		if err := run(domain.WithTenant(unscoped, 2)); err != nil {
+			t.Errorf("%s in a tenant error = %v", name, err)
+		}
+		if err := run(domain.AllTenants(unscoped)); err != nil {
+			t.Errorf("%s across tenants error = %v", name, err)
+		}
+		// A worker that spans tenants scopes each record again
+		if err := run(domain.WithTenant(domain.AllTenants(unscoped), 2)); err != nil {
+			t.Errorf("%s in a tenant after AllTenants error = %v", name, err)
+		}
+	}
+
+	// Tenants themselves are not tenant-owned
+	if err := db.WithContext(unscoped).Find(&[]entities.Tenant{}).Error; err != nil {
+		t.Errorf("listing tenants without a tenant error = %v", err)
+	}
+
+	scoped := db.WithContext(domain.WithTenant(unscoped, 2)).Find(&[]entities.User{}).Statement
+	if sql := scoped.SQL.String(); !strings.Contains(sql, `"users"."tenant_id" = $1`) || fmt.Sprint(scoped.Vars[0]) != "2" {
+		t.Errorf("scoped query = %s %v, want a tenant_id condition on tenant 2", sql, scoped.Vars)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		ctx     context.Context
+		want    interface{}
+		wantErr error
+	}{
+		{"tenant", domain.WithTenant(unscoped, 2), uint(2), nil},
+		{"all tenants", domain.AllTenants(unscoped), nil, nil},
+		{"no tenant", unscoped, nil, domain.ErrTenantRequired},
+	} {
+		if got, err := tenantParam(tt.ctx); got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("tenantParam() with %s = %v, %v, want %v, %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
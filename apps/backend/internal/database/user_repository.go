@@ -2,9 +2,12 @@ package database
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/talent-fit/backend/internal/domain"
 	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/utils"
 	"gorm.io/gorm"
 )
 
@@ -20,13 +23,31 @@ func NewUserRepository(db *gorm.DB) domain.UserRepository {
 	}
 }
 
-// GetAll retrieves all users from database
-func (r *UserRepository) GetAll(ctx context.Context) error {
-	// TODO: Implement database query to get all users
-	// TODO: var users []models.User
-	// TODO: result := r.db.WithContext(ctx).Find(&users)
-	// TODO: return result.Error
-	return nil
+// List retrieves a page of users matching a filter, by name
+func (r *UserRepository) List(ctx context.Context, filter *domain.UserFilter) ([]*entities.User, int64, error) {
+	query := r.db.WithContext(ctx).Model(&entities.User{})
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		pattern := "%" + strings.ToLower(q) + "%"
+		query = query.Where("LOWER(first_name) LIKE ? OR LOWER(last_name) LIKE ? OR LOWER(email) LIKE ? OR LOWER(first_name || ' ' || last_name) LIKE ?",
+			pattern, pattern, pattern, pattern)
+	}
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	switch filter.Status {
+	case domain.UserStatusActive:
+		query = query.Where("deactivated_at IS NULL")
+	case domain.UserStatusDeactivated:
+		query = query.Where("deactivated_at IS NOT NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var users []*entities.User
+	err := query.Order("first_name, last_name, id").Limit(filter.Limit).Offset(filter.Offset).Find(&users).Error
+	return users, total, err
 }
 
 // GetByID retrieves a user by ID from database
@@ -37,25 +58,58 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*entities.User
 	return &user, result.Error
 }
 
+// userUpdateColumns are the columns Update writes, empty values included so fields can be cleared.
+// Deactivation, revoked sessions and the sign-in identity have their own methods.
+var userUpdateColumns = []string{"first_name", "last_name", "email", "role", "slack_user_id", "external_id", "manager_id", "updated_at"}
+
 // Update updates a user in database
 func (r *UserRepository) Update(ctx context.Context, user *entities.User) error {
-	result := r.db.WithContext(ctx).Updates(user).Where("id = ?", user.ID)
+	result := r.db.WithContext(ctx).Model(user).Select(userUpdateColumns).Updates(user)
 	return result.Error
 }
 
-// Create creates a new user in database
-func (r *UserRepository) Create(ctx context.Context) error {
-	// TODO: Implement database query to create user
-	// TODO: result := r.db.WithContext(ctx).Create(&user)
-	// TODO: return result.Error
-	return nil
+// Deactivate marks a user deactivated, clears their availability flag, ends their active
+// allocations and deletes those not yet started, in one transaction. Deactivating a deactivated
+// user changes nothing.
+func (r *UserRepository) Deactivate(ctx context.Context, id uint, now time.Time) error {
+	today := utils.DateOf(now)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.User{}).Where("id = ? AND deactivated_at IS NULL", id).Update("deactivated_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var count int64
+			if err := tx.Model(&entities.User{}).Where("id = ?", id).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return domain.ErrUserNotFound
+			}
+			return nil
+		}
+		if err := tx.Model(&entities.EmployeeProfile{}).Where("user_id = ?", id).Update("availability_flag", false).Error; err != nil {
+			return err
+		}
+		// Ending a future allocation today would put its end before its start
+		if err := tx.Where("employee_id = ? AND start_date > ?", id, today).Delete(&entities.ProjectAllocation{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entities.ProjectAllocation{}).
+			Where("employee_id = ? AND start_date <= ? AND (end_date IS NULL OR end_date > ?)", id, today, today).
+			Update("end_date", today).Error
+	})
 }
 
-// Delete deletes a user from database
-func (r *UserRepository) Delete(ctx context.Context, id string) error {
-	// TODO: Implement database query to delete user
-	// TODO: result := r.db.WithContext(ctx).Delete(&models.User{}, "id = ?", id)
-	// TODO: return result.Error
+// Reactivate clears a user's deactivation
+func (r *UserRepository) Reactivate(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Model(&entities.User{}).Where("id = ?", id).Update("deactivated_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
 	return nil
 }
 
@@ -72,3 +126,38 @@ func (r *UserRepository) CreateWithEntity(ctx context.Context, user *entities.Us
     result := r.db.WithContext(ctx).Session(&gorm.Session{PrepareStmt: false}).Create(user)
     return result.Error
 }
+
+// LinkIdentity records the provider and subject a user signs in with, unless one is already
+// recorded, e.g. by a concurrent first sign-in
+func (r *UserRepository) LinkIdentity(ctx context.Context, id uint, provider, subject string) error {
+	result := r.db.WithContext(ctx).Model(&entities.User{}).
+		Where("id = ? AND auth_subject IS NULL", id).
+		Updates(map[string]interface{}{"auth_provider": provider, "auth_subject": subject})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrIdentityMismatch
+	}
+	return nil
+}
+
+// GetByExternalID retrieves a user by their HR system or import ID
+func (r *UserRepository) GetByExternalID(ctx context.Context, externalID string) (*entities.User, error) {
+	var user entities.User
+	result := r.db.WithContext(ctx).Where("external_id = ?", externalID).First(&user)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+// GetFirstByRole retrieves the user with a role created first
+func (r *UserRepository) GetFirstByRole(ctx context.Context, role string) (*entities.User, error) {
+	var user entities.User
+	result := r.db.WithContext(ctx).Where("role = ?", role).Order("id").First(&user)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &user, nil
+}
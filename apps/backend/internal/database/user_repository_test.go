@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/internal/utils"
+)
+
+func TestUserRepositoryUpdateClearsFields(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewUserRepository(db)
+	ctx := domain.WithTenant(context.Background(), domain.DefaultTenantID)
+
+	manager := &entities.User{ID: 1, FirstName: "Sam", Email: "sam@example.com", Role: "Manager"}
+	user := &entities.User{ID: 2, FirstName: "Jane", LastName: "Doe", Email: "jane@example.com", Role: "Employee", SlackUserID: "U123", ManagerID: &manager.ID}
+	if err := db.Create([]*entities.User{manager, user}).Error; err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := repo.GetByID(ctx, "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Deactivated after the user was loaded; updating the stale copy must not undo it
+	if err := repo.Deactivate(ctx, user.ID, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded.FirstName, loaded.LastName, loaded.SlackUserID, loaded.ManagerID = "", "", "", nil
+	if err := repo.Update(ctx, loaded); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	var stored entities.User
+	if err := db.First(&stored, user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.FirstName != "" || stored.LastName != "" || stored.SlackUserID != "" || stored.ManagerID != nil {
+		t.Errorf("stored user = %+v, want names, Slack user ID and manager cleared", stored)
+	}
+	if stored.DeactivatedAt == nil {
+		t.Error("Update() cleared the user's deactivation")
+	}
+}
+
+func TestUserRepositoryDeactivateEndsAllocations(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewUserRepository(db)
+	ctx := domain.WithTenant(context.Background(), domain.DefaultTenantID)
+	now := time.Now()
+	today := utils.DateOf(now)
+	day := func(offset int) time.Time { return today.AddDate(0, 0, offset) }
+	ended := day(-5)
+
+	fixtures := []interface{}{
+		[]*entities.User{{ID: 1, FirstName: "Jane", Email: "jane@example.com", Role: "Employee"}},
+		[]*entities.EmployeeProfile{{UserID: 1, Type: "Backend", AvailabilityFlag: true}},
+		[]*entities.Project{{ID: 1, Name: "Apollo", RequiredSeats: 4, StartDate: day(-60), EndDate: day(120)}},
+		[]*entities.ProjectAllocation{
+			{ID: 1, ProjectID: 1, EmployeeID: 1, AllocationType: string(models.AllocationFullTime), StartDate: day(-30)},
+			{ID: 2, ProjectID: 1, EmployeeID: 1, AllocationType: string(models.AllocationExtra), StartDate: day(-30), EndDate: &ended},
+			{ID: 3, ProjectID: 1, EmployeeID: 1, AllocationType: string(models.AllocationFullTime), StartDate: day(10)},
+		},
+	}
+	for _, batch := range fixtures {
+		if err := db.Omit("Embedding").Create(batch).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := repo.Deactivate(ctx, 1, now); err != nil {
+		t.Fatalf("Deactivate() error = %v", err)
+	}
+
+	var allocations []*entities.ProjectAllocation
+	if err := db.Order("id").Find(&allocations).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(allocations) != 2 {
+		t.Fatalf("%d allocations left, want the future allocation deleted", len(allocations))
+	}
+	if end := allocations[0].EndDate; end == nil || !end.Equal(today) {
+		t.Errorf("active allocation ends %v, want today", end)
+	}
+	if end := allocations[1].EndDate; end == nil || !end.Equal(ended) {
+		t.Errorf("ended allocation ends %v, want it unchanged at %v", end, ended)
+	}
+
+	var profile entities.EmployeeProfile
+	if err := db.Omit("Embedding").Where("user_id = ?", 1).First(&profile).Error; err != nil {
+		t.Fatal(err)
+	}
+	if profile.AvailabilityFlag {
+		t.Error("deactivated user is still flagged available")
+	}
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// AI features that usage is attributed to
+const (
+	FeatureProjectSummary   = "project_summary"
+	FeatureProjectEmbedding = "project_embedding"
+	FeatureProfileEmbedding = "profile_embedding"
+	FeatureMatchScoring     = "match_scoring"
+	FeatureTalentSearch     = "talent_search"
+	FeatureCVImport         = "cv_import"
+	FeatureUnattributed     = "unattributed"
+)
+
+// Entity types that trigger AI calls
+const (
+	EntityTypeProject = "project"
+	EntityTypeProfile = "profile"
+)
+
+// AIUsageContext attributes AI calls made with a context to a feature and entity
+type AIUsageContext struct {
+	Feature    string
+	EntityType string
+	EntityID   int
+}
+
+type aiUsageContextKey struct{}
+
+// WithAIUsage tags a context so AI calls made with it are attributed to the feature and entity.
+// An entityID of zero records the call without an entity, e.g. before a project is created.
+func WithAIUsage(ctx context.Context, feature string, entityType string, entityID int) context.Context {
+	return context.WithValue(ctx, aiUsageContextKey{}, AIUsageContext{Feature: feature, EntityType: entityType, EntityID: entityID})
+}
+
+// AIUsageFromContext returns the usage attribution set by WithAIUsage
+func AIUsageFromContext(ctx context.Context) (AIUsageContext, bool) {
+	usage, ok := ctx.Value(aiUsageContextKey{}).(AIUsageContext)
+	return usage, ok
+}
+
+// AIUsageAggregate is the usage of one feature on one day
+type AIUsageAggregate struct {
+	Day              time.Time `json:"day"`
+	Feature          string    `json:"feature"`
+	Calls            int64     `json:"calls"`
+	Failures         int64     `json:"failures"`
+	PromptTokens     int64     `json:"prompt_tokens"`
+	CompletionTokens int64     `json:"completion_tokens"`
+	CostUSD          float64   `json:"cost_usd"`
+	AvgLatencyMs     float64   `json:"avg_latency_ms"`
+}
+
+// AIUsageReport aggregates AI cost for a period together with the current budget position
+type AIUsageReport struct {
+	From             time.Time          `json:"from"`
+	To               time.Time          `json:"to"`
+	Daily            []AIUsageAggregate `json:"daily"`
+	TotalCostUSD     float64            `json:"total_cost_usd"`
+	MonthToDateUSD   float64            `json:"month_to_date_usd"`
+	MonthlyBudgetUSD float64            `json:"monthly_budget_usd"`
+	BudgetExceeded   bool               `json:"budget_exceeded"`
+}
+
+// AIUsageRepository defines the interface for AI usage data operations
+type AIUsageRepository interface {
+	Create(ctx context.Context, usage *entities.AIUsage) error
+	DailyByFeature(ctx context.Context, from time.Time, to time.Time) ([]AIUsageAggregate, error)
+	TotalCost(ctx context.Context, from time.Time, to time.Time) (float64, error)
+}
+
+// AIUsageRecorder records AI provider calls
+type AIUsageRecorder interface {
+	Record(ctx context.Context, usage *entities.AIUsage)
+}
+
+// AIBudget reports whether the monthly AI budget has been spent
+type AIBudget interface {
+	BudgetExceeded(ctx context.Context) (bool, error)
+}
+
+// AIUsageService defines the interface for AI usage accounting
+type AIUsageService interface {
+	AIUsageRecorder
+	AIBudget
+	GetReport(ctx context.Context, from time.Time, to time.Time) (*AIUsageReport, error)
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// APIKeyPrefix starts every API key, telling them apart from JWTs in the Authorization header
+const APIKeyPrefix = "tfk_"
+
+// API key errors
+var (
+	ErrInvalidAPIKey          = errors.New("invalid, expired or revoked API key")
+	ErrAPIKeyNotFound         = errors.New("API key not found")
+	ErrServiceAccountNotFound = errors.New("service account not found")
+	ErrServiceAccountExists   = errors.New("a service account with this name already exists")
+	ErrServiceAccountDisabled = errors.New("service account is disabled")
+	ErrInvalidAPIKeyRequest   = errors.New("invalid API key request")
+)
+
+// APIKeyRepository defines the interface for service account and API key data access
+type APIKeyRepository interface {
+	CreateServiceAccount(ctx context.Context, account *entities.ServiceAccount) error
+	// GetServiceAccount returns a service account with its keys, or ErrServiceAccountNotFound
+	GetServiceAccount(ctx context.Context, id uint) (*entities.ServiceAccount, error)
+	ListServiceAccounts(ctx context.Context) ([]*entities.ServiceAccount, error)
+	// DisableServiceAccount disables an account and revokes its keys in one transaction
+	DisableServiceAccount(ctx context.Context, id uint, now time.Time) error
+	CreateKey(ctx context.Context, key *entities.APIKey) error
+	// GetKey returns a key, or ErrAPIKeyNotFound
+	GetKey(ctx context.Context, id uint) (*entities.APIKey, error)
+	// GetKeyByHash returns a key with its service account, or ErrInvalidAPIKey
+	GetKeyByHash(ctx context.Context, keyHash string) (*entities.APIKey, error)
+	// RotateKey stores next and cuts old's expiry to oldExpiresAt, revoking it if that is not
+	// in the future, in one transaction
+	RotateKey(ctx context.Context, old *entities.APIKey, next *entities.APIKey, oldExpiresAt time.Time) error
+	// RevokeKey revokes a key, or returns ErrAPIKeyNotFound
+	RevokeKey(ctx context.Context, id uint, now time.Time) error
+	TouchKey(ctx context.Context, id uint, now time.Time) error
+}
+
+// APIKeyService manages service accounts and their keys, and authenticates keys
+type APIKeyService interface {
+	CreateServiceAccount(ctx context.Context, createdBy uint, request *models.ServiceAccountRequest) (*models.ServiceAccountModel, error)
+	ListServiceAccounts(ctx context.Context) ([]*models.ServiceAccountModel, error)
+	DisableServiceAccount(ctx context.Context, disabledBy uint, id uint) error
+	// CreateKey issues a key; its secret is only returned here
+	CreateKey(ctx context.Context, createdBy uint, serviceAccountID uint, request *models.APIKeyRequest) (*models.CreatedAPIKeyModel, error)
+	// RotateKey issues a key with the same scopes and lifetime, keeping the old one for a grace period
+	RotateKey(ctx context.Context, rotatedBy uint, id uint, request *models.RotateAPIKeyRequest) (*models.CreatedAPIKeyModel, error)
+	RevokeKey(ctx context.Context, revokedBy uint, id uint) error
+	// Authenticate returns the active key for a raw key, with its service account, or ErrInvalidAPIKey
+	Authenticate(ctx context.Context, rawKey string) (*entities.APIKey, error)
+}
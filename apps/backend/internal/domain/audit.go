@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// AuditRepository defines the interface for the audit log
+type AuditRepository interface {
+	Record(ctx context.Context, event *entities.AuditEvent) error
+	// List returns events newest first, filtered by action and email when given
+	List(ctx context.Context, action, email string, limit int) ([]*entities.AuditEvent, error)
+}
+
+// AuditService defines the interface for reading the audit log
+type AuditService interface {
+	List(ctx context.Context, action, email string, limit int) ([]*models.AuditEventModel, error)
+}
@@ -2,17 +2,130 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
 )
 
+// ErrInvalidRefreshToken is returned for a refresh token that is unknown, expired or revoked,
+// or whose user may no longer sign in
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+// ErrUnknownAuthProvider is returned for a login through a provider that is not configured
+var ErrUnknownAuthProvider = errors.New("unknown login provider")
+
+// ErrEmailDomainNotAllowed is returned for a login whose email is outside the provider's allowed domains
+var ErrEmailDomainNotAllowed = errors.New("email domain is not allowed")
+
+// ErrAccountInactive is returned when signing in a user who has been deactivated or whose
+// employment end date has passed
+var ErrAccountInactive = errors.New("account is no longer active")
+
+// ErrIdentityMismatch is returned for a login with a user's email from a provider account other
+// than the one the user first signed in with
+var ErrIdentityMismatch = errors.New("this account signs in with another identity")
+
+// ErrSelfImpersonation is returned when an admin tries to act as themselves
+var ErrSelfImpersonation = errors.New("cannot impersonate yourself")
+
 // GoogleAuthService defines the interface for Google authentication business logic
 type GoogleAuthService interface {
     AuthenticateWithGoogle(ctx context.Context, credential string) (*AuthResponse, error)
 }
 
+// Identity is who a provider's ID token identifies
+type Identity struct {
+	Provider string
+	Subject  string
+	Email    string
+	Name     string
+	Groups   []string
+	// Role is mapped from Groups, or empty if the provider leaves roles to the app
+	Role string
+}
+
+// AuthProvider is a login provider as shown to clients, which run the OpenID Connect flow
+// with the issuer and client ID and post the ID token to /auth/oidc/:name/login
+type AuthProvider struct {
+	Name     string `json:"name"`
+	Issuer   string `json:"issuer"`
+	ClientID string `json:"clientId"`
+}
+
+// IdentityVerifier verifies ID tokens from the configured login providers
+type IdentityVerifier interface {
+	Providers() []AuthProvider
+	Verify(ctx context.Context, provider, rawIDToken string) (*Identity, error)
+}
+
+// OIDCAuthService signs users in with an ID token from any configured OpenID Connect provider
+type OIDCAuthService interface {
+	Providers() []AuthProvider
+	// Authenticate verifies an ID token, creates the user on first sign-in and starts a session
+	Authenticate(ctx context.Context, provider, credential string) (*AuthResponse, error)
+}
+
+// DevAuthService signs in as any seeded user without a login provider, and lets admins act as
+// other users. It is only wired up when config.DevLoginEnabled.
+type DevAuthService interface {
+	// Login starts a session for the user with an email, or else the first user with a role
+	Login(ctx context.Context, email, role string) (*AuthResponse, error)
+	// Impersonate issues an access token, without a refresh token, for a user on behalf of an admin
+	Impersonate(ctx context.Context, actorID, userID uint) (*TokenPair, error)
+}
+
 // AuthResponse represents the result of authentication
 type AuthResponse struct {
     Token string
     Name  string
     Email string
     UserID uint
+    RefreshToken string
+    ExpiresAt    time.Time
+}
+
+// TokenPair is a short-lived access token with the refresh token that renews it
+type TokenPair struct {
+	AccessToken  string    `json:"token"`
+	RefreshToken string    `json:"refreshToken"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+// AuthTokenRepository stores refresh tokens and revoked access tokens
+type AuthTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token *entities.RefreshToken) error
+	// GetRefreshToken returns a refresh token by the hash of its value
+	GetRefreshToken(ctx context.Context, tokenHash string) (*entities.RefreshToken, error)
+	// RotateRefreshToken revokes a token and stores its successor; it fails with
+	// ErrInvalidRefreshToken if the token was revoked concurrently
+	RotateRefreshToken(ctx context.Context, old *entities.RefreshToken, next *entities.RefreshToken) error
+	// RevokeRefreshTokenFamily revokes every token of a session
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	// RevokeAccessToken adds an access token to the revocation list
+	RevokeAccessToken(ctx context.Context, token *entities.RevokedToken) error
+	// IsAccessTokenRevoked reports whether an access token's jti is on the revocation list
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+	// RevokeUserSessions revokes a user's refresh tokens and every access token issued up to now;
+	// it returns ErrUserNotFound for an unknown user
+	RevokeUserSessions(ctx context.Context, userID uint, now time.Time) error
+	// DeleteExpired removes refresh tokens and revocation entries that expired before a time
+	DeleteExpired(ctx context.Context, before time.Time) error
+}
+
+// TokenService issues, rotates and revokes the app's tokens
+type TokenService interface {
+	// Issue starts a session for a user with a new token pair
+	Issue(ctx context.Context, user *entities.User) (*TokenPair, error)
+	// Refresh exchanges a refresh token for a new pair, revoking the one presented. Presenting a
+	// refresh token that was already used revokes its whole session.
+	Refresh(ctx context.Context, refreshToken string) (*TokenPair, error)
+	// RevokeRefreshToken ends the session a refresh token belongs to; unknown tokens are ignored
+	RevokeRefreshToken(ctx context.Context, refreshToken string) error
+	// RevokeAccessToken revokes one access token until it expires
+	RevokeAccessToken(ctx context.Context, jti string, userID uint, expiresAt time.Time) error
+	// IsAccessTokenRevoked reports whether an access token has been revoked
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+	// RevokeAllSessions signs a user out everywhere
+	RevokeAllSessions(ctx context.Context, userID uint) error
 }
@@ -2,8 +2,15 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
 )
 
+// ErrInvalidMetricsGroup is returned for a trends grouping other than all, department or geo
+var ErrInvalidMetricsGroup = errors.New("groupBy must be all, department or geo")
+
 // ManagerDashboardMetrics represents the summary counts needed on the manager dashboard
 type ManagerDashboardMetrics struct {
 	AvailableEngineers int `json:"availableEngineers"`
@@ -13,9 +20,77 @@ type ManagerDashboardMetrics struct {
 	AllocatedEngineers int `json:"allocatedEngineers"`
 }
 
-// DashboardService defines the interface for computing dashboard metrics
-type DashboardService interface {
-	GetManagerDashboardMetrics(ctx context.Context) (*ManagerDashboardMetrics, error)
+// GroupMetrics is the dashboard metrics of the whole organisation or of one department or geo
+type GroupMetrics struct {
+	// Group is the department or geo; empty for the organisation and for employees without one
+	Group     string
+	Headcount int
+	ManagerDashboardMetrics
+	// UtilisationPct is the allocated share of headcount, full-time counting 1 and part-time 0.5
+	UtilisationPct float64
+}
+
+// DashboardMetricsRepository computes the dashboard metrics in the database. A day is a calendar
+// date in the database's time zone, and employees count only while employed on it:
+//
+//   - Allocated engineers: have an allocation that has started and not ended by the day
+//   - Bench resources: employed with no such allocation
+//   - Available engineers: availability_flag set, or on the bench
+//   - Active projects: started and not ended by the day; for a department or geo, those with an
+//     active allocation from someone in it
+//   - Rolling off soon: employees whose end or notice date is within the next 60 days, or who have
+//     an allocation ending within the next 30 days, each counted once
+type DashboardMetricsRepository interface {
+	// Today returns the current date by the database clock
+	Today(ctx context.Context) (time.Time, error)
+	// Compute returns the metrics on a day per group: one group for MetricsGroupAll, otherwise one
+	// per department or geo with employees in it. A nil day means today by the database clock.
+	// Org unit IDs limit the employees and projects counted; nil counts the whole organisation.
+	Compute(ctx context.Context, groupBy string, day *time.Time, orgUnitIDs []uint) ([]*GroupMetrics, error)
+}
+
+// MetricsPoint is one day's snapshot in a trend series
+type MetricsPoint struct {
+	Date      string `json:"date"`
+	Headcount int    `json:"headcount"`
+	ManagerDashboardMetrics
+	UtilisationPct float64 `json:"utilisationPct"`
 }
 
+// MetricsSeries is the daily snapshots of one group, oldest first
+type MetricsSeries struct {
+	// Group is the department or geo; empty for the organisation-wide series
+	Group  string         `json:"group"`
+	Points []MetricsPoint `json:"points"`
+}
+
+// MetricsTrends is the dashboard metrics over a date range for charts
+type MetricsTrends struct {
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	GroupBy string          `json:"groupBy"`
+	Series  []MetricsSeries `json:"series"`
+}
+
+// MetricsSnapshotRepository defines the interface for daily dashboard metrics
+type MetricsSnapshotRepository interface {
+	// Save stores snapshots, replacing any already taken for the same date and group
+	Save(ctx context.Context, snapshots []*entities.MetricsSnapshot) error
+	// Exists reports whether the organisation-wide snapshot for a date has been taken
+	Exists(ctx context.Context, date time.Time) (bool, error)
+	// List returns a grouping's snapshots between two dates, both included, by date and group
+	List(ctx context.Context, groupBy string, from, to time.Time) ([]*entities.MetricsSnapshot, error)
+}
 
+// DashboardService defines the interface for computing dashboard metrics
+type DashboardService interface {
+	// GetManagerDashboardMetrics returns today's metrics for the given org units, nil for all
+	GetManagerDashboardMetrics(ctx context.Context, orgUnitIDs []uint) (*ManagerDashboardMetrics, error)
+	// SnapshotMetrics stores a day's metrics, organisation-wide and per department and geo
+	SnapshotMetrics(ctx context.Context, day time.Time) error
+	// SnapshotToday takes today's snapshot by the database clock; unless replace is set, a
+	// snapshot already taken today is kept
+	SnapshotToday(ctx context.Context, replace bool) error
+	// GetMetricsTrends returns the stored snapshots between two dates for one grouping
+	GetMetricsTrends(ctx context.Context, from, to time.Time, groupBy string) (*MetricsTrends, error)
+}
@@ -10,9 +10,19 @@ type EmbeddingService interface {
 	// GenerateBatchEmbeddings generates embeddings for multiple texts
 	GenerateBatchEmbeddings(ctx context.Context, texts []string) ([][]float32, error)
 	
-	// SummarizeProject generates a structured summary of project requirements
-	SummarizeProject(ctx context.Context, description string, seats map[string]int) (string, error)
+	// SummarizeProject generates a structured summary of project requirements from a rendered summarize_project prompt
+	SummarizeProject(ctx context.Context, prompt *Prompt) (string, error)
 	
-	// GenerateMatchingScores uses the generated matching prompt to score candidates
-	GenerateMatchingScores(ctx context.Context, matchingPrompt string) (string, error)
+	// GenerateMatchingScores uses a rendered score_candidates prompt to score candidates
+	GenerateMatchingScores(ctx context.Context, prompt *Prompt) (string, error)
+	
+	// StreamMatchingScores is GenerateMatchingScores with the response streamed: onDelta receives
+	// each chunk of content as it arrives and the complete response is returned at the end
+	StreamMatchingScores(ctx context.Context, prompt *Prompt, onDelta func(chunk string)) (string, error)
+
+	// ParseTalentQuery turns a rendered parse_talent_query prompt into structured search filters as JSON
+	ParseTalentQuery(ctx context.Context, prompt *Prompt) (string, error)
+
+	// ExtractProfileFromCV proposes employee profile fields as JSON from a rendered extract_cv_profile prompt
+	ExtractProfileFromCV(ctx context.Context, prompt *Prompt) (string, error)
 }
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/talent-fit/backend/internal/entities"
 	"github.com/talent-fit/backend/internal/models"
@@ -14,25 +15,59 @@ type SimilarityMatch struct {
 	Status     string
 }
 
+// ProfileSearch is a structured talent search: filters are hard constraints and the embedding, when set, ranks the rest.
+// Values are already expanded to the spellings stored on profiles and compared case-insensitively.
+type ProfileSearch struct {
+	// Skills matches profiles with at least one of the skills; more matched skills rank higher
+	Skills []string
+	Types  []string
+	Geos   []string
+	// Industries are substrings of the colon-separated industry column
+	Industries         []string
+	MinExperienceYears *int
+	MaxExperienceYears *int
+	// AvailableBy keeps profiles that are free for work on that date
+	AvailableBy *time.Time
+	Embedding   []float32
+	Limit       int
+}
+
+// ProfileSearchMatch is a profile found by a ProfileSearch
+type ProfileSearchMatch struct {
+	Profile       *entities.EmployeeProfile
+	Similarity    float64
+	MatchedSkills int
+	// AvailableFrom is when current allocations end; nil while on an open-ended allocation
+	AvailableFrom *time.Time
+}
+
 // EmployeeProfileRepository defines the interface for employee profile data operations
 type EmployeeProfileRepository interface {
 	GetAll(ctx context.Context) ([]*entities.EmployeeProfile, error)
-	GetFiltered(ctx context.Context, skills []string, geos []string, availableOnly bool) ([]*entities.EmployeeProfile, error)
+	// GetFiltered filters profiles by skills, geos, availability and org units; nil org units match all
+	GetFiltered(ctx context.Context, skills []string, geos []string, availableOnly bool, orgUnitIDs []uint) ([]*entities.EmployeeProfile, error)
 	GetByUserEmail(ctx context.Context, email string) (*entities.EmployeeProfile, error)
 	GetByUserID(ctx context.Context, userID string) (*entities.EmployeeProfile, error)
 	Create(ctx context.Context, profile *entities.EmployeeProfile) (*entities.EmployeeProfile, error)
 	Update(ctx context.Context, userID string, profile *entities.EmployeeProfile) (*entities.EmployeeProfile, error)
+	UpdateEnrichment(ctx context.Context, userID string, profile *entities.EmployeeProfile, columns ...string) error
 	GetAvailableEmployees(ctx context.Context) ([]*entities.EmployeeProfile, error)
 	GetSimilarAvailableProfiles(ctx context.Context, projectID string, limit int) ([]*SimilarityMatch, error)
 	GetSimilarAvailableProfilesWithUser(ctx context.Context, projectID string, limit int) ([]*SimilarityMatch, error)
+	Search(ctx context.Context, search *ProfileSearch) ([]*ProfileSearchMatch, error)
 }
 
 // EmployeeProfileService defines the interface for employee profile business logic
 type EmployeeProfileService interface {
 	GetAllProfiles(ctx context.Context) ([]*models.EmployeeProfileModel, error)
-	SearchProfiles(ctx context.Context, skills []string, geos []string, availableOnly bool) ([]*models.EmployeeProfileModel, error)
+	SearchProfiles(ctx context.Context, skills []string, geos []string, availableOnly bool, orgUnitIDs []uint) ([]*models.EmployeeProfileModel, error)
 	GetProfileByUserEmail(ctx context.Context, email string) (*models.EmployeeProfileModel, error)
 	CreateProfile(ctx context.Context, email string, profile *models.EmployeeProfileModel) (*models.EmployeeProfileModel, error)
 	UpdateProfile(ctx context.Context, userID string, profile *models.EmployeeProfileModel) (*models.EmployeeProfileModel, error)
 	GetAvailableEmployees(ctx context.Context) ([]*models.EmployeeProfileModel, error)
 }
+
+// TalentSearchService defines the interface for natural-language talent search
+type TalentSearchService interface {
+	Search(ctx context.Context, request *models.EmployeeSearchRequest) (*models.EmployeeSearchResponse, error)
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// EnrichmentJobRepository defines the interface for the enrichment job queue
+type EnrichmentJobRepository interface {
+	// Enqueue adds a pending job unless one for the same type and entity is already pending
+	Enqueue(ctx context.Context, job *entities.EnrichmentJob) error
+	// Claim locks the oldest due job for a worker, skipping jobs locked by other workers.
+	// Running jobs locked before staleBefore are reclaimed from crashed workers. It returns nil when no job is due.
+	Claim(ctx context.Context, workerID string, staleBefore time.Time) (*entities.EnrichmentJob, error)
+	MarkSucceeded(ctx context.Context, id int) error
+	// MarkFailed records a failed attempt; the job is retried at retryAt, or fails permanently when retryAt is nil
+	MarkFailed(ctx context.Context, id int, lastError string, retryAt *time.Time) error
+	GetByID(ctx context.Context, id int) (*entities.EnrichmentJob, error)
+	List(ctx context.Context, status string, limit int) ([]*entities.EnrichmentJob, error)
+	CountByStatus(ctx context.Context) (map[string]int64, error)
+	// Retry moves a failed job back to pending with a fresh attempt budget
+	Retry(ctx context.Context, id int) (*entities.EnrichmentJob, error)
+	// EnqueueMissing queues records pending enrichment that have no pending or running job
+	EnqueueMissing(ctx context.Context, maxAttempts int) (int64, error)
+}
+
+// EnrichmentQueue queues summarise and embed jobs for projects and employee profiles
+type EnrichmentQueue interface {
+	// EnqueueProject queues a project; summarize regenerates the summary before embedding
+	EnqueueProject(ctx context.Context, projectID int, summarize bool) error
+	EnqueueProfile(ctx context.Context, userID uint) error
+}
+
+// EnrichmentService defines the interface for processing and administering enrichment jobs
+type EnrichmentService interface {
+	EnrichmentQueue
+	// ProcessNext claims and runs one due job; it reports false when the queue had nothing due
+	ProcessNext(ctx context.Context, workerID string) (bool, error)
+	// RequeueMissing queues records left pending without a job and returns how many were queued
+	RequeueMissing(ctx context.Context) (int64, error)
+	ListJobs(ctx context.Context, status string, limit int) (*models.EnrichmentJobList, error)
+	RetryJob(ctx context.Context, id int) (*models.EnrichmentJobModel, error)
+}
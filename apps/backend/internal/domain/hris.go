@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// ErrHRSyncRunNotFound is returned for unknown sync runs
+var ErrHRSyncRunNotFound = errors.New("HR sync run not found")
+
+// HRRecord is one employee as reported by the HR system. Empty fields and nil dates were not
+// provided by the source and leave the current values alone.
+type HRRecord struct {
+	ExternalID     string
+	Email          string
+	FirstName      string
+	LastName       string
+	Department     string
+	EmploymentType string
+	Geo            string
+	DateOfJoining  *time.Time
+	NoticeDate     *time.Time
+	EndDate        *time.Time
+	// Status is the HR status as reported, lower-cased; empty when the source does not report one
+	Status string
+	// Leaver is set when the status marks the employee terminated or inactive
+	Leaver bool
+}
+
+// HRRejectedRecord is a record, or a whole delivery, the connector could not read
+type HRRejectedRecord struct {
+	// Reference locates the record in the delivery, e.g. "line 4" or "employees[2]"
+	Reference string
+	Message   string
+}
+
+// HRBatch is one delivery of employee records from an HR connector
+type HRBatch struct {
+	// Source is the connector name
+	Source string
+	// Reference identifies the delivery so redelivered webhooks are not applied twice
+	Reference string
+	Records   []HRRecord
+	Rejected  []HRRejectedRecord
+}
+
+// HRSyncFunc applies a batch delivered by a connector
+type HRSyncFunc func(ctx context.Context, batch *HRBatch) error
+
+// HRConnector is an adapter that pulls employee records from an HR system on a schedule.
+// Push sources such as the signed webhook hand their batches to HRSyncService.Sync instead.
+type HRConnector interface {
+	Name() string
+	// Poll passes every batch waiting at the source to sync and marks it processed
+	Poll(ctx context.Context, sync HRSyncFunc) error
+}
+
+// HRSyncRepository defines the interface for the HR sync log
+type HRSyncRepository interface {
+	// Create stores a run with its entries
+	Create(ctx context.Context, run *entities.HRSyncRun) error
+	GetByID(ctx context.Context, id uint) (*entities.HRSyncRun, error)
+	// GetByReference returns the run for a delivery, or nil when it has not been synced
+	GetByReference(ctx context.Context, source, reference string) (*entities.HRSyncRun, error)
+	List(ctx context.Context, limit int) ([]*entities.HRSyncRun, error)
+}
+
+// HRSyncService defines the interface for syncing employees from the HR system
+type HRSyncService interface {
+	// Sync applies a batch to users and employee profiles and logs the run
+	Sync(ctx context.Context, batch *HRBatch) (*models.HRSyncRunModel, error)
+	// Poll runs every configured pull connector once
+	Poll(ctx context.Context) error
+	ListRuns(ctx context.Context, limit int) ([]*models.HRSyncRunModel, error)
+	GetRun(ctx context.Context, id uint) (*models.HRSyncRunModel, error)
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// ImportFile is one uploaded bulk import file
+type ImportFile struct {
+	Kind     models.ImportKind
+	Filename string
+	Data     []byte
+}
+
+// ImportSource locates a record in an import file so errors can point back at its row
+type ImportSource struct {
+	File string
+	Line int
+}
+
+// ImportUser is a validated users row. Columns are the user columns present in the file;
+// only those are written when the user already exists.
+type ImportUser struct {
+	ImportSource
+	User    *entities.User
+	Columns []string
+}
+
+// ImportProfile is a validated profiles row for the user with Email
+type ImportProfile struct {
+	ImportSource
+	Email   string
+	Profile *entities.EmployeeProfile
+	Columns []string
+}
+
+// ImportProject is a validated projects row, matched on its external ID
+type ImportProject struct {
+	ImportSource
+	Project *entities.Project
+	Columns []string
+}
+
+// ImportAllocation is a validated allocations row; the employee and project are resolved when it is applied
+type ImportAllocation struct {
+	ImportSource
+	EmployeeEmail     string
+	ProjectExternalID string
+	Allocation        *entities.ProjectAllocation
+}
+
+// ImportBatch holds the validated rows of every file in one import
+type ImportBatch struct {
+	Users       []ImportUser
+	Profiles    []ImportProfile
+	Projects    []ImportProject
+	Allocations []ImportAllocation
+}
+
+// ImportResult is the outcome of applying a batch
+type ImportResult struct {
+	Created map[models.ImportKind]int
+	Updated map[models.ImportKind]int
+	// Errors are rows that could not be applied, e.g. an allocation for an unknown employee
+	Errors []models.ImportRowError
+	// ProfileUserIDs and Projects are the saved records that need new embeddings;
+	// Projects maps each project ID to whether its summary should be regenerated first
+	ProfileUserIDs []uint
+	Projects       map[int]bool
+}
+
+// ImportRepository defines the interface for writing bulk imports
+type ImportRepository interface {
+	// Apply upserts a batch in one transaction, which is rolled back when dryRun is set or any row fails
+	Apply(ctx context.Context, batch *ImportBatch, dryRun bool) (*ImportResult, error)
+}
+
+// ImportService defines the interface for bulk imports
+type ImportService interface {
+	// Import validates and applies the files; nothing is written on a dry run or when any row has an error
+	Import(ctx context.Context, files []*ImportFile, dryRun bool) (*models.ImportReport, error)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// Invitation errors
+var (
+	// ErrNotInvited is returned for a first sign-in without an invitation or an allowed email domain
+	ErrNotInvited          = errors.New("this email has not been invited; ask a manager for an invitation")
+	ErrInvitationNotFound  = errors.New("invitation not found")
+	ErrInvitationExists    = errors.New("a pending invitation for this email already exists")
+	ErrUserAlreadyExists   = errors.New("a user with this email already exists")
+	ErrInvalidInvitation   = errors.New("email must be valid and role Employee, Manager or Admin")
+	ErrInvitationNotActive = errors.New("invitation has already been accepted or revoked")
+	// ErrAdminInvite is returned when someone without the admin permission invites an admin
+	ErrAdminInvite = errors.New("only admins can invite admins")
+)
+
+// InvitationRepository defines the interface for invitation data access
+type InvitationRepository interface {
+	// Create stores an invitation, revoking any expired one for the same email
+	Create(ctx context.Context, invitation *entities.Invitation) error
+	GetByID(ctx context.Context, id uint) (*entities.Invitation, error)
+	// GetPending returns the invitation for an email that is neither accepted, revoked nor expired
+	GetPending(ctx context.Context, email string, now time.Time) (*entities.Invitation, error)
+	// List returns invitations newest first, optionally only pending ones
+	List(ctx context.Context, pendingOnly bool, now time.Time, limit int) ([]*entities.Invitation, error)
+	// Accept creates the invitee's user and marks the invitation accepted, in one transaction;
+	// it fails with ErrInvitationNotActive if the invitation was accepted or revoked meanwhile
+	Accept(ctx context.Context, invitation *entities.Invitation, user *entities.User, now time.Time) error
+	// Revoke marks a pending invitation revoked, failing with ErrInvitationNotActive otherwise
+	Revoke(ctx context.Context, id uint, now time.Time) error
+}
+
+// InvitationService manages invitations and provisions accounts on first sign-in
+type InvitationService interface {
+	Invite(ctx context.Context, invitedBy uint, request *models.InviteRequest) (*models.InvitationModel, error)
+	List(ctx context.Context, pendingOnly bool, limit int) ([]*models.InvitationModel, error)
+	Revoke(ctx context.Context, revokedBy uint, id uint) error
+	// Provision creates the account of someone signing in for the first time, if they were
+	// invited or their email domain allows sign-up; otherwise it returns ErrNotInvited
+	Provision(ctx context.Context, identity *Identity) (*entities.User, error)
+}
@@ -3,14 +3,22 @@ package domain
 import (
 	"context"
 
+	"github.com/talent-fit/backend/internal/entities"
 	"github.com/talent-fit/backend/internal/models"
 )
 
+// MatchRunRepository defines the interface for match run data operations
+type MatchRunRepository interface {
+	Create(ctx context.Context, run *entities.MatchRun) (*entities.MatchRun, error)
+	GetByProjectID(ctx context.Context, projectID int) ([]*entities.MatchRun, error)
+}
+
 // MatchService defines the interface for AI matching business logic
 type MatchService interface {
 	GetProjectMatches(ctx context.Context, projectID string) error
 	GetEmployeeMatches(ctx context.Context, employeeID string) error
 	GenerateMatchSuggestions(ctx context.Context, projectID string) ([]*models.MatchSuggestion, error)
+	StreamMatchSuggestions(ctx context.Context, projectID string, emit func(models.MatchStreamEvent)) error
 	GetMatchExplanation(ctx context.Context, projectID string, employeeID string) error
 	GetProactiveInsights(ctx context.Context) error
 }
@@ -56,6 +56,12 @@ type Recipient struct {
     Role    string
 }
 
+// Metadata keys naming the employee and project a notification is about
+const (
+    MetadataEmployeeID = "employeeId"
+    MetadataProjectID  = "projectId"
+)
+
 type NotificationMessage struct {
     Type       NotificationType
     Subject    string
@@ -71,3 +77,9 @@ type Notifier interface {
 type NotificationOrchestrator interface {
     Dispatch(ctx context.Context, msg NotificationMessage) error
 }
+
+// RecipientResolver picks who receives a notification from its type and the employee and project
+// named in its metadata. The orchestrator uses it for messages without explicit recipients.
+type RecipientResolver interface {
+    Resolve(ctx context.Context, msg NotificationMessage) ([]Recipient, error)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// Org unit errors
+var (
+	ErrOrgUnitNotFound = errors.New("org unit not found")
+	ErrInvalidOrgUnit  = errors.New("a company has no parent, a practice belongs to a company and a department to a practice")
+	ErrOrgUnitExists   = errors.New("an org unit with this name already exists under the same parent")
+	ErrOrgUnitInUse    = errors.New("org unit still has child units, employees or projects")
+	// ErrInvalidOrgScope is returned for an org_unit filter that is neither an ID nor "all"
+	ErrInvalidOrgScope = errors.New("org_unit must be an org unit ID or all")
+)
+
+// OrgScopeAll requests the whole organisation instead of a caller's default org units
+const OrgScopeAll = "all"
+
+// OrgUnitRepository defines the interface for org unit data access
+type OrgUnitRepository interface {
+	// List returns every org unit by kind and name
+	List(ctx context.Context) ([]*entities.OrgUnit, error)
+	// GetByID returns an org unit, or ErrOrgUnitNotFound
+	GetByID(ctx context.Context, id uint) (*entities.OrgUnit, error)
+	// Create stores an org unit, returning ErrOrgUnitExists for a duplicate name under its parent
+	Create(ctx context.Context, unit *entities.OrgUnit) error
+	// Update saves an org unit, returning ErrOrgUnitExists for a duplicate name under its parent
+	Update(ctx context.Context, unit *entities.OrgUnit) error
+	// Delete removes an org unit, returning ErrOrgUnitInUse while anything belongs to it
+	Delete(ctx context.Context, id uint) error
+	// SubtreeIDs returns the IDs of the given units and every unit below them
+	SubtreeIDs(ctx context.Context, ids []uint) ([]uint, error)
+	// GetHeadedBy returns the org units a user heads
+	GetHeadedBy(ctx context.Context, userID uint) ([]*entities.OrgUnit, error)
+	// GetMemberUnitID returns the org unit of a user's employee profile, or nil
+	GetMemberUnitID(ctx context.Context, userID uint) (*uint, error)
+}
+
+// OrgUnitService defines the interface for managing org units and scoping views to them
+type OrgUnitService interface {
+	ListOrgUnits(ctx context.Context) ([]*models.OrgUnitModel, error)
+	CreateOrgUnit(ctx context.Context, request *models.OrgUnitRequest) (*models.OrgUnitModel, error)
+	UpdateOrgUnit(ctx context.Context, id uint, request *models.OrgUnitRequest) (*models.OrgUnitModel, error)
+	DeleteOrgUnit(ctx context.Context, id uint) error
+
+	// Scope resolves an org_unit filter to the org unit IDs a view covers, including every unit
+	// below. An ID selects that unit and OrgScopeAll the whole organisation (nil). Without a filter,
+	// a user sees the units they head, or else their own profile's unit, or else everything.
+	Scope(ctx context.Context, userID uint, filter string) ([]uint, error)
+}
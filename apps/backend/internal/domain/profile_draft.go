@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+var (
+	// ErrProfileDraftNotFound is returned for drafts that do not exist or belong to another employee
+	ErrProfileDraftNotFound = errors.New("profile draft not found")
+	// ErrProfileDraftClosed is returned when confirming or discarding a draft that is no longer pending
+	ErrProfileDraftClosed = errors.New("profile draft has already been confirmed or discarded")
+	// ErrProfileTypeRequired is returned when confirming a draft would create a profile without a type
+	ErrProfileTypeRequired = errors.New("a new profile needs a type; confirm the proposed type or create the profile first")
+)
+
+// BlobStore keeps uploaded files such as original CVs
+type BlobStore interface {
+	Put(ctx context.Context, key string, contentType string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes a file; deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// CVUpload is a CV file uploaded by an employee
+type CVUpload struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+	// KeepOriginal stores the file in the blob store alongside the draft
+	KeepOriginal bool
+}
+
+// ProfileDraftRepository defines the interface for profile draft data operations
+type ProfileDraftRepository interface {
+	Create(ctx context.Context, draft *entities.ProfileDraft) (*entities.ProfileDraft, error)
+	GetByID(ctx context.Context, id uint) (*entities.ProfileDraft, error)
+	// UpdateStatus moves a pending draft to status; it reports false when the draft was no longer pending
+	UpdateStatus(ctx context.Context, draft *entities.ProfileDraft, status string) (bool, error)
+}
+
+// CVImportService defines the interface for bootstrapping employee profiles from CVs
+type CVImportService interface {
+	// CreateDraft extracts profile fields from a CV and returns them as a draft for the employee to review
+	CreateDraft(ctx context.Context, email string, upload *CVUpload) (*models.ProfileDraftModel, error)
+	GetDraft(ctx context.Context, email string, draftID uint) (*models.ProfileDraftModel, error)
+	// ConfirmDraft applies the selected changes to the profile, creating it if needed, and re-embeds it
+	ConfirmDraft(ctx context.Context, email string, draftID uint, request *models.ConfirmProfileDraftRequest) (*models.EmployeeProfileModel, error)
+	// DiscardDraft closes a draft without changing the profile and deletes any stored original
+	DiscardDraft(ctx context.Context, email string, draftID uint) error
+}
@@ -9,15 +9,17 @@ import (
 
 // ProjectRepository defines the interface for project data operations
 type ProjectRepository interface {
-	GetAll(ctx context.Context) ([]*entities.Project, error)
+	// GetAll retrieves projects in the given org units; nil org units match all
+	GetAll(ctx context.Context, orgUnitIDs []uint) ([]*entities.Project, error)
 	GetByID(ctx context.Context, id int) (*entities.Project, error)
 	Create(ctx context.Context, project *entities.Project) (*entities.Project, error)
 	Update(ctx context.Context, id int, project *entities.Project) (*entities.Project, error)
+	UpdateEnrichment(ctx context.Context, id int, project *entities.Project, columns ...string) error
 }
 
 // ProjectService defines the interface for project business logic
 type ProjectService interface {
-	GetAllProjects(ctx context.Context) ([]*models.ProjectModel, error)
+	GetAllProjects(ctx context.Context, orgUnitIDs []uint) ([]*models.ProjectModel, error)
 	GetProjectByID(ctx context.Context, id int) (*models.ProjectModel, error)
 	CreateProject(ctx context.Context, project *models.ProjectModel) (*models.ProjectModel, error)
 	UpdateProject(ctx context.Context, id int, project *models.ProjectModel) (*models.ProjectModel, error)
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// Prompt errors
+var (
+	ErrUnknownPrompt         = errors.New("unknown prompt template")
+	ErrPromptProjectNotFound = errors.New("project not found")
+)
+
+// Prompt is a rendered prompt ready to send to a chat model
+type Prompt struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	System  string `json:"system"`
+	User    string `json:"user"`
+}
+
+// PromptVersion describes one available version of a prompt template
+type PromptVersion struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Source  string `json:"source"` // "embedded" or "database"
+	Active  bool   `json:"active"`
+}
+
+// PromptRegistry renders versioned prompt templates
+type PromptRegistry interface {
+	// Render renders the active version of the named template
+	Render(ctx context.Context, name string, data interface{}) (*Prompt, error)
+	// RenderVersion renders a specific version of the named template
+	RenderVersion(ctx context.Context, name string, version string, data interface{}) (*Prompt, error)
+	// Versions lists the embedded and database versions of the named template
+	Versions(ctx context.Context, name string) ([]PromptVersion, error)
+	// Validate checks that a template body parses and defines the required sections
+	Validate(body string) error
+	// Invalidate drops the context tenant's cached database override for the named template
+	Invalidate(ctx context.Context, name string)
+}
+
+// PromptTemplateRepository defines the interface for prompt template overrides stored in the database
+type PromptTemplateRepository interface {
+	GetActive(ctx context.Context, name string) (*entities.PromptTemplate, error)
+	GetByVersion(ctx context.Context, name string, version string) (*entities.PromptTemplate, error)
+	GetByName(ctx context.Context, name string) ([]*entities.PromptTemplate, error)
+	Save(ctx context.Context, template *entities.PromptTemplate) (*entities.PromptTemplate, error)
+	Activate(ctx context.Context, name string, version string) error
+}
+
+// PromptService defines the interface for managing and previewing prompt templates
+type PromptService interface {
+	ListVersions(ctx context.Context, name string) ([]PromptVersion, error)
+	SaveOverride(ctx context.Context, template *entities.PromptTemplate) (*entities.PromptTemplate, error)
+	PreviewProjectPrompt(ctx context.Context, name string, version string, projectID int) (*Prompt, error)
+}
+
+// PromptAuditRepository defines the interface for prompt audit log data operations
+type PromptAuditRepository interface {
+	Create(ctx context.Context, log *entities.PromptAuditLog) error
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/tabular"
+)
+
+// Report kinds
+const (
+	// ReportBench lists employees with working days not covered by any allocation
+	ReportBench = "bench"
+	// ReportAllocations lists allocations overlapping the period
+	ReportAllocations = "allocations"
+	// ReportUtilisation lists each employee's allocated share of their working days
+	ReportUtilisation = "utilisation"
+	// ReportRolloffs lists employment, notice and allocation end dates in the period
+	ReportRolloffs = "rolloffs"
+)
+
+// ReportKinds lists the exportable reports
+var ReportKinds = []string{ReportBench, ReportAllocations, ReportUtilisation, ReportRolloffs}
+
+// MaxReportDays bounds the length of a report period
+const MaxReportDays = 366
+
+// ErrUnknownReport is returned for a report kind not in ReportKinds
+var ErrUnknownReport = errors.New("unknown report")
+
+// ReportPeriod is the date range a report covers; both dates are included
+type ReportPeriod struct {
+	From time.Time
+	To   time.Time
+}
+
+// ReportRepository streams the records reports are built from, a batch at a time
+type ReportRepository interface {
+	// EachProfile calls fn for every employee profile, with its user, employed at some point in the period
+	EachProfile(ctx context.Context, period ReportPeriod, fn func(*entities.EmployeeProfile) error) error
+	// EachAllocation calls fn for every allocation overlapping the period, with its project and employee
+	EachAllocation(ctx context.Context, period ReportPeriod, fn func(*entities.ProjectAllocation) error) error
+}
+
+// ReportService defines the interface for staffing report exports
+type ReportService interface {
+	// WriteReport streams one report, a header and then rows, to out
+	WriteReport(ctx context.Context, kind string, period ReportPeriod, out tabular.Writer) error
+	// WriteSummaryPDF renders the dashboard metrics with the roll-off, bench and utilisation tables
+	WriteSummaryPDF(ctx context.Context, period ReportPeriod, w io.Writer) error
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// DefaultTenantID is the tenant that existing data and tokens issued before tenants belong to
+const DefaultTenantID uint = 1
+
+// Tenant errors
+var (
+	ErrTenantNotFound         = errors.New("tenant not found")
+	ErrTenantExists           = errors.New("a tenant with this slug already exists")
+	ErrInvalidTenantSettings  = errors.New("invalid tenant settings")
+	ErrTenantAdminEmailExists = errors.New("a user with the admin's email already exists")
+	ErrTenantMismatch         = errors.New("record belongs to another tenant")
+	// ErrTenantRequired is returned for tenant-owned records read or written without a tenant
+	ErrTenantRequired = errors.New("no tenant in context for a tenant-owned record")
+)
+
+type tenantContextKey struct{}
+
+// WithTenant scopes a context to a tenant: repositories given it only see and write that tenant's rows
+func WithTenant(ctx context.Context, tenantID uint) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// AllTenants lifts the tenant scope, for the few callers that span tenants before they know whose
+// record they handle: sign-in, token and API key lookups, email uniqueness checks, and workers
+// claiming jobs. A later WithTenant scopes the context again. Without either, repositories refuse
+// tenant-owned records.
+func AllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, uint(0))
+}
+
+// TenantFromContext returns the tenant set by WithTenant
+func TenantFromContext(ctx context.Context) (uint, bool) {
+	tenantID, ok := ctx.Value(tenantContextKey{}).(uint)
+	return tenantID, ok && tenantID != 0
+}
+
+// SpansTenants reports whether AllTenants lifted the context's tenant scope
+func SpansTenants(ctx context.Context) bool {
+	tenantID, ok := ctx.Value(tenantContextKey{}).(uint)
+	return ok && tenantID == 0
+}
+
+// TenantRepository defines the interface for tenant data operations
+type TenantRepository interface {
+	List(ctx context.Context) ([]*entities.Tenant, error)
+	GetByID(ctx context.Context, id uint) (*entities.Tenant, error)
+	// GetBySignUpDomain returns the tenant claiming a lowercase email domain, or ErrTenantNotFound
+	GetBySignUpDomain(ctx context.Context, emailDomain string) (*entities.Tenant, error)
+	Create(ctx context.Context, tenant *entities.Tenant) error
+	Update(ctx context.Context, tenant *entities.Tenant) error
+}
+
+// TenantSettingsProvider returns the settings of the context's tenant, or nil when the context has
+// no tenant, in which case callers use the deployment's config
+type TenantSettingsProvider interface {
+	Settings(ctx context.Context) (*entities.Tenant, error)
+}
+
+// TenantService defines the interface for tenant business logic
+type TenantService interface {
+	TenantSettingsProvider
+
+	// CreateTenant creates a tenant together with its first admin, who signs in with their email
+	CreateTenant(ctx context.Context, request *models.CreateTenantRequest) (*models.TenantModel, error)
+
+	// ListTenantIDs returns every tenant's ID, for jobs that run once per tenant
+	ListTenantIDs(ctx context.Context) ([]uint, error)
+
+	// GetCurrent returns the context's tenant with its settings; secrets are only reported as set
+	GetCurrent(ctx context.Context) (*models.TenantModel, error)
+
+	// UpdateSettings changes the context's tenant's Slack, AI provider and scoring settings
+	UpdateSettings(ctx context.Context, updatedBy uint, request *models.TenantSettingsRequest) (*models.TenantModel, error)
+}
@@ -2,32 +2,66 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
 )
 
+// ErrUserNotFound is returned when acting on a user that does not exist
+var ErrUserNotFound = errors.New("user not found")
+
+// User management errors
+var (
+	ErrInvalidUser = errors.New("email must be valid and role Employee, Manager or Admin")
+	// ErrSelfModification protects admins from locking themselves out
+	ErrSelfModification = errors.New("you cannot deactivate yourself or change your own role")
+)
+
+// User statuses for filtering the user list
+const (
+	UserStatusActive      = "active"
+	UserStatusDeactivated = "deactivated"
+)
+
+// UserFilter selects a page of users for the admin user list
+type UserFilter struct {
+	// Query matches first name, last name or email, case-insensitively
+	Query string
+	Role  string
+	// Status is UserStatusActive, UserStatusDeactivated, or empty for both
+	Status string
+	Limit  int
+	Offset int
+}
+
 // UserService defines the interface for user business logic
 type UserService interface {
-	// GetAllUsers retrieves all users
-	GetAllUsers(ctx context.Context) error
+	// ListUsers returns a page of users by name, with the total matching the filter
+	ListUsers(ctx context.Context, filter *UserFilter) (*models.UserPage, error)
+
+	// GetUser retrieves a user by ID
+	GetUser(ctx context.Context, id uint) (*models.UserModel, error)
 
-	// GetUserByID retrieves a user by ID
-	GetUserByID(ctx context.Context, id string) error
+	// CreateUser creates a user ahead of their first sign-in
+	CreateUser(ctx context.Context, createdBy uint, request *models.CreateUserRequest) (*models.UserModel, error)
 
-	// UpdateUser updates a user
-	UpdateUser(ctx context.Context, id string) error
+	// UpdateUser updates a user's name, role, Slack user ID or manager
+	UpdateUser(ctx context.Context, updatedBy uint, id uint, request *models.UpdateUserRequest) (*models.UserModel, error)
 
-	// CreateUser creates a new user
-	CreateUser(ctx context.Context) error
+	// DeactivateUser signs a user out, blocks sign-in, clears their availability and ends their
+	// active allocations
+	DeactivateUser(ctx context.Context, deactivatedBy uint, id uint) (*models.UserModel, error)
 
-	// DeleteUser deletes a user
-	DeleteUser(ctx context.Context, id string) error
+	// ReactivateUser lets a deactivated user sign in again; ended allocations are not restored
+	ReactivateUser(ctx context.Context, reactivatedBy uint, id uint) (*models.UserModel, error)
 }
 
 // UserRepository defines the interface for user data access
 type UserRepository interface {
-	// GetAll retrieves all users from database
-	GetAll(ctx context.Context) error
+	// List retrieves a page of users matching a filter by name, with the total matching it
+	List(ctx context.Context, filter *UserFilter) ([]*entities.User, int64, error)
 
 	// GetByID retrieves a user by ID from database
 	GetByID(ctx context.Context, id string) (*entities.User, error)
@@ -35,15 +69,27 @@ type UserRepository interface {
 	// Update updates a user in database
 	Update(ctx context.Context, user *entities.User) error
 
-	// Create creates a new user in database
-	Create(ctx context.Context) error
+	// Deactivate marks a user deactivated, clears their profile's availability flag, ends their
+	// active allocations on the given day and deletes those starting later, in one transaction; it
+	// returns ErrUserNotFound for an unknown user
+	Deactivate(ctx context.Context, id uint, now time.Time) error
 
-	// Delete deletes a user from database
-	Delete(ctx context.Context, id string) error
+	// Reactivate clears a user's deactivation; it returns ErrUserNotFound for an unknown user
+	Reactivate(ctx context.Context, id uint) error
 
 	// GetByEmail checks user existence by email
 	GetByEmail(ctx context.Context, email string) (*entities.User, error)
 
 	// CreateWithEntity creates a user from entity
-    CreateWithEntity(ctx context.Context, user *entities.User) error
+	CreateWithEntity(ctx context.Context, user *entities.User) error
+
+	// LinkIdentity records the provider and subject a user signs in with, unless one is already
+	// recorded, in which case it returns ErrIdentityMismatch
+	LinkIdentity(ctx context.Context, id uint, provider, subject string) error
+
+	// GetByExternalID retrieves a user by their HR system or import ID
+	GetByExternalID(ctx context.Context, externalID string) (*entities.User, error)
+
+	// GetFirstByRole retrieves the user with a role created first
+	GetFirstByRole(ctx context.Context, role string) (*entities.User, error)
 }
@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// AIUsage records a single call to an AI provider
+type AIUsage struct {
+	ID               int    `gorm:"primaryKey"`
+	TenantID         uint   `gorm:"not null;default:1;index"`
+	Provider         string `gorm:"not null"`
+	Model            string `gorm:"not null"`
+	Operation        string `gorm:"not null"`
+	Feature          string `gorm:"not null;index"`
+	EntityType       string
+	EntityID         *int
+	PromptTokens     int64
+	CompletionTokens int64
+	TotalTokens      int64
+	LatencyMs        int64
+	Success          bool `gorm:"not null"`
+	Error            string
+	CostUSD          float64   `gorm:"column:cost_usd"`
+	CreatedAt        time.Time `gorm:"index"`
+}
+
+// TableName returns the table name for the AIUsage entity
+func (AIUsage) TableName() string {
+	return "ai_usage"
+}
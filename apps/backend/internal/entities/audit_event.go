@@ -0,0 +1,53 @@
+package entities
+
+import "time"
+
+// Audit event actions
+const (
+	AuditUserInvited       = "user_invited"
+	AuditInvitationRevoked = "invitation_revoked"
+	// AuditUserProvisioned is an account created on first sign-in
+	AuditUserProvisioned = "user_provisioned"
+	// AuditSignUpRejected is a first sign-in without an invitation or allowed domain
+	AuditSignUpRejected = "sign_up_rejected"
+	// Users managed by admins
+	AuditUserCreated     = "user_created"
+	AuditUserUpdated     = "user_updated"
+	AuditUserDeactivated = "user_deactivated"
+	AuditUserReactivated = "user_reactivated"
+	// Service accounts and their API keys
+	AuditServiceAccountCreated  = "service_account_created"
+	AuditServiceAccountDisabled = "service_account_disabled"
+	AuditAPIKeyCreated          = "api_key_created"
+	AuditAPIKeyRotated          = "api_key_rotated"
+	AuditAPIKeyRevoked          = "api_key_revoked"
+	// Development sign-in without a login provider, and admins acting as other users
+	AuditDevLogin             = "dev_login"
+	AuditImpersonationStarted = "impersonation_started"
+	// AuditImpersonatedRequest is a change made while an admin acts as another user
+	AuditImpersonatedRequest = "impersonated_request"
+	// Tenants and their settings
+	AuditTenantCreated         = "tenant_created"
+	AuditTenantSettingsUpdated = "tenant_settings_updated"
+)
+
+// AuditEvent records who did what to which account, for account provisioning and access changes
+type AuditEvent struct {
+	ID       uint   `gorm:"primaryKey"`
+	TenantID uint   `gorm:"not null;default:1;index"`
+	Action   string `gorm:"not null;index"`
+	// ActorID is the user who acted; nil for the user themselves signing in
+	ActorID *uint `gorm:"index"`
+	// UserID is the account acted on, if it exists
+	UserID *uint  `gorm:"index"`
+	Email  string `gorm:"index"`
+	// Provider is the login provider, for sign-in events
+	Provider  string
+	Detail    string
+	CreatedAt time.Time `gorm:"index"`
+}
+
+// TableName returns the table name for the AuditEvent entity
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
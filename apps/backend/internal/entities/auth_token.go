@@ -0,0 +1,38 @@
+package entities
+
+import "time"
+
+// RefreshToken is a long-lived token exchanged for new access tokens. Only its SHA-256 hash is
+// stored. Each use rotates it: the token is revoked and a new one issued in the same family, so a
+// revoked token presented again reveals a stolen token and revokes the whole family.
+type RefreshToken struct {
+	ID        uint   `gorm:"primaryKey"`
+	TenantID  uint   `gorm:"not null;default:1;index"`
+	UserID    uint   `gorm:"not null;index"`
+	TokenHash string `gorm:"size:64;uniqueIndex;not null"`
+	// FamilyID is shared by a login's refresh tokens, i.e. one session
+	FamilyID  string     `gorm:"size:32;not null;index"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	RevokedAt *time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
+
+// TableName returns the table name for the RefreshToken entity
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// RevokedToken is an access token revoked before it expires, by its jti claim. Rows are only
+// needed until ExpiresAt, when the token would be rejected anyway.
+type RevokedToken struct {
+	JTI       string    `gorm:"column:jti;primaryKey;size:32"`
+	TenantID  uint      `gorm:"not null;default:1;index"`
+	UserID    uint      `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	RevokedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the RevokedToken entity
+func (RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
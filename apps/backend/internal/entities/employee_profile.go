@@ -41,6 +41,7 @@ func (s Skills) Value() (driver.Value, error) {
 // EmployeeProfile entity for database operations
 type EmployeeProfile struct {
 	UserID            uint `gorm:"primaryKey;foreignKey"`
+	TenantID          uint `gorm:"not null;default:1;index"`
 	Geo               string
 	DateOfJoining     *time.Time
 	EndDate           *time.Time
@@ -52,11 +53,14 @@ type EmployeeProfile struct {
 	Industry          string
 	AvailabilityFlag  bool            `gorm:"default:false"`
 	Embedding         pgvector.Vector `gorm:"type:vector(1536)"`
+	EnrichmentStatus  string          `gorm:"not null;default:'pending'"`
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 	DeletedAt         gorm.DeletedAt `gorm:"index"`
 	Department        string
 	EmploymentType    string
+	// OrgUnitID is the org unit the employee belongs to; Department is the free-text HR value
+	OrgUnitID *uint `gorm:"index"`
 
 	// Relationships
 	User User `gorm:"foreignKey:UserID;references:ID"`
@@ -0,0 +1,49 @@
+package entities
+
+import "time"
+
+// Enrichment job types
+const (
+	JobTypeSummarizeProject = "summarize_project"
+	JobTypeEmbedProject     = "embed_project"
+	JobTypeEmbedProfile     = "embed_profile"
+)
+
+// Enrichment job statuses
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+)
+
+// Enrichment statuses of projects and employee profiles
+const (
+	EnrichmentStatusPending = "pending"
+	EnrichmentStatusReady   = "ready"
+	EnrichmentStatusFailed  = "failed"
+)
+
+// EnrichmentJob is a queued summarise or embed job for a project or employee profile.
+// EntityID is the project ID or the profile's user ID.
+type EnrichmentJob struct {
+	ID          int    `gorm:"primaryKey"`
+	TenantID    uint   `gorm:"not null;default:1;index"`
+	JobType     string `gorm:"not null"`
+	EntityType  string `gorm:"not null"`
+	EntityID    int    `gorm:"not null"`
+	Status      string `gorm:"not null;default:'pending';index"`
+	Attempts    int    `gorm:"not null;default:0"`
+	MaxAttempts int    `gorm:"not null;default:5"`
+	LastError   string
+	RunAfter    time.Time `gorm:"not null"`
+	LockedAt    *time.Time
+	LockedBy    string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName returns the table name for the EnrichmentJob entity
+func (EnrichmentJob) TableName() string {
+	return "enrichment_jobs"
+}
@@ -5,11 +5,28 @@ import "gorm.io/gorm"
 // AllEntities returns a slice of all entity structs for migration
 func AllEntities() []interface{} {
 	return []interface{}{
+		&Tenant{},
 		&User{},
 		&EmployeeProfile{},
 		&Project{},
 		&ProjectAllocation{},
 		&Notification{},
+		&PromptTemplate{},
+		&MatchRun{},
+		&AIUsage{},
+		&PromptAuditLog{},
+		&EnrichmentJob{},
+		&ProfileDraft{},
+		&HRSyncRun{},
+		&HRSyncEntry{},
+		&MetricsSnapshot{},
+		&RefreshToken{},
+		&RevokedToken{},
+		&Invitation{},
+		&AuditEvent{},
+		&ServiceAccount{},
+		&APIKey{},
+		&OrgUnit{},
 	}
 }
 
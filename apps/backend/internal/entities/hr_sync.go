@@ -0,0 +1,70 @@
+package entities
+
+import "time"
+
+// HR sync run statuses
+const (
+	HRSyncStatusSucceeded = "succeeded"
+	// HRSyncStatusConflicts marks a run that applied what it could and logged conflicts or rejected records
+	HRSyncStatusConflicts = "conflicts"
+	HRSyncStatusFailed    = "failed"
+)
+
+// HR sync entry actions
+const (
+	HRSyncActionCreated  = "created"
+	HRSyncActionUpdated  = "updated"
+	HRSyncActionLeaver   = "leaver"
+	HRSyncActionConflict = "conflict"
+	HRSyncActionRejected = "rejected"
+	HRSyncActionSkipped  = "skipped"
+)
+
+// HRSyncRun is one batch of employee records received from an HR connector
+type HRSyncRun struct {
+	ID       uint `gorm:"primaryKey"`
+	TenantID uint `gorm:"not null;default:1;index"`
+	// Source is the connector name, e.g. "webhook" or "file_drop"
+	Source string `gorm:"not null;index:idx_hr_sync_runs_reference"`
+	// Reference identifies the delivery: a webhook delivery ID or a dropped file name
+	Reference  string `gorm:"index:idx_hr_sync_runs_reference"`
+	Status     string `gorm:"not null"`
+	Received   int
+	Created    int
+	Updated    int
+	Unchanged  int
+	Leavers    int
+	Conflicts  int
+	Rejected   int
+	StartedAt  time.Time
+	FinishedAt time.Time
+	CreatedAt  time.Time
+
+	// Relationships
+	Entries []HRSyncEntry `gorm:"foreignKey:RunID"`
+}
+
+// TableName returns the table name for the HRSyncRun entity
+func (HRSyncRun) TableName() string {
+	return "hr_sync_runs"
+}
+
+// HRSyncEntry logs one change or conflict for a record in a sync run
+type HRSyncEntry struct {
+	ID         uint   `gorm:"primaryKey"`
+	TenantID   uint   `gorm:"not null;default:1;index"`
+	RunID      uint   `gorm:"not null;index"`
+	Action     string `gorm:"not null"`
+	ExternalID string
+	Email      string
+	UserID     *uint
+	// Field is the changed or conflicting field, empty for whole-record entries
+	Field     string
+	Detail    string
+	CreatedAt time.Time
+}
+
+// TableName returns the table name for the HRSyncEntry entity
+func (HRSyncEntry) TableName() string {
+	return "hr_sync_entries"
+}
@@ -0,0 +1,27 @@
+package entities
+
+import "time"
+
+// Invitation lets someone sign in for the first time with a preassigned role, Slack user ID
+// and manager. At most one invitation per email is pending at a time.
+type Invitation struct {
+	ID          uint   `gorm:"primaryKey"`
+	TenantID    uint   `gorm:"not null;default:1;index"`
+	Email       string `gorm:"not null;uniqueIndex:idx_invitations_pending_email,where:accepted_at IS NULL AND revoked_at IS NULL"`
+	Role        string `gorm:"not null"`
+	SlackUserID string `gorm:"column:slack_user_id"`
+	ManagerID   *uint
+	InvitedByID uint      `gorm:"not null"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	// AcceptedAt and UserID are set when the invitee first signs in
+	AcceptedAt *time.Time
+	UserID     *uint
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// TableName returns the table name for the Invitation entity
+func (Invitation) TableName() string {
+	return "invitations"
+}
@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// MatchRun entity records each AI scoring run for a project
+type MatchRun struct {
+	ID             uint   `gorm:"primaryKey"`
+	TenantID       uint   `gorm:"not null;default:1;index"`
+	ProjectID      int    `gorm:"not null;index"`
+	PromptVersion  string `gorm:"not null"`
+	Model          string
+	CandidateCount int
+	FallbackCount  int
+	CreatedAt      time.Time
+}
+
+// TableName returns the table name for the MatchRun entity
+func (MatchRun) TableName() string {
+	return "match_runs"
+}
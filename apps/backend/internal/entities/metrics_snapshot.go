@@ -0,0 +1,37 @@
+package entities
+
+import "time"
+
+// Metrics snapshot groupings
+const (
+	// MetricsGroupAll is the organisation-wide snapshot, with an empty group value
+	MetricsGroupAll        = "all"
+	MetricsGroupDepartment = "department"
+	MetricsGroupGeo        = "geo"
+)
+
+// MetricsSnapshot is the manager dashboard metrics for one day, organisation-wide or for one
+// department or geo. There is one row per date and group; retaking a day's snapshot replaces it.
+type MetricsSnapshot struct {
+	ID       uint      `gorm:"primaryKey"`
+	TenantID uint      `gorm:"not null;default:1;uniqueIndex:idx_metrics_snapshots_key"`
+	Date     time.Time `gorm:"type:date;not null;uniqueIndex:idx_metrics_snapshots_key"`
+	// GroupBy is one of the MetricsGroup constants
+	GroupBy string `gorm:"not null;uniqueIndex:idx_metrics_snapshots_key"`
+	// GroupValue is the department or geo; employees without one are grouped under ""
+	GroupValue         string `gorm:"not null;uniqueIndex:idx_metrics_snapshots_key"`
+	Headcount          int
+	AvailableEngineers int
+	ActiveProjects     int
+	RollingOffSoon     int
+	BenchResources     int
+	AllocatedEngineers int
+	// UtilisationPct is the allocated share of headcount, full-time counting 1 and part-time 0.5
+	UtilisationPct float64
+	CreatedAt      time.Time
+}
+
+// TableName returns the table name for the MetricsSnapshot entity
+func (MetricsSnapshot) TableName() string {
+	return "metrics_snapshots"
+}
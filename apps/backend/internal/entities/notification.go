@@ -9,6 +9,7 @@ import (
 // Notification entity for database operations
 type Notification struct {
 	ID        uint      `gorm:"primaryKey"`
+	TenantID  uint      `gorm:"not null;default:1;index"`
 	Type      string    `gorm:"not null"`
 	Message   string    `gorm:"not null"`
 	UserID    uint      `gorm:"not null;index"`
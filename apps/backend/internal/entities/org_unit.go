@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// Org unit kinds, from the top of the tree down
+const (
+	OrgUnitCompany    = "company"
+	OrgUnitPractice   = "practice"
+	OrgUnitDepartment = "department"
+)
+
+// OrgUnit is a node in the organisation tree: companies contain practices, which contain
+// departments. Employee profiles and projects belong to one unit at any level.
+type OrgUnit struct {
+	ID       uint   `gorm:"primaryKey"`
+	TenantID uint   `gorm:"not null;default:1;uniqueIndex:idx_org_units_parent_name"`
+	Name     string `gorm:"not null;uniqueIndex:idx_org_units_parent_name"`
+	Kind     string `gorm:"not null"`
+	// ParentID is empty only for companies
+	ParentID *uint `gorm:"index;uniqueIndex:idx_org_units_parent_name"`
+	// HeadID is the user leading the unit, e.g. the practice head; their views default to it
+	HeadID    *uint `gorm:"index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName returns the table name for the OrgUnit entity
+func (OrgUnit) TableName() string {
+	return "org_units"
+}
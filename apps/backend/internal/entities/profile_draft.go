@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Profile draft statuses
+const (
+	DraftStatusPending   = "pending"
+	DraftStatusConfirmed = "confirmed"
+	DraftStatusDiscarded = "discarded"
+)
+
+// Profile draft sources
+const (
+	DraftSourceModel = "model"
+	DraftSourceRules = "rules"
+)
+
+// ProfileProposal holds the profile values extracted from a CV. Values are merged with the
+// current profile when the draft is shown and again when it is confirmed.
+type ProfileProposal struct {
+	Skills            []string `json:"skills"`
+	YearsOfExperience *int     `json:"years_of_experience"`
+	Industry          []string `json:"industry"`
+	Type              string   `json:"type"`
+}
+
+// Scan implements the Scanner interface for database reading
+func (p *ProfileProposal) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	default:
+		return errors.New("cannot scan into ProfileProposal")
+	}
+}
+
+// Value implements the Valuer interface for database writing
+func (p ProfileProposal) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// ProfileDraft is a profile update proposed from an uploaded CV, waiting for the employee to confirm it.
+// The extracted CV text is not stored; the original file is kept in the blob store only when BlobKey is set.
+type ProfileDraft struct {
+	ID            uint   `gorm:"primaryKey"`
+	TenantID      uint   `gorm:"not null;default:1;index"`
+	UserID        uint   `gorm:"not null;index"`
+	Status        string `gorm:"not null;default:'pending'"`
+	Source        string `gorm:"not null"`
+	PromptVersion string
+	Filename      string
+	Format        string
+	BlobKey       string
+	Extracted     ProfileProposal `gorm:"type:jsonb"`
+	ConfirmedAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// TableName returns the table name for the ProfileDraft entity
+func (ProfileDraft) TableName() string {
+	return "profile_drafts"
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/pgvector/pgvector-go"
@@ -38,9 +39,70 @@ func (s SeatsByType) Value() (driver.Value, error) {
 	return json.Marshal(s)
 }
 
+// Requirement sources
+const (
+	RequirementsSourceAI      = "ai"
+	RequirementsSourceManager = "manager"
+)
+
+// RoleRequirement is the skill set required for one role type on a project
+type RoleRequirement struct {
+	Role   string   `json:"role"`
+	Seats  int      `json:"seats"`
+	Skills []string `json:"skills"`
+}
+
+// ProjectRequirements are the structured requirements extracted from a project description
+type ProjectRequirements struct {
+	Roles              []RoleRequirement `json:"roles"`
+	MinExperienceYears int               `json:"min_experience_years"`
+	Geo                string            `json:"geo"`
+	Industry           string            `json:"industry"`
+	Confidence         float64           `json:"confidence"`
+	Source             string            `json:"source,omitempty"`
+}
+
+// Skills returns every required skill across roles without duplicates
+func (r *ProjectRequirements) Skills() []string {
+	var skills []string
+	seen := make(map[string]bool)
+	for _, role := range r.Roles {
+		for _, skill := range role.Skills {
+			key := strings.ToLower(skill)
+			if !seen[key] {
+				seen[key] = true
+				skills = append(skills, skill)
+			}
+		}
+	}
+	return skills
+}
+
+// Scan implements the Scanner interface for database reading
+func (r *ProjectRequirements) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	default:
+		return errors.New("cannot scan into ProjectRequirements")
+	}
+}
+
+// Value implements the Valuer interface for database writing
+func (r ProjectRequirements) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
 // Project entity for database operations
 type Project struct {
 	ID            int        `gorm:"primaryKey"`
+	TenantID      uint       `gorm:"not null;default:1;index;uniqueIndex:idx_projects_tenant_external_id"`
 	Name          string      `gorm:"not null"`
 	Description   string
 	RequiredSeats int         `gorm:"not null"`
@@ -53,11 +115,22 @@ type Project struct {
 	UpdatedAt     time.Time
 	DeletedAt     gorm.DeletedAt `gorm:"index"`
 	Summary       string 
+	SummaryPromptVersion string
+	Requirements  *ProjectRequirements `gorm:"type:jsonb"`
+	EnrichmentStatus string `gorm:"not null;default:'pending'"`
 	ClientName    string
 	Industry      string
 	GeoPreference string
 	Priority      string
 	Budget        float64
+	// ExternalID is the project's ID in an import file or external system
+	ExternalID    *string `gorm:"uniqueIndex:idx_projects_tenant_external_id"`
+	// OwnerID is the user accountable for the project, e.g. the account manager
+	OwnerID       *uint `gorm:"index"`
+	// DeliveryManagerID is the user running the project day to day
+	DeliveryManagerID *uint `gorm:"index"`
+	// OrgUnitID is the business unit running the project
+	OrgUnitID     *uint `gorm:"index"`
 
 	// Relationships
 	ProjectAllocations []ProjectAllocation `gorm:"foreignKey:ProjectID"`
@@ -9,6 +9,7 @@ import (
 // ProjectAllocation entity for database operations
 type ProjectAllocation struct {
 	ID             int       `gorm:"primaryKey"`
+	TenantID       uint      `gorm:"not null;default:1;index"`
 	ProjectID      int       `gorm:"not null;index"`
 	EmployeeID     int       `gorm:"not null;index"`
 	AllocationType string     `gorm:"not null"`
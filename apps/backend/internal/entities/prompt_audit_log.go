@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// RedactionCounts counts pseudonymised values by kind, e.g. {"person": 3, "email": 1}
+type RedactionCounts map[string]int
+
+// Scan implements the Scanner interface for database reading
+func (r *RedactionCounts) Scan(value interface{}) error {
+	if value == nil {
+		*r = RedactionCounts{}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	default:
+		return errors.New("cannot scan into RedactionCounts")
+	}
+}
+
+// Value implements the Valuer interface for database writing
+func (r RedactionCounts) Value() (driver.Value, error) {
+	if len(r) == 0 {
+		return "{}", nil
+	}
+	return json.Marshal(r)
+}
+
+// PromptAuditLog records what was sent to an external model after redaction.
+// It never stores the pseudonym mapping, so it contains no personal data.
+type PromptAuditLog struct {
+	ID            int    `gorm:"primaryKey"`
+	TenantID      uint   `gorm:"not null;default:1;index"`
+	PromptName    string `gorm:"not null;index"`
+	PromptVersion string `gorm:"not null"`
+	Feature       string
+	EntityType    string
+	EntityID      *int
+	FieldsSent    string
+	Redactions    RedactionCounts `gorm:"type:jsonb"`
+	PromptSHA256  string          `gorm:"column:prompt_sha256;not null"`
+	SentPrompt    string          `gorm:"not null"`
+	CreatedAt     time.Time       `gorm:"index"`
+}
+
+// TableName returns the table name for the PromptAuditLog entity
+func (PromptAuditLog) TableName() string {
+	return "prompt_audit_logs"
+}
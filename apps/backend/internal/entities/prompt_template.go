@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// PromptTemplate entity stores a database override for an embedded prompt template. A nil TenantID
+// marks a deployment default that every tenant uses until it stores its own version.
+type PromptTemplate struct {
+	ID        uint   `gorm:"primaryKey"`
+	TenantID  *uint  `gorm:"uniqueIndex:idx_prompt_templates_tenant_version,where:tenant_id IS NOT NULL"`
+	Name      string `gorm:"not null;uniqueIndex:idx_prompt_templates_tenant_version,where:tenant_id IS NOT NULL;uniqueIndex:idx_prompt_templates_default_version,where:tenant_id IS NULL"`
+	Version   string `gorm:"not null;uniqueIndex:idx_prompt_templates_tenant_version,where:tenant_id IS NOT NULL;uniqueIndex:idx_prompt_templates_default_version,where:tenant_id IS NULL"`
+	Body      string `gorm:"not null"`
+	Active    bool   `gorm:"default:false"`
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName returns the table name for the PromptTemplate entity
+func (PromptTemplate) TableName() string {
+	return "prompt_templates"
+}
@@ -0,0 +1,50 @@
+package entities
+
+import "time"
+
+// ServiceAccount is a non-human caller of the API, e.g. an internal tool or the BI pipeline,
+// authenticating with API keys
+type ServiceAccount struct {
+	ID          uint   `gorm:"primaryKey"`
+	TenantID    uint   `gorm:"not null;default:1;uniqueIndex:idx_service_accounts_tenant_name"`
+	Name        string `gorm:"size:100;uniqueIndex:idx_service_accounts_tenant_name;not null"`
+	Description string
+	CreatedByID uint `gorm:"not null"`
+	// DisabledAt stops the account's keys from authenticating
+	DisabledAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+
+	// Relationships
+	APIKeys []APIKey `gorm:"foreignKey:ServiceAccountID"`
+}
+
+// TableName returns the table name for the ServiceAccount entity
+func (ServiceAccount) TableName() string {
+	return "service_accounts"
+}
+
+// APIKey authenticates a service account with a set of scopes until it expires or is revoked.
+// Only the key's SHA-256 hash is stored; Prefix identifies it in listings.
+type APIKey struct {
+	ID               uint   `gorm:"primaryKey"`
+	TenantID         uint   `gorm:"not null;default:1;index"`
+	ServiceAccountID uint   `gorm:"not null;index"`
+	Prefix           string `gorm:"size:16;not null"`
+	KeyHash          string `gorm:"size:64;uniqueIndex;not null"`
+	// Scopes is a space-separated list of models.APIKeyScope
+	Scopes      string    `gorm:"not null"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	LastUsedAt  *time.Time
+	RevokedAt   *time.Time `gorm:"index"`
+	CreatedByID uint       `gorm:"not null"`
+	CreatedAt   time.Time
+
+	// Relationships
+	ServiceAccount *ServiceAccount `gorm:"foreignKey:ServiceAccountID"`
+}
+
+// TableName returns the table name for the APIKey entity
+func (APIKey) TableName() string {
+	return "api_keys"
+}
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ScoringWeights are a tenant's weights for the matching criteria, see utils.ScoringRules
+type ScoringWeights struct {
+	SkillsWeight     int `json:"skills_weight"`
+	GeoWeight        int `json:"geo_weight"`
+	ExperienceWeight int `json:"experience_weight"`
+	StatusWeight     int `json:"status_weight"`
+}
+
+// Scan implements the Scanner interface for database reading
+func (w *ScoringWeights) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, w)
+	case string:
+		return json.Unmarshal([]byte(v), w)
+	default:
+		return errors.New("cannot scan into ScoringWeights")
+	}
+}
+
+// Value implements the Valuer interface for database writing
+func (w ScoringWeights) Value() (driver.Value, error) {
+	return json.Marshal(w)
+}
+
+// Tenant is an organisation hosted by the deployment, e.g. one subsidiary. Every other table's
+// rows belong to one tenant. Empty settings fall back to the deployment's config.
+type Tenant struct {
+	ID   uint   `gorm:"primaryKey"`
+	Name string `gorm:"not null"`
+	// Slug identifies the tenant in tools and logs, e.g. "acme-uk"
+	Slug string `gorm:"size:63;uniqueIndex;not null"`
+	// SignUpDomains are the comma-separated email domains whose people may sign up to the tenant
+	// without an invitation, e.g. "acme.co.uk,acme.com". Operators set them; no two tenants share one.
+	SignUpDomains string `gorm:"column:signup_domains;not null;default:''"`
+	// Slack workspace the tenant's notifications are posted to
+	SlackBotToken         string
+	SlackDefaultChannelID string
+	// AIProvider names an OpenAI-compatible chat provider at AIBaseURL, used for summaries, scoring,
+	// search and CV extraction. Embeddings always use the deployment's model, so vectors stay comparable.
+	AIProvider string `gorm:"column:ai_provider"`
+	AIBaseURL  string
+	AIAPIKey   string
+	// AIModel overrides the chat model, on the tenant's provider or the deployment's
+	AIModel string
+	// ScoringRules overrides the default matching weights
+	ScoringRules *ScoringWeights `gorm:"type:jsonb"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// TableName returns the table name for the Tenant entity
+func (Tenant) TableName() string {
+	return "tenants"
+}
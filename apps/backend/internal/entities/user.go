@@ -9,11 +9,25 @@ import (
 // User entity for database operations
 type User struct {
 	ID        uint   `gorm:"primaryKey"`
+	TenantID  uint   `gorm:"not null;default:1;index;uniqueIndex:idx_users_tenant_external_id"`
 	FirstName string `gorm:"not null"`
 	LastName  string `gorm:"not null"`
+	// Email is unique across tenants, so sign-in finds the user's tenant from it
 	Email     string `gorm:"uniqueIndex;not null"`
 	Role      string `gorm:"not null"`
     SlackUserID string `gorm:"column:slack_user_id"`
+	// ExternalID is the person's ID in an HR system or import file
+	ExternalID *string `gorm:"uniqueIndex:idx_users_tenant_external_id"`
+	// ManagerID is the user's line manager, if known
+	ManagerID *uint `gorm:"index"`
+	// AuthProvider and AuthSubject are the sign-in identity recorded on first sign-in; later
+	// sign-ins with the user's email must come from the same identity
+	AuthProvider *string `gorm:"uniqueIndex:idx_users_auth_identity"`
+	AuthSubject  *string `gorm:"uniqueIndex:idx_users_auth_identity"`
+	// SessionsRevokedAt invalidates every token issued up to then, e.g. on "sign out all sessions"
+	SessionsRevokedAt *time.Time
+	// DeactivatedAt blocks sign-in and keeps the user out of matching and metrics until reactivated
+	DeactivatedAt *time.Time `gorm:"index"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
@@ -0,0 +1,153 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"text/tabwriter"
+)
+
+// MetricDelta is the change in one metric between a baseline and the current run
+type MetricDelta struct {
+	Scope      string  `json:"scope"`
+	Metric     string  `json:"metric"`
+	Baseline   float64 `json:"baseline"`
+	Current    float64 `json:"current"`
+	Delta      float64 `json:"delta"`
+	Regression bool    `json:"regression"`
+}
+
+// meanScope labels deltas for the metrics averaged over the fixture set
+const meanScope = "mean"
+
+// LoadReport reads a stored report, typically the baseline
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	report := &Report{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline: %w", err)
+	}
+	return report, nil
+}
+
+// WriteReport stores a report as indented JSON so baselines diff cleanly in review
+func WriteReport(path string, report *Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// Compare diffs the current report against a baseline, for the mean and each project in both.
+// A change worse than tolerance is flagged as a regression; higher is better for every metric
+// except bad_in_top_k and fallback_rate.
+func Compare(baseline, current *Report, tolerance float64) []MetricDelta {
+	deltas := compareMetrics(meanScope, baseline.Mean, current.Mean, tolerance)
+
+	previous := make(map[int]ProjectResult, len(baseline.Projects))
+	for _, p := range baseline.Projects {
+		previous[p.ProjectID] = p
+	}
+	for _, p := range current.Projects {
+		if old, ok := previous[p.ProjectID]; ok {
+			deltas = append(deltas, compareMetrics(p.Name, old.Metrics, p.Metrics, tolerance)...)
+		}
+	}
+	return deltas
+}
+
+// HasRegression reports whether any delta is a regression
+func HasRegression(deltas []MetricDelta) bool {
+	for _, d := range deltas {
+		if d.Regression {
+			return true
+		}
+	}
+	return false
+}
+
+func compareMetrics(scope string, baseline, current Metrics, tolerance float64) []MetricDelta {
+	metrics := []struct {
+		name          string
+		before, after float64
+		lowerIsBetter bool
+	}{
+		{"precision_at_k", baseline.PrecisionAtK, current.PrecisionAtK, false},
+		{"ndcg_at_k", baseline.NDCGAtK, current.NDCGAtK, false},
+		{"bad_in_top_k", baseline.BadInTopK, current.BadInTopK, true},
+		{"retrieval_recall", baseline.RetrievalRecall, current.RetrievalRecall, false},
+		{"spearman", baseline.Spearman, current.Spearman, false},
+		{"fallback_rate", baseline.FallbackRate, current.FallbackRate, true},
+	}
+
+	deltas := make([]MetricDelta, 0, len(metrics))
+	for _, m := range metrics {
+		delta := m.after - m.before
+		worse := -delta
+		if m.lowerIsBetter {
+			worse = delta
+		}
+		deltas = append(deltas, MetricDelta{
+			Scope:      scope,
+			Metric:     m.name,
+			Baseline:   m.before,
+			Current:    m.after,
+			Delta:      delta,
+			Regression: worse > tolerance,
+		})
+	}
+	return deltas
+}
+
+// PrintReport writes per-project and mean metrics as a table
+func PrintReport(w io.Writer, report *Report) {
+	fmt.Fprintf(w, "Fixture set: %s  provider: %s  model: %s  prompts: summarize_project@%s score_candidates@%s  k=%d\n\n",
+		report.FixtureSet, report.Provider, report.Model, report.SummaryPromptVersion, report.ScoringPromptVersion, report.K)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROJECT\tP@K\tNDCG@K\tBAD@K\tRECALL\tSPEARMAN\tFALLBACK")
+	for _, p := range report.Projects {
+		printMetricsRow(tw, p.Name, p.Metrics)
+	}
+	printMetricsRow(tw, meanScope, report.Mean)
+	tw.Flush()
+}
+
+// PrintDeltas writes the metrics that changed since the baseline
+func PrintDeltas(w io.Writer, deltas []MetricDelta) {
+	var changed []MetricDelta
+	for _, d := range deltas {
+		if math.Abs(d.Delta) >= 1e-9 {
+			changed = append(changed, d)
+		}
+	}
+	if len(changed) == 0 {
+		fmt.Fprintln(w, "No metric changes against the baseline.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SCOPE\tMETRIC\tBASELINE\tCURRENT\tDELTA\t")
+	for _, d := range changed {
+		flag := ""
+		if d.Regression {
+			flag = "REGRESSION"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%.3f\t%.3f\t%+.3f\t%s\n", d.Scope, d.Metric, d.Baseline, d.Current, d.Delta, flag)
+	}
+	tw.Flush()
+}
+
+func printMetricsRow(w io.Writer, name string, m Metrics) {
+	fmt.Fprintf(w, "%s\t%.3f\t%.3f\t%.2f\t%.3f\t%.3f\t%.2f\n",
+		name, m.PrecisionAtK, m.NDCGAtK, m.BadInTopK, m.RetrievalRecall, m.Spearman, m.FallbackRate)
+}
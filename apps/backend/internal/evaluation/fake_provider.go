@@ -0,0 +1,352 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/talent-fit/backend/internal/domain"
+)
+
+// fakeDimensions keeps fake embeddings small; only relative similarity matters offline
+const fakeDimensions = 512
+
+var (
+	candidateHeader = regexp.MustCompile(`\(ID: (\d+)\)`)
+	similarityLine  = regexp.MustCompile(`Similarity Score: ([\d.]+)%`)
+	experienceYears = regexp.MustCompile(`(?i)(\d+)\+?\s*(?:years|yrs)`)
+	roleSeats       = regexp.MustCompile(`(\d+)\s+([^,]+)`)
+)
+
+// FakeProvider is a deterministic, offline stand-in for the embedding and chat providers.
+// Embeddings are hashed bags of words; summaries and scores are derived from the skills,
+// geos and industries that appear in the fixture set. It reads the layout of the
+// embedded prompt templates, so a prompt change that breaks it shows up as fallback scores.
+type FakeProvider struct {
+	skills     []string
+	geos       []string
+	industries []string
+}
+
+// NewFakeProvider creates a fake provider whose vocabulary comes from the fixture candidates
+func NewFakeProvider(set *FixtureSet) domain.EmbeddingService {
+	p := &FakeProvider{}
+	seen := make(map[string]bool)
+	add := func(list *[]string, value string) {
+		key := strings.ToLower(strings.TrimSpace(value))
+		if key == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		*list = append(*list, strings.TrimSpace(value))
+	}
+	for _, c := range set.Candidates {
+		for _, skill := range c.Skills {
+			add(&p.skills, skill)
+		}
+		add(&p.geos, c.Geo)
+		add(&p.industries, c.Industry)
+	}
+	return p
+}
+
+// GenerateEmbedding hashes words and known vocabulary phrases into a normalised vector
+func (p *FakeProvider) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	vector := make([]float64, fakeDimensions)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	}) {
+		vector[bucket(word)] += 1
+	}
+	for _, skill := range p.skills {
+		if containsPhrase(text, skill) {
+			vector[bucket("skill:"+strings.ToLower(skill))] += 3
+		}
+	}
+
+	var norm float64
+	for _, v := range vector {
+		norm += v * v
+	}
+	norm = math.Sqrt(norm)
+
+	result := make([]float32, fakeDimensions)
+	for i, v := range vector {
+		if norm > 0 {
+			result[i] = float32(v / norm)
+		}
+	}
+	return result, nil
+}
+
+// GenerateBatchEmbeddings embeds each text independently
+func (p *FakeProvider) GenerateBatchEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		embedding, err := p.GenerateEmbedding(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		embeddings[i] = embedding
+	}
+	return embeddings, nil
+}
+
+// SummarizeProject returns structured requirements built from the vocabulary found in the description
+func (p *FakeProvider) SummarizeProject(ctx context.Context, prompt *domain.Prompt) (string, error) {
+	description := sectionAfter(prompt.User, "Description:")
+	if i := strings.LastIndex(description, "\nRoles:"); i >= 0 {
+		description = description[:i]
+	}
+
+	var skills []string
+	for _, skill := range p.skills {
+		if containsPhrase(description, skill) {
+			skills = append(skills, skill)
+		}
+	}
+
+	type role struct {
+		Role   string   `json:"role"`
+		Seats  int      `json:"seats"`
+		Skills []string `json:"skills"`
+	}
+	var roles []role
+	for _, match := range roleSeats.FindAllStringSubmatch(lastLineWithPrefix(prompt.User, "Roles:"), -1) {
+		seats, _ := strconv.Atoi(match[1])
+		roles = append(roles, role{Role: strings.TrimSpace(match[2]), Seats: seats, Skills: skills})
+	}
+	if len(roles) == 0 {
+		roles = append(roles, role{Role: "General", Seats: 1, Skills: skills})
+	}
+
+	// Defaults mirror the summarisation prompt
+	geo := firstPhrase(description, p.geos, "India")
+	industry := firstPhrase(description, p.industries, "Unspecified")
+	experience := 0
+	if match := experienceYears.FindStringSubmatch(description); match != nil {
+		experience, _ = strconv.Atoi(match[1])
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"roles":                roles,
+		"min_experience_years": experience,
+		"geo":                  geo,
+		"industry":             industry,
+		"confidence":           0.5,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// GenerateMatchingScores scores each candidate in the prompt by required-skill overlap, similarity and bench status
+func (p *FakeProvider) GenerateMatchingScores(ctx context.Context, prompt *domain.Prompt) (string, error) {
+	requirements := sectionAfter(prompt.User, "Project requirements:")
+	if i := strings.Index(requirements, "Candidates:"); i >= 0 {
+		requirements = requirements[:i]
+	}
+	var required []string
+	for _, skill := range p.skills {
+		if containsPhrase(requirements, skill) {
+			required = append(required, skill)
+		}
+	}
+
+	type score struct {
+		CandidateID int    `json:"candidate_id"`
+		Score       int    `json:"score"`
+		Reason      string `json:"reason"`
+	}
+	var scores []score
+
+	headers := candidateHeader.FindAllStringSubmatchIndex(prompt.User, -1)
+	for i, header := range headers {
+		end := len(prompt.User)
+		if i+1 < len(headers) {
+			end = headers[i+1][0]
+		}
+		block := prompt.User[header[0]:end]
+		id, _ := strconv.Atoi(prompt.User[header[2]:header[3]])
+
+		candidateSkills := lastLineWithPrefix(block, "Skills:")
+		matched := 0
+		for _, skill := range required {
+			if containsPhrase(candidateSkills, skill) {
+				matched++
+			}
+		}
+		overlap := 0.0
+		if len(required) > 0 {
+			overlap = float64(matched) / float64(len(required))
+		}
+
+		similarity := 0.0
+		if m := similarityLine.FindStringSubmatch(block); m != nil {
+			similarity, _ = strconv.ParseFloat(m[1], 64)
+		}
+
+		value := 60*overlap + 30*similarity/100
+		if strings.Contains(block, "Status: onBench") {
+			value += 10
+		}
+		scores = append(scores, score{
+			CandidateID: id,
+			Score:       int(math.Round(math.Min(100, value))),
+			Reason:      fmt.Sprintf("Matches %d of %d required skills.", matched, len(required)),
+		})
+	}
+	if len(scores) == 0 {
+		return "", fmt.Errorf("fake provider found no candidates in the scoring prompt")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"scores": scores})
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// StreamMatchingScores returns the fake scores one entry per chunk, exercising incremental parsing
+func (p *FakeProvider) StreamMatchingScores(ctx context.Context, prompt *domain.Prompt, onDelta func(chunk string)) (string, error) {
+	response, err := p.GenerateMatchingScores(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	for rest := response; rest != ""; {
+		end := strings.Index(rest, "},")
+		if end < 0 {
+			end = len(rest)
+		} else {
+			end += 2
+		}
+		onDelta(rest[:end])
+		rest = rest[end:]
+	}
+	return response, nil
+}
+
+// ParseTalentQuery picks the fixture skills, geos and industries named in the search and
+// leaves the rest of the query as its semantic part
+func (p *FakeProvider) ParseTalentQuery(ctx context.Context, prompt *domain.Prompt) (string, error) {
+	query := lastLineWithPrefix(prompt.User, "Search:")
+	if query == "" {
+		return "", fmt.Errorf("no search query found in prompt")
+	}
+
+	filters := map[string]interface{}{
+		"skills":         matchingPhrases(query, p.skills),
+		"types":          []string{},
+		"geos":           matchingPhrases(query, p.geos),
+		"industries":     matchingPhrases(query, p.industries),
+		"semantic_query": query,
+	}
+	if match := experienceYears.FindStringSubmatch(query); match != nil {
+		years, _ := strconv.Atoi(match[1])
+		filters["min_experience_years"] = years
+	}
+
+	body, err := json.Marshal(filters)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// ExtractProfileFromCV picks the fixture skills and industries named in the CV and the first stated years of experience
+func (p *FakeProvider) ExtractProfileFromCV(ctx context.Context, prompt *domain.Prompt) (string, error) {
+	cv := strings.TrimSpace(sectionAfter(prompt.User, "CV:"))
+	if cv == "" {
+		return "", fmt.Errorf("no CV found in prompt")
+	}
+
+	fields := map[string]interface{}{
+		"skills":              matchingPhrases(cv, p.skills),
+		"years_of_experience": nil,
+		"industries":          matchingPhrases(cv, p.industries),
+		"type":                "",
+	}
+	if match := experienceYears.FindStringSubmatch(cv); match != nil {
+		years, _ := strconv.Atoi(match[1])
+		fields["years_of_experience"] = years
+	}
+
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+func bucket(token string) int {
+	h := fnv.New32a()
+	h.Write([]byte(token))
+	return int(h.Sum32() % fakeDimensions)
+}
+
+// containsPhrase reports whether text contains phrase as a whole word, ignoring case
+func containsPhrase(text, phrase string) bool {
+	text, phrase = strings.ToLower(text), strings.ToLower(strings.TrimSpace(phrase))
+	if phrase == "" {
+		return false
+	}
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], phrase)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(phrase)
+		if (start == 0 || !isWordByte(text[start-1])) && (end == len(text) || !isWordByte(text[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9')
+}
+
+func firstPhrase(text string, phrases []string, fallback string) string {
+	for _, phrase := range phrases {
+		if containsPhrase(text, phrase) {
+			return phrase
+		}
+	}
+	return fallback
+}
+
+func matchingPhrases(text string, phrases []string) []string {
+	matched := []string{}
+	for _, phrase := range phrases {
+		if containsPhrase(text, phrase) {
+			matched = append(matched, phrase)
+		}
+	}
+	return matched
+}
+
+// sectionAfter returns the text after the last occurrence of marker, or the whole text
+func sectionAfter(text, marker string) string {
+	if i := strings.LastIndex(text, marker); i >= 0 {
+		return text[i+len(marker):]
+	}
+	return text
+}
+
+func lastLineWithPrefix(text, prefix string) string {
+	result := ""
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), prefix) {
+			result = strings.TrimPrefix(strings.TrimSpace(line), prefix)
+		}
+	}
+	return result
+}
@@ -0,0 +1,106 @@
+// Package evaluation measures matching quality offline against labelled fixtures.
+//
+// A fixture set holds a pool of candidate profiles and projects labelled with the
+// candidates that are known good or bad matches. The runner pushes every project
+// through the same summarise, embed, retrieve and score pipeline the API uses and
+// reports ranking metrics that can be diffed against a stored baseline.
+package evaluation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FixtureSet is a labelled set of projects and the candidate pool they are matched against
+type FixtureSet struct {
+	Name       string             `json:"name" yaml:"name"`
+	Candidates []FixtureCandidate `json:"candidates" yaml:"candidates"`
+	Projects   []FixtureProject   `json:"projects" yaml:"projects"`
+}
+
+// FixtureCandidate is an employee profile in the candidate pool
+type FixtureCandidate struct {
+	ID                uint     `json:"id" yaml:"id"`
+	Name              string   `json:"name" yaml:"name"`
+	Type              string   `json:"type" yaml:"type"`
+	Skills            []string `json:"skills" yaml:"skills"`
+	Geo               string   `json:"geo" yaml:"geo"`
+	YearsOfExperience int      `json:"years_of_experience" yaml:"years_of_experience"`
+	Industry          string   `json:"industry" yaml:"industry"`
+	Available         bool     `json:"available" yaml:"available"`
+	// Status mirrors the retrieval query: onBench or onWork
+	Status string `json:"status" yaml:"status"`
+}
+
+// FixtureProject is a project with its labelled matches
+type FixtureProject struct {
+	ID          int            `json:"id" yaml:"id"`
+	Name        string         `json:"name" yaml:"name"`
+	Description string         `json:"description" yaml:"description"`
+	SeatsByType map[string]int `json:"seats_by_type" yaml:"seats_by_type"`
+	// Summary skips the summariser when set, to evaluate scoring in isolation
+	Summary string `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Good    []uint `json:"good" yaml:"good"`
+	Bad     []uint `json:"bad" yaml:"bad"`
+}
+
+// LoadFixtures reads a fixture set from a .json, .yaml or .yml file
+func LoadFixtures(path string) (*FixtureSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	set := &FixtureSet{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, set)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, set)
+	default:
+		return nil, fmt.Errorf("unsupported fixture format %q: use .json, .yaml or .yml", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixtures: %w", err)
+	}
+
+	if err := set.Validate(); err != nil {
+		return nil, err
+	}
+	if set.Name == "" {
+		set.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return set, nil
+}
+
+// Validate checks that every label refers to a candidate in the pool
+func (s *FixtureSet) Validate() error {
+	if len(s.Projects) == 0 {
+		return fmt.Errorf("fixture set has no projects")
+	}
+
+	known := make(map[uint]bool, len(s.Candidates))
+	for _, c := range s.Candidates {
+		if known[c.ID] {
+			return fmt.Errorf("candidate %d is defined more than once", c.ID)
+		}
+		known[c.ID] = true
+	}
+
+	for _, p := range s.Projects {
+		if len(p.Good) == 0 {
+			return fmt.Errorf("project %d (%s) has no good matches labelled", p.ID, p.Name)
+		}
+		for _, id := range append(append([]uint{}, p.Good...), p.Bad...) {
+			if !known[id] {
+				return fmt.Errorf("project %d (%s) labels unknown candidate %d", p.ID, p.Name, id)
+			}
+		}
+	}
+	return nil
+}
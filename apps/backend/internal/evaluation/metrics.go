@@ -0,0 +1,122 @@
+package evaluation
+
+import (
+	"math"
+	"sort"
+)
+
+// PrecisionAtK is the fraction of the top k ranked candidates labelled good
+func PrecisionAtK(ranked []uint, good map[uint]bool, k int) float64 {
+	if k <= 0 {
+		return 0
+	}
+	hits := 0
+	for i := 0; i < k && i < len(ranked); i++ {
+		if good[ranked[i]] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(k)
+}
+
+// CountAtK counts the top k ranked candidates that appear in the label set
+func CountAtK(ranked []uint, labels map[uint]bool, k int) int {
+	count := 0
+	for i := 0; i < k && i < len(ranked); i++ {
+		if labels[ranked[i]] {
+			count++
+		}
+	}
+	return count
+}
+
+// NDCGAtK is the normalised discounted cumulative gain of the top k with binary relevance
+func NDCGAtK(ranked []uint, good map[uint]bool, k int) float64 {
+	dcg := 0.0
+	for i := 0; i < k && i < len(ranked); i++ {
+		if good[ranked[i]] {
+			dcg += 1 / math.Log2(float64(i+2))
+		}
+	}
+
+	ideal := 0.0
+	for i := 0; i < k && i < len(good); i++ {
+		ideal += 1 / math.Log2(float64(i+2))
+	}
+	if ideal == 0 {
+		return 0
+	}
+	return dcg / ideal
+}
+
+// SpearmanCorrelation is the rank correlation of two paired samples, averaging tied ranks.
+// It returns 0 when either sample is constant or there are fewer than two pairs.
+func SpearmanCorrelation(x, y []float64) float64 {
+	if len(x) != len(y) || len(x) < 2 {
+		return 0
+	}
+	return pearson(ranks(x), ranks(y))
+}
+
+// ranks assigns 1-based ranks, giving tied values their average rank
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return values[order[a]] < values[order[b]] })
+
+	result := make([]float64, len(values))
+	for i := 0; i < len(order); {
+		j := i
+		for j+1 < len(order) && values[order[j+1]] == values[order[i]] {
+			j++
+		}
+		rank := float64(i+j)/2 + 1
+		for t := i; t <= j; t++ {
+			result[order[t]] = rank
+		}
+		i = j + 1
+	}
+	return result
+}
+
+func pearson(x, y []float64) float64 {
+	n := float64(len(x))
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	var cov, varX, varY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varX*varY)
+}
+
+// cosineSimilarity compares two embeddings; vectors of different length are unrelated
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
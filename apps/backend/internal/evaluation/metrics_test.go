@@ -0,0 +1,62 @@
+package evaluation
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/prompts"
+)
+
+func TestRankingMetrics(t *testing.T) {
+	ranked := []uint{3, 1, 4, 2}
+	good := map[uint]bool{1: true, 2: true}
+
+	if got := PrecisionAtK(ranked, good, 2); got != 0.5 {
+		t.Errorf("PrecisionAtK() = %v, expected 0.5", got)
+	}
+	expected := (1 / math.Log2(3)) / (1 + 1/math.Log2(3))
+	if got := NDCGAtK(ranked, good, 2); math.Abs(got-expected) > 1e-9 {
+		t.Errorf("NDCGAtK() = %v, expected %v", got, expected)
+	}
+	if got := SpearmanCorrelation([]float64{1, 2, 3, 4}, []float64{10, 20, 30, 40}); math.Abs(got-1) > 1e-9 {
+		t.Errorf("SpearmanCorrelation() = %v, expected 1", got)
+	}
+	if got := SpearmanCorrelation([]float64{1, 2, 3}, []float64{5, 5, 5}); got != 0 {
+		t.Errorf("SpearmanCorrelation() with constant sample = %v, expected 0", got)
+	}
+}
+
+func TestRunnerWithFakeProvider(t *testing.T) {
+	set, err := LoadFixtures("../../evaluation/fixtures/matching.yaml")
+	if err != nil {
+		t.Fatalf("LoadFixtures() error = %v", err)
+	}
+	registry, err := prompts.NewRegistry(nil)
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+
+	runner := NewRunner(NewFakeProvider(set), registry, config.FromEnv(), "fake", 5)
+	report, err := runner.Run(context.Background(), set)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(report.Projects) != len(set.Projects) {
+		t.Fatalf("Run() returned %d projects, expected %d", len(report.Projects), len(set.Projects))
+	}
+	// The fake provider understands the embedded templates, so nothing should fall back
+	if report.Mean.FallbackRate != 0 {
+		t.Errorf("Run() fallback rate = %v, expected the fake scorer to answer every prompt", report.Mean.FallbackRate)
+	}
+
+	again, err := runner.Run(context.Background(), set)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if deltas := Compare(report, again, 0); HasRegression(deltas) {
+		t.Errorf("Run() is not deterministic: %+v", deltas)
+	}
+}
@@ -0,0 +1,272 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/redaction"
+	"github.com/talent-fit/backend/internal/services"
+	"github.com/talent-fit/backend/internal/utils"
+)
+
+// retrievalLimit matches the candidate count the match service sends to the scorer
+const retrievalLimit = 20
+
+// Label values recorded on ranked candidates
+const (
+	LabelGood = "good"
+	LabelBad  = "bad"
+)
+
+// Metrics are the ranking metrics for one project or averaged over a fixture set
+type Metrics struct {
+	PrecisionAtK float64 `json:"precision_at_k"`
+	NDCGAtK      float64 `json:"ndcg_at_k"`
+	BadInTopK    float64 `json:"bad_in_top_k"`
+	// RetrievalRecall is the fraction of good candidates retrieved by vector search at all
+	RetrievalRecall float64 `json:"retrieval_recall"`
+	// Spearman is the rank correlation between vector similarity and the final score
+	Spearman     float64 `json:"spearman"`
+	FallbackRate float64 `json:"fallback_rate"`
+}
+
+// RankedCandidate is one candidate in a project's final ranking
+type RankedCandidate struct {
+	CandidateID uint    `json:"candidate_id"`
+	Score       int     `json:"score"`
+	Similarity  float64 `json:"similarity"`
+	Source      string  `json:"source"`
+	Label       string  `json:"label,omitempty"`
+}
+
+// ProjectResult is the outcome of running one fixture project through the pipeline
+type ProjectResult struct {
+	ProjectID int               `json:"project_id"`
+	Name      string            `json:"name"`
+	Summary   string            `json:"summary"`
+	Ranked    []RankedCandidate `json:"ranked"`
+	Metrics   Metrics           `json:"metrics"`
+}
+
+// Report is the result of evaluating a fixture set; it doubles as the stored baseline format
+type Report struct {
+	FixtureSet           string          `json:"fixture_set"`
+	Provider             string          `json:"provider"`
+	Model                string          `json:"model"`
+	SummaryPromptVersion string          `json:"summary_prompt_version"`
+	ScoringPromptVersion string          `json:"scoring_prompt_version"`
+	K                    int             `json:"k"`
+	Mean                 Metrics         `json:"mean"`
+	Projects             []ProjectResult `json:"projects"`
+}
+
+// Runner pushes fixture projects through the summarise, embed, retrieve and score pipeline
+type Runner struct {
+	embeddingService domain.EmbeddingService
+	embeddingUtils   *utils.EmbeddingEntityUtils
+	prompts          domain.PromptRegistry
+	redactor         *redaction.Redactor
+	scorer           *services.CandidateScorer
+	provider         string
+	k                int
+}
+
+// NewRunner creates a new evaluation runner reporting metrics at the top k candidates.
+// Prompts are redacted with the configured allowlist, as in production, but not audited.
+func NewRunner(embeddingService domain.EmbeddingService, promptRegistry domain.PromptRegistry, cfg *config.Config, provider string, k int) *Runner {
+	redactor := redaction.NewRedactor(cfg, nil)
+	return &Runner{
+		embeddingService: embeddingService,
+		embeddingUtils:   utils.NewEmbeddingEntityUtils(embeddingService),
+		prompts:          promptRegistry,
+		redactor:         redactor,
+		scorer:           services.NewCandidateScorer(embeddingService, promptRegistry, nil, redactor, nil, cfg),
+		provider:         provider,
+		k:                k,
+	}
+}
+
+// Run evaluates every project in the fixture set
+func (r *Runner) Run(ctx context.Context, set *FixtureSet) (*Report, error) {
+	profiles, err := r.embedCandidates(ctx, set.Candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{FixtureSet: set.Name, Provider: r.provider, K: r.k}
+	for _, fixture := range set.Projects {
+		result, project, scoring, err := r.runProject(ctx, fixture, set.Candidates, profiles)
+		if err != nil {
+			return nil, fmt.Errorf("project %d (%s): %w", fixture.ID, fixture.Name, err)
+		}
+		report.Projects = append(report.Projects, *result)
+		if project.SummaryPromptVersion != "" {
+			report.SummaryPromptVersion = project.SummaryPromptVersion
+		}
+		if scoring.PromptVersion != "" {
+			report.ScoringPromptVersion = scoring.PromptVersion
+			report.Model = scoring.Model
+		}
+	}
+
+	report.Mean = meanMetrics(report.Projects)
+	return report, nil
+}
+
+// embedCandidates builds profile entities and embeds them the way the profile service does
+func (r *Runner) embedCandidates(ctx context.Context, candidates []FixtureCandidate) (map[uint]*entities.EmployeeProfile, error) {
+	profiles := make(map[uint]*entities.EmployeeProfile, len(candidates))
+	for _, c := range candidates {
+		firstName, lastName := splitName(c.Name)
+		profile := &entities.EmployeeProfile{
+			UserID:            c.ID,
+			Type:              c.Type,
+			Skills:            entities.Skills(c.Skills),
+			Geo:               c.Geo,
+			YearsOfExperience: c.YearsOfExperience,
+			Industry:          c.Industry,
+			AvailabilityFlag:  c.Available,
+			User:              entities.User{ID: c.ID, FirstName: firstName, LastName: lastName},
+		}
+		if err := r.embeddingUtils.GenerateEmployeeProfileEmbedding(ctx, profile); err != nil {
+			return nil, fmt.Errorf("candidate %d: %w", c.ID, err)
+		}
+		profiles[c.ID] = profile
+	}
+	return profiles, nil
+}
+
+func (r *Runner) runProject(ctx context.Context, fixture FixtureProject, candidates []FixtureCandidate, profiles map[uint]*entities.EmployeeProfile) (*ProjectResult, *entities.Project, *services.ScoringResult, error) {
+	project := &entities.Project{
+		ID:          fixture.ID,
+		Name:        fixture.Name,
+		Description: fixture.Description,
+		SeatsByType: entities.SeatsByType(fixture.SeatsByType),
+		Summary:     fixture.Summary,
+	}
+	if project.Summary == "" {
+		if err := services.SummarizeProject(ctx, r.prompts, r.embeddingService, r.redactor, project); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to summarise: %w", err)
+		}
+	}
+	if err := services.EmbedProject(ctx, r.embeddingUtils, r.redactor, project); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Retrieval: the same availability rules as the similarity query, ranked by cosine similarity
+	projectVector := project.Embedding.Slice()
+	var matches []*domain.SimilarityMatch
+	for _, c := range candidates {
+		if c.Status != "onBench" && !c.Available {
+			continue
+		}
+		profile := profiles[c.ID]
+		matches = append(matches, &domain.SimilarityMatch{
+			Profile:    profile,
+			Similarity: cosineSimilarity(projectVector, profile.Embedding.Slice()),
+			Status:     c.Status,
+		})
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Similarity > matches[j].Similarity })
+	if len(matches) > retrievalLimit {
+		matches = matches[:retrievalLimit]
+	}
+
+	scoring, err := r.scorer.Score(ctx, project, matches, utils.DefaultScoringRules())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	result := &ProjectResult{ProjectID: fixture.ID, Name: fixture.Name, Summary: project.Summary}
+	result.Ranked, result.Metrics = r.evaluate(fixture, matches, scoring)
+	return result, project, scoring, nil
+}
+
+// evaluate computes metrics for the final ranking of one project
+func (r *Runner) evaluate(fixture FixtureProject, matches []*domain.SimilarityMatch, scoring *services.ScoringResult) ([]RankedCandidate, Metrics) {
+	good := labelSet(fixture.Good)
+	bad := labelSet(fixture.Bad)
+
+	similarity := make(map[uint]float64, len(matches))
+	for _, m := range matches {
+		similarity[m.Profile.UserID] = m.Similarity
+	}
+
+	ranked := make([]RankedCandidate, 0, len(scoring.Scores))
+	ids := make([]uint, 0, len(scoring.Scores))
+	var similarities, scores []float64
+	retrievedGood := 0
+	for _, score := range scoring.Scores {
+		id := uint(score.CandidateID)
+		candidate := RankedCandidate{
+			CandidateID: id,
+			Score:       score.Score,
+			Similarity:  similarity[id],
+			Source:      score.Source,
+		}
+		switch {
+		case good[id]:
+			candidate.Label = LabelGood
+			retrievedGood++
+		case bad[id]:
+			candidate.Label = LabelBad
+		}
+		ranked = append(ranked, candidate)
+		ids = append(ids, id)
+		similarities = append(similarities, candidate.Similarity)
+		scores = append(scores, float64(score.Score))
+	}
+
+	metrics := Metrics{
+		PrecisionAtK:    PrecisionAtK(ids, good, r.k),
+		NDCGAtK:         NDCGAtK(ids, good, r.k),
+		BadInTopK:       float64(CountAtK(ids, bad, r.k)),
+		RetrievalRecall: float64(retrievedGood) / float64(len(good)),
+		Spearman:        SpearmanCorrelation(similarities, scores),
+	}
+	if len(scoring.Scores) > 0 {
+		metrics.FallbackRate = float64(scoring.FallbackCount) / float64(len(scoring.Scores))
+	}
+	return ranked, metrics
+}
+
+func meanMetrics(results []ProjectResult) Metrics {
+	var mean Metrics
+	if len(results) == 0 {
+		return mean
+	}
+	for _, r := range results {
+		mean.PrecisionAtK += r.Metrics.PrecisionAtK
+		mean.NDCGAtK += r.Metrics.NDCGAtK
+		mean.BadInTopK += r.Metrics.BadInTopK
+		mean.RetrievalRecall += r.Metrics.RetrievalRecall
+		mean.Spearman += r.Metrics.Spearman
+		mean.FallbackRate += r.Metrics.FallbackRate
+	}
+	n := float64(len(results))
+	mean.PrecisionAtK /= n
+	mean.NDCGAtK /= n
+	mean.BadInTopK /= n
+	mean.RetrievalRecall /= n
+	mean.Spearman /= n
+	mean.FallbackRate /= n
+	return mean
+}
+
+func labelSet(ids []uint) map[uint]bool {
+	set := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func splitName(name string) (string, string) {
+	first, last, _ := strings.Cut(strings.TrimSpace(name), " ")
+	return first, last
+}
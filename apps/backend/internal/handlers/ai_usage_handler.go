@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/domain"
+)
+
+// defaultUsageWindow is the reporting period when no from date is given
+const defaultUsageWindow = 30 * 24 * time.Hour
+
+// AIUsageHandler handles HTTP requests for AI usage and cost reporting
+type AIUsageHandler struct {
+	usageService domain.AIUsageService
+}
+
+// NewAIUsageHandler creates a new AI usage handler
+func NewAIUsageHandler(usageService domain.AIUsageService) *AIUsageHandler {
+	return &AIUsageHandler{
+		usageService: usageService,
+	}
+}
+
+// GetUsage handles GET /admin/ai-usage?from=YYYY-MM-DD&to=YYYY-MM-DD
+// Both dates are inclusive UTC days; the default is the last 30 days.
+func (h *AIUsageHandler) GetUsage(c *gin.Context) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	to := today.Add(24 * time.Hour)
+	from := to.Add(-defaultUsageWindow)
+
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be a date in YYYY-MM-DD format"})
+			return
+		}
+		to = parsed.Add(24 * time.Hour)
+	}
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be a date in YYYY-MM-DD format"})
+			return
+		}
+		from = parsed
+	}
+	if !from.Before(to) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must not be after to"})
+		return
+	}
+
+	report, err := h.usageService.GetReport(c.Request.Context(), from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/pkg/middleware"
+)
+
+// APIKeyHandler handles service accounts and their API keys
+type APIKeyHandler struct {
+	apiKeyService domain.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler
+func NewAPIKeyHandler(apiKeyService domain.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+// ListServiceAccounts handles GET /api/v1/admin/service-accounts
+func (h *APIKeyHandler) ListServiceAccounts(c *gin.Context) {
+	accounts, err := h.apiKeyService.ListServiceAccounts(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"service_accounts": accounts})
+}
+
+// CreateServiceAccount handles POST /api/v1/admin/service-accounts
+func (h *APIKeyHandler) CreateServiceAccount(c *gin.Context) {
+	var req models.ServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdBy, _ := middleware.GetUserID(c)
+	account, err := h.apiKeyService.CreateServiceAccount(c.Request.Context(), createdBy, &req)
+	if err != nil {
+		c.JSON(apiKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, account)
+}
+
+// DisableServiceAccount handles DELETE /api/v1/admin/service-accounts/:id, revoking all its keys
+func (h *APIKeyHandler) DisableServiceAccount(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid service account ID"})
+		return
+	}
+
+	disabledBy, _ := middleware.GetUserID(c)
+	if err := h.apiKeyService.DisableServiceAccount(c.Request.Context(), disabledBy, uint(id)); err != nil {
+		c.JSON(apiKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Service account disabled"})
+}
+
+// CreateKey handles POST /api/v1/admin/service-accounts/:id/keys
+func (h *APIKeyHandler) CreateKey(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid service account ID"})
+		return
+	}
+	var req models.APIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdBy, _ := middleware.GetUserID(c)
+	key, err := h.apiKeyService.CreateKey(c.Request.Context(), createdBy, uint(id), &req)
+	if err != nil {
+		c.JSON(apiKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// RotateKey handles POST /api/v1/admin/api-keys/:id/rotate
+func (h *APIKeyHandler) RotateKey(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid API key ID"})
+		return
+	}
+	// The body is optional; without it the old key stops working immediately
+	var req models.RotateAPIKeyRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	rotatedBy, _ := middleware.GetUserID(c)
+	key, err := h.apiKeyService.RotateKey(c.Request.Context(), rotatedBy, uint(id), &req)
+	if err != nil {
+		c.JSON(apiKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// RevokeKey handles DELETE /api/v1/admin/api-keys/:id
+func (h *APIKeyHandler) RevokeKey(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid API key ID"})
+		return
+	}
+
+	revokedBy, _ := middleware.GetUserID(c)
+	if err := h.apiKeyService.RevokeKey(c.Request.Context(), revokedBy, uint(id)); err != nil {
+		c.JSON(apiKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked"})
+}
+
+// apiKeyErrorStatus maps a service account or API key error to its HTTP status
+func apiKeyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidAPIKeyRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrServiceAccountNotFound), errors.Is(err, domain.ErrAPIKeyNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrServiceAccountExists), errors.Is(err, domain.ErrServiceAccountDisabled), errors.Is(err, domain.ErrInvalidAPIKey):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
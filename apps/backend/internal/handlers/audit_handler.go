@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/domain"
+)
+
+// Page size bounds for listing audit events
+const (
+	defaultAuditEventLimit = 100
+	maxAuditEventLimit     = 1000
+)
+
+// AuditHandler handles the user provisioning audit log
+type AuditHandler struct {
+	auditService domain.AuditService
+}
+
+// NewAuditHandler creates a new AuditHandler
+func NewAuditHandler(auditService domain.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// ListEvents handles GET /api/v1/admin/audit-events, filtered by ?action= and ?email=
+func (h *AuditHandler) ListEvents(c *gin.Context) {
+	limit, ok := listLimit(c, defaultAuditEventLimit, maxAuditEventLimit)
+	if !ok {
+		return
+	}
+
+	events, err := h.auditService.List(c.Request.Context(), c.Query("action"), c.Query("email"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": events})
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/internal/textextract"
+	"github.com/talent-fit/backend/pkg/middleware"
+)
+
+// CVImportHandler handles HTTP requests for CV uploads and the profile drafts made from them
+type CVImportHandler struct {
+	cvImportService domain.CVImportService
+	maxUploadBytes  int64
+}
+
+// NewCVImportHandler creates a new CV import handler
+func NewCVImportHandler(cvImportService domain.CVImportService, cfg *config.Config) *CVImportHandler {
+	return &CVImportHandler{
+		cvImportService: cvImportService,
+		maxUploadBytes:  int64(cfg.Storage.MaxUploadMB) << 20,
+	}
+}
+
+// UploadCV handles POST /employee/me/cv with a multipart "file" field (PDF, DOCX or plain text)
+// and an optional "keep_original" field
+func (h *CVImportHandler) UploadCV(c *gin.Context) {
+	email, ok := middleware.GetUserEmail(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User email not found"})
+		return
+	}
+
+	// Leave room for the multipart envelope around the file itself
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadBytes+1<<20)
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a CV file is required in the \"file\" field"})
+		return
+	}
+	if header.Size > h.maxUploadBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("CV must be at most %d MB", h.maxUploadBytes>>20)})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, h.maxUploadBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	keepOriginal, _ := strconv.ParseBool(c.PostForm("keep_original"))
+	upload := &domain.CVUpload{
+		Filename:     header.Filename,
+		ContentType:  header.Header.Get("Content-Type"),
+		Data:         data,
+		KeepOriginal: keepOriginal,
+	}
+
+	draft, err := h.cvImportService.CreateDraft(c.Request.Context(), email, upload)
+	switch {
+	case errors.Is(err, textextract.ErrUnsupportedFormat):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, textextract.ErrNoText):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, draft)
+}
+
+// GetDraft handles GET /employee/me/cv/drafts/:draftId
+func (h *CVImportHandler) GetDraft(c *gin.Context) {
+	email, draftID, ok := h.draftRequest(c)
+	if !ok {
+		return
+	}
+
+	draft, err := h.cvImportService.GetDraft(c.Request.Context(), email, draftID)
+	if err != nil {
+		respondDraftError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// ConfirmDraft handles POST /employee/me/cv/drafts/:draftId/confirm, optionally with
+// {"fields": ["skills", "years_of_experience", "industry", "type"]} to apply only some changes
+func (h *CVImportHandler) ConfirmDraft(c *gin.Context) {
+	email, draftID, ok := h.draftRequest(c)
+	if !ok {
+		return
+	}
+
+	var request models.ConfirmProfileDraftRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	for _, field := range request.Fields {
+		switch field {
+		case models.DraftFieldSkills, models.DraftFieldYearsOfExperience, models.DraftFieldIndustry, models.DraftFieldType:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "fields must be skills, years_of_experience, industry or type"})
+			return
+		}
+	}
+
+	profile, err := h.cvImportService.ConfirmDraft(c.Request.Context(), email, draftID, &request)
+	if err != nil {
+		respondDraftError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// DiscardDraft handles DELETE /employee/me/cv/drafts/:draftId
+func (h *CVImportHandler) DiscardDraft(c *gin.Context) {
+	email, draftID, ok := h.draftRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.cvImportService.DiscardDraft(c.Request.Context(), email, draftID); err != nil {
+		respondDraftError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// draftRequest reads the signed-in employee and draft ID, writing the error response when either is missing
+func (h *CVImportHandler) draftRequest(c *gin.Context) (string, uint, bool) {
+	email, ok := middleware.GetUserEmail(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User email not found"})
+		return "", 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("draftId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid draft ID"})
+		return "", 0, false
+	}
+	return email, uint(id), true
+}
+
+func respondDraftError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrProfileDraftNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrProfileDraftClosed):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrProfileTypeRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
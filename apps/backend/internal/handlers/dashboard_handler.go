@@ -1,12 +1,20 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/pkg/middleware"
 )
 
+// defaultTrendDays is how far back trends go when no from date is given
+const defaultTrendDays = 90
+
 // DashboardHandler handles HTTP requests for dashboard metrics
 type DashboardHandler struct {
 	dashboardService domain.DashboardService
@@ -17,11 +25,12 @@ func NewDashboardHandler(dashboardService domain.DashboardService) *DashboardHan
 	return &DashboardHandler{dashboardService: dashboardService}
 }
 
-// GetManagerDashboardMetrics handles GET /manager/dashboard/metrics
+// GetManagerDashboardMetrics handles GET /manager/dashboard/metrics?org_unit=, scoped to the
+// caller's org units by default
 func (h *DashboardHandler) GetManagerDashboardMetrics(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	metrics, err := h.dashboardService.GetManagerDashboardMetrics(ctx)
+	metrics, err := h.dashboardService.GetManagerDashboardMetrics(ctx, middleware.GetOrgUnitIDs(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -30,4 +39,40 @@ func (h *DashboardHandler) GetManagerDashboardMetrics(c *gin.Context) {
 	c.JSON(http.StatusOK, metrics)
 }
 
+// GetMetricsTrends handles GET /manager/dashboard/trends?from=YYYY-MM-DD&to=YYYY-MM-DD&groupBy=all|department|geo,
+// defaulting to the last 90 days organisation-wide
+func (h *DashboardHandler) GetMetricsTrends(c *gin.Context) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	from := to.AddDate(0, 0, -defaultTrendDays)
+	for _, field := range []struct {
+		name string
+		date *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		value := c.Query(field.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s must be a date in YYYY-MM-DD format", field.name)})
+			return
+		}
+		*field.date = parsed
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must not be before from"})
+		return
+	}
+
+	trends, err := h.dashboardService.GetMetricsTrends(c.Request.Context(), from, to, c.DefaultQuery("groupBy", entities.MetricsGroupAll))
+	if errors.Is(err, domain.ErrInvalidMetricsGroup) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
+	c.JSON(http.StatusOK, trends)
+}
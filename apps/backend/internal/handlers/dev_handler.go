@@ -1,21 +1,86 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/talent-fit/backend/internal/config"
 	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/pkg/middleware"
 )
 
 // DevHandler provides dev/test utilities
 type DevHandler struct {
     orchestrator domain.NotificationOrchestrator
+    devAuth      domain.DevAuthService
     cfg          *config.Config
 }
 
-func NewDevHandler(orchestrator domain.NotificationOrchestrator, cfg *config.Config) *DevHandler {
-    return &DevHandler{orchestrator: orchestrator, cfg: cfg}
+func NewDevHandler(orchestrator domain.NotificationOrchestrator, devAuth domain.DevAuthService, cfg *config.Config) *DevHandler {
+    return &DevHandler{orchestrator: orchestrator, devAuth: devAuth, cfg: cfg}
+}
+
+// Login handles POST /auth/dev/login, signing in as a seeded user by {"email": ...} or
+// {"role": "Manager"} without a login provider (DEV_LOGIN=true in development only)
+func (h *DevHandler) Login(c *gin.Context) {
+    if !h.cfg.DevLoginEnabled() {
+        c.JSON(http.StatusForbidden, gin.H{"error": "dev login is disabled"})
+        return
+    }
+
+    var req struct {
+        Email string `json:"email"`
+        Role  string `json:"role"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+
+    resp, err := h.devAuth.Login(c.Request.Context(), req.Email, req.Role)
+    if errors.Is(err, domain.ErrUserNotFound) {
+        c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+        return
+    }
+    if err != nil {
+        c.JSON(loginErrorStatus(err), gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, loginResponse(resp))
+}
+
+// Impersonate handles POST /api/v1/admin/impersonate/:id, returning an access token to act as
+// another user. Changes made with it are recorded against the admin in the audit log.
+func (h *DevHandler) Impersonate(c *gin.Context) {
+    if !h.cfg.DevLoginEnabled() {
+        c.JSON(http.StatusForbidden, gin.H{"error": "impersonation is disabled"})
+        return
+    }
+    if _, ok := middleware.GetImpersonatorID(c); ok {
+        c.JSON(http.StatusForbidden, gin.H{"error": "stop impersonating before acting as another user"})
+        return
+    }
+
+    id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+        return
+    }
+
+    actorID, _ := middleware.GetUserID(c)
+    tokens, err := h.devAuth.Impersonate(c.Request.Context(), actorID, uint(id))
+    switch {
+    case errors.Is(err, domain.ErrUserNotFound):
+        c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+    case errors.Is(err, domain.ErrSelfImpersonation):
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+    case err != nil:
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+    default:
+        c.JSON(http.StatusOK, gin.H{"token": tokens.AccessToken, "expiresAt": tokens.ExpiresAt, "userId": uint(id)})
+    }
 }
 
 // SendTestNotification posts a sample message to the default Slack channel (no auth; non-prod only)
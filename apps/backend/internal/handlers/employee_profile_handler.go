@@ -14,16 +14,19 @@ import (
 // EmployeeProfileHandler handles HTTP requests for employee profiles
 type EmployeeProfileHandler struct {
 	profileService domain.EmployeeProfileService
+	searchService  domain.TalentSearchService
 }
 
 // NewEmployeeProfileHandler creates a new employee profile handler
-func NewEmployeeProfileHandler(profileService domain.EmployeeProfileService) *EmployeeProfileHandler {
+func NewEmployeeProfileHandler(profileService domain.EmployeeProfileService, searchService domain.TalentSearchService) *EmployeeProfileHandler {
 	return &EmployeeProfileHandler{
 		profileService: profileService,
+		searchService:  searchService,
 	}
 }
 
-// GetAllProfiles handles GET /profiles
+// GetAllProfiles handles GET /employees?skills=&geo=&available=&org_unit=, scoped to the caller's
+// org units by default
 func (h *EmployeeProfileHandler) GetAllProfiles(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -54,7 +57,7 @@ func (h *EmployeeProfileHandler) GetAllProfiles(c *gin.Context) {
 		}
 	}
 
-	profiles, err := h.profileService.SearchProfiles(ctx, skills, geos, availableOnly)
+	profiles, err := h.profileService.SearchProfiles(ctx, skills, geos, availableOnly, middleware.GetOrgUnitIDs(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -63,6 +66,28 @@ func (h *EmployeeProfileHandler) GetAllProfiles(c *gin.Context) {
 	c.JSON(http.StatusOK, profiles)
 }
 
+// SearchProfiles handles POST /employees/search with a free-text query, e.g.
+// {"query": "senior Go engineer in Europe with fintech and Kafka, free next month"}
+func (h *EmployeeProfileHandler) SearchProfiles(c *gin.Context) {
+	var request models.EmployeeSearchRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if strings.TrimSpace(request.Query) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query is required"})
+		return
+	}
+
+	response, err := h.searchService.Search(c.Request.Context(), &request)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // GetProfileByUserID handles GET /employee/me
 func (h *EmployeeProfileHandler) GetMe(c *gin.Context) {
 	ctx := c.Request.Context()
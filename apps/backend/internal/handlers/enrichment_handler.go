@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// Page size bounds for listing enrichment jobs
+const (
+	defaultJobListLimit = 50
+	maxJobListLimit     = 500
+)
+
+// EnrichmentHandler handles HTTP requests for inspecting and retrying enrichment jobs
+type EnrichmentHandler struct {
+	enrichmentService domain.EnrichmentService
+}
+
+// NewEnrichmentHandler creates a new enrichment handler
+func NewEnrichmentHandler(enrichmentService domain.EnrichmentService) *EnrichmentHandler {
+	return &EnrichmentHandler{
+		enrichmentService: enrichmentService,
+	}
+}
+
+// ListJobs handles GET /admin/enrichment-jobs?status=failed&limit=50
+func (h *EnrichmentHandler) ListJobs(c *gin.Context) {
+	status := c.Query("status")
+	switch status {
+	case "", entities.JobStatusPending, entities.JobStatusRunning, entities.JobStatusSucceeded, entities.JobStatusFailed:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of pending, running, succeeded or failed"})
+		return
+	}
+
+	limit := defaultJobListLimit
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxJobListLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 500"})
+			return
+		}
+		limit = parsed
+	}
+
+	jobs, err := h.enrichmentService.ListJobs(c.Request.Context(), status, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs)
+}
+
+// RetryJob handles POST /admin/enrichment-jobs/:id/retry
+func (h *EnrichmentHandler) RetryJob(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid job ID"})
+		return
+	}
+
+	job, err := h.enrichmentService.RetryJob(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
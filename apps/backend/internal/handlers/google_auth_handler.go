@@ -1,20 +1,26 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/config"
 	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/pkg/middleware"
 )
 
 // GoogleAuthHandler handles Google sign-in
 type GoogleAuthHandler struct {
     authService domain.GoogleAuthService
+    tokenService domain.TokenService
+    cfg          *config.Config
 }
 
 // NewGoogleAuthHandler creates a new GoogleAuthHandler
-func NewGoogleAuthHandler(authService domain.GoogleAuthService) *GoogleAuthHandler {
-    return &GoogleAuthHandler{authService: authService}
+func NewGoogleAuthHandler(authService domain.GoogleAuthService, tokenService domain.TokenService, cfg *config.Config) *GoogleAuthHandler {
+    return &GoogleAuthHandler{authService: authService, tokenService: tokenService, cfg: cfg}
 }
 
 // Login exchanges Google ID token for app JWT and user info
@@ -29,22 +35,47 @@ func (h *GoogleAuthHandler) Login(c *gin.Context) {
 
     resp, err := h.authService.AuthenticateWithGoogle(c.Request.Context(), req.Credential)
     if err != nil {
-        c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+        c.JSON(loginErrorStatus(err), gin.H{"error": err.Error()})
         return
     }
 
-    c.JSON(http.StatusOK, gin.H{
-        "token": resp.Token,
-        "name":  resp.Name,
-        "email": resp.Email,
-        "userId": resp.UserID,
-    })
+    c.JSON(http.StatusOK, loginResponse(resp))
 }
 
-// Logout handles POST /auth/logout
+// Logout handles POST /auth/logout, revoking the bearer access token if still valid and the
+// session of the refresh token in the body, if given
 func (h *GoogleAuthHandler) Logout(c *gin.Context) {
-	// Stateless JWT: clients should discard their token.
-	// If server-side invalidation is required, implement a blacklist store here.
+	var req struct {
+		RefreshToken string `json:"refreshToken"`
+	}
+	_ = c.ShouldBindJSON(&req)
+
+	if raw := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "); raw != "" {
+		// An expired or invalid token needs no revoking
+		if claims, err := middleware.ParseJWTToken(h.cfg, raw); err == nil {
+			jti, _ := claims["jti"].(string)
+			uid, _ := claims["uid"].(float64)
+			expiresAt, _ := claims.GetExpirationTime()
+			if jti != "" && expiresAt != nil {
+				// Logout runs without the auth middleware, so scope the revocation to the token's tenant,
+				// where RejectRevokedTokens looks it up
+				ctx := domain.WithTenant(c.Request.Context(), middleware.TenantFromClaims(claims))
+				if err := h.tokenService.RevokeAccessToken(ctx, jti, uint(uid), expiresAt.Time); err != nil {
+					log.Printf("Warning: logout failed to revoke access token: %v", err)
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke token"})
+					return
+				}
+			}
+		}
+	}
+
+	if req.RefreshToken != "" {
+		if err := h.tokenService.RevokeRefreshToken(c.Request.Context(), req.RefreshToken); err != nil {
+			log.Printf("Warning: logout failed to revoke refresh token: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke token"})
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{"ok": true})
 }
-
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/pkg/middleware"
+)
+
+// fakeRevocationList keeps revoked access tokens by tenant and jti, as the tenant-scoped repository does
+type fakeRevocationList struct {
+	domain.TokenService
+	revoked map[uint]map[string]bool
+}
+
+func (f *fakeRevocationList) RevokeAccessToken(ctx context.Context, jti string, userID uint, expiresAt time.Time) error {
+	tenantID, _ := domain.TenantFromContext(ctx)
+	if f.revoked[tenantID] == nil {
+		f.revoked[tenantID] = make(map[string]bool)
+	}
+	f.revoked[tenantID][jti] = true
+	return nil
+}
+
+func (f *fakeRevocationList) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	tenantID, _ := domain.TenantFromContext(ctx)
+	return f.revoked[tenantID][jti], nil
+}
+
+func TestLogoutRevokesTokenInItsTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	cfg.Auth.JWTSecret = "test-secret"
+	tokens := &fakeRevocationList{revoked: make(map[uint]map[string]bool)}
+	handler := NewGoogleAuthHandler(nil, tokens, cfg)
+
+	router := gin.New()
+	router.POST("/auth/logout", handler.Logout)
+	router.GET("/me", middleware.AuthMiddlewareWithConfig(cfg), middleware.RejectRevokedTokens(tokens), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	token, _, err := middleware.GenerateJWTToken(cfg, 7, 2, "jane@example.com", "Employee")
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := func(method, path string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := request(http.MethodGet, "/me"); code != http.StatusOK {
+		t.Fatalf("GET /me before logout = %d, want %d", code, http.StatusOK)
+	}
+	if code := request(http.MethodPost, "/auth/logout"); code != http.StatusOK {
+		t.Fatalf("POST /auth/logout = %d, want %d", code, http.StatusOK)
+	}
+	if len(tokens.revoked[2]) != 1 {
+		t.Errorf("revoked tokens by tenant = %v, want the token in tenant 2", tokens.revoked)
+	}
+	if code := request(http.MethodGet, "/me"); code != http.StatusUnauthorized {
+		t.Errorf("GET /me after logout = %d, want %d", code, http.StatusUnauthorized)
+	}
+}
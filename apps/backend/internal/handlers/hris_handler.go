@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/hris"
+)
+
+// maxWebhookBytes bounds a webhook delivery
+const maxWebhookBytes = 10 << 20
+
+// Page size bounds for listing HR sync runs
+const (
+	defaultSyncRunLimit = 50
+	maxSyncRunLimit     = 500
+)
+
+// HRISHandler handles the HR system webhook and the HR sync log
+type HRISHandler struct {
+	hrSyncService domain.HRSyncService
+	webhookSecret []byte
+	mapping       hris.Mapping
+}
+
+// NewHRISHandler creates a new HRIS handler; the webhook is disabled when webhookSecret is empty
+func NewHRISHandler(hrSyncService domain.HRSyncService, webhookSecret string, mapping hris.Mapping) *HRISHandler {
+	return &HRISHandler{
+		hrSyncService: hrSyncService,
+		webhookSecret: []byte(webhookSecret),
+		mapping:       mapping,
+	}
+}
+
+// Webhook handles POST /integrations/hris/webhook. Deliveries are signed with the shared secret in the
+// X-HRIS-Signature header over "<X-HRIS-Timestamp>.<body>"; redeliveries return the original run.
+func (h *HRISHandler) Webhook(c *gin.Context) {
+	if len(h.webhookSecret) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "HRIS webhook is not configured"})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookBytes))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "webhook body is too large"})
+		return
+	}
+	err = hris.VerifySignature(h.webhookSecret, c.GetHeader(hris.HeaderTimestamp), c.GetHeader(hris.HeaderSignature), body, time.Now())
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	batch, err := hris.ParseWebhook(body, h.mapping)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	run, err := h.hrSyncService.Sync(c.Request.Context(), batch)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// ListRuns handles GET /admin/hr-sync/runs?limit=50
+func (h *HRISHandler) ListRuns(c *gin.Context) {
+	limit := defaultSyncRunLimit
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxSyncRunLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 500"})
+			return
+		}
+		limit = parsed
+	}
+
+	runs, err := h.hrSyncService.ListRuns(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"runs": runs})
+}
+
+// GetRun handles GET /admin/hr-sync/runs/:id with the run's changes and conflicts
+func (h *HRISHandler) GetRun(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid run ID"})
+		return
+	}
+
+	run, err := h.hrSyncService.GetRun(c.Request.Context(), uint(id))
+	if errors.Is(err, domain.ErrHRSyncRunNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// ImportHandler handles HTTP requests for bulk CSV and XLSX imports
+type ImportHandler struct {
+	importService  domain.ImportService
+	maxUploadBytes int64
+}
+
+// NewImportHandler creates a new import handler
+func NewImportHandler(importService domain.ImportService, cfg *config.Config) *ImportHandler {
+	return &ImportHandler{
+		importService:  importService,
+		maxUploadBytes: int64(cfg.Storage.MaxImportMB) << 20,
+	}
+}
+
+// Import handles POST /admin/imports?dry_run=true with one multipart file per kind in the
+// "users", "profiles", "projects" and "allocations" fields. A report with row errors is
+// returned with 422 and nothing is written.
+func (h *ImportHandler) Import(c *gin.Context) {
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "dry_run must be true or false"})
+			return
+		}
+	}
+
+	// Leave room for the multipart envelope around the files themselves
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadBytes+1<<20)
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("expected a multipart upload of at most %d MB", h.maxUploadBytes>>20)})
+		return
+	}
+
+	var files []*domain.ImportFile
+	for _, kind := range models.ImportKinds {
+		headers := form.File[string(kind)]
+		if len(headers) > 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("upload one %s file", kind)})
+			return
+		}
+		if len(headers) == 0 {
+			continue
+		}
+		file, err := headers[0].Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		files = append(files, &domain.ImportFile{Kind: kind, Filename: headers[0].Filename, Data: data})
+	}
+	if len(files) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "upload at least one file in the users, profiles, projects or allocations field"})
+		return
+	}
+
+	report, err := h.importService.Import(c.Request.Context(), files, dryRun)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(report.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/pkg/middleware"
+)
+
+// Page size bounds for listing invitations
+const (
+	defaultInvitationLimit = 50
+	maxInvitationLimit     = 500
+)
+
+// InvitationHandler handles inviting users before their first sign-in
+type InvitationHandler struct {
+	invitationService domain.InvitationService
+}
+
+// NewInvitationHandler creates a new InvitationHandler
+func NewInvitationHandler(invitationService domain.InvitationService) *InvitationHandler {
+	return &InvitationHandler{invitationService: invitationService}
+}
+
+// CreateInvitation handles POST /api/v1/invitations
+func (h *InvitationHandler) CreateInvitation(c *gin.Context) {
+	var req models.InviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invitedBy, _ := middleware.GetUserID(c)
+	invitation, err := h.invitationService.Invite(c.Request.Context(), invitedBy, &req)
+	switch {
+	case errors.Is(err, domain.ErrInvalidInvitation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrAdminInvite):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrUserAlreadyExists), errors.Is(err, domain.ErrInvitationExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusCreated, invitation)
+	}
+}
+
+// ListInvitations handles GET /api/v1/invitations, with ?status=pending for open invitations only
+func (h *InvitationHandler) ListInvitations(c *gin.Context) {
+	limit, ok := listLimit(c, defaultInvitationLimit, maxInvitationLimit)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.invitationService.List(c.Request.Context(), c.Query("status") == models.InvitationPending, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invitations": invitations})
+}
+
+// RevokeInvitation handles DELETE /api/v1/invitations/:id
+func (h *InvitationHandler) RevokeInvitation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invitation ID"})
+		return
+	}
+
+	revokedBy, _ := middleware.GetUserID(c)
+	err = h.invitationService.Revoke(c.Request.Context(), revokedBy, uint(id))
+	switch {
+	case errors.Is(err, domain.ErrInvitationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvitationNotActive):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, gin.H{"message": "Invitation revoked"})
+	}
+}
+
+// listLimit reads the ?limit query parameter, writing a 400 response when it is out of bounds
+func listLimit(c *gin.Context, defaultLimit, maxLimit int) (int, bool) {
+	value := c.Query("limit")
+	if value == "" {
+		return defaultLimit, true
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 || limit > maxLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxLimit)})
+		return 0, false
+	}
+	return limit, true
+}
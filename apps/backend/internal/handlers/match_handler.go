@@ -5,18 +5,22 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/config"
 	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/models"
 )
 
 // MatchHandler handles HTTP requests for AI matching
 type MatchHandler struct {
 	matchService domain.MatchService
+	config       *config.Config
 }
 
 // NewMatchHandler creates a new match handler
-func NewMatchHandler(matchService domain.MatchService) *MatchHandler {
+func NewMatchHandler(matchService domain.MatchService, cfg *config.Config) *MatchHandler {
 	return &MatchHandler{
 		matchService: matchService,
+		config:       cfg,
 	}
 }
 
@@ -65,6 +69,46 @@ func (h *MatchHandler) GenerateMatchSuggestions(c *gin.Context) {
 	})
 }
 
+// StreamMatchSuggestions handles GET /project/:id/suggestions/stream as Server-Sent Events:
+// a "candidates" event with the retrieved candidates, a "score" event per scored candidate,
+// then a "summary" event with the final ranking, or an "error" event if scoring fails.
+// Under the Lambda adapter the same events are buffered and delivered in one response.
+func (h *MatchHandler) StreamMatchSuggestions(c *gin.Context) {
+	projectID := c.Param("id")
+	if projectID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "project id is required"})
+		return
+	}
+
+	streaming := false
+	emit := func(event models.MatchStreamEvent) {
+		if !streaming {
+			streaming = true
+			c.Header("Content-Type", "text/event-stream")
+			c.Header("Cache-Control", "no-cache")
+			c.Header("Connection", "keep-alive")
+			c.Header("X-Accel-Buffering", "no")
+			if h.config.Server.Lambda {
+				c.Header("X-Stream-Mode", "buffered")
+			}
+			c.Status(http.StatusOK)
+		}
+		c.SSEvent(event.Name, event.Data)
+		// A no-op under the Lambda adapter, which buffers the whole response
+		c.Writer.Flush()
+	}
+
+	log.Printf("Streaming AI match suggestions for project ID: %s", projectID)
+	if err := h.matchService.StreamMatchSuggestions(c.Request.Context(), projectID, emit); err != nil {
+		log.Printf("Error streaming match suggestions for project %s: %v", projectID, err)
+		if !streaming {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		emit(models.MatchStreamEvent{Name: models.MatchEventError, Data: gin.H{"error": err.Error()}})
+	}
+}
+
 // GetMatchExplanation handles GET /matches/projects/:projectId/employees/:employeeId/explanation
 func (h *MatchHandler) GetMatchExplanation(c *gin.Context) {
 	// TODO: Implement handler logic for getting match explanation
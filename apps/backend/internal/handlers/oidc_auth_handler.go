@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/domain"
+)
+
+// OIDCAuthHandler handles sign-in through any configured OpenID Connect provider
+type OIDCAuthHandler struct {
+	authService domain.OIDCAuthService
+}
+
+// NewOIDCAuthHandler creates a new OIDCAuthHandler
+func NewOIDCAuthHandler(authService domain.OIDCAuthService) *OIDCAuthHandler {
+	return &OIDCAuthHandler{authService: authService}
+}
+
+// Providers handles GET /auth/providers, listing the providers clients can offer for sign-in
+func (h *OIDCAuthHandler) Providers(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"providers": h.authService.Providers()})
+}
+
+// Login handles POST /auth/oidc/:provider/login, exchanging the provider's ID token for app tokens
+func (h *OIDCAuthHandler) Login(c *gin.Context) {
+	var req struct {
+		Credential string `json:"credential"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.Credential == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing credential"})
+		return
+	}
+
+	resp, err := h.authService.Authenticate(c.Request.Context(), c.Param("provider"), req.Credential)
+	if err != nil {
+		c.JSON(loginErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, loginResponse(resp))
+}
+
+// loginErrorStatus maps a sign-in error to its HTTP status
+func loginErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrUnknownAuthProvider):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrEmailDomainNotAllowed), errors.Is(err, domain.ErrNotInvited),
+		errors.Is(err, domain.ErrIdentityMismatch):
+		return http.StatusForbidden
+	default:
+		return http.StatusUnauthorized
+	}
+}
+
+// loginResponse is the body returned on sign-in by every provider
+func loginResponse(resp *domain.AuthResponse) gin.H {
+	return gin.H{
+		"token":        resp.Token,
+		"name":         resp.Name,
+		"email":        resp.Email,
+		"userId":       resp.UserID,
+		"refreshToken": resp.RefreshToken,
+		"expiresAt":    resp.ExpiresAt,
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// OrgUnitHandler handles the org unit tree
+type OrgUnitHandler struct {
+	orgUnitService domain.OrgUnitService
+}
+
+// NewOrgUnitHandler creates a new OrgUnitHandler
+func NewOrgUnitHandler(orgUnitService domain.OrgUnitService) *OrgUnitHandler {
+	return &OrgUnitHandler{orgUnitService: orgUnitService}
+}
+
+// ListOrgUnits handles GET /api/v1/org-units
+func (h *OrgUnitHandler) ListOrgUnits(c *gin.Context) {
+	units, err := h.orgUnitService.ListOrgUnits(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"org_units": units})
+}
+
+// CreateOrgUnit handles POST /api/v1/admin/org-units
+func (h *OrgUnitHandler) CreateOrgUnit(c *gin.Context) {
+	var req models.OrgUnitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	unit, err := h.orgUnitService.CreateOrgUnit(c.Request.Context(), &req)
+	respondWithOrgUnit(c, http.StatusCreated, unit, err)
+}
+
+// UpdateOrgUnit handles PUT /api/v1/admin/org-units/:id
+func (h *OrgUnitHandler) UpdateOrgUnit(c *gin.Context) {
+	id, ok := orgUnitIDParam(c)
+	if !ok {
+		return
+	}
+	var req models.OrgUnitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	unit, err := h.orgUnitService.UpdateOrgUnit(c.Request.Context(), id, &req)
+	respondWithOrgUnit(c, http.StatusOK, unit, err)
+}
+
+// DeleteOrgUnit handles DELETE /api/v1/admin/org-units/:id
+func (h *OrgUnitHandler) DeleteOrgUnit(c *gin.Context) {
+	id, ok := orgUnitIDParam(c)
+	if !ok {
+		return
+	}
+
+	err := h.orgUnitService.DeleteOrgUnit(c.Request.Context(), id)
+	respondWithOrgUnit(c, http.StatusOK, gin.H{"message": "Org unit deleted"}, err)
+}
+
+// orgUnitIDParam reads the :id path parameter, writing a 400 response when it is invalid
+func orgUnitIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid org unit ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// respondWithOrgUnit writes a result, or the status matching an org unit service error
+func respondWithOrgUnit(c *gin.Context, status int, result interface{}, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidOrgUnit):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrOrgUnitNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrOrgUnitExists), errors.Is(err, domain.ErrOrgUnitInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(status, result)
+	}
+}
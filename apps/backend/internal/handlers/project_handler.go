@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/talent-fit/backend/internal/domain"
 	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/pkg/middleware"
 )
 
 // ProjectHandler handles HTTP requests for projects
@@ -21,11 +22,11 @@ func NewProjectHandler(projectService domain.ProjectService) *ProjectHandler {
 	}
 }
 
-// GetAllProjects handles GET /projects
+// GetAllProjects handles GET /projects?org_unit=, scoped to the caller's org units by default
 func (h *ProjectHandler) GetAllProjects(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	projects, err := h.projectService.GetAllProjects(ctx)
+	projects, err := h.projectService.GetAllProjects(ctx, middleware.GetOrgUnitIDs(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/pkg/middleware"
+)
+
+// PromptHandler handles HTTP requests for prompt template administration
+type PromptHandler struct {
+	promptService domain.PromptService
+}
+
+// NewPromptHandler creates a new prompt handler
+func NewPromptHandler(promptService domain.PromptService) *PromptHandler {
+	return &PromptHandler{
+		promptService: promptService,
+	}
+}
+
+// ListVersions handles GET /admin/prompts/:name
+func (h *PromptHandler) ListVersions(c *gin.Context) {
+	versions, err := h.promptService.ListVersions(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, versions)
+}
+
+// SaveVersion handles PUT /admin/prompts/:name/versions/:version
+func (h *PromptHandler) SaveVersion(c *gin.Context) {
+	var req struct {
+		Body   string `json:"body" binding:"required"`
+		Active bool   `json:"active"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	email, _ := middleware.GetUserEmail(c)
+	template := &entities.PromptTemplate{
+		Name:      c.Param("name"),
+		Version:   c.Param("version"),
+		Body:      req.Body,
+		Active:    req.Active,
+		CreatedBy: email,
+	}
+
+	saved, err := h.promptService.SaveOverride(c.Request.Context(), template)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// Preview handles GET /admin/prompts/:name/preview?project_id=<id>&version=<version>
+func (h *PromptHandler) Preview(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Query("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "project_id query parameter is required"})
+		return
+	}
+
+	prompt, err := h.promptService.PreviewProjectPrompt(c.Request.Context(), c.Param("name"), c.Query("version"), projectID)
+	switch {
+	case errors.Is(err, domain.ErrUnknownPrompt), errors.Is(err, domain.ErrPromptProjectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, prompt)
+	}
+}
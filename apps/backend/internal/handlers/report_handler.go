@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/tabular"
+)
+
+// defaultReportDays is the length of the report period when no dates are given
+const defaultReportDays = 30
+
+// formatPDF is the only format of the summary report
+const formatPDF = "pdf"
+
+// ReportHandler handles HTTP requests for staffing report exports
+type ReportHandler struct {
+	reportService domain.ReportService
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(reportService domain.ReportService) *ReportHandler {
+	return &ReportHandler{reportService: reportService}
+}
+
+// Export handles GET /reports/:kind?from=YYYY-MM-DD&to=YYYY-MM-DD&format=csv|xlsx|json for the
+// bench, allocations, utilisation and rolloffs reports. Rows are streamed as they are read.
+func (h *ReportHandler) Export(c *gin.Context) {
+	kind := c.Param("kind")
+	known := false
+	for _, k := range domain.ReportKinds {
+		known = known || k == kind
+	}
+	if !known {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("unknown report %q; use bench, allocations, utilisation, rolloffs or summary", kind)})
+		return
+	}
+	period, ok := reportPeriod(c)
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", tabular.FormatJSON)
+	out, err := tabular.NewWriter(format, c.Writer)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", tabular.ContentType(format))
+	if format != tabular.FormatJSON {
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, reportFilename(kind, period, format)))
+	}
+	c.Status(http.StatusOK)
+	if err := h.reportService.WriteReport(c.Request.Context(), kind, period, out); err != nil {
+		h.failStream(c, kind, err)
+	}
+}
+
+// Summary handles GET /reports/summary?from=&to=&format=pdf: the dashboard metrics with the
+// period's roll-off, bench and utilisation tables
+func (h *ReportHandler) Summary(c *gin.Context) {
+	if format := c.DefaultQuery("format", formatPDF); format != formatPDF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "the summary report is only available as pdf"})
+		return
+	}
+	period, ok := reportPeriod(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", "application/pdf")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, reportFilename("summary", period, formatPDF)))
+	c.Status(http.StatusOK)
+	if err := h.reportService.WriteSummaryPDF(c.Request.Context(), period, c.Writer); err != nil {
+		h.failStream(c, "summary", err)
+	}
+}
+
+// failStream reports an error as JSON when nothing has been sent yet. Once rows are on the wire
+// the status cannot change, so the error is logged and the response cut short.
+func (h *ReportHandler) failStream(c *gin.Context, kind string, err error) {
+	log.Printf("Error writing %s report: %v", kind, err)
+	if c.Writer.Written() {
+		c.Abort()
+		return
+	}
+	c.Writer.Header().Del("Content-Disposition")
+	c.Writer.Header().Del("Content-Type")
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// reportPeriod reads the from and to dates, defaulting to the next 30 days from today
+func reportPeriod(c *gin.Context) (domain.ReportPeriod, bool) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	period := domain.ReportPeriod{From: today}
+	for _, field := range []struct {
+		name string
+		date *time.Time
+	}{{"from", &period.From}, {"to", &period.To}} {
+		value := c.Query(field.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(tabular.DateLayout, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s must be a date in YYYY-MM-DD format", field.name)})
+			return period, false
+		}
+		*field.date = parsed
+	}
+	if period.To.IsZero() {
+		period.To = period.From.AddDate(0, 0, defaultReportDays)
+	}
+
+	if period.To.Before(period.From) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must not be before from"})
+		return period, false
+	}
+	if period.To.Sub(period.From) >= domain.MaxReportDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("the report period must not exceed %d days", domain.MaxReportDays)})
+		return period, false
+	}
+	return period, true
+}
+
+func reportFilename(kind string, period domain.ReportPeriod, format string) string {
+	return fmt.Sprintf("%s_%s_%s.%s", kind, period.From.Format(tabular.DateLayout), period.To.Format(tabular.DateLayout), format)
+}
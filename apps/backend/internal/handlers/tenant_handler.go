@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/pkg/middleware"
+)
+
+// TenantHandler handles the settings of the current user's tenant
+type TenantHandler struct {
+	tenantService domain.TenantService
+}
+
+// NewTenantHandler creates a new TenantHandler
+func NewTenantHandler(tenantService domain.TenantService) *TenantHandler {
+	return &TenantHandler{tenantService: tenantService}
+}
+
+// GetTenant handles GET /api/v1/admin/tenant
+func (h *TenantHandler) GetTenant(c *gin.Context) {
+	tenant, err := h.tenantService.GetCurrent(c.Request.Context())
+	respondWithTenant(c, tenant, err)
+}
+
+// UpdateTenant handles PUT /api/v1/admin/tenant
+func (h *TenantHandler) UpdateTenant(c *gin.Context) {
+	var req models.TenantSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedBy, _ := middleware.GetUserID(c)
+	tenant, err := h.tenantService.UpdateSettings(c.Request.Context(), updatedBy, &req)
+	respondWithTenant(c, tenant, err)
+}
+
+// respondWithTenant writes a tenant, or the status matching a tenant service error
+func respondWithTenant(c *gin.Context, tenant *models.TenantModel, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidTenantSettings):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrTenantNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, tenant)
+	}
+}
@@ -1,59 +1,101 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/pkg/middleware"
 )
 
 // TokenHandler handles HTTP requests for authentication tokens
 type TokenHandler struct {
-	googleAuthService domain.GoogleAuthService
+	tokenService domain.TokenService
 }
 
 // NewTokenHandler creates a new token handler
-func NewTokenHandler(googleAuthService domain.GoogleAuthService) *TokenHandler {
+func NewTokenHandler(tokenService domain.TokenService) *TokenHandler {
 	return &TokenHandler{
-		googleAuthService: googleAuthService,
+		tokenService: tokenService,
 	}
 }
 
-// GenerateToken handles POST /auth/generate-token
-func (h *TokenHandler) GenerateToken(c *gin.Context) {
-	// TODO: Implement token generation logic
-	// TODO: 1. Parse Google OAuth token from request
-	// TODO: 2. Call google auth service to validate and generate JWT
-	// TODO: 3. Return JWT token with user info
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Generate token endpoint - TODO",
-		"token":   "dummy-jwt-token",
-	})
+// refreshTokenRequest is the body of the refresh and revoke endpoints
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
 }
 
-// ValidateToken handles POST /auth/validate-token
+// ValidateToken handles POST /auth/validate-token, returning who the bearer token belongs to.
+// The auth middleware has already rejected invalid, expired and revoked tokens.
 func (h *TokenHandler) ValidateToken(c *gin.Context) {
-	// TODO: Implement token validation logic
-	// TODO: 1. Extract token from Authorization header
-	// TODO: 2. Call google auth service to validate token
-	// TODO: 3. Return user info if valid
-	c.JSON(http.StatusOK, gin.H{"message": "Validate token endpoint - TODO"})
+	email, _ := middleware.GetUserEmail(c)
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetUserRole(c)
+	claims, _ := middleware.GetAuthClaims(c)
+	expiresAt, _ := claims.GetExpirationTime()
+
+	response := gin.H{"valid": true, "userId": userID, "email": email, "role": role}
+	if expiresAt != nil {
+		response["expiresAt"] = expiresAt.Time
+	}
+	c.JSON(http.StatusOK, response)
 }
 
-// RefreshToken handles POST /auth/refresh-token
+// RefreshToken handles POST /auth/refresh-token, exchanging a refresh token for a new token pair
 func (h *TokenHandler) RefreshToken(c *gin.Context) {
-	// TODO: Implement token refresh logic
-	// TODO: 1. Extract refresh token from request
-	// TODO: 2. Call google auth service to refresh token
-	// TODO: 3. Return new token pair
-	c.JSON(http.StatusOK, gin.H{"message": "Refresh token endpoint - TODO"})
+	var req refreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "refreshToken is required"})
+		return
+	}
+
+	tokens, err := h.tokenService.Refresh(c.Request.Context(), req.RefreshToken)
+	if errors.Is(err, domain.ErrInvalidRefreshToken) || errors.Is(err, domain.ErrAccountInactive) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
 }
 
-// RevokeToken handles POST /auth/revoke-token
+// RevokeToken handles POST /auth/revoke-token, ending the session of a refresh token. Unknown
+// tokens are not an error, so the response does not reveal whether a token existed.
 func (h *TokenHandler) RevokeToken(c *gin.Context) {
-	// TODO: Implement token revocation logic
-	// TODO: 1. Extract token from request
-	// TODO: 2. Call google auth service to revoke token
-	// TODO: 3. Return success response
-	c.JSON(http.StatusOK, gin.H{"message": "Revoke token endpoint - TODO"})
+	var req refreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "refreshToken is required"})
+		return
+	}
+
+	if err := h.tokenService.RevokeRefreshToken(c.Request.Context(), req.RefreshToken); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"ok": true})
+}
+
+// RevokeUserSessions handles DELETE /admin/users/:id/sessions, signing a user out everywhere
+func (h *TokenHandler) RevokeUserSessions(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	err = h.tokenService.RevokeAllSessions(c.Request.Context(), uint(userID))
+	if errors.Is(err, domain.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"ok": true})
 }
@@ -1,7 +1,20 @@
 package handlers
 
 import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
 	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/pkg/middleware"
+)
+
+// Page size bounds for the admin user list
+const (
+	defaultUserLimit = 50
+	maxUserLimit     = 200
 )
 
 // UserHandler handles HTTP requests for user operations
@@ -15,3 +28,126 @@ func NewUserHandler(userService domain.UserService) *UserHandler {
 		userService: userService,
 	}
 }
+
+// ListUsers handles GET /api/v1/admin/users?q=&role=&status=&limit=&offset=
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	limit, ok := listLimit(c, defaultUserLimit, maxUserLimit)
+	if !ok {
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+		return
+	}
+	status := c.Query("status")
+	if status != "" && status != domain.UserStatusActive && status != domain.UserStatusDeactivated {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be active or deactivated"})
+		return
+	}
+
+	page, err := h.userService.ListUsers(c.Request.Context(), &domain.UserFilter{
+		Query:  c.Query("q"),
+		Role:   c.Query("role"),
+		Status: status,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// GetUser handles GET /api/v1/admin/users/:id
+func (h *UserHandler) GetUser(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.GetUser(c.Request.Context(), id)
+	respondWithUser(c, http.StatusOK, user, err)
+}
+
+// CreateUser handles POST /api/v1/admin/users
+func (h *UserHandler) CreateUser(c *gin.Context) {
+	var req models.CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdBy, _ := middleware.GetUserID(c)
+	user, err := h.userService.CreateUser(c.Request.Context(), createdBy, &req)
+	respondWithUser(c, http.StatusCreated, user, err)
+}
+
+// UpdateUser handles PATCH /api/v1/admin/users/:id
+func (h *UserHandler) UpdateUser(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+	var req models.UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedBy, _ := middleware.GetUserID(c)
+	user, err := h.userService.UpdateUser(c.Request.Context(), updatedBy, id, &req)
+	respondWithUser(c, http.StatusOK, user, err)
+}
+
+// DeactivateUser handles POST /api/v1/admin/users/:id/deactivate
+func (h *UserHandler) DeactivateUser(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	deactivatedBy, _ := middleware.GetUserID(c)
+	user, err := h.userService.DeactivateUser(c.Request.Context(), deactivatedBy, id)
+	respondWithUser(c, http.StatusOK, user, err)
+}
+
+// ReactivateUser handles POST /api/v1/admin/users/:id/reactivate
+func (h *UserHandler) ReactivateUser(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	reactivatedBy, _ := middleware.GetUserID(c)
+	user, err := h.userService.ReactivateUser(c.Request.Context(), reactivatedBy, id)
+	respondWithUser(c, http.StatusOK, user, err)
+}
+
+// userIDParam reads the :id path parameter, writing a 400 response when it is invalid
+func userIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// respondWithUser writes a user, or the status matching a user service error
+func respondWithUser(c *gin.Context, status int, user *models.UserModel, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidUser), errors.Is(err, domain.ErrSelfModification):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrUserAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(status, user)
+	}
+}
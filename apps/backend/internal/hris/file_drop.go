@@ -0,0 +1,116 @@
+package hris
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/tabular"
+)
+
+// SourceFileDrop is the connector name of the file drop
+const SourceFileDrop = "file_drop"
+
+// Subdirectories of the drop directory that read files are moved into
+const (
+	processedDir = "processed"
+	failedDir    = "failed"
+)
+
+// minFileAge skips files that may still be uploading
+const minFileAge = 10 * time.Second
+
+// FileDrop reads CSV or XLSX exports from a directory, e.g. the target of an SFTP upload.
+// Read files are moved to processed/, or to failed/ when they cannot be read at all.
+type FileDrop struct {
+	dir     string
+	mapping Mapping
+	now     func() time.Time
+}
+
+// NewFileDrop creates a file drop connector for dir, creating its subdirectories if needed
+func NewFileDrop(dir string, mapping Mapping) (*FileDrop, error) {
+	for _, sub := range []string{processedDir, failedDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create HR drop directory: %w", err)
+		}
+	}
+	return &FileDrop{dir: dir, mapping: mapping, now: time.Now}, nil
+}
+
+// Name returns the connector name
+func (f *FileDrop) Name() string {
+	return SourceFileDrop
+}
+
+// Poll syncs every dropped file, oldest name first. A file whose sync fails is left in place to be retried.
+func (f *FileDrop) Poll(ctx context.Context, sync domain.HRSyncFunc) error {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return fmt.Errorf("failed to list HR drop directory: %w", err)
+	}
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		name := entry.Name()
+		ext := strings.ToLower(filepath.Ext(name))
+		if !entry.Type().IsRegular() || strings.HasPrefix(name, ".") || (ext != ".csv" && ext != ".xlsx") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || f.now().Sub(info.ModTime()) < minFileAge {
+			continue
+		}
+
+		// The modification time keeps a daily export with the same name from looking like a redelivery
+		batch := &domain.HRBatch{Source: SourceFileDrop, Reference: name + "@" + info.ModTime().UTC().Format(time.RFC3339)}
+		target := processedDir
+		if err := f.read(filepath.Join(f.dir, name), batch); err != nil {
+			batch.Rejected = append(batch.Rejected, domain.HRRejectedRecord{Reference: name, Message: err.Error()})
+			target = failedDir
+		}
+		if err := sync(ctx, batch); err != nil {
+			return fmt.Errorf("failed to sync %s: %w", name, err)
+		}
+		moved := fmt.Sprintf("%s-%s", f.now().UTC().Format("20060102T150405"), name)
+		if err := os.Rename(filepath.Join(f.dir, name), filepath.Join(f.dir, target, moved)); err != nil {
+			return fmt.Errorf("failed to move %s to %s: %w", name, target, err)
+		}
+	}
+	return nil
+}
+
+// read parses a dropped file into the batch, rejecting rows that cannot be mapped
+func (f *FileDrop) read(path string, batch *domain.HRBatch) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	table, err := tabular.Read(filepath.Base(path), data)
+	if err != nil {
+		return err
+	}
+	for _, row := range table.Rows {
+		values := make(map[string]string, len(table.Header))
+		for i, name := range table.Header {
+			if i < len(row.Values) {
+				values[name] = row.Values[i]
+			}
+		}
+		record, err := f.mapping.Record(values)
+		if err != nil {
+			batch.Rejected = append(batch.Rejected, domain.HRRejectedRecord{
+				Reference: fmt.Sprintf("line %d", row.Line),
+				Message:   err.Error(),
+			})
+			continue
+		}
+		batch.Records = append(batch.Records, record)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package hris
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+)
+
+func TestWebhook(t *testing.T) {
+	secret := []byte("s3cret")
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	body := []byte(`{"delivery_id": "d-1", "employees": [
+		{"Employee Number": 1042, "email": "Jane@Example.com", "status": "Terminated", "termination_date": "2026-11-30"},
+		{"Employee Number": "1043", "hire_date": "30/11/2026"},
+		{"email": "sam@example.com", "hire_date": "2024-02-01", "location": "EU"}
+	]}`)
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	if err := VerifySignature(secret, timestamp, Sign(secret, timestamp, body), body, now); err != nil {
+		t.Errorf("VerifySignature() error = %v", err)
+	}
+	if err := VerifySignature(secret, timestamp, Sign([]byte("other"), timestamp, body), body, now); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifySignature() error = %v, expected a wrong secret rejected", err)
+	}
+	if err := VerifySignature(secret, timestamp, Sign(secret, timestamp, body), body, now.Add(time.Hour)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifySignature() error = %v, expected an old delivery rejected", err)
+	}
+
+	mapping, err := ParseMapping("external_id=Employee Number")
+	if err != nil {
+		t.Fatalf("ParseMapping() error = %v", err)
+	}
+	batch, err := ParseWebhook(body, mapping)
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+	if batch.Reference != "d-1" || len(batch.Records) != 2 || len(batch.Rejected) != 1 {
+		t.Fatalf("batch = %+v, expected two records and the bad date rejected", batch)
+	}
+	leaver := batch.Records[0]
+	if leaver.ExternalID != "1042" || leaver.Email != "jane@example.com" || !leaver.Leaver ||
+		leaver.EndDate == nil || leaver.EndDate.Format("2006-01-02") != "2026-11-30" {
+		t.Errorf("leaver = %+v", leaver)
+	}
+	if batch.Rejected[0].Reference != "employees[1]" {
+		t.Errorf("rejected = %+v", batch.Rejected)
+	}
+	if joiner := batch.Records[1]; joiner.Leaver || joiner.Geo != "EU" || joiner.DateOfJoining == nil {
+		t.Errorf("joiner = %+v", joiner)
+	}
+
+	if _, err := ParseMapping("salary=Pay"); err == nil {
+		t.Error("ParseMapping() expected unknown fields rejected")
+	}
+}
+
+func TestFileDrop(t *testing.T) {
+	dir := t.TempDir()
+	mapping, _ := ParseMapping("email=Work Email")
+	drop, err := NewFileDrop(dir, mapping)
+	if err != nil {
+		t.Fatalf("NewFileDrop() error = %v", err)
+	}
+	now := time.Now()
+	drop.now = func() time.Time { return now }
+
+	write := func(name, content string, age time.Duration) {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		os.Chtimes(path, now.Add(-age), now.Add(-age))
+	}
+	write("employees.csv", "Work Email,Status,Termination Date\nann@example.com,active,\nbob@example.com,terminated,2026-10-31\n,active,\n", time.Minute)
+	write("uploading.csv", "Work Email\nlate@example.com\n", time.Second)
+	write("notes.txt", "ignored", time.Minute)
+
+	var batches []*domain.HRBatch
+	err = drop.Poll(context.Background(), func(_ context.Context, batch *domain.HRBatch) error {
+		batches = append(batches, batch)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	if len(batches) != 1 {
+		t.Fatalf("batches = %d, expected only the finished CSV", len(batches))
+	}
+	batch := batches[0]
+	if len(batch.Records) != 2 || !batch.Records[1].Leaver || len(batch.Rejected) != 1 || batch.Rejected[0].Reference != "line 4" {
+		t.Errorf("batch = %+v", batch)
+	}
+
+	processed, _ := os.ReadDir(filepath.Join(dir, processedDir))
+	if len(processed) != 1 {
+		t.Errorf("processed = %v, expected the CSV moved", processed)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "uploading.csv")); err != nil {
+		t.Errorf("expected the recent file left in place: %v", err)
+	}
+}
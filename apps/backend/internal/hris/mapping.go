@@ -0,0 +1,118 @@
+// Package hris provides connectors that sync employees from an HR system: a signed webhook
+// and a directory where CSV or XLSX exports are dropped.
+package hris
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/tabular"
+)
+
+// Fields an HR record can carry, named as they appear in webhook payloads and, unless mapped
+// otherwise, in dropped file headers
+const (
+	FieldExternalID      = "external_id"
+	FieldEmail           = "email"
+	FieldFirstName       = "first_name"
+	FieldLastName        = "last_name"
+	FieldStatus          = "status"
+	FieldDepartment      = "department"
+	FieldEmploymentType  = "employment_type"
+	FieldLocation        = "location"
+	FieldHireDate        = "hire_date"
+	FieldNoticeDate      = "notice_date"
+	FieldTerminationDate = "termination_date"
+)
+
+var fields = []string{
+	FieldExternalID, FieldEmail, FieldFirstName, FieldLastName, FieldStatus, FieldDepartment,
+	FieldEmploymentType, FieldLocation, FieldHireDate, FieldNoticeDate, FieldTerminationDate,
+}
+
+// leaverStatuses are the HR statuses of people who have left or are leaving
+var leaverStatuses = map[string]bool{
+	"terminated": true, "inactive": true, "left": true, "leaver": true, "former": true, "ended": true, "offboarded": true,
+}
+
+// Mapping maps record fields to the keys the HR system uses for them
+type Mapping map[string]string
+
+// ParseMapping reads a mapping such as "external_id=Employee Number;hire_date=Start Date".
+// Fields that are not mentioned keep their own names.
+func ParseMapping(spec string) (Mapping, error) {
+	mapping := make(Mapping, len(fields))
+	for _, field := range fields {
+		mapping[field] = field
+	}
+	for _, pair := range strings.Split(spec, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		field, key, ok := strings.Cut(pair, "=")
+		field = normalizeKey(field)
+		if _, known := mapping[field]; !ok || !known || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid HR field mapping %q; expected field=Source Column with field one of %s",
+				strings.TrimSpace(pair), strings.Join(fields, ", "))
+		}
+		mapping[field] = normalizeKey(key)
+	}
+	return mapping, nil
+}
+
+// Record converts the values of one source record, keyed by the HR system's names, to an HR record
+func (m Mapping) Record(values map[string]string) (domain.HRRecord, error) {
+	normalized := make(map[string]string, len(values))
+	for key, value := range values {
+		normalized[normalizeKey(key)] = strings.TrimSpace(value)
+	}
+	value := func(field string) string {
+		return normalized[m[field]]
+	}
+
+	record := domain.HRRecord{
+		ExternalID:     value(FieldExternalID),
+		Email:          strings.ToLower(value(FieldEmail)),
+		FirstName:      value(FieldFirstName),
+		LastName:       value(FieldLastName),
+		Department:     value(FieldDepartment),
+		EmploymentType: value(FieldEmploymentType),
+		Geo:            value(FieldLocation),
+		Status:         strings.ToLower(value(FieldStatus)),
+	}
+	record.Leaver = leaverStatuses[record.Status]
+	if record.ExternalID == "" && record.Email == "" {
+		return record, fmt.Errorf("%s or %s is required", m[FieldExternalID], m[FieldEmail])
+	}
+	if record.Email != "" {
+		if address, err := mail.ParseAddress(record.Email); err != nil || address.Address != record.Email {
+			return record, fmt.Errorf("invalid email address %q", record.Email)
+		}
+	}
+
+	dates := []struct {
+		field  string
+		target **time.Time
+	}{
+		{FieldHireDate, &record.DateOfJoining},
+		{FieldNoticeDate, &record.NoticeDate},
+		{FieldTerminationDate, &record.EndDate},
+	}
+	for _, date := range dates {
+		if raw := value(date.field); raw != "" {
+			t, err := tabular.ParseDate(raw)
+			if err != nil {
+				return record, fmt.Errorf("%s: %w", m[date.field], err)
+			}
+			*date.target = &t
+		}
+	}
+	return record, nil
+}
+
+func normalizeKey(key string) string {
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(key)))
+}
@@ -0,0 +1,103 @@
+package hris
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+)
+
+// SourceWebhook is the connector name of the inbound webhook
+const SourceWebhook = "webhook"
+
+// Signature headers sent with webhook deliveries
+const (
+	HeaderTimestamp = "X-HRIS-Timestamp"
+	HeaderSignature = "X-HRIS-Signature"
+)
+
+// maxSignatureAge rejects replayed deliveries
+const maxSignatureAge = 5 * time.Minute
+
+// ErrInvalidSignature is returned for webhook deliveries that are unsigned, wrongly signed or too old
+var ErrInvalidSignature = errors.New("invalid or expired webhook signature")
+
+// webhookPayload is the body of a webhook delivery. Employee keys are record field names
+// or the HR system's own names when a mapping is configured.
+type webhookPayload struct {
+	DeliveryID string                   `json:"delivery_id"`
+	Employees  []map[string]interface{} `json:"employees"`
+}
+
+// Sign returns the signature header value for a body: the hex HMAC-SHA256 of "<timestamp>.<body>"
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks a delivery's signature and that its Unix timestamp is recent
+func VerifySignature(secret []byte, timestamp, signature string, body []byte, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(secret) == 0 {
+		return ErrInvalidSignature
+	}
+	age := now.Sub(time.Unix(seconds, 0))
+	if age > maxSignatureAge || age < -maxSignatureAge {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(strings.TrimSpace(signature))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ParseWebhook reads a webhook delivery into a batch. Records that cannot be read are rejected
+// individually; a body that is not a delivery at all is an error.
+func ParseWebhook(body []byte, mapping Mapping) (*domain.HRBatch, error) {
+	var payload webhookPayload
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+	if payload.Employees == nil {
+		return nil, fmt.Errorf("invalid webhook payload: employees is required")
+	}
+
+	batch := &domain.HRBatch{Source: SourceWebhook, Reference: payload.DeliveryID}
+	for i, employee := range payload.Employees {
+		values := make(map[string]string, len(employee))
+		for key, value := range employee {
+			switch v := value.(type) {
+			case nil:
+			case string:
+				values[key] = v
+			case json.Number, bool:
+				values[key] = fmt.Sprint(v)
+			default:
+				// Nested objects are not mapped; reading them as empty avoids rejecting the record
+			}
+		}
+		record, err := mapping.Record(values)
+		if err != nil {
+			batch.Rejected = append(batch.Rejected, domain.HRRejectedRecord{
+				Reference: fmt.Sprintf("employees[%d]", i),
+				Message:   err.Error(),
+			})
+			continue
+		}
+		batch.Records = append(batch.Records, record)
+	}
+	return batch, nil
+}
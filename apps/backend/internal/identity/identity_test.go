@@ -0,0 +1,145 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/coreos/go-oidc/v3/oidc/oidctest"
+	"github.com/talent-fit/backend/internal/domain"
+)
+
+func TestProviders(t *testing.T) {
+	providers, err := Providers("google-client", `[{"name": "microsoft", "issuer": "https://login.microsoftonline.com/tenant/v2.0", "clientId": "ms-client"}]`)
+	if err != nil {
+		t.Fatalf("Providers() error = %v", err)
+	}
+	if len(providers) != 2 || providers[0].Name != GoogleProvider || providers[0].Issuer != GoogleIssuer || providers[1].Name != "microsoft" {
+		t.Errorf("Providers() = %+v, want google then microsoft", providers)
+	}
+
+	providers, err = Providers("google-client", `[{"name": "google", "issuer": "https://accounts.google.com", "clientId": "other", "allowedDomains": ["example.com"]}]`)
+	if err != nil || len(providers) != 1 || providers[0].ClientID != "other" {
+		t.Errorf("Providers() = %+v, %v, want the configured google entry only", providers, err)
+	}
+
+	for _, invalid := range []string{
+		`{`,
+		`[{"name": "okta", "issuer": "https://example.okta.com"}]`,
+		`[{"name": "okta", "issuer": "https://example.okta.com", "clientId": "x", "roleMapping": [{"group": "admins", "role": "Owner"}]}]`,
+		`[{"name": "a", "issuer": "https://a", "clientId": "x"}, {"name": "a", "issuer": "https://b", "clientId": "y"}]`,
+	} {
+		if _, err := Providers("", invalid); err == nil {
+			t.Errorf("Providers(%s) error = nil, want an error", invalid)
+		}
+	}
+}
+
+// testIssuer serves an OpenID Connect issuer and returns its signing key and the number of
+// discovery requests it received
+func testIssuer(t *testing.T) (*rsa.PrivateKey, *httptest.Server, *atomic.Int32) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock := &oidctest.Server{PublicKeys: []oidctest.PublicKey{{PublicKey: key.Public(), KeyID: "test-key", Algorithm: oidc.RS256}}}
+	var discoveries atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/.well-known/openid-configuration" {
+			discoveries.Add(1)
+		}
+		mock.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	mock.SetIssuer(server.URL)
+	return key, server, &discoveries
+}
+
+func TestRegistryVerify(t *testing.T) {
+	key, server, discoveries := testIssuer(t)
+	registry := NewRegistry([]ProviderConfig{{
+		Name:           "keycloak",
+		Issuer:         server.URL,
+		ClientID:       "talent-fit",
+		AllowedDomains: []string{"example.com"},
+		RoleMapping:    []RoleRule{{Group: "staffing-managers", Role: "Manager"}},
+	}}, server.Client())
+
+	token := func(email, audience string, groups string) string {
+		return oidctest.SignIDToken(key, "test-key", oidc.RS256, fmt.Sprintf(
+			`{"iss": %q, "aud": %q, "sub": "123", "email": %q, "email_verified": true, "name": "Ada Lovelace", "groups": %s, "exp": %d}`,
+			server.URL, audience, email, groups, time.Now().Add(time.Hour).Unix()))
+	}
+	ctx := context.Background()
+
+	identity, err := registry.Verify(ctx, "keycloak", token("Ada@Example.com", "talent-fit", `["staff", "staffing-managers"]`))
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if identity.Email != "ada@example.com" || identity.Name != "Ada Lovelace" || identity.Role != "Manager" || identity.Subject != "123" {
+		t.Errorf("Verify() = %+v", identity)
+	}
+
+	identity, err = registry.Verify(ctx, "keycloak", token("bob@example.com", "talent-fit", `"staff"`))
+	if err != nil || identity.Role != "Employee" {
+		t.Errorf("Verify() = %+v, %v, want the Employee role", identity, err)
+	}
+
+	if _, err := registry.Verify(ctx, "keycloak", token("eve@other.com", "talent-fit", `[]`)); !errors.Is(err, domain.ErrEmailDomainNotAllowed) {
+		t.Errorf("Verify() error = %v, want ErrEmailDomainNotAllowed", err)
+	}
+	if _, err := registry.Verify(ctx, "keycloak", token("ada@example.com", "another-app", `[]`)); err == nil {
+		t.Error("Verify() of a token for another client error = nil, want an error")
+	}
+	if _, err := registry.Verify(ctx, "okta", token("ada@example.com", "talent-fit", `[]`)); !errors.Is(err, domain.ErrUnknownAuthProvider) {
+		t.Errorf("Verify() error = %v, want ErrUnknownAuthProvider", err)
+	}
+	if n := discoveries.Load(); n != 1 {
+		t.Errorf("discovery ran %d times, want once", n)
+	}
+}
+
+func TestRegistryEmailVerification(t *testing.T) {
+	key, server, _ := testIssuer(t)
+	registry := NewRegistry([]ProviderConfig{
+		{Name: "keycloak", Issuer: server.URL, ClientID: "talent-fit"},
+		{Name: "azure", Issuer: server.URL, ClientID: "talent-fit", AllowedDomains: []string{"example.com"}, TrustEmail: true},
+	}, server.Client())
+	token := func(verified string) string {
+		return oidctest.SignIDToken(key, "test-key", oidc.RS256, fmt.Sprintf(
+			`{"iss": %q, "aud": "talent-fit", "sub": "123", "email": "ada@example.com"%s, "exp": %d}`,
+			server.URL, verified, time.Now().Add(time.Hour).Unix()))
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		provider string
+		verified string
+		wantErr  bool
+	}{
+		{"keycloak", `, "email_verified": true`, false},
+		{"keycloak", `, "email_verified": false`, true},
+		{"keycloak", ``, true},
+		{"keycloak", `, "email_verified": "true"`, true},
+		{"azure", ``, false},
+	}
+	for _, tt := range tests {
+		_, err := registry.Verify(ctx, tt.provider, token(tt.verified))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Verify() by %s with claims %q error = %v, want error %v", tt.provider, tt.verified, err, tt.wantErr)
+		}
+	}
+
+	if _, err := Providers("", `[{"name": "azure", "issuer": "https://login.microsoftonline.com/tenant/v2.0", "clientId": "x", "trustEmail": true}]`); err == nil {
+		t.Error("Providers() trusting emails without allowedDomains error = nil, want an error")
+	}
+}
@@ -0,0 +1,132 @@
+// Package identity verifies ID tokens from the OpenID Connect providers users sign in with, such
+// as Google, Azure AD, Okta or Keycloak, and maps their claims to an email and role.
+package identity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// GoogleProvider is the name of the provider configured by GOOGLE_CLIENT_ID
+const GoogleProvider = "google"
+
+// GoogleIssuer is Google's OpenID Connect issuer
+const GoogleIssuer = "https://accounts.google.com"
+
+// Claims read when none are configured
+const (
+	defaultEmailClaim  = "email"
+	defaultGroupsClaim = "groups"
+)
+
+// RoleRule gives users in a group a role
+type RoleRule struct {
+	Group string `json:"group"`
+	Role  string `json:"role"`
+}
+
+// ProviderConfig is one OpenID Connect issuer users may sign in with
+type ProviderConfig struct {
+	// Name identifies the provider in the login URL, e.g. "microsoft"
+	Name     string `json:"name"`
+	Issuer   string `json:"issuer"`
+	ClientID string `json:"clientId"`
+	// AllowedDomains restricts sign-in to emails in these domains; empty allows any
+	AllowedDomains []string `json:"allowedDomains,omitempty"`
+	// TrustEmail accepts emails the token does not mark verified, for issuers such as Azure AD
+	// that omit email_verified; it requires AllowedDomains, whose directory owns those emails
+	TrustEmail bool `json:"trustEmail,omitempty"`
+	// EmailClaim defaults to "email"; Azure AD accounts may only have "preferred_username"
+	EmailClaim string `json:"emailClaim,omitempty"`
+	// GroupsClaim defaults to "groups", e.g. "roles" for Azure AD app roles
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+	// RoleMapping sets the user's role from their groups on every sign-in, the first matching
+	// rule winning; users in none get DefaultRole. Without rules, roles are managed in the app.
+	RoleMapping []RoleRule `json:"roleMapping,omitempty"`
+	DefaultRole string     `json:"defaultRole,omitempty"`
+}
+
+// Providers returns the configured providers: Google when googleClientID is set, and those in
+// providersJSON, a JSON array of ProviderConfig. An entry named "google" replaces the default one.
+func Providers(googleClientID, providersJSON string) ([]ProviderConfig, error) {
+	var configured []ProviderConfig
+	if strings.TrimSpace(providersJSON) != "" {
+		if err := json.Unmarshal([]byte(providersJSON), &configured); err != nil {
+			return nil, fmt.Errorf("invalid OIDC_PROVIDERS: %w", err)
+		}
+	}
+
+	var providers []ProviderConfig
+	seen := make(map[string]bool)
+	for _, provider := range configured {
+		if err := provider.validate(); err != nil {
+			return nil, fmt.Errorf("invalid OIDC_PROVIDERS: %w", err)
+		}
+		if seen[provider.Name] {
+			return nil, fmt.Errorf("invalid OIDC_PROVIDERS: provider %q is configured twice", provider.Name)
+		}
+		seen[provider.Name] = true
+		providers = append(providers, provider)
+	}
+	if googleClientID != "" && !seen[GoogleProvider] {
+		providers = append([]ProviderConfig{{Name: GoogleProvider, Issuer: GoogleIssuer, ClientID: googleClientID}}, providers...)
+	}
+	return providers, nil
+}
+
+// validate checks a provider has what is needed to verify its tokens and that its roles exist
+func (p ProviderConfig) validate() error {
+	if p.Name == "" || p.Issuer == "" || p.ClientID == "" {
+		return fmt.Errorf("name, issuer and clientId are required")
+	}
+	if p.TrustEmail && len(p.AllowedDomains) == 0 {
+		return fmt.Errorf("provider %q: trustEmail requires allowedDomains", p.Name)
+	}
+	for _, rule := range p.RoleMapping {
+		if rule.Group == "" || !models.UserRole(rule.Role).IsValid() {
+			return fmt.Errorf("provider %q: role mapping needs a group and a role of Employee, Manager or Admin", p.Name)
+		}
+	}
+	if p.DefaultRole != "" && !models.UserRole(p.DefaultRole).IsValid() {
+		return fmt.Errorf("provider %q: unknown default role %q", p.Name, p.DefaultRole)
+	}
+	return nil
+}
+
+// emailAllowed reports whether an email is in one of the allowed domains
+func (p ProviderConfig) emailAllowed(email string) bool {
+	if len(p.AllowedDomains) == 0 {
+		return true
+	}
+	_, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return false
+	}
+	for _, allowed := range p.AllowedDomains {
+		if strings.EqualFold(domain, strings.TrimPrefix(allowed, "@")) {
+			return true
+		}
+	}
+	return false
+}
+
+// role maps groups to a role, or returns "" when the provider has no role mapping
+func (p ProviderConfig) role(groups []string) string {
+	if len(p.RoleMapping) == 0 {
+		return ""
+	}
+	for _, rule := range p.RoleMapping {
+		for _, group := range groups {
+			if group == rule.Group {
+				return rule.Role
+			}
+		}
+	}
+	if p.DefaultRole != "" {
+		return p.DefaultRole
+	}
+	return string(models.RoleEmployee)
+}
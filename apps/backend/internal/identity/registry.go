@@ -0,0 +1,152 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/talent-fit/backend/internal/domain"
+)
+
+// discoveryTimeout bounds fetching a provider's discovery document and keys
+const discoveryTimeout = 10 * time.Second
+
+// Registry verifies ID tokens against the configured providers. Each provider's discovery
+// document is fetched on first use and cached; a failed discovery is retried on the next login.
+type Registry struct {
+	providers []*provider
+	client    *http.Client
+}
+
+type provider struct {
+	config   ProviderConfig
+	mu       sync.Mutex
+	verifier *oidc.IDTokenVerifier
+}
+
+// NewRegistry creates a registry for the providers; client is used for discovery and key
+// fetches and defaults to one with a timeout
+func NewRegistry(configs []ProviderConfig, client *http.Client) *Registry {
+	if client == nil {
+		client = &http.Client{Timeout: discoveryTimeout}
+	}
+	registry := &Registry{client: client}
+	for _, config := range configs {
+		registry.providers = append(registry.providers, &provider{config: config})
+	}
+	return registry
+}
+
+// Providers returns the configured providers in order
+func (r *Registry) Providers() []domain.AuthProvider {
+	providers := make([]domain.AuthProvider, len(r.providers))
+	for i, p := range r.providers {
+		providers[i] = domain.AuthProvider{Name: p.config.Name, Issuer: p.config.Issuer, ClientID: p.config.ClientID}
+	}
+	return providers
+}
+
+// Verify checks an ID token from a provider and returns who it identifies. It fails with
+// domain.ErrUnknownAuthProvider, domain.ErrEmailDomainNotAllowed or a verification error.
+func (r *Registry) Verify(ctx context.Context, providerName, rawIDToken string) (*domain.Identity, error) {
+	p := r.lookup(providerName)
+	if p == nil {
+		return nil, domain.ErrUnknownAuthProvider
+	}
+	verifier, err := r.verifier(p)
+	if err != nil {
+		return nil, err
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, errors.New("invalid claims")
+	}
+
+	emailClaim := p.config.EmailClaim
+	if emailClaim == "" {
+		emailClaim = defaultEmailClaim
+	}
+	email, _ := claims[emailClaim].(string)
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || !strings.Contains(email, "@") {
+		return nil, errors.New("email not present in token")
+	}
+	// Without a verified email anyone could claim a user's address at a provider that lets
+	// accounts set their own, so a missing claim counts as unverified
+	if verified, _ := claims["email_verified"].(bool); !verified && !p.config.TrustEmail {
+		return nil, errors.New("email is not verified")
+	}
+	if !p.config.emailAllowed(email) {
+		return nil, domain.ErrEmailDomainNotAllowed
+	}
+
+	groupsClaim := p.config.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = defaultGroupsClaim
+	}
+	groups := stringList(claims[groupsClaim])
+	name, _ := claims["name"].(string)
+	return &domain.Identity{
+		Provider: p.config.Name,
+		Subject:  idToken.Subject,
+		Email:    email,
+		Name:     name,
+		Groups:   groups,
+		Role:     p.config.role(groups),
+	}, nil
+}
+
+// lookup returns a provider by name
+func (r *Registry) lookup(name string) *provider {
+	for _, p := range r.providers {
+		if p.config.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// verifier returns the provider's cached verifier, running discovery the first time. Discovery
+// runs outside the request's context, as the key set it sets up outlives the request.
+func (r *Registry) verifier(p *provider) (*oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.verifier != nil {
+		return p.verifier, nil
+	}
+
+	discoveryCtx := oidc.ClientContext(context.Background(), r.client)
+	discovered, err := oidc.NewProvider(discoveryCtx, p.config.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("oidc provider %s discovery failed: %w", p.config.Name, err)
+	}
+	p.verifier = discovered.VerifierContext(discoveryCtx, &oidc.Config{ClientID: p.config.ClientID})
+	return p.verifier, nil
+}
+
+// stringList reads a claim that is a list of strings or a single string
+func stringList(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
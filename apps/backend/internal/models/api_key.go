@@ -0,0 +1,110 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// API key statuses, derived from RevokedAt and ExpiresAt
+const (
+	APIKeyActive  = "active"
+	APIKeyRevoked = "revoked"
+	APIKeyExpired = "expired"
+)
+
+// ServiceAccountRequest is the body of POST /admin/service-accounts
+type ServiceAccountRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// APIKeyRequest is the body of POST /admin/service-accounts/:id/keys
+type APIKeyRequest struct {
+	Scopes []APIKeyScope `json:"scopes" binding:"required"`
+	// ExpiresInDays defaults to 90
+	ExpiresInDays int `json:"expires_in_days"`
+}
+
+// RotateAPIKeyRequest is the body of POST /admin/api-keys/:id/rotate
+type RotateAPIKeyRequest struct {
+	// GraceHours keeps the old key working while callers switch over; 0 revokes it immediately
+	GraceHours int `json:"grace_hours"`
+}
+
+// ServiceAccountModel represents a service account with its keys, without their secrets
+type ServiceAccountModel struct {
+	ID          uint          `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	CreatedByID uint          `json:"created_by_id"`
+	DisabledAt  *time.Time    `json:"disabled_at,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	Keys        []APIKeyModel `json:"keys"`
+}
+
+// FromEntity converts entity to ServiceAccountModel, deriving key statuses at now
+func (m *ServiceAccountModel) FromEntity(entity *entities.ServiceAccount, now time.Time) {
+	m.ID = entity.ID
+	m.Name = entity.Name
+	m.Description = entity.Description
+	m.CreatedByID = entity.CreatedByID
+	m.DisabledAt = entity.DisabledAt
+	m.CreatedAt = entity.CreatedAt
+	m.Keys = make([]APIKeyModel, len(entity.APIKeys))
+	for i := range entity.APIKeys {
+		m.Keys[i].FromEntity(&entity.APIKeys[i], now)
+	}
+}
+
+// APIKeyModel represents an API key without its secret
+type APIKeyModel struct {
+	ID               uint          `json:"id"`
+	ServiceAccountID uint          `json:"service_account_id"`
+	Prefix           string        `json:"prefix"`
+	Scopes           []APIKeyScope `json:"scopes"`
+	Status           string        `json:"status"`
+	ExpiresAt        time.Time     `json:"expires_at"`
+	LastUsedAt       *time.Time    `json:"last_used_at,omitempty"`
+	RevokedAt        *time.Time    `json:"revoked_at,omitempty"`
+	CreatedByID      uint          `json:"created_by_id"`
+	CreatedAt        time.Time     `json:"created_at"`
+}
+
+// FromEntity converts entity to APIKeyModel, deriving its status at now
+func (m *APIKeyModel) FromEntity(entity *entities.APIKey, now time.Time) {
+	m.ID = entity.ID
+	m.ServiceAccountID = entity.ServiceAccountID
+	m.Prefix = entity.Prefix
+	m.Scopes = ParseScopes(entity.Scopes)
+	m.ExpiresAt = entity.ExpiresAt
+	m.LastUsedAt = entity.LastUsedAt
+	m.RevokedAt = entity.RevokedAt
+	m.CreatedByID = entity.CreatedByID
+	m.CreatedAt = entity.CreatedAt
+	switch {
+	case entity.RevokedAt != nil:
+		m.Status = APIKeyRevoked
+	case !entity.ExpiresAt.After(now):
+		m.Status = APIKeyExpired
+	default:
+		m.Status = APIKeyActive
+	}
+}
+
+// CreatedAPIKeyModel is a new API key with its secret, which is only ever returned once
+type CreatedAPIKeyModel struct {
+	APIKeyModel
+	Key string `json:"key"`
+}
+
+// ParseScopes splits an entities.APIKey's space-separated scopes
+func ParseScopes(scopes string) []APIKeyScope {
+	fields := strings.Fields(scopes)
+	result := make([]APIKeyScope, len(fields))
+	for i, field := range fields {
+		result[i] = APIKeyScope(field)
+	}
+	return result
+}
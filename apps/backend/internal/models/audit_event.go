@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// AuditEventModel represents an audited change to an account
+type AuditEventModel struct {
+	ID        uint      `json:"id"`
+	Action    string    `json:"action"`
+	ActorID   *uint     `json:"actor_id,omitempty"`
+	UserID    *uint     `json:"user_id,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	Provider  string    `json:"provider,omitempty"`
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FromEntity converts entity to AuditEventModel
+func (m *AuditEventModel) FromEntity(entity *entities.AuditEvent) {
+	m.ID = entity.ID
+	m.Action = entity.Action
+	m.ActorID = entity.ActorID
+	m.UserID = entity.UserID
+	m.Email = entity.Email
+	m.Provider = entity.Provider
+	m.Detail = entity.Detail
+	m.CreatedAt = entity.CreatedAt
+}
@@ -40,7 +40,10 @@ type EmployeeProfileModel struct {
 	Department        string     `json:"department"`
 	ExperienceLevel   string     `json:"experience_level"`
 	EmploymentType    string     `json:"employment_type"`
+	OrgUnitID         *uint      `json:"org_unit_id,omitempty"`
 	Name              string     `json:"name"`
+	// EnrichmentStatus is pending until the embedding exists; read-only
+	EnrichmentStatus string `json:"enrichment_status,omitempty"`
 
 	// Relationships
 	User UserModel `json:"user,omitempty"`
@@ -64,6 +67,7 @@ func (ep *EmployeeProfileModel) ToEntity() *entities.EmployeeProfile {
 		Department:        ep.Department,
 		ExperienceLevel:   ep.ExperienceLevel,
 		EmploymentType:    ep.EmploymentType,
+		OrgUnitID:         ep.OrgUnitID,
 	}
 	return entity
 }
@@ -85,5 +89,7 @@ func (ep *EmployeeProfileModel) FromEntity(entity *entities.EmployeeProfile) {
 	ep.Department = entity.Department
 	ep.ExperienceLevel = entity.ExperienceLevel
 	ep.EmploymentType = entity.EmploymentType
+	ep.OrgUnitID = entity.OrgUnitID
+	ep.EnrichmentStatus = entity.EnrichmentStatus
 	ep.User.FromEntity(&entity.User)
 }
@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// How a natural-language search was turned into filters
+const (
+	SearchParserModel = "model"
+	SearchParserRules = "rules"
+)
+
+// Highlight keys name the profile fields a search result matched on
+const (
+	HighlightSkills       = "skills"
+	HighlightType         = "type"
+	HighlightGeo          = "geo"
+	HighlightIndustry     = "industry"
+	HighlightExperience   = "years_of_experience"
+	HighlightAvailability = "availability"
+)
+
+// EmployeeSearchRequest is a free-text talent search, e.g. "senior Go engineer in Europe, free next month"
+type EmployeeSearchRequest struct {
+	Query string `json:"query" binding:"required"`
+	Limit int    `json:"limit"`
+}
+
+// EmployeeSearchFilters are the structured filters parsed from a search query.
+// Every non-empty filter must match; skills must match at least one of the listed skills.
+type EmployeeSearchFilters struct {
+	Skills             []string   `json:"skills"`
+	Types              []string   `json:"types"`
+	Geos               []string   `json:"geos"`
+	Industries         []string   `json:"industries"`
+	MinExperienceYears *int       `json:"min_experience_years,omitempty"`
+	MaxExperienceYears *int       `json:"max_experience_years,omitempty"`
+	AvailableBy        *time.Time `json:"available_by,omitempty"`
+}
+
+// EmployeeSearchResult is one ranked profile with the fields it matched on
+type EmployeeSearchResult struct {
+	Profile *EmployeeProfileModel `json:"profile"`
+	// Similarity to the semantic part of the query; 0 when there is none or the profile has no embedding yet
+	Similarity float64 `json:"similarity"`
+	// AvailableFrom is when current allocations end; nil while on an open-ended allocation
+	AvailableFrom *time.Time `json:"available_from,omitempty"`
+	// Highlights maps profile fields to the values that matched the query
+	Highlights map[string][]string `json:"highlights"`
+}
+
+// EmployeeSearchResponse returns the interpreted query alongside the results so users can refine it
+type EmployeeSearchResponse struct {
+	Query         string                  `json:"query"`
+	Parser        string                  `json:"parser"`
+	Filters       EmployeeSearchFilters   `json:"filters"`
+	SemanticQuery string                  `json:"semantic_query"`
+	Results       []*EmployeeSearchResult `json:"results"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// EnrichmentJobModel represents a summarise or embed job in the enrichment queue
+type EnrichmentJobModel struct {
+	ID          int        `json:"id"`
+	JobType     string     `json:"job_type"`
+	EntityType  string     `json:"entity_type"`
+	EntityID    int        `json:"entity_id"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	MaxAttempts int        `json:"max_attempts"`
+	LastError   string     `json:"last_error,omitempty"`
+	RunAfter    time.Time  `json:"run_after"`
+	LockedAt    *time.Time `json:"locked_at,omitempty"`
+	LockedBy    string     `json:"locked_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// EnrichmentJobList is a page of enrichment jobs with the number of jobs in each status
+type EnrichmentJobList struct {
+	Jobs   []*EnrichmentJobModel `json:"jobs"`
+	Counts map[string]int64      `json:"counts"`
+}
+
+// FromEntity converts entity to EnrichmentJobModel
+func (j *EnrichmentJobModel) FromEntity(entity *entities.EnrichmentJob) {
+	j.ID = entity.ID
+	j.JobType = entity.JobType
+	j.EntityType = entity.EntityType
+	j.EntityID = entity.EntityID
+	j.Status = entity.Status
+	j.Attempts = entity.Attempts
+	j.MaxAttempts = entity.MaxAttempts
+	j.LastError = entity.LastError
+	j.RunAfter = entity.RunAfter
+	j.LockedAt = entity.LockedAt
+	j.LockedBy = entity.LockedBy
+	j.CreatedAt = entity.CreatedAt
+	j.UpdatedAt = entity.UpdatedAt
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// HRSyncRunModel represents one batch synced from the HR system
+type HRSyncRunModel struct {
+	ID         uint               `json:"id"`
+	Source     string             `json:"source"`
+	Reference  string             `json:"reference,omitempty"`
+	Status     string             `json:"status"`
+	Received   int                `json:"received"`
+	Created    int                `json:"created"`
+	Updated    int                `json:"updated"`
+	Unchanged  int                `json:"unchanged"`
+	Leavers    int                `json:"leavers"`
+	Conflicts  int                `json:"conflicts"`
+	Rejected   int                `json:"rejected"`
+	StartedAt  time.Time          `json:"started_at"`
+	FinishedAt time.Time          `json:"finished_at"`
+	Entries    []HRSyncEntryModel `json:"entries,omitempty"`
+}
+
+// HRSyncEntryModel represents a change or conflict logged for one synced record
+type HRSyncEntryModel struct {
+	Action     string `json:"action"`
+	ExternalID string `json:"external_id,omitempty"`
+	Email      string `json:"email,omitempty"`
+	UserID     *uint  `json:"user_id,omitempty"`
+	Field      string `json:"field,omitempty"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+// FromEntity converts entity to HRSyncRunModel, including any loaded entries
+func (r *HRSyncRunModel) FromEntity(entity *entities.HRSyncRun) {
+	r.ID = entity.ID
+	r.Source = entity.Source
+	r.Reference = entity.Reference
+	r.Status = entity.Status
+	r.Received = entity.Received
+	r.Created = entity.Created
+	r.Updated = entity.Updated
+	r.Unchanged = entity.Unchanged
+	r.Leavers = entity.Leavers
+	r.Conflicts = entity.Conflicts
+	r.Rejected = entity.Rejected
+	r.StartedAt = entity.StartedAt
+	r.FinishedAt = entity.FinishedAt
+	r.Entries = nil
+	for _, entry := range entity.Entries {
+		r.Entries = append(r.Entries, HRSyncEntryModel{
+			Action:     entry.Action,
+			ExternalID: entry.ExternalID,
+			Email:      entry.Email,
+			UserID:     entry.UserID,
+			Field:      entry.Field,
+			Detail:     entry.Detail,
+		})
+	}
+}
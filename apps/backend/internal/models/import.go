@@ -0,0 +1,43 @@
+package models
+
+// ImportKind is the kind of record a bulk import file holds
+type ImportKind string
+
+// Import kinds, in the order files are applied so later kinds can refer to earlier ones
+const (
+	ImportUsers       ImportKind = "users"
+	ImportProfiles    ImportKind = "profiles"
+	ImportProjects    ImportKind = "projects"
+	ImportAllocations ImportKind = "allocations"
+)
+
+// ImportKinds lists every import kind in the order files are applied
+var ImportKinds = []ImportKind{ImportUsers, ImportProfiles, ImportProjects, ImportAllocations}
+
+// ImportRowError is a validation error for one row, or for the header when Line is 1
+type ImportRowError struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportFileReport summarises one imported file
+type ImportFileReport struct {
+	Kind     ImportKind `json:"kind"`
+	Filename string     `json:"filename"`
+	Rows     int        `json:"rows"`
+	Created  int        `json:"created"`
+	Updated  int        `json:"updated"`
+}
+
+// ImportReport is the outcome of a bulk import. Nothing is written unless the import has no errors
+// and is not a dry run; a dry run reports the same counts the import would produce.
+type ImportReport struct {
+	DryRun    bool               `json:"dry_run"`
+	Committed bool               `json:"committed"`
+	Files     []ImportFileReport `json:"files"`
+	Errors    []ImportRowError   `json:"errors"`
+	// Enqueued is the number of profiles and projects queued for summaries and embeddings
+	Enqueued int `json:"enqueued"`
+}
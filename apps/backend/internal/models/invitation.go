@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// Invitation statuses, derived from the invitation's dates
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationRevoked  = "revoked"
+	InvitationExpired  = "expired"
+)
+
+// InviteRequest is the body of POST /invitations
+type InviteRequest struct {
+	Email       string   `json:"email" binding:"required"`
+	Role        UserRole `json:"role"`
+	SlackUserID string   `json:"slack_user_id"`
+	ManagerID   *uint    `json:"manager_id"`
+	// ExpiresInDays defaults to 14
+	ExpiresInDays int `json:"expires_in_days"`
+}
+
+// InvitationModel represents an invitation to sign up
+type InvitationModel struct {
+	ID          uint       `json:"id"`
+	Email       string     `json:"email"`
+	Role        UserRole   `json:"role"`
+	SlackUserID string     `json:"slack_user_id,omitempty"`
+	ManagerID   *uint      `json:"manager_id,omitempty"`
+	InvitedByID uint       `json:"invited_by_id"`
+	Status      string     `json:"status"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	AcceptedAt  *time.Time `json:"accepted_at,omitempty"`
+	UserID      *uint      `json:"user_id,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// FromEntity converts entity to InvitationModel, deriving its status at now
+func (m *InvitationModel) FromEntity(entity *entities.Invitation, now time.Time) {
+	m.ID = entity.ID
+	m.Email = entity.Email
+	m.Role = UserRole(entity.Role)
+	m.SlackUserID = entity.SlackUserID
+	m.ManagerID = entity.ManagerID
+	m.InvitedByID = entity.InvitedByID
+	m.ExpiresAt = entity.ExpiresAt
+	m.AcceptedAt = entity.AcceptedAt
+	m.UserID = entity.UserID
+	m.RevokedAt = entity.RevokedAt
+	m.CreatedAt = entity.CreatedAt
+	switch {
+	case entity.AcceptedAt != nil:
+		m.Status = InvitationAccepted
+	case entity.RevokedAt != nil:
+		m.Status = InvitationRevoked
+	case !now.Before(entity.ExpiresAt):
+		m.Status = InvitationExpired
+	default:
+		m.Status = InvitationPending
+	}
+}
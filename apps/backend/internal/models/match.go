@@ -5,6 +5,8 @@ type CandidateScore struct {
 	CandidateID int    `json:"candidate_id"`
 	Score       int    `json:"score"`
 	Reason      string `json:"reason"`
+	// Source is "ai" for validated model scores and "fallback" for backfilled ones
+	Source string `json:"source,omitempty"`
 }
 
 // MatchSuggestion represents a complete match suggestion with candidate details
@@ -12,3 +14,36 @@ type MatchSuggestion struct {
 	CandidateScore
 	Profile *EmployeeProfileModel `json:"profile"`
 }
+
+// Match suggestion stream event names
+const (
+	MatchEventCandidates = "candidates"
+	MatchEventScore      = "score"
+	MatchEventSummary    = "summary"
+	MatchEventError      = "error"
+)
+
+// MatchStreamEvent is one server-sent event of a streamed match suggestion run
+type MatchStreamEvent struct {
+	Name string
+	Data interface{}
+}
+
+// RetrievedCandidate is a candidate found by vector search, sent before it is scored
+type RetrievedCandidate struct {
+	CandidateID int                   `json:"candidate_id"`
+	Similarity  float64               `json:"similarity"`
+	Status      string                `json:"status"`
+	Profile     *EmployeeProfileModel `json:"profile"`
+}
+
+// MatchStreamSummary closes a streamed run; Ranking lists candidate IDs from best to worst
+type MatchStreamSummary struct {
+	Count          int    `json:"count"`
+	Ranking        []int  `json:"ranking"`
+	PromptVersion  string `json:"prompt_version"`
+	Model          string `json:"model"`
+	FallbackCount  int    `json:"fallback_count"`
+	BudgetExceeded bool   `json:"budget_exceeded"`
+	DurationMs     int64  `json:"duration_ms"`
+}
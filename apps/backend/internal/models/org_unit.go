@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// OrgUnitModel is a node of the org unit tree
+type OrgUnitModel struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Kind      string    `json:"kind"`
+	ParentID  *uint     `json:"parent_id,omitempty"`
+	HeadID    *uint     `json:"head_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FromEntity converts entity to OrgUnitModel
+func (m *OrgUnitModel) FromEntity(entity *entities.OrgUnit) {
+	m.ID = entity.ID
+	m.Name = entity.Name
+	m.Kind = entity.Kind
+	m.ParentID = entity.ParentID
+	m.HeadID = entity.HeadID
+	m.CreatedAt = entity.CreatedAt
+	m.UpdatedAt = entity.UpdatedAt
+}
+
+// OrgUnitRequest is the body of POST and PUT /admin/org-units
+type OrgUnitRequest struct {
+	Name string `json:"name" binding:"required"`
+	// Kind is company, practice or department; a practice's parent is a company and a
+	// department's a practice
+	Kind     string `json:"kind" binding:"required"`
+	ParentID *uint  `json:"parent_id"`
+	HeadID   *uint  `json:"head_id"`
+}
@@ -0,0 +1,107 @@
+package models
+
+// Permission is an action on a kind of resource that a role may be granted
+type Permission string
+
+const (
+	// Other employees' profiles, listing and search; everyone may read and edit their own
+	PermEmployeesRead  Permission = "employees:read"
+	PermEmployeesWrite Permission = "employees:write"
+	PermProjectsRead   Permission = "projects:read"
+	PermProjectsWrite  Permission = "projects:write"
+	// Project staffing, and other employees' allocations; everyone may read their own
+	PermAllocationsRead  Permission = "allocations:read"
+	PermAllocationsWrite Permission = "allocations:write"
+	PermMatchesRead      Permission = "matches:read"
+	PermDashboardRead    Permission = "dashboard:read"
+	PermReportsRead      Permission = "reports:read"
+	// Other users' notifications, and sending notifications
+	PermNotificationsManage Permission = "notifications:manage"
+	// Inviting users with a preassigned role, and revoking invitations
+	PermUsersInvite Permission = "users:invite"
+	// Prompts, AI usage, enrichment jobs, imports, the HR sync log, users, sessions, API keys and
+	// tenant settings; also needed to invite another admin
+	PermAdmin Permission = "admin"
+)
+
+// managerPermissions is what line managers may do: staffing and managing their people, but not
+// administering the tenant
+var managerPermissions = []Permission{
+	PermEmployeesRead,
+	PermEmployeesWrite,
+	PermProjectsRead,
+	PermProjectsWrite,
+	PermAllocationsRead,
+	PermAllocationsWrite,
+	PermMatchesRead,
+	PermDashboardRead,
+	PermReportsRead,
+	PermNotificationsManage,
+	PermUsersInvite,
+}
+
+// rolePermissions is the permission matrix: what each role may do beyond its own records
+var rolePermissions = map[UserRole][]Permission{
+	RoleEmployee: {
+		PermProjectsRead,
+	},
+	RoleManager: managerPermissions,
+	RoleAdmin:   append(append([]Permission{}, managerPermissions...), PermAdmin),
+}
+
+// APIKeyScope is what a service account's API key may do; each scope grants one permission.
+// Keys can never be granted admin or user-management permissions.
+type APIKeyScope string
+
+const (
+	ScopeProfilesRead     APIKeyScope = "profiles:read"
+	ScopeProfilesWrite    APIKeyScope = "profiles:write"
+	ScopeProjectsRead     APIKeyScope = "projects:read"
+	ScopeProjectsWrite    APIKeyScope = "projects:write"
+	ScopeAllocationsRead  APIKeyScope = "allocations:read"
+	ScopeAllocationsWrite APIKeyScope = "allocations:write"
+	ScopeMatchesRead      APIKeyScope = "matches:read"
+	ScopeDashboardRead    APIKeyScope = "dashboard:read"
+	ScopeReportsRead      APIKeyScope = "reports:read"
+)
+
+// scopePermissions maps each API key scope to the permission it grants
+var scopePermissions = map[APIKeyScope]Permission{
+	ScopeProfilesRead:     PermEmployeesRead,
+	ScopeProfilesWrite:    PermEmployeesWrite,
+	ScopeProjectsRead:     PermProjectsRead,
+	ScopeProjectsWrite:    PermProjectsWrite,
+	ScopeAllocationsRead:  PermAllocationsRead,
+	ScopeAllocationsWrite: PermAllocationsWrite,
+	ScopeMatchesRead:      PermMatchesRead,
+	ScopeDashboardRead:    PermDashboardRead,
+	ScopeReportsRead:      PermReportsRead,
+}
+
+// IsValid reports whether the scope is one API keys can be granted
+func (s APIKeyScope) IsValid() bool {
+	_, ok := scopePermissions[s]
+	return ok
+}
+
+// Grants reports whether the scope grants a permission
+func (s APIKeyScope) Grants(permission Permission) bool {
+	granted, ok := scopePermissions[s]
+	return ok && granted == permission
+}
+
+// IsValid reports whether the role is one the permission matrix knows
+func (r UserRole) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Can reports whether the role has been granted a permission; unknown roles have none
+func (r UserRole) Can(permission Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
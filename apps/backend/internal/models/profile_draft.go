@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// Profile fields a CV draft can change
+const (
+	DraftFieldSkills            = "skills"
+	DraftFieldYearsOfExperience = "years_of_experience"
+	DraftFieldIndustry          = "industry"
+	DraftFieldType              = "type"
+)
+
+// ProfileFieldChange is one difference between the current profile and a CV draft
+type ProfileFieldChange struct {
+	Field    string      `json:"field"`
+	Current  interface{} `json:"current"`
+	Proposed interface{} `json:"proposed"`
+	// Added lists the skills or industries the CV adds; existing values are always kept
+	Added []string `json:"added,omitempty"`
+}
+
+// ProfileDraftModel is a profile update proposed from an uploaded CV
+type ProfileDraftModel struct {
+	ID             uint                     `json:"id"`
+	Status         string                   `json:"status"`
+	Source         string                   `json:"source"`
+	Filename       string                   `json:"filename"`
+	Format         string                   `json:"format"`
+	OriginalStored bool                     `json:"original_stored"`
+	Extracted      entities.ProfileProposal `json:"extracted"`
+	// ProfileExists is false when confirming the draft creates the profile
+	ProfileExists bool                 `json:"profile_exists"`
+	Changes       []ProfileFieldChange `json:"changes"`
+	CreatedAt     time.Time            `json:"created_at"`
+	ConfirmedAt   *time.Time           `json:"confirmed_at,omitempty"`
+}
+
+// FromEntity converts entity to ProfileDraftModel; changes are filled in by the service
+func (d *ProfileDraftModel) FromEntity(entity *entities.ProfileDraft) {
+	d.ID = entity.ID
+	d.Status = entity.Status
+	d.Source = entity.Source
+	d.Filename = entity.Filename
+	d.Format = entity.Format
+	d.OriginalStored = entity.BlobKey != ""
+	d.Extracted = entity.Extracted
+	d.CreatedAt = entity.CreatedAt
+	d.ConfirmedAt = entity.ConfirmedAt
+}
+
+// ConfirmProfileDraftRequest selects which proposed changes to apply
+type ConfirmProfileDraftRequest struct {
+	// Fields lists the fields to update, e.g. ["skills", "type"]; every proposed change is applied when empty
+	Fields []string `json:"fields"`
+}
@@ -20,6 +20,10 @@ type ProjectModel struct {
 	Name          string              `json:"name"`
 	Description   string              `json:"description"`
 	Summary       string              `json:"summary"`
+	SummaryPromptVersion string        `json:"summary_prompt_version,omitempty"`
+	Requirements  *entities.ProjectRequirements `json:"requirements,omitempty"`
+	// EnrichmentStatus is pending until the summary and embedding exist; read-only
+	EnrichmentStatus string           `json:"enrichment_status,omitempty"`
 	RequiredSeats int                 `json:"required_seats"`
 	SeatsByType   map[string]int      `json:"seats_by_type"`
 	StartDate     time.Time           `json:"start_date"`
@@ -32,6 +36,11 @@ type ProjectModel struct {
 	GeoPreference string              `json:"geo_preference"`
 	Priority      string              `json:"priority"`
 	Budget        float64             `json:"budget"`
+	ExternalID    string              `json:"external_id,omitempty"`
+	// OwnerID and DeliveryManagerID are users notified about the project alongside its team
+	OwnerID           *uint           `json:"owner_id,omitempty"`
+	DeliveryManagerID *uint           `json:"delivery_manager_id,omitempty"`
+	OrgUnitID         *uint           `json:"org_unit_id,omitempty"`
 	// Relationships
 	ProjectAllocations []ProjectAllocationModel `json:"project_allocations,omitempty"`
 }
@@ -43,6 +52,8 @@ func (p *ProjectModel) ToEntity() *entities.Project {
 		Name:          p.Name,
 		Description:   p.Description,
 		Summary:       p.Summary,
+		SummaryPromptVersion: p.SummaryPromptVersion,
+		Requirements:  p.Requirements,
 		RequiredSeats: p.RequiredSeats,
 		SeatsByType:   entities.SeatsByType(p.SeatsByType),
 		StartDate:     p.StartDate,
@@ -55,6 +66,10 @@ func (p *ProjectModel) ToEntity() *entities.Project {
 		GeoPreference: p.GeoPreference,
 		Priority:      p.Priority,
 		Budget:        p.Budget,
+		ExternalID:    optionalString(p.ExternalID),
+		OwnerID:       p.OwnerID,
+		DeliveryManagerID: p.DeliveryManagerID,
+		OrgUnitID:     p.OrgUnitID,
 	}
 	return entity
 }
@@ -65,6 +80,9 @@ func (p *ProjectModel) FromEntity(entity *entities.Project) {
 	p.Name = entity.Name
 	p.Description = entity.Description
 	p.Summary = entity.Summary
+	p.SummaryPromptVersion = entity.SummaryPromptVersion
+	p.Requirements = entity.Requirements
+	p.EnrichmentStatus = entity.EnrichmentStatus
 	p.RequiredSeats = entity.RequiredSeats
 	p.SeatsByType = map[string]int(entity.SeatsByType)
 	p.StartDate = entity.StartDate
@@ -77,4 +95,10 @@ func (p *ProjectModel) FromEntity(entity *entities.Project) {
 	p.GeoPreference = entity.GeoPreference
 	p.Priority = entity.Priority
 	p.Budget = entity.Budget
+	p.OwnerID = entity.OwnerID
+	p.DeliveryManagerID = entity.DeliveryManagerID
+	p.OrgUnitID = entity.OrgUnitID
+	if entity.ExternalID != nil {
+		p.ExternalID = *entity.ExternalID
+	}
 }
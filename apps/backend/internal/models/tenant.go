@@ -0,0 +1,71 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// TenantModel is a tenant with its settings. Secrets are never returned, only whether they are set.
+type TenantModel struct {
+	ID                    uint                     `json:"id"`
+	Name                  string                   `json:"name"`
+	Slug                  string                   `json:"slug"`
+	SignUpDomains         []string                 `json:"signup_domains,omitempty"`
+	SlackBotTokenSet      bool                     `json:"slack_bot_token_set"`
+	SlackDefaultChannelID string                   `json:"slack_default_channel_id,omitempty"`
+	AIProvider            string                   `json:"ai_provider,omitempty"`
+	AIBaseURL             string                   `json:"ai_base_url,omitempty"`
+	AIAPIKeySet           bool                     `json:"ai_api_key_set"`
+	AIModel               string                   `json:"ai_model,omitempty"`
+	ScoringRules          *entities.ScoringWeights `json:"scoring_rules,omitempty"`
+	CreatedAt             time.Time                `json:"created_at"`
+	UpdatedAt             time.Time                `json:"updated_at"`
+}
+
+// FromEntity converts entity to TenantModel
+func (m *TenantModel) FromEntity(entity *entities.Tenant) {
+	m.ID = entity.ID
+	m.Name = entity.Name
+	m.Slug = entity.Slug
+	if entity.SignUpDomains != "" {
+		m.SignUpDomains = strings.Split(entity.SignUpDomains, ",")
+	}
+	m.SlackBotTokenSet = entity.SlackBotToken != ""
+	m.SlackDefaultChannelID = entity.SlackDefaultChannelID
+	m.AIProvider = entity.AIProvider
+	m.AIBaseURL = entity.AIBaseURL
+	m.AIAPIKeySet = entity.AIAPIKey != ""
+	m.AIModel = entity.AIModel
+	m.ScoringRules = entity.ScoringRules
+	m.CreatedAt = entity.CreatedAt
+	m.UpdatedAt = entity.UpdatedAt
+}
+
+// CreateTenantRequest creates a tenant and its first admin, see cmd/tenant
+type CreateTenantRequest struct {
+	Name           string
+	Slug           string
+	AdminEmail     string
+	AdminFirstName string
+	AdminLastName  string
+	// SignUpDomains is a comma-separated list of email domains that may sign up without an invitation
+	SignUpDomains string
+}
+
+// TenantSettingsRequest is the body of PUT /admin/tenant. It replaces every setting except the
+// secrets, which are kept when omitted and cleared when empty.
+type TenantSettingsRequest struct {
+	Name                  string  `json:"name" binding:"required"`
+	SlackBotToken         *string `json:"slack_bot_token"`
+	SlackDefaultChannelID string  `json:"slack_default_channel_id"`
+	// AIProvider, AIBaseURL and AIAPIKey switch chat calls to an OpenAI-compatible provider;
+	// AIModel alone only changes the model on the deployment's provider
+	AIProvider string  `json:"ai_provider"`
+	AIBaseURL  string  `json:"ai_base_url"`
+	AIAPIKey   *string `json:"ai_api_key"`
+	AIModel    string  `json:"ai_model"`
+	// ScoringRules replaces the default matching weights; they must add up to 100
+	ScoringRules *entities.ScoringWeights `json:"scoring_rules"`
+}
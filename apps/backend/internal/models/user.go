@@ -12,17 +12,23 @@ type UserRole string
 const (
 	RoleEmployee UserRole = "Employee"
 	RoleManager  UserRole = "Manager"
+	RoleAdmin    UserRole = "Admin"
 )
 
 // UserModel represents the user business model
 type UserModel struct {
-	ID        uint      `json:"id"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	Email     string    `json:"email"`
-	Role      UserRole  `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            uint       `json:"id"`
+	FirstName     string     `json:"first_name"`
+	LastName      string     `json:"last_name"`
+	Email         string     `json:"email"`
+	Role          UserRole   `json:"role"`
+	SlackUserID   string     `json:"slack_user_id,omitempty"`
+	ExternalID    string     `json:"external_id,omitempty"`
+	ManagerID     *uint      `json:"manager_id,omitempty"`
+	Active        bool       `json:"active"`
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 
 	// Relationships
 	EmployeeProfile    *EmployeeProfileModel    `json:"employee_profile,omitempty"`
@@ -33,13 +39,17 @@ type UserModel struct {
 // ToEntity converts UserModel to entity
 func (u *UserModel) ToEntity() *entities.User {
 	entity := &entities.User{
-		ID:        u.ID,
-		FirstName: u.FirstName,
-		LastName:  u.LastName,
-		Email:     u.Email,
-		Role:      string(u.Role),
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:            u.ID,
+		FirstName:     u.FirstName,
+		LastName:      u.LastName,
+		Email:         u.Email,
+		Role:          string(u.Role),
+		SlackUserID:   u.SlackUserID,
+		ExternalID:    optionalString(u.ExternalID),
+		ManagerID:     u.ManagerID,
+		DeactivatedAt: u.DeactivatedAt,
+		CreatedAt:     u.CreatedAt,
+		UpdatedAt:     u.UpdatedAt,
 	}
 	return entity
 }
@@ -51,11 +61,54 @@ func (u *UserModel) FromEntity(entity *entities.User) {
 	u.LastName = entity.LastName
 	u.Email = entity.Email
 	u.Role = UserRole(entity.Role)
+	u.SlackUserID = entity.SlackUserID
+	if entity.ExternalID != nil {
+		u.ExternalID = *entity.ExternalID
+	}
+	u.ManagerID = entity.ManagerID
+	u.Active = entity.DeactivatedAt == nil
+	u.DeactivatedAt = entity.DeactivatedAt
 	u.CreatedAt = entity.CreatedAt
 	u.UpdatedAt = entity.UpdatedAt
 }
 
+// UserPage is a page of the admin user list
+type UserPage struct {
+	Users  []*UserModel `json:"users"`
+	Total  int64        `json:"total"`
+	Limit  int          `json:"limit"`
+	Offset int          `json:"offset"`
+}
+
+// CreateUserRequest is the body of POST /admin/users
+type CreateUserRequest struct {
+	Email       string   `json:"email" binding:"required"`
+	FirstName   string   `json:"first_name"`
+	LastName    string   `json:"last_name"`
+	Role        UserRole `json:"role"`
+	SlackUserID string   `json:"slack_user_id"`
+	ManagerID   *uint    `json:"manager_id"`
+}
+
+// UpdateUserRequest is the body of PATCH /admin/users/:id; omitted fields are left unchanged
+type UpdateUserRequest struct {
+	FirstName   *string   `json:"first_name"`
+	LastName    *string   `json:"last_name"`
+	Role        *UserRole `json:"role"`
+	SlackUserID *string   `json:"slack_user_id"`
+	// ManagerID 0 removes the manager
+	ManagerID *uint `json:"manager_id"`
+}
+
 // GetFullName returns the full name (first name + last name)
 func (u *UserModel) GetFullName() string {
 	return u.FirstName + " " + u.LastName
 }
+
+// optionalString maps an empty string to nil for nullable columns
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
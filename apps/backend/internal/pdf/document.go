@@ -0,0 +1,291 @@
+// Package pdf writes simple text-only PDF documents: headings, paragraphs and fixed-width
+// tables on A4 landscape pages.
+//
+// It uses the standard Helvetica and Courier fonts, which every reader provides, so nothing is
+// embedded. Text is encoded as WinAnsi; characters outside Latin-1 are replaced with '?'.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Page geometry in points
+const (
+	pageWidth  = 842.0
+	pageHeight = 595.0
+	margin     = 40.0
+)
+
+// Font sizes and line heights in points
+const (
+	headingSize = 14.0
+	textSize    = 10.0
+	tableSize   = 8.0
+	lineFactor  = 1.35
+)
+
+// Character widths as a fraction of the font size: Courier exactly, Helvetica on average
+const (
+	courierAdvance   = 0.6
+	helveticaAdvance = 0.5
+)
+
+// Columns are never narrower or wider than these, in characters
+const (
+	minColumnWidth = 4
+	maxColumnWidth = 40
+)
+
+// Font resource names
+const (
+	fontText    = "F1"
+	fontHeading = "F2"
+	fontTable   = "F3"
+)
+
+// Document is a PDF being built page by page
+type Document struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	// y is the baseline of the next line on the current page
+	y float64
+}
+
+// New creates an empty document
+func New() *Document {
+	return &Document{}
+}
+
+// Heading adds a bold heading, with space above it unless it starts a page
+func (d *Document) Heading(text string) {
+	if d.page != nil && d.y < pageHeight-margin-headingSize {
+		d.y -= textSize
+	}
+	d.line(fontHeading, headingSize, margin, text)
+}
+
+// Text adds a paragraph, wrapped to the page width
+func (d *Document) Text(text string) {
+	width := charsPerLine(textSize * helveticaAdvance)
+	for _, line := range wrap(text, width) {
+		d.line(fontText, textSize, margin, line)
+	}
+}
+
+// Table adds a table in a fixed-width font. Columns are sized to their widest cell, long cells
+// are cut short with "..." and the header is repeated on every page the table spans.
+func (d *Document) Table(header []string, rows [][]string) {
+	widths := columnWidths(header, rows, charsPerLine(tableSize*courierAdvance))
+	headerLine := formatRow(header, widths)
+	rule := strings.Repeat("-", len([]rune(headerLine)))
+
+	lineHeight := tableSize * lineFactor
+	if d.page == nil || d.y-3*lineHeight < margin {
+		d.newPage()
+	}
+	d.line(fontTable, tableSize, margin, headerLine)
+	d.line(fontTable, tableSize, margin, rule)
+	for _, row := range rows {
+		if d.y-lineHeight < margin {
+			d.newPage()
+			d.line(fontTable, tableSize, margin, headerLine)
+			d.line(fontTable, tableSize, margin, rule)
+		}
+		d.line(fontTable, tableSize, margin, formatRow(row, widths))
+	}
+}
+
+// WriteTo writes the finished document, numbering its pages
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if d.page == nil {
+		d.newPage()
+	}
+
+	out := &countingWriter{w: w}
+	var offsets []int64
+	object := func(body string) {
+		offsets = append(offsets, out.n)
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	const firstPageObject = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObject+2*i)
+	}
+
+	io.WriteString(out, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		content := bytes.NewBuffer(page.Bytes())
+		footer := fmt.Sprintf("Page %d of %d", i+1, len(d.pages))
+		writeText(content, fontText, tableSize, pageWidth-margin-float64(len(footer))*tableSize*helveticaAdvance, margin/2, footer)
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		zw.Write(content.Bytes())
+		zw.Close()
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /%s 3 0 R /%s 4 0 R /%s 5 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fontText, fontHeading, fontTable, firstPageObject+2*i+1))
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.Bytes()))
+	}
+
+	xref := out.n
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.n, out.err
+}
+
+// line writes one line of text, starting a new page when the current one is full
+func (d *Document) line(font string, size, x float64, text string) {
+	height := size * lineFactor
+	if d.page == nil || d.y-height < margin {
+		d.newPage()
+	}
+	d.y -= height
+	writeText(d.page, font, size, x, d.y, text)
+}
+
+func (d *Document) newPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+	d.y = pageHeight - margin
+}
+
+// charsPerLine is how many characters of the given width fit between the margins
+func charsPerLine(charWidth float64) int {
+	return int((pageWidth - 2*margin) / charWidth)
+}
+
+func writeText(w io.Writer, font string, size, x, y float64, text string) {
+	fmt.Fprintf(w, "BT /%s %g Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(text))
+}
+
+// escape encodes text as a WinAnsi PDF string literal body
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		case r == '\t' || r == '\n' || r == '\r':
+			b.WriteByte(' ')
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// columnWidths sizes columns to their widest cell, then narrows the widest columns until the
+// row fits in lineWidth characters
+func columnWidths(header []string, rows [][]string, lineWidth int) []int {
+	widths := make([]int, len(header))
+	for i, cell := range header {
+		widths[i] = len([]rune(cell))
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) && len([]rune(cell)) > widths[i] {
+				widths[i] = len([]rune(cell))
+			}
+		}
+	}
+	total := 0
+	for i := range widths {
+		if widths[i] > maxColumnWidth {
+			widths[i] = maxColumnWidth
+		}
+		if widths[i] < minColumnWidth {
+			widths[i] = minColumnWidth
+		}
+		total += widths[i]
+	}
+	total += 2 * (len(widths) - 1)
+	for total > lineWidth {
+		widest := 0
+		for i := range widths {
+			if widths[i] > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// formatRow pads or cuts each cell to its column width, separating columns with two spaces
+func formatRow(cells []string, widths []int) string {
+	parts := make([]string, len(widths))
+	for i, width := range widths {
+		var cell []rune
+		if i < len(cells) {
+			cell = []rune(cells[i])
+		}
+		if len(cell) > width {
+			cell = append(cell[:width-3], []rune("...")...)
+		}
+		parts[i] = string(cell) + strings.Repeat(" ", width-len(cell))
+	}
+	return strings.TrimRight(strings.Join(parts, "  "), " ")
+}
+
+// wrap breaks text into lines of at most width characters at spaces
+func wrap(text string, width int) []string {
+	var lines []string
+	var current []string
+	length := 0
+	for _, word := range strings.Fields(text) {
+		if length > 0 && length+1+len([]rune(word)) > width {
+			lines = append(lines, strings.Join(current, " "))
+			current, length = nil, 0
+		}
+		if length > 0 {
+			length++
+		}
+		current = append(current, word)
+		length += len([]rune(word))
+	}
+	if len(current) > 0 || len(lines) == 0 {
+		lines = append(lines, strings.Join(current, " "))
+	}
+	return lines
+}
+
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
@@ -0,0 +1,51 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDocument(t *testing.T) {
+	doc := New()
+	doc.Heading("Weekly staffing (summary)")
+	doc.Text("Café opening – employees on the bench")
+	rows := make([][]string, 120)
+	for i := range rows {
+		rows[i] = []string{strconv.Itoa(i), strings.Repeat("x", 60), "Go, Kafka"}
+	}
+	doc.Table([]string{"id", "name", "skills"}, rows)
+
+	var buf bytes.Buffer
+	n, err := doc.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	out := buf.Bytes()
+	if int(n) != len(out) || !bytes.HasPrefix(out, []byte("%PDF-1.4")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatalf("WriteTo() wrote %d bytes, expected a complete PDF", n)
+	}
+	if count := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(out); count == nil || string(count[1]) != "3" {
+		t.Errorf("page count = %s, expected the table to span 3 pages", count)
+	}
+
+	// Every cross-reference entry must point at the start of its object
+	xref := bytes.LastIndex(out, []byte("\nxref\n"))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(out[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj", i+1); !bytes.HasPrefix(out[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q", i+1, out[offset:offset+10])
+		}
+	}
+
+	if got := escape("Café (draft) – 50%"); got != `Caf\351 \(draft\) ? 50%` {
+		t.Errorf("escape() = %q", got)
+	}
+	if got := formatRow([]string{"abcdefgh", "b"}, []int{6, 4}); got != "abc...  b" {
+		t.Errorf("formatRow() = %q", got)
+	}
+}
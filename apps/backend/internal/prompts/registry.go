@@ -0,0 +1,292 @@
+// Package prompts holds the versioned prompt templates sent to chat models.
+//
+// Templates live in templates/<name>/<version>.tmpl and are embedded in the binary.
+// Each template defines a "system" and a "user" section using text/template syntax.
+// The highest embedded version is used unless an active override exists in the
+// prompt_templates table, which lets prompt tweaks ship without a deploy. A tenant's
+// own active override wins over the deployment default one.
+package prompts
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+// Template names
+const (
+	SummarizeProject = "summarize_project"
+	ScoreCandidates  = "score_candidates"
+	ParseTalentQuery = "parse_talent_query"
+	ExtractCVProfile = "extract_cv_profile"
+)
+
+// Version sources
+const (
+	SourceEmbedded = "embedded"
+	SourceDatabase = "database"
+)
+
+// overrideCacheTTL bounds how long a database override lookup is reused
+const overrideCacheTTL = time.Minute
+
+//go:embed templates
+var embedded embed.FS
+
+// overrideKey identifies a cached override; tenant 0 is the deployment default
+type overrideKey struct {
+	tenantID uint
+	name     string
+}
+
+type cachedOverride struct {
+	template  *template.Template
+	version   string
+	found     bool
+	expiresAt time.Time
+}
+
+// Registry implements domain.PromptRegistry using embedded templates with optional database overrides
+type Registry struct {
+	overrides domain.PromptTemplateRepository
+	embedded  map[string]map[string]*template.Template
+	latest    map[string]string
+
+	mu    sync.Mutex
+	cache map[overrideKey]cachedOverride
+}
+
+// NewRegistry parses the embedded templates and creates a new prompt registry.
+// overrides may be nil, in which case only embedded templates are used.
+func NewRegistry(overrides domain.PromptTemplateRepository) (domain.PromptRegistry, error) {
+	r := &Registry{
+		overrides: overrides,
+		embedded:  make(map[string]map[string]*template.Template),
+		latest:    make(map[string]string),
+		cache:     make(map[overrideKey]cachedOverride),
+	}
+
+	err := fs.WalkDir(embedded, "templates", func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(filePath) != ".tmpl" {
+			return err
+		}
+		name := path.Base(path.Dir(filePath))
+		version := strings.TrimSuffix(path.Base(filePath), ".tmpl")
+
+		body, err := embedded.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		tmpl, err := parse(name+"@"+version, string(body))
+		if err != nil {
+			return fmt.Errorf("failed to parse prompt template %s: %w", filePath, err)
+		}
+
+		if r.embedded[name] == nil {
+			r.embedded[name] = make(map[string]*template.Template)
+		}
+		r.embedded[name][version] = tmpl
+		if current, ok := r.latest[name]; !ok || compareVersions(version, current) > 0 {
+			r.latest[name] = version
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Render renders the active version of the named template
+func (r *Registry) Render(ctx context.Context, name string, data interface{}) (*domain.Prompt, error) {
+	if override := r.activeOverride(ctx, name); override.found {
+		return execute(name, override.version, override.template, data)
+	}
+
+	version, ok := r.latest[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", domain.ErrUnknownPrompt, name)
+	}
+	return execute(name, version, r.embedded[name][version], data)
+}
+
+// RenderVersion renders a specific version of the named template, embedded or from the database
+func (r *Registry) RenderVersion(ctx context.Context, name string, version string, data interface{}) (*domain.Prompt, error) {
+	if tmpl, ok := r.embedded[name][version]; ok {
+		return execute(name, version, tmpl, data)
+	}
+
+	if r.overrides != nil {
+		stored, err := r.overrides.GetByVersion(ctx, name, version)
+		if err == nil {
+			tmpl, err := parse(name+"@"+version, stored.Body)
+			if err != nil {
+				return nil, fmt.Errorf("stored prompt %s@%s is invalid: %w", name, version, err)
+			}
+			return execute(name, version, tmpl, data)
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to load prompt %s@%s: %w", name, version, err)
+		}
+	}
+
+	return nil, fmt.Errorf("%w version %s@%s", domain.ErrUnknownPrompt, name, version)
+}
+
+// Versions lists the embedded and database versions of the named template
+func (r *Registry) Versions(ctx context.Context, name string) ([]domain.PromptVersion, error) {
+	var versions []domain.PromptVersion
+
+	hasActiveOverride := false
+	if r.overrides != nil {
+		stored, err := r.overrides.GetByName(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list prompt overrides: %w", err)
+		}
+		for _, t := range stored {
+			hasActiveOverride = hasActiveOverride || t.Active
+			versions = append(versions, domain.PromptVersion{Name: name, Version: t.Version, Source: SourceDatabase, Active: t.Active})
+		}
+	}
+
+	for version := range r.embedded[name] {
+		versions = append(versions, domain.PromptVersion{
+			Name:    name,
+			Version: version,
+			Source:  SourceEmbedded,
+			Active:  !hasActiveOverride && version == r.latest[name],
+		})
+	}
+
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w %q", domain.ErrUnknownPrompt, name)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i].Version, versions[j].Version) < 0
+	})
+	return versions, nil
+}
+
+// Validate checks that a template body parses and defines the required sections
+func (r *Registry) Validate(body string) error {
+	_, err := parse("validate", body)
+	return err
+}
+
+// Invalidate drops the context tenant's cached database override for the named template. Without a
+// tenant the default changed, so every tenant's cached override for the template is dropped.
+func (r *Registry) Invalidate(ctx context.Context, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if tenantID, ok := domain.TenantFromContext(ctx); ok {
+		delete(r.cache, overrideKey{tenantID: tenantID, name: name})
+		return
+	}
+	for key := range r.cache {
+		if key.name == name {
+			delete(r.cache, key)
+		}
+	}
+}
+
+// activeOverride returns the context tenant's active database override for a template, cached for
+// overrideCacheTTL
+func (r *Registry) activeOverride(ctx context.Context, name string) cachedOverride {
+	if r.overrides == nil {
+		return cachedOverride{}
+	}
+
+	key := overrideKey{name: name}
+	key.tenantID, _ = domain.TenantFromContext(ctx)
+	r.mu.Lock()
+	cached, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached
+	}
+
+	entry := cachedOverride{expiresAt: time.Now().Add(overrideCacheTTL)}
+	stored, err := r.overrides.GetActive(ctx, name)
+	switch {
+	case err == nil:
+		tmpl, parseErr := parse(name+"@"+stored.Version, stored.Body)
+		if parseErr != nil {
+			// A broken override must never take down prompting; fall back to the embedded version
+			break
+		}
+		entry.template = tmpl
+		entry.version = stored.Version
+		entry.found = true
+	case errors.Is(err, gorm.ErrRecordNotFound):
+	default:
+		// Transient lookup failure: use embedded templates without caching the miss
+		return cachedOverride{}
+	}
+
+	r.mu.Lock()
+	r.cache[key] = entry
+	r.mu.Unlock()
+	return entry
+}
+
+// parse parses a template body and checks it defines both sections
+func parse(name string, body string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	for _, section := range []string{"system", "user"} {
+		if tmpl.Lookup(section) == nil {
+			return nil, fmt.Errorf("template must define a %q section", section)
+		}
+	}
+	return tmpl, nil
+}
+
+// execute renders both sections of a parsed template
+func execute(name string, version string, tmpl *template.Template, data interface{}) (*domain.Prompt, error) {
+	var system, user bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&system, "system", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s@%s system prompt: %w", name, version, err)
+	}
+	if err := tmpl.ExecuteTemplate(&user, "user", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s@%s user prompt: %w", name, version, err)
+	}
+	return &domain.Prompt{
+		Name:    name,
+		Version: version,
+		System:  strings.TrimSpace(system.String()),
+		User:    strings.TrimSpace(user.String()),
+	}, nil
+}
+
+// compareVersions orders "v2" before "v10"; non-numeric versions sort lexically after numeric ones
+func compareVersions(a, b string) int {
+	na, errA := strconv.Atoi(strings.TrimPrefix(a, "v"))
+	nb, errB := strconv.Atoi(strings.TrimPrefix(b, "v"))
+	switch {
+	case errA == nil && errB == nil:
+		return na - nb
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
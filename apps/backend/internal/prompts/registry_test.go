@@ -0,0 +1,208 @@
+package prompts
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+type fakeOverrides struct {
+	domain.PromptTemplateRepository
+	templates []*entities.PromptTemplate
+}
+
+// GetActive returns the context tenant's active override before the default one
+func (f *fakeOverrides) GetActive(ctx context.Context, name string) (*entities.PromptTemplate, error) {
+	tenantID, _ := domain.TenantFromContext(ctx)
+	var fallback *entities.PromptTemplate
+	for _, t := range f.templates {
+		if t.Name != name || !t.Active {
+			continue
+		}
+		if t.TenantID == nil {
+			fallback = t
+		} else if *t.TenantID == tenantID {
+			return t, nil
+		}
+	}
+	if fallback == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return fallback, nil
+}
+
+func (f *fakeOverrides) GetByVersion(ctx context.Context, name string, version string) (*entities.PromptTemplate, error) {
+	for _, t := range f.templates {
+		if t.Name == name && t.Version == version {
+			return t, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeOverrides) GetByName(ctx context.Context, name string) ([]*entities.PromptTemplate, error) {
+	var templates []*entities.PromptTemplate
+	for _, t := range f.templates {
+		if t.Name == name {
+			templates = append(templates, t)
+		}
+	}
+	return templates, nil
+}
+
+const overrideBody = `{{define "system"}}override system{{end}}{{define "user"}}override {{.Description}}{{end}}`
+
+var summaryData = map[string]interface{}{"Description": "a project", "Roles": "Backend: 1"}
+
+func TestRegistryOverridePrecedence(t *testing.T) {
+	ctx := context.Background()
+	overrides := &fakeOverrides{}
+	registry, err := NewRegistry(overrides)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prompt, err := registry.Render(ctx, SummarizeProject, summaryData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompt.Version != "v2" {
+		t.Errorf("Render() without overrides used %s, want the latest embedded v2", prompt.Version)
+	}
+
+	// An inactive override is listed but not used
+	overrides.templates = append(overrides.templates, &entities.PromptTemplate{Name: SummarizeProject, Version: "v3", Body: overrideBody})
+	registry.Invalidate(ctx, SummarizeProject)
+	if prompt, _ := registry.Render(ctx, SummarizeProject, summaryData); prompt.Version != "v2" {
+		t.Errorf("Render() with an inactive override used %s, want v2", prompt.Version)
+	}
+
+	overrides.templates[0].Active = true
+	registry.Invalidate(ctx, SummarizeProject)
+	prompt, err = registry.Render(ctx, SummarizeProject, summaryData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompt.Version != "v3" || prompt.User != "override a project" {
+		t.Errorf("Render() with an active override = %s %q, want v3 %q", prompt.Version, prompt.User, "override a project")
+	}
+
+	versions, err := registry.Versions(ctx, SummarizeProject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var listed []string
+	for _, v := range versions {
+		listed = append(listed, v.Version+":"+v.Source+":"+map[bool]string{true: "active", false: "inactive"}[v.Active])
+	}
+	want := "v1:embedded:inactive v2:embedded:inactive v3:database:active"
+	if got := strings.Join(listed, " "); got != want {
+		t.Errorf("Versions() = %s, want %s", got, want)
+	}
+
+	// Embedded versions stay renderable by name, and stored ones by version
+	if prompt, err := registry.RenderVersion(ctx, SummarizeProject, "v1", summaryData); err != nil || prompt.Version != "v1" {
+		t.Errorf("RenderVersion(v1) = %v, %v", prompt, err)
+	}
+	if prompt, err := registry.RenderVersion(ctx, SummarizeProject, "v3", summaryData); err != nil || prompt.User != "override a project" {
+		t.Errorf("RenderVersion(v3) = %v, %v", prompt, err)
+	}
+}
+
+func TestRegistryOverridesPerTenant(t *testing.T) {
+	acme, globex := uint(2), uint(3)
+	acmeCtx := domain.WithTenant(context.Background(), acme)
+	globexCtx := domain.WithTenant(context.Background(), globex)
+	overrides := &fakeOverrides{}
+	registry, err := NewRegistry(overrides)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Both tenants cache the embedded version before Acme activates its own
+	for _, ctx := range []context.Context{acmeCtx, globexCtx} {
+		if prompt, _ := registry.Render(ctx, SummarizeProject, summaryData); prompt.Version != "v2" {
+			t.Fatalf("Render() without overrides used %s, want v2", prompt.Version)
+		}
+	}
+	overrides.templates = append(overrides.templates, &entities.PromptTemplate{TenantID: &acme, Name: SummarizeProject, Version: "acme-1", Body: overrideBody, Active: true})
+	registry.Invalidate(acmeCtx, SummarizeProject)
+
+	if prompt, _ := registry.Render(acmeCtx, SummarizeProject, summaryData); prompt.Version != "acme-1" {
+		t.Errorf("Render() for Acme used %s, want its own acme-1", prompt.Version)
+	}
+	if prompt, _ := registry.Render(globexCtx, SummarizeProject, summaryData); prompt.Version != "v2" {
+		t.Errorf("Render() for Globex used %s, want v2 rather than Acme's override", prompt.Version)
+	}
+
+	// A new default drops every tenant's cached override and applies to tenants without their own
+	overrides.templates = append(overrides.templates, &entities.PromptTemplate{Name: SummarizeProject, Version: "v3", Body: overrideBody, Active: true})
+	registry.Invalidate(domain.AllTenants(context.Background()), SummarizeProject)
+	if prompt, _ := registry.Render(globexCtx, SummarizeProject, summaryData); prompt.Version != "v3" {
+		t.Errorf("Render() for Globex used %s, want the default v3", prompt.Version)
+	}
+	if prompt, _ := registry.Render(acmeCtx, SummarizeProject, summaryData); prompt.Version != "acme-1" {
+		t.Errorf("Render() for Acme used %s, want its own acme-1 over the default", prompt.Version)
+	}
+}
+
+func TestRegistryBrokenOverrideFallsBack(t *testing.T) {
+	overrides := &fakeOverrides{templates: []*entities.PromptTemplate{
+		{Name: SummarizeProject, Version: "v3", Body: `{{define "system"}}only system{{end}}`, Active: true},
+	}}
+	registry, err := NewRegistry(overrides)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prompt, err := registry.Render(context.Background(), SummarizeProject, summaryData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompt.Version != "v2" {
+		t.Errorf("Render() with a broken override used %s, want the embedded v2", prompt.Version)
+	}
+	if _, err := registry.RenderVersion(context.Background(), SummarizeProject, "v3", summaryData); err == nil {
+		t.Error("RenderVersion() of a broken override succeeded, want an error")
+	}
+}
+
+func TestRegistryErrors(t *testing.T) {
+	ctx := context.Background()
+	registry, err := NewRegistry(&fakeOverrides{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := registry.Render(ctx, "unknown", summaryData); !errors.Is(err, domain.ErrUnknownPrompt) {
+		t.Errorf("Render() of an unknown template error = %v, want %v", err, domain.ErrUnknownPrompt)
+	}
+	if _, err := registry.RenderVersion(ctx, SummarizeProject, "v9", summaryData); !errors.Is(err, domain.ErrUnknownPrompt) {
+		t.Errorf("RenderVersion() of an unknown version error = %v, want %v", err, domain.ErrUnknownPrompt)
+	}
+	if _, err := registry.Versions(ctx, "unknown"); !errors.Is(err, domain.ErrUnknownPrompt) {
+		t.Errorf("Versions() of an unknown template error = %v, want %v", err, domain.ErrUnknownPrompt)
+	}
+
+	// Missing data is a render error rather than "<no value>" in the prompt
+	if _, err := registry.Render(ctx, SummarizeProject, map[string]interface{}{"Description": "a project"}); err == nil {
+		t.Error("Render() with missing data succeeded, want an error")
+	}
+
+	for name, body := range map[string]string{
+		"unparseable":     `{{define "system"}}{{.Broken`,
+		"missing section": `{{define "system"}}system{{end}}`,
+	} {
+		if err := registry.Validate(body); err == nil {
+			t.Errorf("Validate(%s) succeeded, want an error", name)
+		}
+	}
+	if err := registry.Validate(overrideBody); err != nil {
+		t.Errorf("Validate() of a valid body error = %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package redaction
+
+import (
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/utils"
+)
+
+// unknownName is the placeholder prompt data uses for candidates without a name
+const unknownName = "Unknown"
+
+// MinimiseMatchingData applies the allowlist to score_candidates prompt data.
+// Names that are not allowed become person tokens; other fields are withheld.
+// A withheld similarity is sent as zero.
+func (s *Session) MinimiseMatchingData(data *utils.MatchingPromptData) {
+	for i := range data.Candidates {
+		c := &data.Candidates[i]
+		if c.Name != unknownName {
+			c.Name = s.IdentityField("name", KindPerson, c.Name)
+		}
+		c.Skills = s.Field("skills", c.Skills)
+		c.Geo = s.Field("geo", c.Geo)
+		c.Experience = s.Field("experience", c.Experience)
+		c.Industry = s.Field("industry", c.Industry)
+		c.Availability = s.Field("availability", c.Availability)
+		c.Status = s.Field("status", c.Status)
+		if s.Field("similarity", "") == Withheld {
+			c.Similarity = 0
+		}
+	}
+}
+
+// MinimiseSummaryData applies the allowlist to summarize_project prompt data.
+// The client name is registered so it is replaced wherever it appears in the description.
+func (s *Session) MinimiseSummaryData(data *utils.SummaryPromptData, clientName string) {
+	s.Register(KindClient, clientName)
+	data.Description = s.Field("description", data.Description)
+	data.Roles = s.Field("roles", data.Roles)
+}
+
+// MinimiseSearchData applies the allowlist to parse_talent_query prompt data
+func (s *Session) MinimiseSearchData(data *utils.TalentQueryPromptData) {
+	data.Query = s.Field("query", data.Query)
+}
+
+// MinimiseCVData applies the allowlist to extract_cv_profile prompt data.
+// The employee's name is registered so it is replaced wherever it appears in the CV.
+func (s *Session) MinimiseCVData(data *utils.CVProfilePromptData, names ...string) {
+	for _, name := range names {
+		s.Register(KindPerson, name)
+	}
+	data.CV = s.Field("cv_text", data.CV)
+}
+
+// MinimiseEmbeddingProject returns a copy of a project for the embeddings API with the client name,
+// emails and phone numbers in its free text tokenised. The stored summary has real names restored for
+// display, so it is redacted again rather than sent as stored. Tokens are stable, so embeddings of the
+// same project stay comparable.
+func (s *Session) MinimiseEmbeddingProject(project *entities.Project) *entities.Project {
+	s.Register(KindClient, project.ClientName)
+	minimised := *project
+	minimised.Name = s.Redact(project.Name)
+	minimised.Description = s.Redact(project.Description)
+	minimised.Summary = s.Redact(project.Summary)
+	return &minimised
+}
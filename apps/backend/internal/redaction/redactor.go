@@ -0,0 +1,261 @@
+// Package redaction keeps personal and client data out of prompts sent to external models.
+//
+// Every outbound prompt is built inside a Session. Prompt data is first minimised
+// against a per-prompt field allowlist: identifying fields that are not allowed are
+// replaced with stable pseudonymous tokens and other fields are withheld. The rendered
+// prompt is then scrubbed of any registered names, emails and phone numbers before it is
+// sent and audited. The session keeps the token mapping so model responses can be
+// restored before they are shown to users; the mapping itself is never persisted.
+package redaction
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// Kinds of pseudonymised values
+const (
+	KindPerson = "person"
+	KindClient = "client"
+	KindEmail  = "email"
+	KindPhone  = "phone"
+)
+
+// Withheld replaces allowlisted-out fields that carry no identity worth a token
+const Withheld = "withheld"
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	// Only international numbers: bare digit runs are too often dates, IDs or amounts
+	phonePattern = regexp.MustCompile(`\+\d[\d\s().-]{6,}\d`)
+)
+
+// Redactor applies the redaction policy to outbound prompts
+type Redactor struct {
+	secret    []byte
+	allowlist map[string]map[string]bool
+	audit     domain.PromptAuditRepository
+}
+
+// NewRedactor creates a new redactor. audit may be nil, in which case sent prompts are not audited.
+func NewRedactor(cfg *config.Config, audit domain.PromptAuditRepository) *Redactor {
+	allowlist := make(map[string]map[string]bool, len(cfg.AI.PromptFieldAllowlist))
+	for prompt, fields := range cfg.AI.PromptFieldAllowlist {
+		allowlist[prompt] = make(map[string]bool, len(fields))
+		for _, field := range fields {
+			allowlist[prompt][strings.ToLower(field)] = true
+		}
+	}
+	return &Redactor{
+		secret:    []byte(cfg.AI.RedactionSecret),
+		allowlist: allowlist,
+		audit:     audit,
+	}
+}
+
+// NewSession starts redaction for one outbound prompt
+func (r *Redactor) NewSession(promptName string) *Session {
+	return &Session{
+		redactor:   r,
+		promptName: promptName,
+		tokens:     make(map[string]string),
+		values:     make(map[string]string),
+		kinds:      make(map[string]string),
+	}
+}
+
+// Allowed reports whether a field may be sent as-is for a prompt.
+// Prompts without an allowlist entry send nothing that is not explicitly allowed.
+func (r *Redactor) Allowed(promptName string, field string) bool {
+	return r.allowlist[promptName][strings.ToLower(field)]
+}
+
+// Session holds the pseudonym mapping for one prompt and its response
+type Session struct {
+	redactor   *Redactor
+	promptName string
+	fieldsSent map[string]bool
+	tokens     map[string]string // kind + normalised value -> token
+	values     map[string]string // token -> original value
+	kinds      map[string]string // token -> kind
+}
+
+// Field returns value if the field is allowlisted for this prompt, and Withheld otherwise
+func (s *Session) Field(field string, value string) string {
+	if s.redactor.Allowed(s.promptName, field) {
+		s.markSent(field)
+		return value
+	}
+	return Withheld
+}
+
+// IdentityField returns value if the field is allowlisted, and a pseudonymous token for it otherwise
+func (s *Session) IdentityField(field string, kind string, value string) string {
+	if s.redactor.Allowed(s.promptName, field) {
+		s.markSent(field)
+		return value
+	}
+	return s.Pseudonymize(kind, value)
+}
+
+// Register marks a value that must not appear in free text, e.g. a client name in a description
+func (s *Session) Register(kind string, value string) {
+	if strings.TrimSpace(value) != "" {
+		s.Pseudonymize(kind, value)
+	}
+}
+
+// Pseudonymize returns the stable token for a value. The same value always maps to the
+// same token under one secret, so tokens can be correlated across audit logs without
+// revealing the value.
+func (s *Session) Pseudonymize(kind string, value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return value
+	}
+	key := kind + "\x00" + strings.ToLower(value)
+	if token, ok := s.tokens[key]; ok {
+		return token
+	}
+
+	mac := hmac.New(sha256.New, s.redactor.secret)
+	mac.Write([]byte(key))
+	digest := hex.EncodeToString(mac.Sum(nil))
+
+	// Lengthen the token on the unlikely collision with a different value
+	token := ""
+	for length := 6; length <= len(digest); length += 2 {
+		token = "[" + strings.ToUpper(kind) + "_" + digest[:length] + "]"
+		if existing, taken := s.values[token]; !taken || strings.EqualFold(existing, value) {
+			break
+		}
+	}
+
+	s.tokens[key] = token
+	s.values[token] = value
+	s.kinds[token] = kind
+	return token
+}
+
+// Redact replaces registered values, emails and international phone numbers in free text
+func (s *Session) Redact(text string) string {
+	text = emailPattern.ReplaceAllStringFunc(text, func(email string) string {
+		return s.Pseudonymize(KindEmail, email)
+	})
+	text = phonePattern.ReplaceAllStringFunc(text, func(phone string) string {
+		return s.Pseudonymize(KindPhone, phone)
+	})
+
+	// Longest values first so "Acme Corp" is replaced before "Acme"
+	tokens := make([]string, 0, len(s.values))
+	for token := range s.values {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool { return len(s.values[tokens[i]]) > len(s.values[tokens[j]]) })
+	for _, token := range tokens {
+		text = replaceFold(text, s.values[token], token)
+	}
+	return text
+}
+
+// Restore replaces tokens in a model response with the original values
+func (s *Session) Restore(text string) string {
+	for token, value := range s.values {
+		text = strings.ReplaceAll(text, token, value)
+	}
+	return text
+}
+
+// Counts returns how many distinct values of each kind were pseudonymised
+func (s *Session) Counts() entities.RedactionCounts {
+	counts := entities.RedactionCounts{}
+	for _, kind := range s.kinds {
+		counts[kind]++
+	}
+	return counts
+}
+
+// Scrub returns a copy of a rendered prompt with free text redacted
+func (s *Session) Scrub(prompt *domain.Prompt) *domain.Prompt {
+	scrubbed := *prompt
+	scrubbed.System = s.Redact(prompt.System)
+	scrubbed.User = s.Redact(prompt.User)
+	return &scrubbed
+}
+
+// Seal scrubs a rendered prompt, records what is about to be sent and returns the prompt to send
+func (s *Session) Seal(ctx context.Context, prompt *domain.Prompt) *domain.Prompt {
+	sealed := s.Scrub(prompt)
+	s.auditSent(ctx, sealed)
+	return sealed
+}
+
+func (s *Session) auditSent(ctx context.Context, prompt *domain.Prompt) {
+	if s.redactor.audit == nil {
+		return
+	}
+
+	text := prompt.System + "\n\n" + prompt.User
+	digest := sha256.Sum256([]byte(text))
+	entry := &entities.PromptAuditLog{
+		PromptName:    prompt.Name,
+		PromptVersion: prompt.Version,
+		FieldsSent:    strings.Join(s.sentFields(), ","),
+		Redactions:    s.Counts(),
+		PromptSHA256:  hex.EncodeToString(digest[:]),
+		SentPrompt:    text,
+	}
+	if attribution, ok := domain.AIUsageFromContext(ctx); ok {
+		entry.Feature = attribution.Feature
+		entry.EntityType = attribution.EntityType
+		if attribution.EntityID != 0 {
+			entityID := attribution.EntityID
+			entry.EntityID = &entityID
+		}
+	}
+
+	if err := s.redactor.audit.Create(context.WithoutCancel(ctx), entry); err != nil {
+		log.Printf("Warning: Failed to audit prompt %s@%s: %v", prompt.Name, prompt.Version, err)
+	}
+}
+
+func (s *Session) markSent(field string) {
+	if s.fieldsSent == nil {
+		s.fieldsSent = make(map[string]bool)
+	}
+	s.fieldsSent[strings.ToLower(field)] = true
+}
+
+func (s *Session) sentFields() []string {
+	fields := make([]string, 0, len(s.fieldsSent))
+	for field := range s.fieldsSent {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// replaceFold replaces whole-word, case-insensitive occurrences of old in text.
+// Underscores count as word characters so values never match inside a token.
+func replaceFold(text string, old string, replacement string) string {
+	if old == "" {
+		return text
+	}
+	pattern := regexp.MustCompile(`(?i)(^|[^\pL\pN_])` + regexp.QuoteMeta(old) + `($|[^\pL\pN_])`)
+	escaped := strings.ReplaceAll(replacement, "$", "$$")
+	// Adjacent matches share a boundary character, so a second pass catches the ones skipped
+	for pass := 0; pass < 2; pass++ {
+		text = pattern.ReplaceAllString(text, "${1}"+escaped+"${2}")
+	}
+	return text
+}
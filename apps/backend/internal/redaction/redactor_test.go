@@ -0,0 +1,119 @@
+package redaction
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/utils"
+)
+
+func testRedactor() *Redactor {
+	cfg := &config.Config{}
+	cfg.AI.RedactionSecret = "test-secret"
+	cfg.AI.PromptFieldAllowlist = map[string][]string{
+		"score_candidates": {"skills", "status"},
+	}
+	return NewRedactor(cfg, nil)
+}
+
+func TestSessionRedactsAndRestores(t *testing.T) {
+	redactor := testRedactor()
+	session := redactor.NewSession("score_candidates")
+	session.Register(KindClient, "Acme Corp")
+
+	prompt := &domain.Prompt{
+		Name: "score_candidates",
+		User: "Project for ACME CORP. Contact jane.doe@acme.com or +44 20 7946 0958. Candidate: Jane Doe",
+	}
+	person := session.Pseudonymize(KindPerson, "Jane Doe")
+	scrubbed := session.Scrub(prompt)
+
+	for _, leaked := range []string{"ACME CORP", "jane.doe@acme.com", "+44 20 7946 0958", "Jane Doe"} {
+		if strings.Contains(scrubbed.User, leaked) {
+			t.Errorf("scrubbed prompt still contains %q: %s", leaked, scrubbed.User)
+		}
+	}
+	if !strings.Contains(scrubbed.User, person) {
+		t.Errorf("scrubbed prompt missing person token %s: %s", person, scrubbed.User)
+	}
+	if prompt.User == scrubbed.User {
+		t.Error("Scrub modified nothing")
+	}
+
+	restored := session.Restore("Strong fit: " + person + " has worked with " + session.Pseudonymize(KindClient, "acme corp"))
+	if restored != "Strong fit: Jane Doe has worked with Acme Corp" {
+		t.Errorf("unexpected restored text: %s", restored)
+	}
+
+	counts := session.Counts()
+	if counts[KindClient] != 1 || counts[KindPerson] != 1 || counts[KindEmail] != 1 || counts[KindPhone] != 1 {
+		t.Errorf("unexpected redaction counts: %v", counts)
+	}
+}
+
+func TestPseudonymsAreStableAcrossSessions(t *testing.T) {
+	redactor := testRedactor()
+	first := redactor.NewSession("score_candidates").Pseudonymize(KindPerson, "Jane Doe")
+	second := redactor.NewSession("summarize_project").Pseudonymize(KindPerson, " jane doe ")
+	if first != second {
+		t.Errorf("expected stable token, got %s and %s", first, second)
+	}
+	if other := redactor.NewSession("score_candidates").Pseudonymize(KindClient, "Jane Doe"); other == first {
+		t.Errorf("expected kinds to produce different tokens, got %s for both", other)
+	}
+}
+
+func TestMinimiseMatchingDataAppliesAllowlist(t *testing.T) {
+	session := testRedactor().NewSession("score_candidates")
+	data := utils.MatchingPromptData{
+		Candidates: []utils.MatchingPromptCandidate{{
+			Name:       "Jane Doe",
+			Skills:     "Go, PostgreSQL",
+			Geo:        "London",
+			Status:     "onBench",
+			Similarity: 87.5,
+		}},
+	}
+	session.MinimiseMatchingData(&data)
+
+	c := data.Candidates[0]
+	if !strings.HasPrefix(c.Name, "[PERSON_") {
+		t.Errorf("expected name to be pseudonymised, got %q", c.Name)
+	}
+	if c.Skills != "Go, PostgreSQL" || c.Status != "onBench" {
+		t.Errorf("allowlisted fields changed: %+v", c)
+	}
+	if c.Geo != Withheld || c.Similarity != 0 {
+		t.Errorf("expected geo and similarity to be withheld: %+v", c)
+	}
+	if fields := session.sentFields(); strings.Join(fields, ",") != "skills,status" {
+		t.Errorf("unexpected fields sent: %v", fields)
+	}
+}
+
+func TestMinimiseEmbeddingProjectRedactsFreeText(t *testing.T) {
+	project := &entities.Project{
+		Name:        "Acme Corp portal",
+		ClientName:  "Acme Corp",
+		Description: "Rebuild the Acme Corp portal; contact jane@acme.example or +44 20 7946 0000",
+		Summary:     "Project requires: Skills: Go. Client: Acme Corp.",
+	}
+	minimised := testRedactor().NewSession(domain.FeatureProjectEmbedding).MinimiseEmbeddingProject(project)
+
+	for field, text := range map[string]string{"name": minimised.Name, "description": minimised.Description, "summary": minimised.Summary} {
+		for _, value := range []string{"Acme", "jane@acme.example", "7946"} {
+			if strings.Contains(text, value) {
+				t.Errorf("%s still contains %q: %q", field, value, text)
+			}
+		}
+	}
+	if !strings.Contains(minimised.Summary, "Skills: Go") {
+		t.Errorf("summary lost its requirements: %q", minimised.Summary)
+	}
+	if project.Summary != "Project requires: Skills: Go. Client: Acme Corp." {
+		t.Errorf("stored summary was changed: %q", project.Summary)
+	}
+}
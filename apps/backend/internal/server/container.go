@@ -7,7 +7,12 @@ import (
 	"github.com/talent-fit/backend/internal/database"
 	"github.com/talent-fit/backend/internal/domain"
 	"github.com/talent-fit/backend/internal/handlers"
+	"github.com/talent-fit/backend/internal/hris"
+	"github.com/talent-fit/backend/internal/identity"
+	"github.com/talent-fit/backend/internal/prompts"
+	"github.com/talent-fit/backend/internal/redaction"
 	"github.com/talent-fit/backend/internal/services"
+	"github.com/talent-fit/backend/internal/storage"
 	n "github.com/talent-fit/backend/internal/services/notifiers"
 )
 
@@ -25,9 +30,43 @@ type Container struct {
 	EmployeeProfileHandler   *handlers.EmployeeProfileHandler
 	TokenHandler             *handlers.TokenHandler
 	GoogleAuthHandler        *handlers.GoogleAuthHandler
+	OIDCAuthHandler          *handlers.OIDCAuthHandler
+	InvitationHandler        *handlers.InvitationHandler
+	AuditHandler             *handlers.AuditHandler
+	APIKeyHandler            *handlers.APIKeyHandler
+	OrgUnitHandler           *handlers.OrgUnitHandler
+	TenantHandler            *handlers.TenantHandler
     DevHandler               *handlers.DevHandler
     Orchestrator             *services.Orchestrator
     DashboardHandler         *handlers.DashboardHandler
+    PromptHandler            *handlers.PromptHandler
+    AIUsageHandler           *handlers.AIUsageHandler
+    EnrichmentHandler        *handlers.EnrichmentHandler
+    CVImportHandler          *handlers.CVImportHandler
+    ImportHandler            *handlers.ImportHandler
+    HRISHandler              *handlers.HRISHandler
+    ReportHandler            *handlers.ReportHandler
+
+    // Background summarise and embed jobs, run by the server's workers or cmd/enrich
+    EnrichmentService domain.EnrichmentService
+    // Bulk imports, used by the admin endpoint and cmd/import
+    ImportService domain.ImportService
+    // HR system sync, polled by the server or cmd/hrsync
+    HRSyncService domain.HRSyncService
+    // Dashboard metrics, snapshotted daily by the server or cmd/snapshot
+    DashboardService domain.DashboardService
+    // Current user and role lookup for the API's auth middleware
+    Users domain.UserRepository
+    // Access token revocation list checked by the API's auth middleware
+    TokenService domain.TokenService
+    // Service accounts' API keys, accepted by the API's auth middleware
+    APIKeyService domain.APIKeyService
+    // Audit log of changes made while an admin acts as another user
+    Audit domain.AuditRepository
+    // Org unit scoping of employee, project and dashboard views
+    OrgUnits domain.OrgUnitService
+    // Tenants, for cmd/tenant and the jobs that run once per tenant
+    Tenants domain.TenantService
 }
 
 // NewContainer creates and initializes all application dependencies
@@ -49,37 +88,123 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	allocationRepo := database.NewProjectAllocationRepository(db.DB)
 	notificationRepo := database.NewNotificationRepository(db.DB)
 	profileRepo := database.NewEmployeeProfileRepository(db.DB)
+	promptTemplateRepo := database.NewPromptTemplateRepository(db.DB)
+	matchRunRepo := database.NewMatchRunRepository(db.DB)
+	aiUsageRepo := database.NewAIUsageRepository(db.DB)
+	promptAuditRepo := database.NewPromptAuditRepository(db.DB)
+	enrichmentJobRepo := database.NewEnrichmentJobRepository(db.DB)
+	profileDraftRepo := database.NewProfileDraftRepository(db.DB)
+	importRepo := database.NewImportRepository(db.DB)
+	hrSyncRepo := database.NewHRSyncRepository(db.DB)
+	reportRepo := database.NewReportRepository(db.DB)
+	snapshotRepo := database.NewMetricsSnapshotRepository(db.DB)
+	dashboardMetricsRepo := database.NewDashboardMetricsRepository(db.DB)
+	authTokenRepo := database.NewAuthTokenRepository(db.DB)
+	invitationRepo := database.NewInvitationRepository(db.DB)
+	auditRepo := database.NewAuditRepository(db.DB)
+	apiKeyRepo := database.NewAPIKeyRepository(db.DB)
+	orgUnitRepo := database.NewOrgUnitRepository(db.DB)
+	tenantRepo := database.NewTenantRepository(db.DB)
+
+    // Prompt templates (embedded, with optional database overrides)
+    promptRegistry, err := prompts.NewRegistry(promptTemplateRepo)
+    if err != nil {
+        return nil, fmt.Errorf("failed to load prompt templates: %w", err)
+    }
+
+    // Original CVs are kept only when a blob store is configured
+    blobStore, err := storage.NewBlobStore(cfg)
+    if err != nil {
+        return nil, fmt.Errorf("failed to initialize blob store: %w", err)
+    }
+
+    // Redaction of personal and client data in prompts sent to external models
+    redactor := redaction.NewRedactor(cfg, promptAuditRepo)
 
     // Initialize services
-    embeddingService := services.NewOpenAIEmbeddingService(cfg)
-    userService := services.NewUserService(userRepo)
+    // Tenants' settings override the Slack, AI provider and scoring config of the services below
+    tenantService := services.NewTenantService(tenantRepo, userRepo, auditRepo)
+    // AI usage accounting is created first so every provider call is recorded
+    aiUsageService := services.NewAIUsageService(aiUsageRepo, cfg)
+    embeddingService := services.NewOpenAIEmbeddingService(cfg, aiUsageService, tenantService)
 
     // Notifiers and orchestrator (must be created before services that depend on it)
     inAppNotifier := n.NewInAppNotifier(db.DB)
-    slackNotifier := n.NewSlackNotifier(cfg)
-    orchestrator := services.NewOrchestrator(inAppNotifier, slackNotifier)
+    slackNotifier := n.NewSlackNotifier(cfg, tenantService)
+    // Recipients follow reporting lines and project ownership
+    recipientResolver := services.NewRecipientResolver(userRepo, projectRepo, allocationRepo)
+    orchestrator := services.NewOrchestrator(inAppNotifier, slackNotifier, recipientResolver)
+
+    // Summaries and embeddings are generated by queued jobs rather than inside requests
+    enrichmentService := services.NewEnrichmentService(enrichmentJobRepo, projectRepo, profileRepo, embeddingService, promptRegistry, redactor, cfg)
 
-    projectService := services.NewProjectService(projectRepo, embeddingService, allocationRepo, orchestrator)
+    projectService := services.NewProjectService(projectRepo, orchestrator, enrichmentService)
     allocationService := services.NewProjectAllocationService(allocationRepo, profileRepo, orchestrator)
-    matchService := services.NewMatchService(userRepo, projectRepo, allocationRepo, profileRepo, embeddingService)
+    matchService := services.NewMatchService(userRepo, projectRepo, allocationRepo, profileRepo, embeddingService, matchRunRepo, promptRegistry, aiUsageService, redactor, tenantService, cfg)
     notificationService := services.NewNotificationService(notificationRepo)
-    profileService := services.NewEmployeeProfileService(profileRepo, embeddingService, orchestrator, userRepo)
-    googleAuthService := services.NewGoogleAuthService(userRepo, cfg)
-    // Dashboard service depends on repos directly to compute metrics
-    var _ domain.DashboardService
-    dashboardService := services.NewDashboardService(projectRepo, allocationRepo, profileRepo)
+    profileService := services.NewEmployeeProfileService(profileRepo, orchestrator, userRepo, enrichmentService)
+    talentSearchService := services.NewTalentSearchService(profileRepo, embeddingService, promptRegistry, aiUsageService, redactor)
+    cvImportService := services.NewCVImportService(profileDraftRepo, profileRepo, userRepo, profileService, embeddingService, promptRegistry, aiUsageService, redactor, blobStore)
+    // Bulk CSV/XLSX imports of users, profiles, projects and allocations
+    importService := services.NewImportService(importRepo, enrichmentService)
+    // HR system sync: the webhook pushes batches, the drop directory is polled
+    hrFieldMapping, err := hris.ParseMapping(cfg.HRIS.FieldMap)
+    if err != nil {
+        return nil, err
+    }
+    var hrConnectors []domain.HRConnector
+    if cfg.HRIS.DropDir != "" {
+        fileDrop, err := hris.NewFileDrop(cfg.HRIS.DropDir, hrFieldMapping)
+        if err != nil {
+            return nil, err
+        }
+        hrConnectors = append(hrConnectors, fileDrop)
+    }
+    hrSyncService := services.NewHRSyncService(hrSyncRepo, userRepo, profileRepo, profileService, cfg.HRIS.TenantID, hrConnectors...)
+    tokenService := services.NewTokenService(authTokenRepo, userRepo, profileRepo, cfg)
+    // Admin user management; deactivation also ends the user's sessions
+    userService := services.NewUserService(userRepo, tokenService, auditRepo)
+    // Sign-in through Google and any further OpenID Connect providers
+    authProviders, err := identity.Providers(cfg.Auth.GoogleClientID, cfg.Auth.OIDCProviders)
+    if err != nil {
+        return nil, err
+    }
+    // First sign-ins need an invitation unless their email domain is open for sign-up
+    invitationService := services.NewInvitationService(invitationRepo, userRepo, tenantRepo, auditRepo, cfg.Auth.SignUpDomains)
+    auditService := services.NewAuditService(auditRepo)
+    apiKeyService := services.NewAPIKeyService(apiKeyRepo, auditRepo)
+    orgUnitService := services.NewOrgUnitService(orgUnitRepo, userRepo)
+    oidcAuthService := services.NewOIDCAuthService(identity.NewRegistry(authProviders, nil), userRepo, invitationService, tokenService)
+    googleAuthService := services.NewGoogleAuthService(oidcAuthService)
+    // Dashboard metrics are aggregated in SQL by the metrics repository
+    dashboardService := services.NewDashboardService(dashboardMetricsRepo, snapshotRepo)
+    reportService := services.NewReportService(reportRepo, dashboardService)
+    promptService := services.NewPromptService(promptRegistry, promptTemplateRepo, projectRepo, profileRepo, redactor)
 
 	// Initialize handlers
     userHandler := handlers.NewUserHandler(userService)
 	projectHandler := handlers.NewProjectHandler(projectService)
     allocationHandler := handlers.NewProjectAllocationHandler(allocationService)
-	matchHandler := handlers.NewMatchHandler(matchService)
+	matchHandler := handlers.NewMatchHandler(matchService, cfg)
 	notificationHandler := handlers.NewNotificationHandler(notificationService)
-    profileHandler := handlers.NewEmployeeProfileHandler(profileService)
-	tokenHandler := handlers.NewTokenHandler(googleAuthService)
-    googleAuthHandler := handlers.NewGoogleAuthHandler(googleAuthService)
-    devHandler := handlers.NewDevHandler(orchestrator, cfg)
+    profileHandler := handlers.NewEmployeeProfileHandler(profileService, talentSearchService)
+	tokenHandler := handlers.NewTokenHandler(tokenService)
+    googleAuthHandler := handlers.NewGoogleAuthHandler(googleAuthService, tokenService, cfg)
+    oidcAuthHandler := handlers.NewOIDCAuthHandler(oidcAuthService)
+    invitationHandler := handlers.NewInvitationHandler(invitationService)
+    auditHandler := handlers.NewAuditHandler(auditService)
+    apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+    orgUnitHandler := handlers.NewOrgUnitHandler(orgUnitService)
+    tenantHandler := handlers.NewTenantHandler(tenantService)
+    devHandler := handlers.NewDevHandler(orchestrator, services.NewDevAuthService(userRepo, tokenService, auditRepo, cfg), cfg)
     dashboardHandler := handlers.NewDashboardHandler(dashboardService)
+    promptHandler := handlers.NewPromptHandler(promptService)
+    aiUsageHandler := handlers.NewAIUsageHandler(aiUsageService)
+    enrichmentHandler := handlers.NewEnrichmentHandler(enrichmentService)
+    cvImportHandler := handlers.NewCVImportHandler(cvImportService, cfg)
+    importHandler := handlers.NewImportHandler(importService, cfg)
+    hrisHandler := handlers.NewHRISHandler(hrSyncService, cfg.HRIS.WebhookSecret, hrFieldMapping)
+    reportHandler := handlers.NewReportHandler(reportService)
 
 	return &Container{
 		DB:                       db,
@@ -91,8 +216,31 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		EmployeeProfileHandler:   profileHandler,
 		TokenHandler:             tokenHandler,
         GoogleAuthHandler:        googleAuthHandler,
+        OIDCAuthHandler:          oidcAuthHandler,
+        InvitationHandler:        invitationHandler,
+        AuditHandler:             auditHandler,
+        APIKeyHandler:            apiKeyHandler,
+        OrgUnitHandler:           orgUnitHandler,
+        TenantHandler:            tenantHandler,
         DevHandler:               devHandler,
         DashboardHandler:         dashboardHandler,
+        PromptHandler:            promptHandler,
+        AIUsageHandler:           aiUsageHandler,
+        EnrichmentHandler:        enrichmentHandler,
+        CVImportHandler:          cvImportHandler,
+        ImportHandler:            importHandler,
+        HRISHandler:              hrisHandler,
+        ReportHandler:            reportHandler,
+        EnrichmentService:        enrichmentService,
+        ImportService:            importService,
+        HRSyncService:            hrSyncService,
+        DashboardService:         dashboardService,
+        Users:                    userRepo,
+        TokenService:             tokenService,
+        APIKeyService:            apiKeyService,
+        Audit:                    auditRepo,
+        OrgUnits:                 orgUnitService,
+        Tenants:                  tenantService,
 	}, nil
 }
 
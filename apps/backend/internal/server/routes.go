@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/models"
 	"github.com/talent-fit/backend/pkg/middleware"
 )
 
@@ -16,6 +17,9 @@ func (s *Server) setupRoutes() {
 	// Open routes (no authentication required)
 	s.setupAuthRoutes()
 
+	// Inbound integrations, authenticated by their own signatures
+	s.setupIntegrationRoutes()
+
 	// Protected API routes (require authentication)
 	s.setupProtectedRoutes()
 }
@@ -34,19 +38,43 @@ func (s *Server) setupAuthRoutes() {
 	auth := s.router.Group("/auth")
 	{
     auth.POST("/google/login", s.container.GoogleAuthHandler.Login)
+		// Other OpenID Connect providers, e.g. /auth/oidc/microsoft/login
+		auth.GET("/providers", s.container.OIDCAuthHandler.Providers)
+		auth.POST("/oidc/:provider/login", s.container.OIDCAuthHandler.Login)
 		auth.POST("/logout", s.container.GoogleAuthHandler.Logout)
+		// Rotating refresh tokens: {"refreshToken": "..."}
+		auth.POST("/refresh-token", s.container.TokenHandler.RefreshToken)
+		auth.POST("/revoke-token", s.container.TokenHandler.RevokeToken)
+		auth.POST("/validate-token", append(s.authMiddleware(), s.container.TokenHandler.ValidateToken)...)
 	}
 
     // Dev-only route for sending a test notification to Slack default channel (no JWT)
     if s.config.IsDevelopment() {
         s.router.POST("/notifications/test", s.container.DevHandler.SendTestNotification)
     }
+
+    // Dev-only sign-in as any seeded user by email or role, without a login provider
+    if s.config.DevLoginEnabled() {
+        auth.POST("/dev/login", s.container.DevHandler.Login)
+    }
+}
+
+// setupIntegrationRoutes sets up webhooks called by external systems (no JWT)
+func (s *Server) setupIntegrationRoutes() {
+	integrations := s.router.Group("/integrations")
+	{
+		// HR system employee records, signed with HRIS_WEBHOOK_SECRET
+		integrations.POST("/hris/webhook", s.container.HRISHandler.Webhook)
+	}
 }
 
 // setupProtectedRoutes sets up all protected API routes
 func (s *Server) setupProtectedRoutes() {
     api := s.router.Group("/api/v1")
-    api.Use(middleware.AuthMiddlewareWithConfig(s.config)) // Apply auth middleware to all API routes
+    // Service accounts' API keys are accepted alongside users' bearer JWTs
+    api.Use(middleware.AuthenticateAPIKey(s.container.APIKeyService))
+    api.Use(s.authMiddleware()...) // Apply auth middleware to all API routes
+    api.Use(middleware.RecordImpersonation(s.container.Audit))
 
 	// Employee routes (personal and professional details)
 	s.setupEmployeeRoutes(api)
@@ -54,63 +82,192 @@ func (s *Server) setupProtectedRoutes() {
 	// Manager routes (employee management and projects)
 	s.setupManagerRoutes(api)
 
+	// Report exports
+	s.setupReportRoutes(api)
+
 	// Notification routes
 	s.setupNotificationRoutes(api)
+
+	// Invitations for first sign-in
+	s.setupInvitationRoutes(api)
+
+	// Org unit tree, for everyone signed in to pick from
+	api.GET("/org-units", s.container.OrgUnitHandler.ListOrgUnits)
+
+	// Admin routes
+	s.setupAdminRoutes(api)
+}
+
+// authMiddleware verifies the bearer token, rejects revoked tokens and loads the current user.
+// The user's role is re-read from the database rather than trusted from the token, and protected
+// routes are gated by the permission matrix in models/permission.go.
+func (s *Server) authMiddleware() []gin.HandlerFunc {
+	return []gin.HandlerFunc{
+		middleware.AuthMiddlewareWithConfig(s.config),
+		middleware.RejectRevokedTokens(s.container.TokenService),
+		middleware.LoadCurrentUser(s.container.Users),
+	}
 }
 
 // setupEmployeeRoutes sets up employee-specific routes (personal and professional details)
 func (s *Server) setupEmployeeRoutes(api *gin.RouterGroup) {
+	// The current user's own records, not available to API keys
+	user := middleware.RequireUser()
+
 	// Employee details for both personal and professional
-	api.GET("/employee/me", s.container.EmployeeProfileHandler.GetMe) 
-	api.POST("/employee/:id", s.container.EmployeeProfileHandler.CreateProfile)     
-	api.PATCH("/employee/:id", s.container.EmployeeProfileHandler.UpdateProfile)    
+	api.GET("/employee/me", user, s.container.EmployeeProfileHandler.GetMe) 
+	// Employees may only create and edit their own profile
+	api.POST("/employee/:id", user, middleware.RequireSelfOrPermission("id", models.PermEmployeesWrite), s.container.EmployeeProfileHandler.CreateProfile)
+	api.PATCH("/employee/:id", middleware.RequireSelfOrPermission("id", models.PermEmployeesWrite), s.container.EmployeeProfileHandler.UpdateProfile)
+
+	// CV upload: proposes a profile draft that only changes the profile once confirmed
+	api.POST("/employee/me/cv", user, s.container.CVImportHandler.UploadCV)
+	api.GET("/employee/me/cv/drafts/:draftId", user, s.container.CVImportHandler.GetDraft)
+	api.POST("/employee/me/cv/drafts/:draftId/confirm", user, s.container.CVImportHandler.ConfirmDraft)
+	api.DELETE("/employee/me/cv/drafts/:draftId", user, s.container.CVImportHandler.DiscardDraft)
 
 	// Projects for employee
-	api.GET("/employee/:id/projects", s.container.ProjectAllocationHandler.GetAllocationsByEmployee) 
+	api.GET("/employee/:id/projects", middleware.RequireSelfOrPermission("id", models.PermAllocationsRead), s.container.ProjectAllocationHandler.GetAllocationsByEmployee)
 	// GET /employee/:id/projects/:id (specific project detail for employee - not implemented yet)
 }
 
 // setupManagerRoutes sets up manager-specific routes (employee management and projects)
 func (s *Server) setupManagerRoutes(api *gin.RouterGroup) {
+	// Lists and metrics cover the caller's org units unless ?org_unit=<id>|all says otherwise
+	orgScope := middleware.ScopeToOrgUnit(s.container.OrgUnits)
+
 	// Employee management for managers (with query parameters for filtering)
-	// GET /employees?skills=<>&geo=<>&availability=<>
-	api.GET("/employees", s.container.EmployeeProfileHandler.GetAllProfiles) // Will handle query params
+	// GET /employees?skills=<>&geo=<>&availability=<>&org_unit=<>
+	api.GET("/employees", middleware.RequirePermission(models.PermEmployeesRead), orgScope, s.container.EmployeeProfileHandler.GetAllProfiles) // Will handle query params
+	// POST /employees/search {"query": "senior Go engineer in Europe, free next month"}
+	api.POST("/employees/search", middleware.RequirePermission(models.PermEmployeesRead), s.container.EmployeeProfileHandler.SearchProfiles)
 
 	// Project management
-	api.GET("/projects", s.container.ProjectHandler.GetAllProjects)     
-	api.POST("/projects", s.container.ProjectHandler.CreateProject)     
-	api.GET("/project/:id", s.container.ProjectHandler.GetProjectByID)  
-	api.PATCH("/project/:id", s.container.ProjectHandler.UpdateProject) 
+	api.GET("/projects", middleware.RequirePermission(models.PermProjectsRead), orgScope, s.container.ProjectHandler.GetAllProjects)
+	api.POST("/projects", middleware.RequirePermission(models.PermProjectsWrite), s.container.ProjectHandler.CreateProject)
+	api.GET("/project/:id", middleware.RequirePermission(models.PermProjectsRead), s.container.ProjectHandler.GetProjectByID)
+	api.PATCH("/project/:id", middleware.RequirePermission(models.PermProjectsWrite), s.container.ProjectHandler.UpdateProject)
 
 	// Employee suggestions (AI matching)
-	api.GET("/project/:id/suggestions", s.container.MatchHandler.GenerateMatchSuggestions)
+	api.GET("/project/:id/suggestions", middleware.RequirePermission(models.PermMatchesRead), s.container.MatchHandler.GenerateMatchSuggestions)
+	api.GET("/project/:id/suggestions/stream", middleware.RequirePermission(models.PermMatchesRead), s.container.MatchHandler.StreamMatchSuggestions)
 
 	// Project allocations
-	api.GET("/project/:id/allocation", s.container.ProjectAllocationHandler.GetAllocationsByProject) 
-	api.PATCH("/project/:id/allocation", s.container.ProjectAllocationHandler.UpdateAllocation)      
-	api.POST("/project/:id/allocation", s.container.ProjectAllocationHandler.CreateAllocation)      
+	api.GET("/project/:id/allocation", middleware.RequirePermission(models.PermAllocationsRead), s.container.ProjectAllocationHandler.GetAllocationsByProject)
+	api.PATCH("/project/:id/allocation", middleware.RequirePermission(models.PermAllocationsWrite), s.container.ProjectAllocationHandler.UpdateAllocation)
+	api.POST("/project/:id/allocation", middleware.RequirePermission(models.PermAllocationsWrite), s.container.ProjectAllocationHandler.CreateAllocation)
+
+	// Manager dashboard metrics
+	dashboard := api.Group("/manager/dashboard", middleware.RequirePermission(models.PermDashboardRead))
+	{
+		dashboard.GET("/metrics", orgScope, s.container.DashboardHandler.GetManagerDashboardMetrics)
+		// Daily snapshots of the same metrics for charts: ?from=&to=&groupBy=all|department|geo
+		dashboard.GET("/trends", s.container.DashboardHandler.GetMetricsTrends)
+	}
+}
 
-    // Manager dashboard metrics
-    api.GET("/manager/dashboard/metrics", s.container.DashboardHandler.GetManagerDashboardMetrics)
+// setupReportRoutes sets up staffing report exports
+func (s *Server) setupReportRoutes(api *gin.RouterGroup) {
+	reports := api.Group("/reports", middleware.RequirePermission(models.PermReportsRead))
+	{
+		// PDF summary: dashboard metrics with roll-off, bench and utilisation tables
+		reports.GET("/summary", s.container.ReportHandler.Summary)
+		// bench, allocations, utilisation and rolloffs as ?format=csv|xlsx|json
+		reports.GET("/:kind", s.container.ReportHandler.Export)
+	}
 }
 
 // setupNotificationRoutes sets up notification routes
 func (s *Server) setupNotificationRoutes(api *gin.RouterGroup) {
 	notifications := api.Group("/notifications")
 	{
-		notifications.GET("", s.container.NotificationHandler.GetAllNotifications)
-		notifications.GET("/:id", s.container.NotificationHandler.GetNotificationByID)
-		notifications.POST("", s.container.NotificationHandler.CreateNotification)
-		notifications.PUT("/:id", s.container.NotificationHandler.UpdateNotification)
-		notifications.DELETE("/:id", s.container.NotificationHandler.DeleteNotification)
-		notifications.POST("/:id/read", s.container.NotificationHandler.MarkAsRead)
+		manage := middleware.RequirePermission(models.PermNotificationsManage)
+		notifications.GET("", manage, s.container.NotificationHandler.GetAllNotifications)
+		// TODO: check the notification belongs to the current user once these are implemented
+		notifications.GET("/:id", middleware.RequireUser(), s.container.NotificationHandler.GetNotificationByID)
+		notifications.POST("", manage, s.container.NotificationHandler.CreateNotification)
+		notifications.PUT("/:id", manage, s.container.NotificationHandler.UpdateNotification)
+		notifications.DELETE("/:id", manage, s.container.NotificationHandler.DeleteNotification)
+		notifications.POST("/:id/read", middleware.RequireUser(), s.container.NotificationHandler.MarkAsRead)
+	}
+
+	// User-specific notification routes, for the user themselves
+	users := api.Group("/users/:userId", middleware.RequireSelfOrPermission("userId", models.PermNotificationsManage))
+	{
+		users.GET("/notifications", s.container.NotificationHandler.GetUserNotifications)
+		users.GET("/notifications/unread", s.container.NotificationHandler.GetUnreadNotifications)
+		users.POST("/notifications/read", s.container.NotificationHandler.MarkAllAsRead)
 	}
+}
 
-	// User-specific notification routes
-	users := api.Group("/users")
+// setupInvitationRoutes sets up invitations, which let someone sign in for the first time with a
+// preassigned role, Slack user ID and manager
+func (s *Server) setupInvitationRoutes(api *gin.RouterGroup) {
+	invitations := api.Group("/invitations", middleware.RequirePermission(models.PermUsersInvite))
 	{
-		users.GET("/:userId/notifications", s.container.NotificationHandler.GetUserNotifications)
-		users.GET("/:userId/notifications/unread", s.container.NotificationHandler.GetUnreadNotifications)
-		users.POST("/:userId/notifications/read", s.container.NotificationHandler.MarkAllAsRead)
+		invitations.POST("", s.container.InvitationHandler.CreateInvitation)
+		invitations.GET("", s.container.InvitationHandler.ListInvitations)
+		invitations.DELETE("/:id", s.container.InvitationHandler.RevokeInvitation)
+	}
+}
+
+// setupAdminRoutes sets up administration routes
+func (s *Server) setupAdminRoutes(api *gin.RouterGroup) {
+	admin := api.Group("/admin", middleware.RequirePermission(models.PermAdmin))
+	{
+		// Prompt templates: list versions, store DB overrides and preview rendered prompts
+		admin.GET("/prompts/:name", s.container.PromptHandler.ListVersions)
+		admin.PUT("/prompts/:name/versions/:version", s.container.PromptHandler.SaveVersion)
+		admin.GET("/prompts/:name/preview", s.container.PromptHandler.Preview)
+
+		// AI usage and cost per day and feature
+		admin.GET("/ai-usage", s.container.AIUsageHandler.GetUsage)
+
+		// Summarise and embed jobs: inspect the queue and retry failed jobs
+		admin.GET("/enrichment-jobs", s.container.EnrichmentHandler.ListJobs)
+		admin.POST("/enrichment-jobs/:id/retry", s.container.EnrichmentHandler.RetryJob)
+
+		// Bulk CSV/XLSX imports, with ?dry_run=true to validate without writing
+		admin.POST("/imports", s.container.ImportHandler.Import)
+
+		// HR system sync log: runs with their changes, leavers and conflicts
+		admin.GET("/hr-sync/runs", s.container.HRISHandler.ListRuns)
+		admin.GET("/hr-sync/runs/:id", s.container.HRISHandler.GetRun)
+
+		// Org unit tree: company > practice > department
+		admin.POST("/org-units", s.container.OrgUnitHandler.CreateOrgUnit)
+		admin.PUT("/org-units/:id", s.container.OrgUnitHandler.UpdateOrgUnit)
+		admin.DELETE("/org-units/:id", s.container.OrgUnitHandler.DeleteOrgUnit)
+
+		// User management: search, create, change roles, deactivate and reactivate
+		admin.GET("/users", s.container.UserHandler.ListUsers)
+		admin.POST("/users", s.container.UserHandler.CreateUser)
+		admin.GET("/users/:id", s.container.UserHandler.GetUser)
+		admin.PATCH("/users/:id", s.container.UserHandler.UpdateUser)
+		admin.POST("/users/:id/deactivate", s.container.UserHandler.DeactivateUser)
+		admin.POST("/users/:id/reactivate", s.container.UserHandler.ReactivateUser)
+
+		// Sign a user out of every session
+		admin.DELETE("/users/:id/sessions", s.container.TokenHandler.RevokeUserSessions)
+
+		// User provisioning audit log: invitations, sign-ups, user changes, rejected sign-ins and API keys
+		admin.GET("/audit-events", s.container.AuditHandler.ListEvents)
+
+		// Service accounts and their scoped API keys; a key's secret is only returned when issued
+		admin.GET("/service-accounts", s.container.APIKeyHandler.ListServiceAccounts)
+		admin.POST("/service-accounts", s.container.APIKeyHandler.CreateServiceAccount)
+		admin.DELETE("/service-accounts/:id", s.container.APIKeyHandler.DisableServiceAccount)
+		admin.POST("/service-accounts/:id/keys", s.container.APIKeyHandler.CreateKey)
+		admin.POST("/api-keys/:id/rotate", s.container.APIKeyHandler.RotateKey)
+		admin.DELETE("/api-keys/:id", s.container.APIKeyHandler.RevokeKey)
+
+		// The tenant's own Slack workspace, AI provider and scoring weights; secrets are write-only
+		admin.GET("/tenant", s.container.TenantHandler.GetTenant)
+		admin.PUT("/tenant", s.container.TenantHandler.UpdateTenant)
+
+		// Dev-only: act as another user to reproduce what they see
+		if s.config.DevLoginEnabled() {
+			admin.POST("/impersonate/:id", s.container.DevHandler.Impersonate)
+		}
 	}
 }
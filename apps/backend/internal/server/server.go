@@ -7,11 +7,13 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/services"
 )
 
 // Server represents the HTTP server
@@ -83,6 +85,13 @@ func (s *Server) Start() error {
 		Handler: s.router,
 	}
 
+	// Background enrichment workers run alongside the HTTP server and stop with it
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	workers := s.startEnrichmentWorkers(workerCtx)
+	hrPoller := s.startHRSyncPoller(workerCtx)
+	snapshotter := s.startMetricsSnapshotter(workerCtx)
+
 	// Channel to listen for interrupt signal to trigger shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -109,10 +118,91 @@ func (s *Server) Start() error {
 		return err
 	}
 
+	// Let workers finish their current job; an unfinished job is reclaimed after the lock timeout
+	stopWorkers()
+	select {
+	case <-workers:
+	case <-ctx.Done():
+		log.Println("Enrichment workers did not stop before the shutdown deadline")
+	}
+	select {
+	case <-hrPoller:
+	case <-ctx.Done():
+		log.Println("HR sync poller did not stop before the shutdown deadline")
+	}
+	select {
+	case <-snapshotter:
+	case <-ctx.Done():
+		log.Println("Metrics snapshotter did not stop before the shutdown deadline")
+	}
+
 	log.Println("Server stopped gracefully")
 	return nil
 }
 
+// startEnrichmentWorkers starts the configured number of enrichment workers and returns
+// a channel that is closed once they have all stopped
+func (s *Server) startEnrichmentWorkers(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	count := s.config.Enrichment.Workers
+	if count <= 0 {
+		close(done)
+		return done
+	}
+
+	hostname, _ := os.Hostname()
+	pollInterval := time.Duration(s.config.Enrichment.PollIntervalSeconds) * time.Second
+
+	var wg sync.WaitGroup
+	for i := 1; i <= count; i++ {
+		wg.Add(1)
+		workerID := fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), i)
+		go func() {
+			defer wg.Done()
+			services.RunEnrichmentWorker(ctx, s.container.EnrichmentService, workerID, pollInterval)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	return done
+}
+
+// startHRSyncPoller polls the HR drop directory when an interval is configured and returns
+// a channel that is closed once the poller has stopped
+func (s *Server) startHRSyncPoller(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	if s.config.HRIS.PollIntervalSeconds <= 0 || s.config.HRIS.DropDir == "" {
+		close(done)
+		return done
+	}
+
+	interval := time.Duration(s.config.HRIS.PollIntervalSeconds) * time.Second
+	go func() {
+		defer close(done)
+		services.RunHRSyncPoller(ctx, s.container.HRSyncService, interval)
+	}()
+	return done
+}
+
+// startMetricsSnapshotter takes the daily metrics snapshot when a check interval is configured
+// and returns a channel that is closed once it has stopped
+func (s *Server) startMetricsSnapshotter(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	if s.config.Metrics.SnapshotCheckMinutes <= 0 {
+		close(done)
+		return done
+	}
+
+	interval := time.Duration(s.config.Metrics.SnapshotCheckMinutes) * time.Minute
+	go func() {
+		defer close(done)
+		services.RunMetricsSnapshotter(ctx, s.container.DashboardService, s.container.Tenants, interval)
+	}()
+	return done
+}
+
 // Close closes the server and database connections
 func (s *Server) Close() error {
 	if s.container != nil {
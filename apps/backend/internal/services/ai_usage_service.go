@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// budgetCacheTTL bounds how often the month-to-date spend is re-read for budget checks
+const budgetCacheTTL = time.Minute
+
+// AIUsageService implements the domain.AIUsageService interface
+type AIUsageService struct {
+	usageRepo     domain.AIUsageRepository
+	pricing       map[string]config.ModelPrice
+	monthlyBudget float64
+
+	mu sync.Mutex
+	// budgets caches each tenant's budget position; 0 is the whole deployment
+	budgets map[uint]budgetState
+}
+
+// budgetState is a tenant's cached budget position
+type budgetState struct {
+	checked  time.Time
+	exceeded bool
+}
+
+// NewAIUsageService creates a new AI usage service
+func NewAIUsageService(usageRepo domain.AIUsageRepository, cfg *config.Config) domain.AIUsageService {
+	return &AIUsageService{
+		usageRepo:     usageRepo,
+		pricing:       cfg.AI.Pricing,
+		monthlyBudget: cfg.AI.MonthlyBudgetUSD,
+		budgets:       make(map[uint]budgetState),
+	}
+}
+
+// Record prices and stores an AI provider call, attributing it from the context.
+// Failures are logged rather than returned so accounting never breaks the AI call itself.
+func (s *AIUsageService) Record(ctx context.Context, usage *entities.AIUsage) {
+	usage.Feature = domain.FeatureUnattributed
+	if attribution, ok := domain.AIUsageFromContext(ctx); ok {
+		usage.Feature = attribution.Feature
+		usage.EntityType = attribution.EntityType
+		if attribution.EntityID != 0 {
+			entityID := attribution.EntityID
+			usage.EntityID = &entityID
+		}
+	}
+
+	if price, ok := s.pricing[usage.Model]; ok {
+		usage.CostUSD = (float64(usage.PromptTokens)*price.PromptPerMillion + float64(usage.CompletionTokens)*price.CompletionPerMillion) / 1_000_000
+	}
+
+	// The request may already be cancelled; the call still happened and must be recorded
+	if err := s.usageRepo.Create(context.WithoutCancel(ctx), usage); err != nil {
+		log.Printf("Warning: Failed to record AI usage for %s/%s: %v", usage.Provider, usage.Model, err)
+	}
+}
+
+// BudgetExceeded reports whether the context's tenant has spent the monthly budget this month.
+// Every tenant has the whole budget to itself; across domain.AllTenants the whole deployment's spend counts.
+func (s *AIUsageService) BudgetExceeded(ctx context.Context) (bool, error) {
+	if s.monthlyBudget <= 0 {
+		return false, nil
+	}
+	tenantID, _ := domain.TenantFromContext(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if state, ok := s.budgets[tenantID]; ok && time.Since(state.checked) < budgetCacheTTL {
+		return state.exceeded, nil
+	}
+
+	spent, err := s.monthToDate(ctx)
+	if err != nil {
+		return false, err
+	}
+	state := budgetState{checked: time.Now(), exceeded: spent >= s.monthlyBudget}
+	s.budgets[tenantID] = state
+	return state.exceeded, nil
+}
+
+// GetReport aggregates cost per day and feature for [from, to) with the current budget position
+func (s *AIUsageService) GetReport(ctx context.Context, from time.Time, to time.Time) (*domain.AIUsageReport, error) {
+	daily, err := s.usageRepo.DailyByFeature(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate AI usage: %w", err)
+	}
+
+	report := &domain.AIUsageReport{
+		From:             from,
+		To:               to,
+		Daily:            daily,
+		MonthlyBudgetUSD: s.monthlyBudget,
+	}
+	for _, day := range daily {
+		report.TotalCostUSD += day.CostUSD
+	}
+
+	report.MonthToDateUSD, err = s.monthToDate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get month-to-date AI cost: %w", err)
+	}
+	report.BudgetExceeded = s.monthlyBudget > 0 && report.MonthToDateUSD >= s.monthlyBudget
+	return report, nil
+}
+
+func (s *AIUsageService) monthToDate(ctx context.Context) (float64, error) {
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return s.usageRepo.TotalCost(ctx, monthStart, now.Add(time.Second))
+}
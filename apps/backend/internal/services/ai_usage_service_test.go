@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/internal/utils"
+)
+
+type fakeAIUsageRepo struct {
+	domain.AIUsageRepository
+	usages     []*entities.AIUsage
+	spent      float64
+	err        error
+	totalCalls int
+	// spentByTenant, when set, is the spend the repository's tenant scope would see
+	spentByTenant map[uint]float64
+}
+
+func (f *fakeAIUsageRepo) Create(ctx context.Context, usage *entities.AIUsage) error {
+	f.usages = append(f.usages, usage)
+	return nil
+}
+
+func (f *fakeAIUsageRepo) TotalCost(ctx context.Context, from time.Time, to time.Time) (float64, error) {
+	f.totalCalls++
+	if tenantID, ok := domain.TenantFromContext(ctx); ok && f.spentByTenant != nil {
+		return f.spentByTenant[tenantID], f.err
+	}
+	return f.spent, f.err
+}
+
+func newTestAIUsageService(repo *fakeAIUsageRepo, budget float64) domain.AIUsageService {
+	cfg := &config.Config{}
+	cfg.AI.MonthlyBudgetUSD = budget
+	cfg.AI.Pricing = map[string]config.ModelPrice{"grok-4-fast": {PromptPerMillion: 0.20, CompletionPerMillion: 0.50}}
+	return NewAIUsageService(repo, cfg)
+}
+
+func TestAIUsageRecordCost(t *testing.T) {
+	repo := &fakeAIUsageRepo{}
+	service := newTestAIUsageService(repo, 0)
+
+	ctx := domain.WithAIUsage(context.Background(), domain.FeatureMatchScoring, domain.EntityTypeProject, 7)
+	service.Record(ctx, &entities.AIUsage{Model: "grok-4-fast", PromptTokens: 2_000_000, CompletionTokens: 1_000_000})
+	service.Record(context.Background(), &entities.AIUsage{Model: "unpriced", PromptTokens: 1000})
+
+	priced, unpriced := repo.usages[0], repo.usages[1]
+	if math.Abs(priced.CostUSD-0.90) > 1e-9 {
+		t.Errorf("cost = %v, want 0.90 for 2M prompt and 1M completion tokens", priced.CostUSD)
+	}
+	if priced.Feature != domain.FeatureMatchScoring || priced.EntityType != domain.EntityTypeProject || priced.EntityID == nil || *priced.EntityID != 7 {
+		t.Errorf("attribution = %s %s %v, want match_scoring project 7", priced.Feature, priced.EntityType, priced.EntityID)
+	}
+	if unpriced.CostUSD != 0 || unpriced.Feature != domain.FeatureUnattributed {
+		t.Errorf("unpriced usage = cost %v feature %s, want 0 unattributed", unpriced.CostUSD, unpriced.Feature)
+	}
+}
+
+func TestAIUsageBudgetExceeded(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no budget", func(t *testing.T) {
+		repo := &fakeAIUsageRepo{spent: 1000}
+		if exceeded, err := newTestAIUsageService(repo, 0).BudgetExceeded(ctx); exceeded || err != nil {
+			t.Errorf("BudgetExceeded() = %v, %v, want false without a budget", exceeded, err)
+		}
+		if repo.totalCalls != 0 {
+			t.Errorf("spend was read %d times without a budget", repo.totalCalls)
+		}
+	})
+
+	for _, tt := range []struct {
+		spent float64
+		want  bool
+	}{{9.99, false}, {10, true}, {12, true}} {
+		repo := &fakeAIUsageRepo{spent: tt.spent}
+		if exceeded, err := newTestAIUsageService(repo, 10).BudgetExceeded(ctx); exceeded != tt.want || err != nil {
+			t.Errorf("BudgetExceeded() with %v of 10 spent = %v, %v, want %v", tt.spent, exceeded, err, tt.want)
+		}
+	}
+
+	t.Run("cached", func(t *testing.T) {
+		repo := &fakeAIUsageRepo{spent: 5}
+		service := newTestAIUsageService(repo, 10)
+		service.BudgetExceeded(ctx)
+		repo.spent = 20
+		if exceeded, _ := service.BudgetExceeded(ctx); exceeded || repo.totalCalls != 1 {
+			t.Errorf("second check = %v after %d reads, want the cached false after 1", exceeded, repo.totalCalls)
+		}
+	})
+
+	t.Run("per tenant", func(t *testing.T) {
+		repo := &fakeAIUsageRepo{spentByTenant: map[uint]float64{1: 12, 2: 3}}
+		service := newTestAIUsageService(repo, 10)
+		if exceeded, _ := service.BudgetExceeded(domain.WithTenant(ctx, 1)); !exceeded {
+			t.Error("tenant 1 with 12 of 10 spent is not over budget")
+		}
+		if exceeded, _ := service.BudgetExceeded(domain.WithTenant(ctx, 2)); exceeded {
+			t.Error("tenant 2 got tenant 1's cached budget position")
+		}
+		if repo.totalCalls != 2 {
+			t.Errorf("spend was read %d times for two tenants, want 2", repo.totalCalls)
+		}
+	})
+
+	t.Run("failed lookup", func(t *testing.T) {
+		repo := &fakeAIUsageRepo{err: errors.New("connection refused")}
+		service := newTestAIUsageService(repo, 10)
+		if _, err := service.BudgetExceeded(ctx); err == nil {
+			t.Error("BudgetExceeded() error = nil, want the lookup error")
+		}
+		// A failure is not cached, so the next check reads the spend again
+		repo.err, repo.spent = nil, 10
+		if exceeded, err := service.BudgetExceeded(ctx); !exceeded || err != nil {
+			t.Errorf("BudgetExceeded() after a failure = %v, %v, want true", exceeded, err)
+		}
+	})
+}
+
+// unusedEmbeddingService fails any call, proving the scorer did not reach the model
+type unusedEmbeddingService struct {
+	domain.EmbeddingService
+}
+
+func TestCandidateScorerBudgetFallback(t *testing.T) {
+	repo := &fakeAIUsageRepo{spent: 10}
+	scorer := NewCandidateScorer(unusedEmbeddingService{}, nil, newTestAIUsageService(repo, 10), nil, nil, &config.Config{})
+
+	project := &entities.Project{
+		Requirements: &entities.ProjectRequirements{Roles: []entities.RoleRequirement{{Role: "Backend", Seats: 1, Skills: []string{"Go"}}}},
+	}
+	candidates := []*domain.SimilarityMatch{
+		{Profile: &entities.EmployeeProfile{UserID: 1, Skills: entities.Skills{"Java"}}, Similarity: 0.5},
+		{Profile: &entities.EmployeeProfile{UserID: 2, Skills: entities.Skills{"Go"}}, Similarity: 0.9, Status: "onBench"},
+	}
+
+	var streamed []models.CandidateScore
+	result, err := scorer.ScoreStream(context.Background(), project, candidates, utils.DefaultScoringRules(), func(score models.CandidateScore) {
+		streamed = append(streamed, score)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.BudgetExceeded || result.FallbackCount != 2 || result.PromptVersion != utils.ScoreSourceFallback {
+		t.Errorf("result = budget exceeded %v, %d fallbacks, version %q, want a full fallback", result.BudgetExceeded, result.FallbackCount, result.PromptVersion)
+	}
+	if len(result.Scores) != 2 || result.Scores[0].CandidateID != 2 || result.Scores[0].Source != utils.ScoreSourceFallback {
+		t.Fatalf("scores = %+v, want candidate 2 ranked first from similarity", result.Scores)
+	}
+	if len(streamed) != 2 {
+		t.Errorf("streamed %d scores, want every fallback score", len(streamed))
+	}
+}
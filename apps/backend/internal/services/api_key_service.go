@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// API key lifetimes in days
+const (
+	defaultAPIKeyDays = 90
+	maxAPIKeyDays     = 365
+)
+
+// maxRotationGraceHours caps how long a rotated key keeps working
+const maxRotationGraceHours = 7 * 24
+
+// lastUsedResolution is how stale a key's last-used time may get, so a busy key is not written on
+// every request
+const lastUsedResolution = time.Minute
+
+// apiKeyPrefixLength is how much of a key is kept in plain text to identify it
+const apiKeyPrefixLength = len(domain.APIKeyPrefix) + 8
+
+// APIKeyService implements domain.APIKeyService
+type APIKeyService struct {
+	apiKeyRepo domain.APIKeyRepository
+	auditRepo  domain.AuditRepository
+	now        func() time.Time
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(apiKeyRepo domain.APIKeyRepository, auditRepo domain.AuditRepository) domain.APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		auditRepo:  auditRepo,
+		now:        time.Now,
+	}
+}
+
+// CreateServiceAccount creates a service account without keys
+func (s *APIKeyService) CreateServiceAccount(ctx context.Context, createdBy uint, request *models.ServiceAccountRequest) (*models.ServiceAccountModel, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" || len(name) > 100 {
+		return nil, fmt.Errorf("%w: name must be 1 to 100 characters", domain.ErrInvalidAPIKeyRequest)
+	}
+	account := &entities.ServiceAccount{
+		Name:        name,
+		Description: strings.TrimSpace(request.Description),
+		CreatedByID: createdBy,
+	}
+	if err := s.apiKeyRepo.CreateServiceAccount(ctx, account); err != nil {
+		if errors.Is(err, domain.ErrServiceAccountExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create service account: %w", err)
+	}
+	s.audit(ctx, createdBy, entities.AuditServiceAccountCreated, fmt.Sprintf("service account %d %q", account.ID, account.Name))
+
+	var model models.ServiceAccountModel
+	model.FromEntity(account, s.now())
+	return &model, nil
+}
+
+// ListServiceAccounts returns every service account with its keys
+func (s *APIKeyService) ListServiceAccounts(ctx context.Context) ([]*models.ServiceAccountModel, error) {
+	accounts, err := s.apiKeyRepo.ListServiceAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	now := s.now()
+	result := make([]*models.ServiceAccountModel, len(accounts))
+	for i, account := range accounts {
+		result[i] = &models.ServiceAccountModel{}
+		result[i].FromEntity(account, now)
+	}
+	return result, nil
+}
+
+// DisableServiceAccount disables a service account and revokes all its keys
+func (s *APIKeyService) DisableServiceAccount(ctx context.Context, disabledBy uint, id uint) error {
+	if err := s.apiKeyRepo.DisableServiceAccount(ctx, id, s.now()); err != nil {
+		return err
+	}
+	s.audit(ctx, disabledBy, entities.AuditServiceAccountDisabled, fmt.Sprintf("service account %d", id))
+	return nil
+}
+
+// CreateKey issues a key for a service account with the requested scopes
+func (s *APIKeyService) CreateKey(ctx context.Context, createdBy uint, serviceAccountID uint, request *models.APIKeyRequest) (*models.CreatedAPIKeyModel, error) {
+	scopes, err := validScopes(request.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	days := request.ExpiresInDays
+	if days <= 0 {
+		days = defaultAPIKeyDays
+	}
+	if days > maxAPIKeyDays {
+		return nil, fmt.Errorf("%w: expires_in_days must be at most %d", domain.ErrInvalidAPIKeyRequest, maxAPIKeyDays)
+	}
+	account, err := s.apiKeyRepo.GetServiceAccount(ctx, serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.DisabledAt != nil {
+		return nil, domain.ErrServiceAccountDisabled
+	}
+
+	now := s.now()
+	key, raw, err := newAPIKey(account.ID, scopes, createdBy, now, now.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apiKeyRepo.CreateKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+	s.audit(ctx, createdBy, entities.AuditAPIKeyCreated, fmt.Sprintf("%s for service account %q with %s", key.Prefix, account.Name, key.Scopes))
+	return createdKey(key, raw, now), nil
+}
+
+// RotateKey issues a key with the old key's scopes and lifetime. The old key keeps working for
+// the grace period, if any, so callers can switch over without downtime.
+func (s *APIKeyService) RotateKey(ctx context.Context, rotatedBy uint, id uint, request *models.RotateAPIKeyRequest) (*models.CreatedAPIKeyModel, error) {
+	if request.GraceHours < 0 || request.GraceHours > maxRotationGraceHours {
+		return nil, fmt.Errorf("%w: grace_hours must be between 0 and %d", domain.ErrInvalidAPIKeyRequest, maxRotationGraceHours)
+	}
+	old, err := s.apiKeyRepo.GetKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if old.RevokedAt != nil || !old.ExpiresAt.After(now) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	lifetime := old.ExpiresAt.Sub(old.CreatedAt)
+	next, raw, err := newAPIKey(old.ServiceAccountID, old.Scopes, rotatedBy, now, now.Add(lifetime))
+	if err != nil {
+		return nil, err
+	}
+	oldExpiresAt := now.Add(time.Duration(request.GraceHours) * time.Hour)
+	if old.ExpiresAt.Before(oldExpiresAt) {
+		oldExpiresAt = old.ExpiresAt
+	}
+	if err := s.apiKeyRepo.RotateKey(ctx, old, next, oldExpiresAt); err != nil {
+		if errors.Is(err, domain.ErrInvalidAPIKey) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to rotate API key: %w", err)
+	}
+	s.audit(ctx, rotatedBy, entities.AuditAPIKeyRotated, fmt.Sprintf("%s replaced by %s, old key valid until %s", old.Prefix, next.Prefix, oldExpiresAt.Format(time.RFC3339)))
+	return createdKey(next, raw, now), nil
+}
+
+// RevokeKey revokes a key immediately
+func (s *APIKeyService) RevokeKey(ctx context.Context, revokedBy uint, id uint) error {
+	key, err := s.apiKeyRepo.GetKey(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.apiKeyRepo.RevokeKey(ctx, id, s.now()); err != nil {
+		return err
+	}
+	s.audit(ctx, revokedBy, entities.AuditAPIKeyRevoked, key.Prefix)
+	return nil
+}
+
+// Authenticate looks a raw key up by its hash and checks it and its service account are active
+func (s *APIKeyService) Authenticate(ctx context.Context, rawKey string) (*entities.APIKey, error) {
+	if !strings.HasPrefix(rawKey, domain.APIKeyPrefix) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+	// The key decides the tenant, so it is looked up across them
+	key, err := s.apiKeyRepo.GetKeyByHash(domain.AllTenants(ctx), hashToken(rawKey))
+	if err != nil {
+		return nil, err
+	}
+	ctx = domain.WithTenant(ctx, key.TenantID)
+	now := s.now()
+	if key.RevokedAt != nil || !key.ExpiresAt.After(now) ||
+		key.ServiceAccount == nil || key.ServiceAccount.DisabledAt != nil {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := s.apiKeyRepo.TouchKey(ctx, key.ID, now); err != nil {
+			log.Printf("Warning: failed to record use of API key %s: %v", key.Prefix, err)
+		}
+		key.LastUsedAt = &now
+	}
+	return key, nil
+}
+
+// audit records an event; the audited change has already happened, so failures are only logged
+func (s *APIKeyService) audit(ctx context.Context, actorID uint, action, detail string) {
+	event := &entities.AuditEvent{Action: action, ActorID: &actorID, Detail: detail}
+	if err := s.auditRepo.Record(ctx, event); err != nil {
+		log.Printf("Warning: failed to record audit event %s: %v", action, err)
+	}
+}
+
+// validScopes checks requested scopes and joins them, without duplicates, for storage
+func validScopes(scopes []models.APIKeyScope) (string, error) {
+	if len(scopes) == 0 {
+		return "", fmt.Errorf("%w: at least one scope is required", domain.ErrInvalidAPIKeyRequest)
+	}
+	seen := map[models.APIKeyScope]bool{}
+	var result []string
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return "", fmt.Errorf("%w: unknown scope %q", domain.ErrInvalidAPIKeyRequest, scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, string(scope))
+		}
+	}
+	return strings.Join(result, " "), nil
+}
+
+// newAPIKey generates a key, returning the entity to store and the raw key to hand out once
+func newAPIKey(serviceAccountID uint, scopes string, createdBy uint, now, expiresAt time.Time) (*entities.APIKey, string, error) {
+	secret, err := randomHex(24)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	raw := domain.APIKeyPrefix + secret
+	key := &entities.APIKey{
+		ServiceAccountID: serviceAccountID,
+		Prefix:           raw[:apiKeyPrefixLength],
+		KeyHash:          hashToken(raw),
+		Scopes:           scopes,
+		ExpiresAt:        expiresAt,
+		CreatedByID:      createdBy,
+		CreatedAt:        now,
+	}
+	return key, raw, nil
+}
+
+// createdKey converts a new key and its secret to the response returned once
+func createdKey(key *entities.APIKey, raw string, now time.Time) *models.CreatedAPIKeyModel {
+	model := &models.CreatedAPIKeyModel{Key: raw}
+	model.APIKeyModel.FromEntity(key, now)
+	return model
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// fakeAPIKeyRepo stores keys of a single service account, rotating and expiring them like the repository
+type fakeAPIKeyRepo struct {
+	domain.APIKeyRepository
+	account *entities.ServiceAccount
+	keys    []*entities.APIKey
+}
+
+func (f *fakeAPIKeyRepo) GetServiceAccount(ctx context.Context, id uint) (*entities.ServiceAccount, error) {
+	if id != f.account.ID {
+		return nil, domain.ErrServiceAccountNotFound
+	}
+	return f.account, nil
+}
+
+func (f *fakeAPIKeyRepo) CreateKey(ctx context.Context, key *entities.APIKey) error {
+	key.ID = uint(len(f.keys) + 1)
+	key.ServiceAccount = f.account
+	f.keys = append(f.keys, key)
+	return nil
+}
+
+func (f *fakeAPIKeyRepo) GetKey(ctx context.Context, id uint) (*entities.APIKey, error) {
+	for _, key := range f.keys {
+		if key.ID == id {
+			return key, nil
+		}
+	}
+	return nil, domain.ErrAPIKeyNotFound
+}
+
+func (f *fakeAPIKeyRepo) GetKeyByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	for _, key := range f.keys {
+		if key.KeyHash == keyHash {
+			return key, nil
+		}
+	}
+	return nil, domain.ErrInvalidAPIKey
+}
+
+func (f *fakeAPIKeyRepo) RotateKey(ctx context.Context, old *entities.APIKey, next *entities.APIKey, oldExpiresAt time.Time) error {
+	if err := f.CreateKey(ctx, next); err != nil {
+		return err
+	}
+	old.ExpiresAt = oldExpiresAt
+	if !oldExpiresAt.After(next.CreatedAt) {
+		old.RevokedAt = &oldExpiresAt
+	}
+	return nil
+}
+
+func (f *fakeAPIKeyRepo) TouchKey(ctx context.Context, id uint, now time.Time) error {
+	return nil
+}
+
+func newTestAPIKeyService() (*fakeAPIKeyRepo, *APIKeyService, *time.Time) {
+	keys := &fakeAPIKeyRepo{account: &entities.ServiceAccount{ID: 3, Name: "bi-pipeline"}}
+	service := NewAPIKeyService(keys, &fakeAuditRepo{}).(*APIKeyService)
+	now := time.Date(2026, time.October, 19, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	return keys, service, &now
+}
+
+func TestAPIKeyRotationGraceWindow(t *testing.T) {
+	ctx := context.Background()
+	_, service, now := newTestAPIKeyService()
+	created, err := service.CreateKey(ctx, 1, 3, &models.APIKeyRequest{Scopes: []models.APIKeyScope{models.ScopeProfilesRead}, ExpiresInDays: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, err := service.RotateKey(ctx, 1, created.ID, &models.RotateAPIKeyRequest{GraceHours: 2})
+	if err != nil {
+		t.Fatalf("RotateKey() error = %v", err)
+	}
+	for _, raw := range []string{created.Key, rotated.Key} {
+		if _, err := service.Authenticate(ctx, raw); err != nil {
+			t.Errorf("Authenticate() during the grace window error = %v", err)
+		}
+	}
+
+	*now = now.Add(2 * time.Hour)
+	if _, err := service.Authenticate(ctx, created.Key); !errors.Is(err, domain.ErrInvalidAPIKey) {
+		t.Errorf("Authenticate() of the old key after the grace window error = %v, want %v", err, domain.ErrInvalidAPIKey)
+	}
+	if _, err := service.Authenticate(ctx, rotated.Key); err != nil {
+		t.Errorf("Authenticate() of the new key error = %v", err)
+	}
+	if _, err := service.RotateKey(ctx, 1, created.ID, &models.RotateAPIKeyRequest{}); !errors.Is(err, domain.ErrInvalidAPIKey) {
+		t.Errorf("RotateKey() of an expired key error = %v, want %v", err, domain.ErrInvalidAPIKey)
+	}
+
+	// The new key has the old one's 30-day lifetime, counted from the rotation
+	*now = now.Add(30*24*time.Hour - 2*time.Hour)
+	if _, err := service.Authenticate(ctx, rotated.Key); !errors.Is(err, domain.ErrInvalidAPIKey) {
+		t.Errorf("Authenticate() of an expired key error = %v, want %v", err, domain.ErrInvalidAPIKey)
+	}
+}
+
+func TestAPIKeyRotationWithoutGrace(t *testing.T) {
+	ctx := context.Background()
+	_, service, _ := newTestAPIKeyService()
+	created, _ := service.CreateKey(ctx, 1, 3, &models.APIKeyRequest{Scopes: []models.APIKeyScope{models.ScopeProfilesRead}})
+
+	if _, err := service.RotateKey(ctx, 1, created.ID, &models.RotateAPIKeyRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.Authenticate(ctx, created.Key); !errors.Is(err, domain.ErrInvalidAPIKey) {
+		t.Errorf("Authenticate() of a key rotated without grace error = %v, want %v", err, domain.ErrInvalidAPIKey)
+	}
+	if _, err := service.RotateKey(ctx, 1, created.ID, &models.RotateAPIKeyRequest{GraceHours: maxRotationGraceHours + 1}); !errors.Is(err, domain.ErrInvalidAPIKeyRequest) {
+		t.Errorf("RotateKey() with a grace beyond the cap error = %v, want %v", err, domain.ErrInvalidAPIKeyRequest)
+	}
+}
+
+func TestAPIKeyDisabledServiceAccount(t *testing.T) {
+	ctx := context.Background()
+	keys, service, now := newTestAPIKeyService()
+	created, _ := service.CreateKey(ctx, 1, 3, &models.APIKeyRequest{Scopes: []models.APIKeyScope{models.ScopeProfilesRead}})
+
+	// Keys of a disabled account stop working even if they were not revoked with it
+	disabledAt := *now
+	keys.account.DisabledAt = &disabledAt
+	if _, err := service.Authenticate(ctx, created.Key); !errors.Is(err, domain.ErrInvalidAPIKey) {
+		t.Errorf("Authenticate() for a disabled service account error = %v, want %v", err, domain.ErrInvalidAPIKey)
+	}
+	if _, err := service.CreateKey(ctx, 1, 3, &models.APIKeyRequest{Scopes: []models.APIKeyScope{models.ScopeProfilesRead}}); !errors.Is(err, domain.ErrServiceAccountDisabled) {
+		t.Errorf("CreateKey() for a disabled service account error = %v, want %v", err, domain.ErrServiceAccountDisabled)
+	}
+}
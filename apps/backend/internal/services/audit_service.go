@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// AuditService implements domain.AuditService
+type AuditService struct {
+	auditRepo domain.AuditRepository
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(auditRepo domain.AuditRepository) domain.AuditService {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+// List returns audit events newest first
+func (s *AuditService) List(ctx context.Context, action, email string, limit int) ([]*models.AuditEventModel, error) {
+	events, err := s.auditRepo.List(ctx, action, strings.ToLower(strings.TrimSpace(email)), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load audit events: %w", err)
+	}
+	result := make([]*models.AuditEventModel, len(events))
+	for i, event := range events {
+		result[i] = &models.AuditEventModel{}
+		result[i].FromEntity(event)
+	}
+	return result, nil
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/internal/prompts"
+	"github.com/talent-fit/backend/internal/redaction"
+	"github.com/talent-fit/backend/internal/utils"
+)
+
+// defaultScoringAttempts is used when AI_SCORING_MAX_ATTEMPTS is unset or invalid
+const defaultScoringAttempts = 3
+
+// CandidateScorer scores retrieved candidates with the chat model.
+// Model output is validated against the candidate list and re-asked a bounded number of times;
+// candidates the model never scores validly are backfilled from vector similarity.
+type CandidateScorer struct {
+	embeddingService domain.EmbeddingService
+	prompts          domain.PromptRegistry
+	budget           domain.AIBudget
+	redactor         *redaction.Redactor
+	maxAttempts      int
+	// model is the deployment's chat model; tenants may override it
+	model   string
+	tenants domain.TenantSettingsProvider
+}
+
+// ScoringResult holds the scores of one scoring run and how they were produced
+type ScoringResult struct {
+	Scores        []models.CandidateScore
+	PromptVersion string
+	Model         string
+	FallbackCount int
+	// BudgetExceeded is set when the monthly AI budget was spent and no model call was made
+	BudgetExceeded bool
+}
+
+// NewCandidateScorer creates a new candidate scorer. budget may be nil, in which case spend is not
+// capped, and tenants may be nil, in which case results name the deployment's model.
+func NewCandidateScorer(embeddingService domain.EmbeddingService, promptRegistry domain.PromptRegistry, budget domain.AIBudget, redactor *redaction.Redactor, tenants domain.TenantSettingsProvider, cfg *config.Config) *CandidateScorer {
+	maxAttempts := cfg.AI.ScoringMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultScoringAttempts
+	}
+	return &CandidateScorer{
+		embeddingService: embeddingService,
+		prompts:          promptRegistry,
+		budget:           budget,
+		redactor:         redactor,
+		maxAttempts:      maxAttempts,
+		model:            cfg.AI.GrokModel,
+		tenants:          tenants,
+	}
+}
+
+// Score returns exactly one score per candidate, ordered from best to worst
+func (s *CandidateScorer) Score(ctx context.Context, project *entities.Project, candidates []*domain.SimilarityMatch, rules utils.ScoringRules) (*ScoringResult, error) {
+	return s.score(ctx, project, candidates, rules, nil)
+}
+
+// ScoreStream scores like Score but streams the model response, passing each candidate's score to
+// onScore as soon as it is accepted. Backfilled scores follow once the model is done, so onScore is
+// called exactly once per candidate, in arrival order; the result holds the final ranking.
+func (s *CandidateScorer) ScoreStream(ctx context.Context, project *entities.Project, candidates []*domain.SimilarityMatch, rules utils.ScoringRules, onScore func(models.CandidateScore)) (*ScoringResult, error) {
+	return s.score(ctx, project, candidates, rules, onScore)
+}
+
+// score runs the validate-and-repair loop; with onScore set the first attempt is streamed
+func (s *CandidateScorer) score(ctx context.Context, project *entities.Project, candidates []*domain.SimilarityMatch, rules utils.ScoringRules, onScore func(models.CandidateScore)) (*ScoringResult, error) {
+	if len(candidates) == 0 {
+		return &ScoringResult{Scores: []models.CandidateScore{}, Model: s.modelFor(ctx)}, nil
+	}
+
+	if s.budgetExceeded(ctx) {
+		log.Printf("Warning: monthly AI budget exceeded, scoring %d candidates from similarity", len(candidates))
+		result := s.fallbackResult(project, candidates, rules)
+		if onScore != nil {
+			for _, score := range result.Scores {
+				onScore(score)
+			}
+		}
+		return result, nil
+	}
+
+	// Minimise candidate data before rendering; names become tokens restored in the reasons
+	session := s.redactor.NewSession(prompts.ScoreCandidates)
+	session.Register(redaction.KindClient, project.ClientName)
+	data := utils.NewMatchingPromptData(project.Summary, candidates, rules)
+	session.MinimiseMatchingData(&data)
+
+	prompt, err := s.prompts.Render(ctx, prompts.ScoreCandidates, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render scoring prompt: %w", err)
+	}
+	accepted := make(map[int]models.CandidateScore, len(candidates))
+	// accept keeps the first valid score per candidate across attempts
+	accept := func(valid []models.CandidateScore) {
+		for _, score := range valid {
+			if _, exists := accepted[score.CandidateID]; exists {
+				continue
+			}
+			score.Reason = session.Restore(score.Reason)
+			accepted[score.CandidateID] = score
+			if onScore != nil {
+				onScore(score)
+			}
+		}
+	}
+
+	sealed := session.Seal(ctx, prompt)
+	request := sealed
+	for attempt := 1; attempt <= s.maxAttempts; attempt++ {
+		var response string
+		if onScore != nil && attempt == 1 {
+			// Entries are validated one at a time as they complete; duplicates are caught by accepted
+			parser := utils.NewScoreStreamParser()
+			response, err = s.embeddingService.StreamMatchingScores(ctx, request, func(chunk string) {
+				for _, score := range parser.Write(chunk) {
+					valid, _, _ := utils.ValidateCandidateScores([]models.CandidateScore{score}, candidates)
+					accept(valid)
+				}
+			})
+		} else {
+			response, err = s.embeddingService.GenerateMatchingScores(ctx, request)
+		}
+		if err != nil {
+			log.Printf("Warning: scoring attempt %d/%d failed: %v", attempt, s.maxAttempts, err)
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+
+		// Only candidates without an accepted score are validated, and asked for again on repair
+		pending := pendingCandidates(candidates, accepted)
+		scores, problems, err := utils.ParseCandidateScores(response)
+		if err != nil {
+			problems = []string{err.Error()}
+		} else {
+			valid, _, invalid := utils.ValidateCandidateScores(scores, pending)
+			accept(valid)
+			problems = append(problems, invalid...)
+		}
+
+		pending = pendingCandidates(candidates, accepted)
+		if len(pending) == 0 {
+			break
+		}
+		log.Printf("Warning: scoring attempt %d/%d returned invalid output: %v", attempt, s.maxAttempts, problems)
+		missing := make([]int, len(pending))
+		for i, candidate := range pending {
+			missing[i] = int(candidate.Profile.UserID)
+		}
+		repair := *sealed
+		repair.User = sealed.User + "\n\n" + utils.GenerateScoringRepairPrompt(response, problems, missing)
+		request = session.Seal(ctx, &repair)
+	}
+
+	results := make([]models.CandidateScore, 0, len(candidates))
+	backfilled := 0
+	for _, candidate := range candidates {
+		if score, ok := accepted[int(candidate.Profile.UserID)]; ok {
+			results = append(results, score)
+			continue
+		}
+		fallback := s.fallbackScore(project, candidate, rules)
+		if onScore != nil {
+			onScore(fallback)
+		}
+		results = append(results, fallback)
+		backfilled++
+	}
+	if backfilled > 0 {
+		log.Printf("Warning: backfilled %d of %d candidate scores from similarity", backfilled, len(candidates))
+	}
+
+	utils.SortCandidateScores(results)
+	return &ScoringResult{
+		Scores:        results,
+		PromptVersion: prompt.Version,
+		Model:         s.modelFor(ctx),
+		FallbackCount: backfilled,
+	}, nil
+}
+
+// pendingCandidates returns the candidates without an accepted score, in their original order
+func pendingCandidates(candidates []*domain.SimilarityMatch, accepted map[int]models.CandidateScore) []*domain.SimilarityMatch {
+	var pending []*domain.SimilarityMatch
+	for _, candidate := range candidates {
+		if _, ok := accepted[int(candidate.Profile.UserID)]; !ok {
+			pending = append(pending, candidate)
+		}
+	}
+	return pending
+}
+
+// modelFor returns the chat model scoring the context's tenant's candidates
+func (s *CandidateScorer) modelFor(ctx context.Context) string {
+	if s.tenants == nil {
+		return s.model
+	}
+	if tenant, err := s.tenants.Settings(ctx); err == nil && tenant != nil && tenant.AIModel != "" {
+		return tenant.AIModel
+	}
+	return s.model
+}
+
+// budgetExceeded checks the monthly AI budget; a failed check does not block scoring
+func (s *CandidateScorer) budgetExceeded(ctx context.Context) bool {
+	if s.budget == nil {
+		return false
+	}
+	exceeded, err := s.budget.BudgetExceeded(ctx)
+	if err != nil {
+		log.Printf("Warning: failed to check AI budget: %v", err)
+		return false
+	}
+	return exceeded
+}
+
+// fallbackResult scores every candidate without the model
+func (s *CandidateScorer) fallbackResult(project *entities.Project, candidates []*domain.SimilarityMatch, rules utils.ScoringRules) *ScoringResult {
+	results := make([]models.CandidateScore, 0, len(candidates))
+	for _, candidate := range candidates {
+		results = append(results, s.fallbackScore(project, candidate, rules))
+	}
+	utils.SortCandidateScores(results)
+	return &ScoringResult{
+		Scores:         results,
+		PromptVersion:  utils.ScoreSourceFallback,
+		FallbackCount:  len(results),
+		BudgetExceeded: true,
+	}
+}
+
+// fallbackScore scores one candidate from similarity, explained against the structured requirements when known
+func (s *CandidateScorer) fallbackScore(project *entities.Project, candidate *domain.SimilarityMatch, rules utils.ScoringRules) models.CandidateScore {
+	fallback := utils.FallbackCandidateScore(candidate, rules)
+	if project.Requirements != nil {
+		fallback.Reason += " " + utils.ExplainSkillMatch(project.Requirements, candidate.Profile.Skills)
+	}
+	return fallback
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/internal/prompts"
+	"github.com/talent-fit/backend/internal/redaction"
+	"github.com/talent-fit/backend/internal/textextract"
+	"github.com/talent-fit/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+// CVImportService implements the domain.CVImportService interface.
+// Text is extracted locally; the chat model proposes profile fields from it, or built-in rules do
+// when the model is unavailable, over budget or not allowed to see CVs. Nothing changes on the
+// profile until the employee confirms the draft.
+type CVImportService struct {
+	draftRepo        domain.ProfileDraftRepository
+	profileRepo      domain.EmployeeProfileRepository
+	userRepo         domain.UserRepository
+	profileService   domain.EmployeeProfileService
+	embeddingService domain.EmbeddingService
+	prompts          domain.PromptRegistry
+	budget           domain.AIBudget
+	redactor         *redaction.Redactor
+	blobStore        domain.BlobStore
+}
+
+// NewCVImportService creates a new CV import service. budget may be nil, in which case spend is not capped,
+// and blobStore may be nil, in which case original files are never kept.
+func NewCVImportService(draftRepo domain.ProfileDraftRepository, profileRepo domain.EmployeeProfileRepository,
+	userRepo domain.UserRepository, profileService domain.EmployeeProfileService, embeddingService domain.EmbeddingService,
+	promptRegistry domain.PromptRegistry, budget domain.AIBudget, redactor *redaction.Redactor, blobStore domain.BlobStore) domain.CVImportService {
+	return &CVImportService{
+		draftRepo:        draftRepo,
+		profileRepo:      profileRepo,
+		userRepo:         userRepo,
+		profileService:   profileService,
+		embeddingService: embeddingService,
+		prompts:          promptRegistry,
+		budget:           budget,
+		redactor:         redactor,
+		blobStore:        blobStore,
+	}
+}
+
+// CreateDraft extracts profile fields from a CV and stores them as a pending draft
+func (s *CVImportService) CreateDraft(ctx context.Context, email string, upload *domain.CVUpload) (*models.ProfileDraftModel, error) {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	document, err := textextract.Extract(upload.Filename, upload.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = domain.WithAIUsage(ctx, domain.FeatureCVImport, domain.EntityTypeProfile, int(user.ID))
+	extracted, source, version := s.extract(ctx, user, document.Text, time.Now())
+
+	draft := &entities.ProfileDraft{
+		UserID:        user.ID,
+		Status:        entities.DraftStatusPending,
+		Source:        source,
+		PromptVersion: version,
+		Filename:      filepath.Base(upload.Filename),
+		Format:        document.Format,
+		Extracted:     *extracted,
+	}
+	if upload.KeepOriginal && s.blobStore != nil {
+		key, err := cvBlobKey(user.ID, upload.Filename)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.blobStore.Put(ctx, key, upload.ContentType, upload.Data); err != nil {
+			return nil, fmt.Errorf("failed to store original CV: %w", err)
+		}
+		draft.BlobKey = key
+	}
+
+	created, err := s.draftRepo.Create(ctx, draft)
+	if err != nil {
+		s.deleteBlob(ctx, draft.BlobKey)
+		return nil, err
+	}
+
+	current, err := s.currentProfile(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	return draftModel(created, current), nil
+}
+
+// GetDraft returns one of the employee's drafts with its changes against the current profile
+func (s *CVImportService) GetDraft(ctx context.Context, email string, draftID uint) (*models.ProfileDraftModel, error) {
+	_, draft, err := s.ownedDraft(ctx, email, draftID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.currentProfile(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	return draftModel(draft, current), nil
+}
+
+// ConfirmDraft applies the selected changes through the profile service, which queues re-embedding
+func (s *CVImportService) ConfirmDraft(ctx context.Context, email string, draftID uint, request *models.ConfirmProfileDraftRequest) (*models.EmployeeProfileModel, error) {
+	user, draft, err := s.ownedDraft(ctx, email, draftID)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status != entities.DraftStatusPending {
+		return nil, domain.ErrProfileDraftClosed
+	}
+	current, err := s.currentProfile(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]bool, len(request.Fields))
+	for _, field := range request.Fields {
+		selected[field] = true
+	}
+	proposed := utils.MergeProfileProposal(current, &draft.Extracted)
+	// Only changed fields are set: the profile update skips zero values, so other fields are left alone
+	profile := &models.EmployeeProfileModel{UserID: user.ID}
+	for _, change := range utils.ProfileDraftChanges(current, &proposed) {
+		if len(selected) > 0 && !selected[change.Field] {
+			continue
+		}
+		switch change.Field {
+		case models.DraftFieldSkills:
+			profile.Skills = proposed.Skills
+		case models.DraftFieldYearsOfExperience:
+			profile.YearsOfExperience = *proposed.YearsOfExperience
+		case models.DraftFieldIndustry:
+			profile.Industry = proposed.Industry
+		case models.DraftFieldType:
+			profile.Type = models.UserType(proposed.Type)
+		}
+	}
+
+	if current == nil {
+		if profile.Type == "" {
+			return nil, domain.ErrProfileTypeRequired
+		}
+		if _, err := s.profileService.CreateProfile(ctx, email, profile); err != nil {
+			return nil, err
+		}
+	} else if _, err := s.profileService.UpdateProfile(ctx, strconv.FormatUint(uint64(user.ID), 10), profile); err != nil {
+		return nil, err
+	}
+
+	if ok, err := s.draftRepo.UpdateStatus(ctx, draft, entities.DraftStatusConfirmed); err != nil {
+		log.Printf("Warning: Failed to mark profile draft %d confirmed: %v", draft.ID, err)
+	} else if !ok {
+		log.Printf("Warning: Profile draft %d was closed while it was being confirmed", draft.ID)
+	}
+	return s.profileService.GetProfileByUserEmail(ctx, email)
+}
+
+// DiscardDraft closes a pending draft and deletes its stored original
+func (s *CVImportService) DiscardDraft(ctx context.Context, email string, draftID uint) error {
+	_, draft, err := s.ownedDraft(ctx, email, draftID)
+	if err != nil {
+		return err
+	}
+	ok, err := s.draftRepo.UpdateStatus(ctx, draft, entities.DraftStatusDiscarded)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrProfileDraftClosed
+	}
+	s.deleteBlob(ctx, draft.BlobKey)
+	return nil
+}
+
+// extract proposes profile fields with the model, falling back to the rules extractor
+func (s *CVImportService) extract(ctx context.Context, user *entities.User, text string, now time.Time) (*entities.ProfileProposal, string, string) {
+	if !s.redactor.Allowed(prompts.ExtractCVProfile, "cv_text") || s.budgetExceeded(ctx) {
+		return utils.ExtractCVProfileRules(text, now), entities.DraftSourceRules, ""
+	}
+
+	proposal, version, err := s.extractWithModel(ctx, user, text, now)
+	if err != nil {
+		log.Printf("Warning: Failed to extract profile from CV with the model, using rules: %v", err)
+		return utils.ExtractCVProfileRules(text, now), entities.DraftSourceRules, ""
+	}
+	return proposal, entities.DraftSourceModel, version
+}
+
+func (s *CVImportService) extractWithModel(ctx context.Context, user *entities.User, text string, now time.Time) (*entities.ProfileProposal, string, error) {
+	session := s.redactor.NewSession(prompts.ExtractCVProfile)
+	data := utils.NewCVProfilePromptData(text, now)
+	session.MinimiseCVData(&data, strings.TrimSpace(user.FirstName+" "+user.LastName), user.FirstName, user.LastName)
+
+	prompt, err := s.prompts.Render(ctx, prompts.ExtractCVProfile, data)
+	if err != nil {
+		return nil, "", err
+	}
+	response, err := s.embeddingService.ExtractProfileFromCV(ctx, session.Seal(ctx, prompt))
+	if err != nil {
+		return nil, "", err
+	}
+	proposal, err := utils.ParseCVProfileResponse(session.Restore(response))
+	if err != nil {
+		return nil, "", err
+	}
+	return proposal, prompt.Version, nil
+}
+
+// ownedDraft loads a draft of the signed-in employee; other employees' drafts are reported as not found
+func (s *CVImportService) ownedDraft(ctx context.Context, email string, draftID uint) (*entities.User, *entities.ProfileDraft, error) {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, nil, err
+	}
+	draft, err := s.draftRepo.GetByID(ctx, draftID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, domain.ErrProfileDraftNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if draft.UserID != user.ID {
+		return nil, nil, domain.ErrProfileDraftNotFound
+	}
+	return user, draft, nil
+}
+
+// currentProfile returns the employee's profile, or nil when they have none yet
+func (s *CVImportService) currentProfile(ctx context.Context, email string) (*entities.EmployeeProfile, error) {
+	profile, err := s.profileRepo.GetByUserEmail(ctx, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return profile, err
+}
+
+// deleteBlob removes a stored original; a failure only leaves an orphaned file behind
+func (s *CVImportService) deleteBlob(ctx context.Context, key string) {
+	if key == "" || s.blobStore == nil {
+		return
+	}
+	if err := s.blobStore.Delete(ctx, key); err != nil {
+		log.Printf("Warning: Failed to delete stored CV %s: %v", key, err)
+	}
+}
+
+// budgetExceeded checks the monthly AI budget; a failed check does not block the model
+func (s *CVImportService) budgetExceeded(ctx context.Context) bool {
+	if s.budget == nil {
+		return false
+	}
+	exceeded, err := s.budget.BudgetExceeded(ctx)
+	if err != nil {
+		log.Printf("Warning: failed to check AI budget: %v", err)
+		return false
+	}
+	return exceeded
+}
+
+// draftModel converts a draft and lists its changes against the current profile
+func draftModel(draft *entities.ProfileDraft, current *entities.EmployeeProfile) *models.ProfileDraftModel {
+	model := &models.ProfileDraftModel{}
+	model.FromEntity(draft)
+	model.ProfileExists = current != nil
+	proposed := utils.MergeProfileProposal(current, &draft.Extracted)
+	model.Changes = utils.ProfileDraftChanges(current, &proposed)
+	return model
+}
+
+// cvBlobKey returns a unique, unguessable key for an employee's CV that keeps the file extension
+func cvBlobKey(userID uint, filename string) (string, error) {
+	random := make([]byte, 12)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate CV key: %w", err)
+	}
+	ext := strings.ToLower(filepath.Ext(filename))
+	if len(ext) > 8 {
+		ext = ""
+	}
+	return fmt.Sprintf("cvs/%d/%s%s", userID, hex.EncodeToString(random), ext), nil
+}
@@ -2,105 +2,156 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"sort"
 	"time"
 
 	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/utils"
 )
 
 // DashboardService implements domain.DashboardService
 type DashboardService struct {
-	projectRepo    domain.ProjectRepository
-	allocationRepo domain.ProjectAllocationRepository
-	profileRepo    domain.EmployeeProfileRepository
+	metricsRepo  domain.DashboardMetricsRepository
+	snapshotRepo domain.MetricsSnapshotRepository
 }
 
 // NewDashboardService creates a new dashboard service
-func NewDashboardService(projectRepo domain.ProjectRepository, allocationRepo domain.ProjectAllocationRepository, profileRepo domain.EmployeeProfileRepository) domain.DashboardService {
+func NewDashboardService(metricsRepo domain.DashboardMetricsRepository, snapshotRepo domain.MetricsSnapshotRepository) domain.DashboardService {
 	return &DashboardService{
-		projectRepo:    projectRepo,
-		allocationRepo: allocationRepo,
-		profileRepo:    profileRepo,
+		metricsRepo:  metricsRepo,
+		snapshotRepo: snapshotRepo,
 	}
 }
 
-// GetManagerDashboardMetrics computes summary metrics used on the manager dashboard
-func (s *DashboardService) GetManagerDashboardMetrics(ctx context.Context) (*domain.ManagerDashboardMetrics, error) {
-	// Available engineers:
-	//  - availability_flag = true OR no active allocations
-	// Bench resources:
-	//  - no allocations at all OR no ACTIVE allocations
-	// Allocated engineers:
-	//  - have an ACTIVE allocation (allocation end_date is nil or in future)
-	// Active projects:
-	//  - project.start_date < today AND project.end_date > today
-	// Rolling off soon:
-	//  - employees with end_date or notice_date within next 60 days
-	//  - OR allocations with end_date within next 30 days
-
-	// Fetch source data
-	profiles, err := s.profileRepo.GetAll(ctx)
-	if err != nil {
-		return nil, err
-	}
-	projects, err := s.projectRepo.GetAll(ctx)
+// GetManagerDashboardMetrics computes summary metrics used on the manager dashboard. Like the
+// snapshots, it only counts employees employed today, leaving out future joiners and leavers.
+func (s *DashboardService) GetManagerDashboardMetrics(ctx context.Context, orgUnitIDs []uint) (*domain.ManagerDashboardMetrics, error) {
+	groups, err := s.metricsRepo.Compute(ctx, entities.MetricsGroupAll, nil, orgUnitIDs)
 	if err != nil {
 		return nil, err
 	}
+	metrics := groups[0].ManagerDashboardMetrics
+	return &metrics, nil
+}
 
-	now := time.Now()
-	soon30 := now.AddDate(0, 0, 30)
-	soon60 := now.AddDate(0, 0, 60)
-
-	// Index allocations by employee for quick checks
-	// We do not have a repository method to fetch all allocations, so we will
-	// derive allocated state from projects' preloaded allocations.
-	// projectRepo.GetAll preloads ProjectAllocations.
-	allocatedEmployeeIDs := make(map[int]bool)
-	rollingOffFromAlloc := 0
-
-	activeProjects := 0
-	for _, p := range projects {
-		if p.StartDate.Before(now) && p.EndDate.After(now) {
-			activeProjects++
+// SnapshotMetrics stores a day's metrics, organisation-wide and per department and geo. Profiles
+// and availability flags are read as they are now, so snapshots of past days only approximate
+// what the dashboard showed on the day.
+func (s *DashboardService) SnapshotMetrics(ctx context.Context, day time.Time) error {
+	date := utils.DateOf(day)
+	var snapshots []*entities.MetricsSnapshot
+	for _, groupBy := range []string{entities.MetricsGroupAll, entities.MetricsGroupDepartment, entities.MetricsGroupGeo} {
+		groups, err := s.metricsRepo.Compute(ctx, groupBy, &date, nil)
+		if err != nil {
+			return err
 		}
-		for _, alloc := range p.ProjectAllocations {
-			isActive := alloc.StartDate.Before(now) && (alloc.EndDate == nil || alloc.EndDate.After(now))
-			if isActive {
-				allocatedEmployeeIDs[int(alloc.EmployeeID)] = true
-			}
-			if alloc.EndDate != nil && alloc.EndDate.After(now) && alloc.EndDate.Before(soon30) {
-				rollingOffFromAlloc++
-			}
+		for _, group := range groups {
+			snapshots = append(snapshots, &entities.MetricsSnapshot{
+				Date:               date,
+				GroupBy:            groupBy,
+				GroupValue:         group.Group,
+				Headcount:          group.Headcount,
+				AvailableEngineers: group.AvailableEngineers,
+				ActiveProjects:     group.ActiveProjects,
+				RollingOffSoon:     group.RollingOffSoon,
+				BenchResources:     group.BenchResources,
+				AllocatedEngineers: group.AllocatedEngineers,
+				UtilisationPct:     group.UtilisationPct,
+			})
 		}
 	}
 
-	allocatedEngineers := len(allocatedEmployeeIDs)
+	if err := s.snapshotRepo.Save(ctx, snapshots); err != nil {
+		return fmt.Errorf("failed to save metrics snapshots: %w", err)
+	}
+	return nil
+}
 
-	// Determine availability/bench/rollingOff from profiles
-	availableEngineers := 0
-	benchResources := 0
-	rollingOffFromProfiles := 0
-	for _, prof := range profiles {
-		_, hasActiveAlloc := allocatedEmployeeIDs[int(prof.UserID)]
-		if prof.AvailabilityFlag || !hasActiveAlloc {
-			availableEngineers++
+// SnapshotToday takes today's snapshot, skipping it when another instance or run already has unless replace is set
+func (s *DashboardService) SnapshotToday(ctx context.Context, replace bool) error {
+	today, err := s.metricsRepo.Today(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read the database date: %w", err)
+	}
+	if !replace {
+		exists, err := s.snapshotRepo.Exists(ctx, today)
+		if err != nil {
+			return fmt.Errorf("failed to check metrics snapshots: %w", err)
 		}
-		if !hasActiveAlloc {
-			benchResources++
+		if exists {
+			return nil
 		}
-		if (prof.EndDate != nil && prof.EndDate.After(now) && prof.EndDate.Before(soon60)) || (prof.NoticeDate != nil && prof.NoticeDate.After(now) && prof.NoticeDate.Before(soon60)) {
-			rollingOffFromProfiles++
+	}
+	return s.SnapshotMetrics(ctx, today)
+}
+
+// GetMetricsTrends returns one series per group with a point for each day a snapshot was taken
+func (s *DashboardService) GetMetricsTrends(ctx context.Context, from, to time.Time, groupBy string) (*domain.MetricsTrends, error) {
+	switch groupBy {
+	case entities.MetricsGroupAll, entities.MetricsGroupDepartment, entities.MetricsGroupGeo:
+	default:
+		return nil, domain.ErrInvalidMetricsGroup
+	}
+
+	snapshots, err := s.snapshotRepo.List(ctx, groupBy, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metrics snapshots: %w", err)
+	}
+
+	series := make(map[string]*domain.MetricsSeries)
+	for _, snapshot := range snapshots {
+		group := series[snapshot.GroupValue]
+		if group == nil {
+			group = &domain.MetricsSeries{Group: snapshot.GroupValue, Points: []domain.MetricsPoint{}}
+			series[snapshot.GroupValue] = group
 		}
+		group.Points = append(group.Points, domain.MetricsPoint{
+			Date:      snapshot.Date.Format("2006-01-02"),
+			Headcount: snapshot.Headcount,
+			ManagerDashboardMetrics: domain.ManagerDashboardMetrics{
+				AvailableEngineers: snapshot.AvailableEngineers,
+				ActiveProjects:     snapshot.ActiveProjects,
+				RollingOffSoon:     snapshot.RollingOffSoon,
+				BenchResources:     snapshot.BenchResources,
+				AllocatedEngineers: snapshot.AllocatedEngineers,
+			},
+			UtilisationPct: snapshot.UtilisationPct,
+		})
 	}
 
-	metrics := &domain.ManagerDashboardMetrics{
-		AvailableEngineers: availableEngineers,
-		ActiveProjects:     activeProjects,
-		RollingOffSoon:     rollingOffFromAlloc + rollingOffFromProfiles,
-		BenchResources:     benchResources,
-		AllocatedEngineers: allocatedEngineers,
+	trends := &domain.MetricsTrends{
+		From:    from.Format("2006-01-02"),
+		To:      to.Format("2006-01-02"),
+		GroupBy: groupBy,
+		Series:  make([]domain.MetricsSeries, 0, len(series)),
+	}
+	for _, group := range series {
+		trends.Series = append(trends.Series, *group)
 	}
-	return metrics, nil
+	sort.Slice(trends.Series, func(i, j int) bool { return trends.Series[i].Group < trends.Series[j].Group })
+	return trends, nil
 }
 
+// RunMetricsSnapshotter takes every tenant's snapshot for today now and then checks again every
+// interval, so new snapshots are taken shortly after midnight in the database's time zone, until
+// ctx is cancelled
+func RunMetricsSnapshotter(ctx context.Context, service domain.DashboardService, tenants domain.TenantService, interval time.Duration) {
+	log.Printf("Metrics snapshotter started")
+	defer log.Printf("Metrics snapshotter stopped")
 
+	for ctx.Err() == nil {
+		err := ForEachTenant(ctx, tenants, func(ctx context.Context) error {
+			return service.SnapshotToday(ctx, false)
+		})
+		if err != nil {
+			log.Printf("Warning: metrics snapshot failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+type fakeDashboardMetricsRepo struct {
+	today  time.Time
+	groups map[string][]*domain.GroupMetrics
+	days   []time.Time
+}
+
+func (f *fakeDashboardMetricsRepo) Today(ctx context.Context) (time.Time, error) {
+	return f.today, nil
+}
+
+func (f *fakeDashboardMetricsRepo) Compute(ctx context.Context, groupBy string, day *time.Time, orgUnitIDs []uint) ([]*domain.GroupMetrics, error) {
+	if day != nil {
+		f.days = append(f.days, *day)
+	}
+	return f.groups[groupBy], nil
+}
+
+type fakeSnapshotRepo struct {
+	domain.MetricsSnapshotRepository
+	saved     []*entities.MetricsSnapshot
+	exists    bool
+	snapshots []*entities.MetricsSnapshot
+}
+
+func (f *fakeSnapshotRepo) Save(ctx context.Context, snapshots []*entities.MetricsSnapshot) error {
+	f.saved = append(f.saved, snapshots...)
+	return nil
+}
+
+func (f *fakeSnapshotRepo) Exists(ctx context.Context, date time.Time) (bool, error) {
+	return f.exists, nil
+}
+
+func (f *fakeSnapshotRepo) List(ctx context.Context, groupBy string, from, to time.Time) ([]*entities.MetricsSnapshot, error) {
+	return f.snapshots, nil
+}
+
+func newFakeDashboardMetrics() *fakeDashboardMetricsRepo {
+	return &fakeDashboardMetricsRepo{
+		today: time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+		groups: map[string][]*domain.GroupMetrics{
+			entities.MetricsGroupAll: {{Headcount: 5, ManagerDashboardMetrics: domain.ManagerDashboardMetrics{BenchResources: 2, AllocatedEngineers: 3}, UtilisationPct: 60}},
+			entities.MetricsGroupDepartment: {
+				{Group: "", Headcount: 1},
+				{Group: "Engineering", Headcount: 4, ManagerDashboardMetrics: domain.ManagerDashboardMetrics{AllocatedEngineers: 3}},
+			},
+			entities.MetricsGroupGeo: {{Group: "India", Headcount: 3}, {Group: "UK", Headcount: 2}},
+		},
+	}
+}
+
+// snapshotKeys describes snapshots as "groupBy/value:headcount"
+func snapshotKeys(snapshots []*entities.MetricsSnapshot) string {
+	keys := make([]string, len(snapshots))
+	for i, snapshot := range snapshots {
+		keys[i] = fmt.Sprintf("%s/%s:%d", snapshot.GroupBy, snapshot.GroupValue, snapshot.Headcount)
+	}
+	return strings.Join(keys, " ")
+}
+
+func TestSnapshotMetricsGroups(t *testing.T) {
+	metrics, snapshots := newFakeDashboardMetrics(), &fakeSnapshotRepo{}
+	service := NewDashboardService(metrics, snapshots)
+
+	day := time.Date(2026, time.October, 18, 23, 30, 0, 0, time.UTC)
+	if err := service.SnapshotMetrics(context.Background(), day); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "all/:5 department/:1 department/Engineering:4 geo/India:3 geo/UK:2"
+	if got := snapshotKeys(snapshots.saved); got != want {
+		t.Errorf("saved %s, want %s", got, want)
+	}
+	for _, snapshot := range snapshots.saved {
+		if !snapshot.Date.Equal(time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("%s/%s snapshot dated %v, want 2026-10-18", snapshot.GroupBy, snapshot.GroupValue, snapshot.Date)
+		}
+	}
+	if all := snapshots.saved[0]; all.BenchResources != 2 || all.AllocatedEngineers != 3 || all.UtilisationPct != 60 {
+		t.Errorf("organisation snapshot = %+v, want the computed metrics", all)
+	}
+	if len(metrics.days) != 3 || !metrics.days[0].Equal(snapshots.saved[0].Date) {
+		t.Errorf("metrics computed for %v, want the snapshot date for each grouping", metrics.days)
+	}
+}
+
+func TestSnapshotToday(t *testing.T) {
+	tests := []struct {
+		name    string
+		exists  bool
+		replace bool
+		want    int
+	}{
+		{"first run of the day", false, false, 5},
+		{"already taken", true, false, 0},
+		{"replace", true, true, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics, snapshots := newFakeDashboardMetrics(), &fakeSnapshotRepo{exists: tt.exists}
+			if err := NewDashboardService(metrics, snapshots).SnapshotToday(context.Background(), tt.replace); err != nil {
+				t.Fatal(err)
+			}
+			if len(snapshots.saved) != tt.want {
+				t.Errorf("saved %d snapshots, want %d", len(snapshots.saved), tt.want)
+			}
+			for _, snapshot := range snapshots.saved {
+				if !snapshot.Date.Equal(metrics.today) {
+					t.Errorf("snapshot dated %v, want the database's today %v", snapshot.Date, metrics.today)
+				}
+			}
+		})
+	}
+}
+
+func TestGetMetricsTrends(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, time.October, d, 0, 0, 0, 0, time.UTC) }
+	// Listed by date, then group, as the repository orders them
+	snapshots := &fakeSnapshotRepo{snapshots: []*entities.MetricsSnapshot{
+		{Date: day(1), GroupBy: entities.MetricsGroupGeo, GroupValue: "India", Headcount: 3},
+		{Date: day(1), GroupBy: entities.MetricsGroupGeo, GroupValue: "UK", Headcount: 2, BenchResources: 1},
+		{Date: day(2), GroupBy: entities.MetricsGroupGeo, GroupValue: "", Headcount: 1},
+		{Date: day(2), GroupBy: entities.MetricsGroupGeo, GroupValue: "UK", Headcount: 4, UtilisationPct: 75},
+	}}
+	service := NewDashboardService(newFakeDashboardMetrics(), snapshots)
+
+	trends, err := service.GetMetricsTrends(context.Background(), day(1), day(2), entities.MetricsGroupGeo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trends.From != "2026-10-01" || trends.To != "2026-10-02" || trends.GroupBy != entities.MetricsGroupGeo {
+		t.Errorf("trends range = %s..%s by %s", trends.From, trends.To, trends.GroupBy)
+	}
+
+	var got []string
+	for _, series := range trends.Series {
+		var points []string
+		for _, point := range series.Points {
+			points = append(points, fmt.Sprintf("%s=%d", point.Date, point.Headcount))
+		}
+		got = append(got, series.Group+"["+strings.Join(points, ",")+"]")
+	}
+	want := "[2026-10-02=1] India[2026-10-01=3] UK[2026-10-01=2,2026-10-02=4]"
+	if strings.Join(got, " ") != want {
+		t.Errorf("series = %s, want %s", strings.Join(got, " "), want)
+	}
+	uk := trends.Series[2].Points
+	if uk[0].BenchResources != 1 || uk[1].UtilisationPct != 75 {
+		t.Errorf("UK points = %+v, want the snapshot metrics", uk)
+	}
+
+	if _, err := service.GetMetricsTrends(context.Background(), day(1), day(2), "team"); !errors.Is(err, domain.ErrInvalidMetricsGroup) {
+		t.Errorf("GetMetricsTrends() by team error = %v, want %v", err, domain.ErrInvalidMetricsGroup)
+	}
+}
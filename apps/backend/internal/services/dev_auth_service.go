@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/pkg/middleware"
+	"gorm.io/gorm"
+)
+
+// DevAuthService implements domain.DevAuthService
+type DevAuthService struct {
+	userRepo     domain.UserRepository
+	tokenService domain.TokenService
+	auditRepo    domain.AuditRepository
+	cfg          *config.Config
+}
+
+// NewDevAuthService creates a new development auth service
+func NewDevAuthService(userRepo domain.UserRepository, tokenService domain.TokenService, auditRepo domain.AuditRepository, cfg *config.Config) domain.DevAuthService {
+	return &DevAuthService{
+		userRepo:     userRepo,
+		tokenService: tokenService,
+		auditRepo:    auditRepo,
+		cfg:          cfg,
+	}
+}
+
+// Login starts a session for an existing user, picked by email or else by role
+func (s *DevAuthService) Login(ctx context.Context, email, role string) (*domain.AuthResponse, error) {
+	var user *entities.User
+	var err error
+	switch {
+	case strings.TrimSpace(email) != "":
+		user, err = s.userRepo.GetByEmail(domain.AllTenants(ctx), strings.ToLower(strings.TrimSpace(email)))
+	case models.UserRole(role).IsValid():
+		user, err = s.userRepo.GetFirstByRole(domain.AllTenants(ctx), role)
+	default:
+		return nil, errors.New("email or a role of Employee, Manager or Admin is required")
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	ctx = domain.WithTenant(ctx, user.TenantID)
+
+	tokens, err := s.tokenService.Issue(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	s.audit(ctx, &entities.AuditEvent{
+		Action: entities.AuditDevLogin,
+		UserID: &user.ID,
+		Email:  user.Email,
+		Detail: "signed in as " + user.Role,
+	})
+	return &domain.AuthResponse{
+		Token:        tokens.AccessToken,
+		Name:         strings.TrimSpace(user.FirstName + " " + user.LastName),
+		Email:        user.Email,
+		UserID:       user.ID,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+	}, nil
+}
+
+// Impersonate issues an access token for a user with an act claim naming the admin. It has no
+// refresh token, so acting as someone ends when the token expires.
+func (s *DevAuthService) Impersonate(ctx context.Context, actorID, userID uint) (*domain.TokenPair, error) {
+	if actorID == userID {
+		return nil, domain.ErrSelfImpersonation
+	}
+	actor, err := s.userRepo.GetByID(ctx, strconv.FormatUint(uint64(actorID), 10))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load current user: %w", err)
+	}
+	user, err := s.userRepo.GetByID(ctx, strconv.FormatUint(uint64(userID), 10))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	token, expiresAt, err := middleware.GenerateImpersonationToken(s.cfg, user, actor)
+	if err != nil {
+		return nil, errors.New("failed to generate jwt")
+	}
+	s.audit(ctx, &entities.AuditEvent{
+		Action:  entities.AuditImpersonationStarted,
+		ActorID: &actor.ID,
+		UserID:  &user.ID,
+		Email:   user.Email,
+		Detail:  fmt.Sprintf("%s acting as %s until %s", actor.Email, user.Email, expiresAt.UTC().Format("15:04 MST")),
+	})
+	return &domain.TokenPair{AccessToken: token, ExpiresAt: expiresAt}, nil
+}
+
+// audit records an event; the audited change has already happened, so failures are only logged
+func (s *DevAuthService) audit(ctx context.Context, event *entities.AuditEvent) {
+	if err := s.auditRepo.Record(ctx, event); err != nil {
+		log.Printf("Warning: failed to record audit event %s for %s: %v", event.Action, event.Email, err)
+	}
+}
@@ -7,29 +7,27 @@ import (
   "time"
 
   "github.com/talent-fit/backend/internal/domain"
+  "github.com/talent-fit/backend/internal/entities"
   "github.com/talent-fit/backend/internal/models"
-  "github.com/talent-fit/backend/internal/utils"
 )
 
 // EmployeeProfileService implements the domain.EmployeeProfileService interface
 type EmployeeProfileService struct {
-  profileRepo      domain.EmployeeProfileRepository
-  embeddingService domain.EmbeddingService
-  embeddingUtils   *utils.EmbeddingEntityUtils
-  orchestrator     domain.NotificationOrchestrator
-  userRepo         domain.UserRepository
+  profileRepo  domain.EmployeeProfileRepository
+  orchestrator domain.NotificationOrchestrator
+  userRepo     domain.UserRepository
+  enrichment   domain.EnrichmentQueue
 }
 
 // NewEmployeeProfileService creates a new employee profile service
 func NewEmployeeProfileService(profileRepo domain.EmployeeProfileRepository,
-  embeddingService domain.EmbeddingService, orchestrator domain.NotificationOrchestrator,
-  userRepo domain.UserRepository) domain.EmployeeProfileService {
+  orchestrator domain.NotificationOrchestrator, userRepo domain.UserRepository,
+  enrichment domain.EnrichmentQueue) domain.EmployeeProfileService {
   return &EmployeeProfileService{
-    profileRepo:      profileRepo,
-    embeddingService: embeddingService,
-    embeddingUtils:   utils.NewEmbeddingEntityUtils(embeddingService),
-    orchestrator:     orchestrator,
-    userRepo:         userRepo,
+    profileRepo:  profileRepo,
+    orchestrator: orchestrator,
+    userRepo:     userRepo,
+    enrichment:   enrichment,
   }
 }
 
@@ -43,8 +41,8 @@ func (s *EmployeeProfileService) GetAllProfiles(ctx context.Context) ([]*models.
 }
 
 // SearchProfiles retrieves profiles by filters
-func (s *EmployeeProfileService) SearchProfiles(ctx context.Context, skills []string, geos []string, availableOnly bool) ([]*models.EmployeeProfileModel, error) {
-  entities, err := s.profileRepo.GetFiltered(ctx, skills, geos, availableOnly)
+func (s *EmployeeProfileService) SearchProfiles(ctx context.Context, skills []string, geos []string, availableOnly bool, orgUnitIDs []uint) ([]*models.EmployeeProfileModel, error) {
+  entities, err := s.profileRepo.GetFiltered(ctx, skills, geos, availableOnly, orgUnitIDs)
   if err != nil {
     return nil, err
   }
@@ -87,17 +85,13 @@ func (s *EmployeeProfileService) CreateProfile(ctx context.Context, email string
     }
   }
 
-  // Generate embedding before creating
-  err = s.embeddingUtils.GenerateEmployeeProfileEmbedding(ctx, entityProfile)
-  if err != nil {
-    // Log the error but don't fail the creation - embedding generation is optional
-    log.Printf("Warning: Failed to generate embedding for profile: %v", err)
-  }
-
+  // The embedding is generated by a queued enrichment job after the profile is saved
+  entityProfile.EnrichmentStatus = entities.EnrichmentStatusPending
   entity, err := s.profileRepo.Create(ctx, entityProfile)
   if err != nil {
     return nil, err
   }
+  s.enqueueEnrichment(ctx, entity.UserID)
   entity.User = *user
   var model models.EmployeeProfileModel
   model.FromEntity(entity)
@@ -127,17 +121,13 @@ func (s *EmployeeProfileService) UpdateProfile(ctx context.Context, userID strin
   // Load existing to detect rolloff trigger
   existing, _ := s.profileRepo.GetByUserID(ctx, userID)
 
-  // Generate embedding before updating (force update to reflect changes)
-  err = s.embeddingUtils.UpdateEmployeeProfileEmbedding(ctx, entityProfile, true)
-  if err != nil {
-    // Log the error but don't fail the update - embedding generation is optional
-    log.Printf("Warning: Failed to generate embedding for profile update: %v", err)
-  }
-
+  // Re-embed in the background to reflect changes; the current embedding stays until then
+  entityProfile.EnrichmentStatus = entities.EnrichmentStatusPending
   entity, err := s.profileRepo.Update(ctx, userID, entityProfile)
   if err != nil {
     return nil, err
   }
+  s.enqueueEnrichment(ctx, user.ID)
   entity.User = *user
   var model models.EmployeeProfileModel
   model.FromEntity(entity)
@@ -152,19 +142,24 @@ func (s *EmployeeProfileService) UpdateProfile(ctx context.Context, userID strin
       Subject: subject,
       Body:    body,
       Metadata: map[string]string{
-        "employeeId": userID,
-        "endDate":    entity.EndDate.Format(time.RFC3339),
+        domain.MetadataEmployeeID: userID,
+        "endDate":                 entity.EndDate.Format(time.RFC3339),
       },
-      // Manager resolution unknown -> send to default channel only
-      Recipients: []domain.Recipient{{}},
     }
-    // In-app still needs a user to persist against; use the manager-less pathway by logging only
-    // As minimal approach, post only to Slack default channel (recipient UserID 0)
+    // Recipients are resolved from the reporting line: the line manager and project leads
     _ = s.orchestrator.Dispatch(ctx, msg)
   }
   return &model, nil
 }
 
+// enqueueEnrichment queues embedding for a saved profile. A failure is logged rather than returned
+// because the profile itself was saved; the worker's sweep picks up profiles left pending.
+func (s *EmployeeProfileService) enqueueEnrichment(ctx context.Context, userID uint) {
+  if err := s.enrichment.EnqueueProfile(ctx, userID); err != nil {
+    log.Printf("Warning: Failed to queue enrichment for profile %d: %v", userID, err)
+  }
+}
+
 // GetAvailableEmployees retrieves available employees
 func (s *EmployeeProfileService) GetAvailableEmployees(ctx context.Context) ([]*models.EmployeeProfileModel, error) {
   // TODO: Implement business logic for getting available employees
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/internal/redaction"
+	"github.com/talent-fit/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+// maxRetryDelay caps the exponential backoff between attempts
+const maxRetryDelay = time.Hour
+
+// EnrichmentService implements the domain.EnrichmentService interface.
+// Summaries and embeddings are generated by queued jobs instead of inside the request,
+// so a provider outage delays enrichment rather than silently dropping it.
+type EnrichmentService struct {
+	jobRepo          domain.EnrichmentJobRepository
+	projectRepo      domain.ProjectRepository
+	profileRepo      domain.EmployeeProfileRepository
+	embeddingService domain.EmbeddingService
+	embeddingUtils   *utils.EmbeddingEntityUtils
+	prompts          domain.PromptRegistry
+	redactor         *redaction.Redactor
+	maxAttempts      int
+	retryBase        time.Duration
+	lockTimeout      time.Duration
+}
+
+// NewEnrichmentService creates a new enrichment service
+func NewEnrichmentService(
+	jobRepo domain.EnrichmentJobRepository,
+	projectRepo domain.ProjectRepository,
+	profileRepo domain.EmployeeProfileRepository,
+	embeddingService domain.EmbeddingService,
+	promptRegistry domain.PromptRegistry,
+	redactor *redaction.Redactor,
+	cfg *config.Config,
+) domain.EnrichmentService {
+	return &EnrichmentService{
+		jobRepo:          jobRepo,
+		projectRepo:      projectRepo,
+		profileRepo:      profileRepo,
+		embeddingService: embeddingService,
+		embeddingUtils:   utils.NewEmbeddingEntityUtils(embeddingService),
+		prompts:          promptRegistry,
+		redactor:         redactor,
+		maxAttempts:      cfg.Enrichment.MaxAttempts,
+		retryBase:        time.Duration(cfg.Enrichment.RetryBaseSeconds) * time.Second,
+		lockTimeout:      time.Duration(cfg.Enrichment.LockTimeoutSeconds) * time.Second,
+	}
+}
+
+// EnqueueProject queues a project for summarisation and embedding, or embedding only
+func (s *EnrichmentService) EnqueueProject(ctx context.Context, projectID int, summarize bool) error {
+	jobType := entities.JobTypeEmbedProject
+	if summarize {
+		jobType = entities.JobTypeSummarizeProject
+	}
+	return s.enqueue(ctx, jobType, domain.EntityTypeProject, projectID)
+}
+
+// EnqueueProfile queues an employee profile for embedding
+func (s *EnrichmentService) EnqueueProfile(ctx context.Context, userID uint) error {
+	return s.enqueue(ctx, entities.JobTypeEmbedProfile, domain.EntityTypeProfile, int(userID))
+}
+
+// ProcessNext claims one due job and runs it. Failures are retried with exponential backoff;
+// once attempts are exhausted the job and its record are marked failed.
+func (s *EnrichmentService) ProcessNext(ctx context.Context, workerID string) (bool, error) {
+	job, err := s.jobRepo.Claim(ctx, workerID, time.Now().Add(-s.lockTimeout))
+	if err != nil {
+		return false, fmt.Errorf("failed to claim enrichment job: %w", err)
+	}
+	if job == nil {
+		return false, nil
+	}
+	// Workers claim jobs of every tenant and handle each in its own
+	ctx = domain.WithTenant(ctx, job.TenantID)
+
+	runErr := s.run(ctx, job)
+	if runErr == nil {
+		if err := s.jobRepo.MarkSucceeded(ctx, job.ID); err != nil {
+			return true, fmt.Errorf("failed to complete enrichment job %d: %w", job.ID, err)
+		}
+		return true, nil
+	}
+
+	if job.Attempts >= job.MaxAttempts {
+		log.Printf("Warning: Enrichment job %d (%s %s %d) failed permanently after %d attempts: %v",
+			job.ID, job.JobType, job.EntityType, job.EntityID, job.Attempts, runErr)
+		s.failRecord(ctx, job)
+		if err := s.jobRepo.MarkFailed(ctx, job.ID, runErr.Error(), nil); err != nil {
+			return true, fmt.Errorf("failed to fail enrichment job %d: %w", job.ID, err)
+		}
+		return true, nil
+	}
+
+	retryAt := time.Now().Add(s.retryDelay(job.Attempts))
+	log.Printf("Warning: Enrichment job %d (%s %s %d) attempt %d failed, retrying at %s: %v",
+		job.ID, job.JobType, job.EntityType, job.EntityID, job.Attempts, retryAt.Format(time.RFC3339), runErr)
+	if err := s.jobRepo.MarkFailed(ctx, job.ID, runErr.Error(), &retryAt); err != nil {
+		return true, fmt.Errorf("failed to reschedule enrichment job %d: %w", job.ID, err)
+	}
+	return true, nil
+}
+
+// RequeueMissing queues records that are pending enrichment but have no job
+func (s *EnrichmentService) RequeueMissing(ctx context.Context) (int64, error) {
+	queued, err := s.jobRepo.EnqueueMissing(ctx, s.maxAttempts)
+	if err != nil {
+		return queued, fmt.Errorf("failed to requeue records pending enrichment: %w", err)
+	}
+	return queued, nil
+}
+
+// ListJobs lists recent jobs, optionally by status, with the number of jobs in each status
+func (s *EnrichmentService) ListJobs(ctx context.Context, status string, limit int) (*models.EnrichmentJobList, error) {
+	jobs, err := s.jobRepo.List(ctx, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list enrichment jobs: %w", err)
+	}
+	counts, err := s.jobRepo.CountByStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count enrichment jobs: %w", err)
+	}
+
+	list := &models.EnrichmentJobList{Jobs: make([]*models.EnrichmentJobModel, 0, len(jobs)), Counts: counts}
+	for _, job := range jobs {
+		model := &models.EnrichmentJobModel{}
+		model.FromEntity(job)
+		list.Jobs = append(list.Jobs, model)
+	}
+	return list, nil
+}
+
+// RetryJob requeues a failed job and marks its record pending again
+func (s *EnrichmentService) RetryJob(ctx context.Context, id int) (*models.EnrichmentJobModel, error) {
+	job, err := s.jobRepo.Retry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.setEnrichmentStatus(ctx, job, entities.EnrichmentStatusPending)
+
+	model := &models.EnrichmentJobModel{}
+	model.FromEntity(job)
+	return model, nil
+}
+
+func (s *EnrichmentService) enqueue(ctx context.Context, jobType string, entityType string, entityID int) error {
+	err := s.jobRepo.Enqueue(ctx, &entities.EnrichmentJob{
+		JobType:     jobType,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		MaxAttempts: s.maxAttempts,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enqueue %s job: %w", jobType, err)
+	}
+	return nil
+}
+
+func (s *EnrichmentService) run(ctx context.Context, job *entities.EnrichmentJob) error {
+	var err error
+	switch job.JobType {
+	case entities.JobTypeSummarizeProject:
+		err = s.summarizeProject(ctx, job.EntityID)
+	case entities.JobTypeEmbedProject:
+		err = s.embedProject(ctx, job.EntityID)
+	case entities.JobTypeEmbedProfile:
+		err = s.embedProfile(ctx, job.EntityID)
+	default:
+		return fmt.Errorf("unknown enrichment job type %q", job.JobType)
+	}
+
+	// The record was deleted after the job was queued; there is nothing left to enrich
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
+// summarizeProject regenerates the summary and requirements, then queues the embedding.
+// The embedding is queued even if summarisation keeps failing, so the project still becomes matchable.
+func (s *EnrichmentService) summarizeProject(ctx context.Context, projectID int) error {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if project.Description == "" {
+		return s.EnqueueProject(ctx, projectID, false)
+	}
+
+	if err := SummarizeProject(ctx, s.prompts, s.embeddingService, s.redactor, project); err != nil {
+		return fmt.Errorf("failed to generate project summary: %w", err)
+	}
+	if err := s.projectRepo.UpdateEnrichment(ctx, projectID, project, "summary", "summary_prompt_version", "requirements"); err != nil {
+		return fmt.Errorf("failed to save project summary: %w", err)
+	}
+	return s.EnqueueProject(ctx, projectID, false)
+}
+
+func (s *EnrichmentService) embedProject(ctx context.Context, projectID int) error {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if err := EmbedProject(ctx, s.embeddingUtils, s.redactor, project); err != nil {
+		return err
+	}
+	project.EnrichmentStatus = entities.EnrichmentStatusReady
+	if err := s.projectRepo.UpdateEnrichment(ctx, projectID, project, "embedding", "enrichment_status"); err != nil {
+		return fmt.Errorf("failed to save project embedding: %w", err)
+	}
+	return nil
+}
+
+func (s *EnrichmentService) embedProfile(ctx context.Context, userID int) error {
+	id := strconv.Itoa(userID)
+	profile, err := s.profileRepo.GetByUserID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.embeddingUtils.GenerateEmployeeProfileEmbedding(ctx, profile); err != nil {
+		return err
+	}
+	profile.EnrichmentStatus = entities.EnrichmentStatusReady
+	if err := s.profileRepo.UpdateEnrichment(ctx, id, profile, "embedding", "enrichment_status"); err != nil {
+		return fmt.Errorf("failed to save profile embedding: %w", err)
+	}
+	return nil
+}
+
+// failRecord marks the record of a permanently failed job as failed. A project whose summary
+// cannot be generated is still embedded from its description so it stays matchable.
+func (s *EnrichmentService) failRecord(ctx context.Context, job *entities.EnrichmentJob) {
+	if job.JobType == entities.JobTypeSummarizeProject {
+		if err := s.EnqueueProject(ctx, job.EntityID, false); err != nil {
+			log.Printf("Warning: Failed to queue embedding for project %d: %v", job.EntityID, err)
+			s.setEnrichmentStatus(ctx, job, entities.EnrichmentStatusFailed)
+		}
+		return
+	}
+	s.setEnrichmentStatus(ctx, job, entities.EnrichmentStatusFailed)
+}
+
+// setEnrichmentStatus updates the status of the record a job enriches
+func (s *EnrichmentService) setEnrichmentStatus(ctx context.Context, job *entities.EnrichmentJob, status string) {
+	var err error
+	switch job.EntityType {
+	case domain.EntityTypeProject:
+		err = s.projectRepo.UpdateEnrichment(ctx, job.EntityID, &entities.Project{EnrichmentStatus: status}, "enrichment_status")
+	case domain.EntityTypeProfile:
+		err = s.profileRepo.UpdateEnrichment(ctx, strconv.Itoa(job.EntityID), &entities.EmployeeProfile{EnrichmentStatus: status}, "enrichment_status")
+	}
+	if err != nil {
+		log.Printf("Warning: Failed to set enrichment status of %s %d to %s: %v", job.EntityType, job.EntityID, status, err)
+	}
+}
+
+// retryDelay doubles the base delay with every attempt, up to maxRetryDelay
+func (s *EnrichmentService) retryDelay(attempts int) time.Duration {
+	delay := s.retryBase
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// fakeJobQueue holds a single job and claims it like the repository: due pending jobs only
+type fakeJobQueue struct {
+	domain.EnrichmentJobRepository
+	job     *entities.EnrichmentJob
+	retryAt *time.Time
+}
+
+func (f *fakeJobQueue) Claim(ctx context.Context, workerID string, staleBefore time.Time) (*entities.EnrichmentJob, error) {
+	if f.job.Status != entities.JobStatusPending {
+		return nil, nil
+	}
+	f.job.Status = entities.JobStatusRunning
+	f.job.Attempts++
+	claimed := *f.job
+	return &claimed, nil
+}
+
+func (f *fakeJobQueue) MarkSucceeded(ctx context.Context, id int) error {
+	f.job.Status = entities.JobStatusSucceeded
+	return nil
+}
+
+func (f *fakeJobQueue) MarkFailed(ctx context.Context, id int, lastError string, retryAt *time.Time) error {
+	f.job.Status, f.job.LastError, f.retryAt = entities.JobStatusFailed, lastError, retryAt
+	if retryAt != nil {
+		f.job.Status = entities.JobStatusPending
+	}
+	return nil
+}
+
+type fakeEnrichmentProfileRepo struct {
+	domain.EmployeeProfileRepository
+	profile *entities.EmployeeProfile
+}
+
+func (f *fakeEnrichmentProfileRepo) GetByUserID(ctx context.Context, userID string) (*entities.EmployeeProfile, error) {
+	if strconv.Itoa(int(f.profile.UserID)) != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return f.profile, nil
+}
+
+func (f *fakeEnrichmentProfileRepo) UpdateEnrichment(ctx context.Context, userID string, profile *entities.EmployeeProfile, columns ...string) error {
+	f.profile.EnrichmentStatus = profile.EnrichmentStatus
+	return nil
+}
+
+// failingEmbeddingService fails every embedding, like a provider outage
+type failingEmbeddingService struct {
+	domain.EmbeddingService
+	calls int
+}
+
+func (f *failingEmbeddingService) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	f.calls++
+	return nil, errors.New("provider unavailable")
+}
+
+func newTestEnrichmentService(jobs domain.EnrichmentJobRepository, profiles domain.EmployeeProfileRepository, embeddings domain.EmbeddingService) *EnrichmentService {
+	cfg := &config.Config{}
+	cfg.Enrichment.MaxAttempts = 3
+	cfg.Enrichment.RetryBaseSeconds = 30
+	cfg.Enrichment.LockTimeoutSeconds = 300
+	return NewEnrichmentService(jobs, nil, profiles, embeddings, nil, nil, cfg).(*EnrichmentService)
+}
+
+func TestEnrichmentRetryDelay(t *testing.T) {
+	service := newTestEnrichmentService(nil, nil, nil)
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{7, 32 * time.Minute},
+		{8, time.Hour},
+		{50, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := service.retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestEnrichmentJobRetriesThenFails(t *testing.T) {
+	ctx := context.Background()
+	queue := &fakeJobQueue{job: &entities.EnrichmentJob{
+		ID: 1, TenantID: 2, JobType: entities.JobTypeEmbedProfile, EntityType: domain.EntityTypeProfile, EntityID: 7,
+		Status: entities.JobStatusPending, MaxAttempts: 3,
+	}}
+	profiles := &fakeEnrichmentProfileRepo{profile: &entities.EmployeeProfile{
+		UserID: 7, Type: "Backend", Skills: entities.Skills{"Go"}, EnrichmentStatus: entities.EnrichmentStatusPending,
+	}}
+	embeddings := &failingEmbeddingService{}
+	service := newTestEnrichmentService(queue, profiles, embeddings)
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		started := time.Now()
+		if processed, err := service.ProcessNext(ctx, "worker"); !processed || err != nil {
+			t.Fatalf("attempt %d: ProcessNext() = %v, %v", attempt, processed, err)
+		}
+		if queue.job.Status != entities.JobStatusPending || queue.retryAt == nil {
+			t.Fatalf("attempt %d left the job %s, want it pending for a retry", attempt, queue.job.Status)
+		}
+		if wait := queue.retryAt.Sub(started); wait < service.retryDelay(attempt) {
+			t.Errorf("attempt %d retries after %v, want at least %v", attempt, wait, service.retryDelay(attempt))
+		}
+		if profiles.profile.EnrichmentStatus != entities.EnrichmentStatusPending {
+			t.Errorf("attempt %d set the profile %s, want it pending until the last attempt", attempt, profiles.profile.EnrichmentStatus)
+		}
+	}
+
+	if processed, err := service.ProcessNext(ctx, "worker"); !processed || err != nil {
+		t.Fatalf("last attempt: ProcessNext() = %v, %v", processed, err)
+	}
+	if queue.job.Status != entities.JobStatusFailed || queue.retryAt != nil || queue.job.LastError != "failed to generate profile embedding: provider unavailable" {
+		t.Errorf("job after the last attempt = %s %q, want failed without a retry", queue.job.Status, queue.job.LastError)
+	}
+	if profiles.profile.EnrichmentStatus != entities.EnrichmentStatusFailed {
+		t.Errorf("profile enrichment status = %s, want %s", profiles.profile.EnrichmentStatus, entities.EnrichmentStatusFailed)
+	}
+	if embeddings.calls != 3 {
+		t.Errorf("embedding was attempted %d times, want 3", embeddings.calls)
+	}
+
+	if processed, _ := service.ProcessNext(ctx, "worker"); processed {
+		t.Error("ProcessNext() claimed a permanently failed job")
+	}
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+)
+
+// sweepInterval is how often a worker requeues records left pending without a job
+const sweepInterval = 10 * time.Minute
+
+// RunEnrichmentWorker processes enrichment jobs until ctx is cancelled, polling every
+// pollInterval while the queue is empty. A job in progress is finished before returning.
+func RunEnrichmentWorker(ctx context.Context, service domain.EnrichmentService, workerID string, pollInterval time.Duration) {
+	log.Printf("Enrichment worker %s started", workerID)
+	defer log.Printf("Enrichment worker %s stopped", workerID)
+	// Workers claim every tenant's jobs; each job then runs in its own tenant
+	ctx = domain.AllTenants(ctx)
+
+	var lastSweep time.Time
+	for ctx.Err() == nil {
+		if time.Since(lastSweep) >= sweepInterval {
+			requeueMissing(ctx, service, workerID)
+			lastSweep = time.Now()
+		}
+
+		processed, err := service.ProcessNext(context.WithoutCancel(ctx), workerID)
+		if err != nil {
+			log.Printf("Warning: Enrichment worker %s: %v", workerID, err)
+		}
+		if processed && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// DrainEnrichmentQueue processes due jobs until none are left or ctx is done, and returns how many ran.
+// It suits deployments without long-running processes, such as a scheduled Lambda.
+func DrainEnrichmentQueue(ctx context.Context, service domain.EnrichmentService, workerID string) (int, error) {
+	ctx = domain.AllTenants(ctx)
+	requeueMissing(ctx, service, workerID)
+
+	count := 0
+	for ctx.Err() == nil {
+		processed, err := service.ProcessNext(ctx, workerID)
+		if err != nil {
+			return count, err
+		}
+		if !processed {
+			break
+		}
+		count++
+	}
+	return count, nil
+}
+
+func requeueMissing(ctx context.Context, service domain.EnrichmentService, workerID string) {
+	queued, err := service.RequeueMissing(ctx)
+	if err != nil {
+		log.Printf("Warning: Enrichment worker %s: %v", workerID, err)
+		return
+	}
+	if queued > 0 {
+		log.Printf("Enrichment worker %s queued %d records left pending without a job", workerID, queued)
+	}
+}
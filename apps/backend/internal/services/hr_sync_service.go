@@ -0,0 +1,418 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// HRSyncService implements the domain.HRSyncService interface.
+// The HR system is the source of truth for who works here and their joining, notice and end dates.
+// Profile changes go through the profile service, so a new end date raises the usual roll-off alert.
+// What cannot be applied safely, such as an HR ID already linked to someone else, is logged as a conflict.
+type HRSyncService struct {
+	syncRepo       domain.HRSyncRepository
+	userRepo       domain.UserRepository
+	profileRepo    domain.EmployeeProfileRepository
+	profileService domain.EmployeeProfileService
+	connectors     []domain.HRConnector
+	// tenantID is the tenant the HR system's employees belong to
+	tenantID uint
+	now      func() time.Time
+}
+
+// NewHRSyncService creates a new HR sync service for a tenant, polling the given pull connectors
+func NewHRSyncService(syncRepo domain.HRSyncRepository, userRepo domain.UserRepository, profileRepo domain.EmployeeProfileRepository,
+	profileService domain.EmployeeProfileService, tenantID uint, connectors ...domain.HRConnector) domain.HRSyncService {
+	return &HRSyncService{
+		syncRepo:       syncRepo,
+		userRepo:       userRepo,
+		profileRepo:    profileRepo,
+		profileService: profileService,
+		connectors:     connectors,
+		tenantID:       tenantID,
+		now:            time.Now,
+	}
+}
+
+// Sync applies a batch to the service's tenant and logs the run. A redelivered batch returns the
+// earlier run without applying it again.
+func (s *HRSyncService) Sync(ctx context.Context, batch *domain.HRBatch) (*models.HRSyncRunModel, error) {
+	ctx = domain.WithTenant(ctx, s.tenantID)
+	if batch.Reference != "" {
+		existing, err := s.syncRepo.GetByReference(ctx, batch.Source, batch.Reference)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return hrSyncRunModel(existing), nil
+		}
+	}
+
+	run := &entities.HRSyncRun{
+		Source:    batch.Source,
+		Reference: batch.Reference,
+		StartedAt: s.now(),
+		Received:  len(batch.Records) + len(batch.Rejected),
+	}
+	for _, rejected := range batch.Rejected {
+		addHRSyncEntry(run, entities.HRSyncActionRejected, domain.HRRecord{}, nil, "", rejected.Reference+": "+rejected.Message)
+	}
+
+	seen := make(map[string]bool, len(batch.Records))
+	for _, record := range batch.Records {
+		key := "id:" + record.ExternalID
+		if record.ExternalID == "" {
+			key = "email:" + record.Email
+		}
+		if seen[key] {
+			addHRSyncEntry(run, entities.HRSyncActionConflict, record, nil, "", "appears more than once in the delivery; only the first record was applied")
+			continue
+		}
+		seen[key] = true
+
+		if err := s.syncRecord(ctx, run, record); err != nil {
+			return nil, fmt.Errorf("failed to sync HR record %s: %w", hrRecordName(record), err)
+		}
+	}
+
+	run.Status = entities.HRSyncStatusSucceeded
+	switch {
+	case len(batch.Records) == 0 && len(batch.Rejected) > 0:
+		run.Status = entities.HRSyncStatusFailed
+	case run.Conflicts > 0 || run.Rejected > 0:
+		run.Status = entities.HRSyncStatusConflicts
+	}
+	run.FinishedAt = s.now()
+	if err := s.syncRepo.Create(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to save HR sync log: %w", err)
+	}
+	return hrSyncRunModel(run), nil
+}
+
+// syncRecord matches a record to a user, creating or updating the user and their profile dates
+func (s *HRSyncService) syncRecord(ctx context.Context, run *entities.HRSyncRun, record domain.HRRecord) error {
+	user, byExternalID, err := s.findUser(ctx, record)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return s.createUser(ctx, run, record)
+	}
+
+	// An HR ID only ever belongs to one person; a different one means the records were mixed up
+	if record.ExternalID != "" && user.ExternalID != nil && *user.ExternalID != record.ExternalID {
+		addHRSyncEntry(run, entities.HRSyncActionConflict, record, &user.ID, "external_id",
+			fmt.Sprintf("%s is linked to HR ID %s", user.Email, *user.ExternalID))
+		return nil
+	}
+
+	userChanged, err := s.updateUser(ctx, run, record, user, byExternalID)
+	if err != nil {
+		return err
+	}
+	profileChanged, err := s.updateProfile(ctx, run, record, user)
+	if err != nil {
+		return err
+	}
+	if userChanged || profileChanged {
+		run.Updated++
+	} else {
+		run.Unchanged++
+	}
+	return nil
+}
+
+// findUser looks a record up by HR ID, then by email. It reports whether the HR ID matched.
+func (s *HRSyncService) findUser(ctx context.Context, record domain.HRRecord) (*entities.User, bool, error) {
+	if record.ExternalID != "" {
+		user, err := s.userRepo.GetByExternalID(ctx, record.ExternalID)
+		if err == nil {
+			return user, true, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, err
+		}
+	}
+	if record.Email == "" {
+		return nil, false, nil
+	}
+	user, err := s.userRepo.GetByEmail(ctx, record.Email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return user, false, nil
+}
+
+// createUser adds a new joiner as an employee. Their profile, which needs a type, is created in the app;
+// the HR dates are applied by the next sync after that.
+func (s *HRSyncService) createUser(ctx context.Context, run *entities.HRSyncRun, record domain.HRRecord) error {
+	if record.Leaver {
+		addHRSyncEntry(run, entities.HRSyncActionSkipped, record, nil, "", "leaver without an account")
+		run.Unchanged++
+		return nil
+	}
+	if record.Email == "" || record.FirstName == "" || record.LastName == "" {
+		addHRSyncEntry(run, entities.HRSyncActionConflict, record, nil, "",
+			"no matching user, and email, first name and last name are all needed to create one")
+		return nil
+	}
+
+	user := &entities.User{
+		Email:     record.Email,
+		FirstName: record.FirstName,
+		LastName:  record.LastName,
+		Role:      string(models.RoleEmployee),
+	}
+	if record.ExternalID != "" {
+		externalID := record.ExternalID
+		user.ExternalID = &externalID
+	}
+	if err := s.userRepo.CreateWithEntity(ctx, user); err != nil {
+		return err
+	}
+	run.Created++
+	addHRSyncEntry(run, entities.HRSyncActionCreated, record, &user.ID, "", "")
+	addHRSyncEntry(run, entities.HRSyncActionSkipped, record, &user.ID, "profile", "no employee profile yet; HR dates apply once one exists")
+	return nil
+}
+
+// updateUser links the HR ID and applies names and, for users matched by HR ID, the email
+func (s *HRSyncService) updateUser(ctx context.Context, run *entities.HRSyncRun, record domain.HRRecord, user *entities.User, byExternalID bool) (bool, error) {
+	changed := false
+	if record.ExternalID != "" && user.ExternalID == nil {
+		externalID := record.ExternalID
+		user.ExternalID = &externalID
+		addHRSyncEntry(run, entities.HRSyncActionUpdated, record, &user.ID, "external_id", "linked to HR ID "+externalID)
+		changed = true
+	}
+	if byExternalID && record.Email != "" && record.Email != user.Email {
+		other, err := s.userRepo.GetByEmail(ctx, record.Email)
+		switch {
+		case err == nil && other.ID != user.ID:
+			addHRSyncEntry(run, entities.HRSyncActionConflict, record, &user.ID, "email",
+				fmt.Sprintf("%s belongs to another user", record.Email))
+		case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+			return false, err
+		default:
+			addHRSyncEntry(run, entities.HRSyncActionUpdated, record, &user.ID, "email", hrChange(user.Email, record.Email))
+			user.Email = record.Email
+			changed = true
+		}
+	}
+	if record.FirstName != "" && record.FirstName != user.FirstName {
+		addHRSyncEntry(run, entities.HRSyncActionUpdated, record, &user.ID, "first_name", hrChange(user.FirstName, record.FirstName))
+		user.FirstName = record.FirstName
+		changed = true
+	}
+	if record.LastName != "" && record.LastName != user.LastName {
+		addHRSyncEntry(run, entities.HRSyncActionUpdated, record, &user.ID, "last_name", hrChange(user.LastName, record.LastName))
+		user.LastName = record.LastName
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+	return true, s.userRepo.Update(ctx, user)
+}
+
+// updateProfile applies HR dates and employment details to the employee's profile
+func (s *HRSyncService) updateProfile(ctx context.Context, run *entities.HRSyncRun, record domain.HRRecord, user *entities.User) (bool, error) {
+	userID := strconv.FormatUint(uint64(user.ID), 10)
+	profile, err := s.profileRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		addHRSyncEntry(run, entities.HRSyncActionSkipped, record, &user.ID, "profile", "no employee profile yet; HR dates apply once one exists")
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Only changed fields are set: the profile update skips zero values
+	update := &models.EmployeeProfileModel{UserID: user.ID}
+	changed := false
+	if date, ok := changedDate(record.DateOfJoining, profile.DateOfJoining); ok {
+		addHRSyncEntry(run, entities.HRSyncActionUpdated, record, &user.ID, "date_of_joining", hrChange(formatDate(profile.DateOfJoining), formatDate(date)))
+		update.DateOfJoining = date
+		changed = true
+	}
+	if date, ok := changedDate(record.NoticeDate, profile.NoticeDate); ok {
+		addHRSyncEntry(run, entities.HRSyncActionUpdated, record, &user.ID, "notice_date", hrChange(formatDate(profile.NoticeDate), formatDate(date)))
+		update.NoticeDate = date
+		changed = true
+	}
+
+	endDate := record.EndDate
+	if record.Leaver && endDate == nil && profile.EndDate == nil {
+		// A leaver reported without a date has left by the time the HR system says so
+		today := s.now().UTC().Truncate(24 * time.Hour)
+		endDate = &today
+	}
+	if date, ok := changedDate(endDate, profile.EndDate); ok {
+		if profile.EndDate == nil {
+			addHRSyncEntry(run, entities.HRSyncActionLeaver, record, &user.ID, "end_date", "leaving on "+formatDate(date))
+			run.Leavers++
+		} else {
+			addHRSyncEntry(run, entities.HRSyncActionUpdated, record, &user.ID, "end_date", hrChange(formatDate(profile.EndDate), formatDate(date)))
+		}
+		update.EndDate = date
+		changed = true
+	} else if record.Status != "" && !record.Leaver && record.EndDate == nil && profile.EndDate != nil {
+		// End dates are never cleared automatically; someone has to confirm the departure was called off
+		addHRSyncEntry(run, entities.HRSyncActionConflict, record, &user.ID, "end_date",
+			fmt.Sprintf("ends on %s here, but HR reports the employee as %s with no end date", formatDate(profile.EndDate), record.Status))
+	}
+
+	for _, field := range []struct {
+		name           string
+		value, current string
+		target         *string
+	}{
+		{"department", record.Department, profile.Department, &update.Department},
+		{"employment_type", record.EmploymentType, profile.EmploymentType, &update.EmploymentType},
+		{"geo", record.Geo, profile.Geo, &update.Geo},
+	} {
+		if field.value != "" && field.value != field.current {
+			addHRSyncEntry(run, entities.HRSyncActionUpdated, record, &user.ID, field.name, hrChange(field.current, field.value))
+			*field.target = field.value
+			changed = true
+		}
+	}
+
+	if !changed {
+		return false, nil
+	}
+	if _, err := s.profileService.UpdateProfile(ctx, userID, update); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Poll runs every pull connector once; a failing connector does not stop the others
+func (s *HRSyncService) Poll(ctx context.Context) error {
+	var errs []error
+	for _, connector := range s.connectors {
+		err := connector.Poll(ctx, func(ctx context.Context, batch *domain.HRBatch) error {
+			run, err := s.Sync(ctx, batch)
+			if err == nil {
+				log.Printf("HR sync %s %s: %s, %d created, %d updated, %d leavers, %d conflicts, %d rejected",
+					run.Source, run.Reference, run.Status, run.Created, run.Updated, run.Leavers, run.Conflicts, run.Rejected)
+			}
+			return err
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", connector.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ListRuns returns the most recent sync runs without their entries
+func (s *HRSyncService) ListRuns(ctx context.Context, limit int) ([]*models.HRSyncRunModel, error) {
+	runs, err := s.syncRepo.List(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.HRSyncRunModel, 0, len(runs))
+	for _, run := range runs {
+		result = append(result, hrSyncRunModel(run))
+	}
+	return result, nil
+}
+
+// GetRun returns a sync run with its changes and conflicts
+func (s *HRSyncService) GetRun(ctx context.Context, id uint) (*models.HRSyncRunModel, error) {
+	run, err := s.syncRepo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrHRSyncRunNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return hrSyncRunModel(run), nil
+}
+
+// RunHRSyncPoller polls the HR connectors every interval until ctx is cancelled
+func RunHRSyncPoller(ctx context.Context, service domain.HRSyncService, interval time.Duration) {
+	log.Printf("HR sync poller started")
+	defer log.Printf("HR sync poller stopped")
+
+	for ctx.Err() == nil {
+		if err := service.Poll(ctx); err != nil {
+			log.Printf("Warning: HR sync poll failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(interval):
+		}
+	}
+}
+
+func addHRSyncEntry(run *entities.HRSyncRun, action string, record domain.HRRecord, userID *uint, field, detail string) {
+	switch action {
+	case entities.HRSyncActionConflict:
+		run.Conflicts++
+	case entities.HRSyncActionRejected:
+		run.Rejected++
+	}
+	var id *uint
+	if userID != nil {
+		value := *userID
+		id = &value
+	}
+	run.Entries = append(run.Entries, entities.HRSyncEntry{
+		Action:     action,
+		ExternalID: record.ExternalID,
+		Email:      record.Email,
+		UserID:     id,
+		Field:      field,
+		Detail:     detail,
+	})
+}
+
+// changedDate reports whether the HR date differs from the current one by calendar day
+func changedDate(hr, current *time.Time) (*time.Time, bool) {
+	if hr == nil {
+		return nil, false
+	}
+	if current != nil && formatDate(current) == formatDate(hr) {
+		return nil, false
+	}
+	return hr, true
+}
+
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.UTC().Format("2006-01-02")
+}
+
+func hrChange(from, to string) string {
+	if from == "" {
+		from = "(empty)"
+	}
+	return from + " → " + to
+}
+
+func hrRecordName(record domain.HRRecord) string {
+	return strings.TrimSpace(record.ExternalID + " " + record.Email)
+}
+
+func hrSyncRunModel(run *entities.HRSyncRun) *models.HRSyncRunModel {
+	model := &models.HRSyncRunModel{}
+	model.FromEntity(run)
+	return model
+}
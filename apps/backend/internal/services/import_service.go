@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/internal/tabular"
+	"github.com/talent-fit/backend/internal/utils"
+)
+
+// ImportService implements the domain.ImportService interface.
+// Every file is validated before anything is written, and the rows of all files are applied
+// in one transaction, so an import either lands completely or not at all.
+type ImportService struct {
+	importRepo domain.ImportRepository
+	enrichment domain.EnrichmentQueue
+}
+
+// NewImportService creates a new import service
+func NewImportService(importRepo domain.ImportRepository, enrichment domain.EnrichmentQueue) domain.ImportService {
+	return &ImportService{
+		importRepo: importRepo,
+		enrichment: enrichment,
+	}
+}
+
+// Import validates the files, applies them unless this is a dry run or a row failed, and queues
+// summaries and embeddings for the imported profiles and projects
+func (s *ImportService) Import(ctx context.Context, files []*domain.ImportFile, dryRun bool) (*models.ImportReport, error) {
+	report := &models.ImportReport{DryRun: dryRun, Files: []models.ImportFileReport{}, Errors: []models.ImportRowError{}}
+	batch := &domain.ImportBatch{}
+
+	// Files are read in dependency order so allocations see the users and projects before them
+	for _, kind := range models.ImportKinds {
+		for _, file := range files {
+			if file.Kind != kind {
+				continue
+			}
+			fileReport := models.ImportFileReport{Kind: kind, Filename: file.Filename}
+			table, err := tabular.Read(file.Filename, file.Data)
+			if err != nil {
+				report.Errors = append(report.Errors, models.ImportRowError{File: file.Filename, Message: err.Error()})
+			} else {
+				fileReport.Rows = len(table.Rows)
+				report.Errors = append(report.Errors, utils.ParseImportTable(kind, file.Filename, table, batch)...)
+			}
+			report.Files = append(report.Files, fileReport)
+		}
+	}
+	if len(report.Files) == 0 {
+		return nil, fmt.Errorf("no import files given")
+	}
+
+	// Rows that failed validation are left out of the batch; applying the rest still reports
+	// reference errors and counts, and the transaction is rolled back
+	result, err := s.importRepo.Apply(ctx, batch, dryRun || len(report.Errors) > 0)
+	if err != nil {
+		return nil, err
+	}
+	report.Errors = append(report.Errors, result.Errors...)
+	for i := range report.Files {
+		report.Files[i].Created = result.Created[report.Files[i].Kind]
+		report.Files[i].Updated = result.Updated[report.Files[i].Kind]
+	}
+	if dryRun || len(report.Errors) > 0 {
+		return report, nil
+	}
+	report.Committed = true
+
+	for _, userID := range result.ProfileUserIDs {
+		if err := s.enrichment.EnqueueProfile(ctx, userID); err != nil {
+			log.Printf("Warning: Failed to queue enrichment for imported profile %d: %v", userID, err)
+			continue
+		}
+		report.Enqueued++
+	}
+	for projectID, summarize := range result.Projects {
+		if err := s.enrichment.EnqueueProject(ctx, projectID, summarize); err != nil {
+			log.Printf("Warning: Failed to queue enrichment for imported project %d: %v", projectID, err)
+			continue
+		}
+		report.Enqueued++
+	}
+	return report, nil
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// defaultInvitationDays is how long an invitation stays valid unless the inviter says otherwise
+const defaultInvitationDays = 14
+
+// maxInvitationDays caps how long an invitation can stay valid
+const maxInvitationDays = 90
+
+// InvitationService implements domain.InvitationService
+type InvitationService struct {
+	invitationRepo domain.InvitationRepository
+	userRepo       domain.UserRepository
+	tenantRepo     domain.TenantRepository
+	auditRepo      domain.AuditRepository
+	// signUpDomains lets anyone with an email in these domains sign up to the default tenant
+	// without an invitation, unless a tenant claims the domain
+	signUpDomains []string
+	now           func() time.Time
+}
+
+// NewInvitationService creates a new invitation service. signUpDomains is a comma-separated list
+// of email domains that may sign up to the default tenant without an invitation; other tenants
+// claim their own in tenants.signup_domains.
+func NewInvitationService(invitationRepo domain.InvitationRepository, userRepo domain.UserRepository, tenantRepo domain.TenantRepository,
+	auditRepo domain.AuditRepository, signUpDomains string) domain.InvitationService {
+	return &InvitationService{
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		tenantRepo:     tenantRepo,
+		auditRepo:      auditRepo,
+		signUpDomains:  parseEmailDomains(signUpDomains),
+		now:            time.Now,
+	}
+}
+
+// parseEmailDomains splits a comma-separated list of email domains, e.g. "acme.com, @acme.co.uk",
+// into lowercase domains without a leading @
+func parseEmailDomains(list string) []string {
+	var domains []string
+	for _, domain := range strings.Split(list, ",") {
+		if domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@")); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// Invite creates an invitation with a preassigned role, Slack user ID and manager
+func (s *InvitationService) Invite(ctx context.Context, invitedBy uint, request *models.InviteRequest) (*models.InvitationModel, error) {
+	email := strings.ToLower(strings.TrimSpace(request.Email))
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return nil, domain.ErrInvalidInvitation
+	}
+	role := request.Role
+	if role == "" {
+		role = models.RoleEmployee
+	}
+	if !role.IsValid() {
+		return nil, domain.ErrInvalidInvitation
+	}
+	// Managers may invite their people, but only admins may hand out the admin permission
+	if role.Can(models.PermAdmin) {
+		inviter, err := s.userRepo.GetByID(ctx, strconv.FormatUint(uint64(invitedBy), 10))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load inviter: %w", err)
+		}
+		if !models.UserRole(inviter.Role).Can(models.PermAdmin) {
+			return nil, domain.ErrAdminInvite
+		}
+	}
+	days := request.ExpiresInDays
+	if days <= 0 {
+		days = defaultInvitationDays
+	}
+	if days > maxInvitationDays {
+		return nil, fmt.Errorf("%w: expires_in_days must be at most %d", domain.ErrInvalidInvitation, maxInvitationDays)
+	}
+
+	// Emails are unique across tenants, and sign-in finds the tenant from them, so check them all
+	if _, err := s.userRepo.GetByEmail(domain.AllTenants(ctx), email); err == nil {
+		return nil, domain.ErrUserAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check user: %w", err)
+	}
+	now := s.now()
+	if _, err := s.invitationRepo.GetPending(domain.AllTenants(ctx), email, now); err == nil {
+		return nil, domain.ErrInvitationExists
+	} else if !errors.Is(err, domain.ErrInvitationNotFound) {
+		return nil, fmt.Errorf("failed to check invitations: %w", err)
+	}
+	if request.ManagerID != nil {
+		if _, err := s.userRepo.GetByID(ctx, strconv.FormatUint(uint64(*request.ManagerID), 10)); err != nil {
+			return nil, fmt.Errorf("%w: manager %d not found", domain.ErrInvalidInvitation, *request.ManagerID)
+		}
+	}
+
+	invitation := &entities.Invitation{
+		Email:       email,
+		Role:        string(role),
+		SlackUserID: strings.TrimSpace(request.SlackUserID),
+		ManagerID:   request.ManagerID,
+		InvitedByID: invitedBy,
+		ExpiresAt:   now.AddDate(0, 0, days),
+		CreatedAt:   now,
+	}
+	if err := s.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+	s.audit(ctx, &entities.AuditEvent{
+		Action:  entities.AuditUserInvited,
+		ActorID: &invitedBy,
+		Email:   email,
+		Detail:  fmt.Sprintf("invitation %d as %s", invitation.ID, invitation.Role),
+	})
+
+	var model models.InvitationModel
+	model.FromEntity(invitation, now)
+	return &model, nil
+}
+
+// List returns invitations newest first
+func (s *InvitationService) List(ctx context.Context, pendingOnly bool, limit int) ([]*models.InvitationModel, error) {
+	now := s.now()
+	invitations, err := s.invitationRepo.List(ctx, pendingOnly, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	result := make([]*models.InvitationModel, len(invitations))
+	for i, invitation := range invitations {
+		result[i] = &models.InvitationModel{}
+		result[i].FromEntity(invitation, now)
+	}
+	return result, nil
+}
+
+// Revoke withdraws a pending invitation
+func (s *InvitationService) Revoke(ctx context.Context, revokedBy uint, id uint) error {
+	invitation, err := s.invitationRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.invitationRepo.Revoke(ctx, id, s.now()); err != nil {
+		return err
+	}
+	s.audit(ctx, &entities.AuditEvent{
+		Action:  entities.AuditInvitationRevoked,
+		ActorID: &revokedBy,
+		Email:   invitation.Email,
+		Detail:  fmt.Sprintf("invitation %d", id),
+	})
+	return nil
+}
+
+// Provision creates the account of a first sign-in from an invitation, in the invitation's tenant, or
+// from an email domain open for sign-up, in the tenant that claims the domain. Users are Employees unless the
+// invitation or the login provider sets the role. The account is linked to the identity signing in.
+func (s *InvitationService) Provision(ctx context.Context, identity *domain.Identity) (*entities.User, error) {
+	now := s.now()
+	user := &entities.User{
+		FirstName:    identity.Name,
+		LastName:     "",
+		Email:        identity.Email,
+		Role:         string(models.RoleEmployee),
+		AuthProvider: &identity.Provider,
+		AuthSubject:  &identity.Subject,
+	}
+
+	// Until an invitation or the email's domain decides the tenant, only cross-tenant lookups are made
+	invitation, err := s.invitationRepo.GetPending(domain.AllTenants(ctx), identity.Email, now)
+	switch {
+	case err == nil:
+		ctx = domain.WithTenant(ctx, invitation.TenantID)
+		user.TenantID = invitation.TenantID
+		user.Role = invitation.Role
+		user.SlackUserID = invitation.SlackUserID
+		user.ManagerID = invitation.ManagerID
+		// A provider that maps groups to roles decides the role on every sign-in
+		if identity.Role != "" {
+			user.Role = identity.Role
+		}
+		if err := s.invitationRepo.Accept(ctx, invitation, user, now); err != nil {
+			if errors.Is(err, domain.ErrInvitationNotActive) {
+				return nil, domain.ErrNotInvited
+			}
+			return nil, errors.New("failed to create user")
+		}
+		s.audit(ctx, &entities.AuditEvent{
+			Action:   entities.AuditUserProvisioned,
+			ActorID:  &invitation.InvitedByID,
+			UserID:   &user.ID,
+			Email:    user.Email,
+			Provider: identity.Provider,
+			Detail:   fmt.Sprintf("invitation %d as %s", invitation.ID, user.Role),
+		})
+		return user, nil
+
+	case !errors.Is(err, domain.ErrInvitationNotFound):
+		return nil, fmt.Errorf("failed to check invitations: %w", err)
+	}
+
+	tenantID, err := s.signUpTenant(ctx, identity.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check sign-up domains: %w", err)
+	}
+	if tenantID == 0 {
+		s.audit(domain.AllTenants(ctx), &entities.AuditEvent{
+			Action:   entities.AuditSignUpRejected,
+			Email:    identity.Email,
+			Provider: identity.Provider,
+			Detail:   "no pending invitation",
+		})
+		return nil, domain.ErrNotInvited
+	}
+
+	ctx = domain.WithTenant(ctx, tenantID)
+	user.TenantID = tenantID
+	if identity.Role != "" {
+		user.Role = identity.Role
+	}
+	if err := s.userRepo.CreateWithEntity(ctx, user); err != nil {
+		return nil, errors.New("failed to create user")
+	}
+	s.audit(ctx, &entities.AuditEvent{
+		Action:   entities.AuditUserProvisioned,
+		UserID:   &user.ID,
+		Email:    user.Email,
+		Provider: identity.Provider,
+		Detail:   "allowed sign-up domain as " + user.Role,
+	})
+	return user, nil
+}
+
+// signUpTenant returns the tenant an email may sign up to without an invitation: the one claiming
+// its domain, else the default tenant for SIGNUP_ALLOWED_DOMAINS, else 0
+func (s *InvitationService) signUpTenant(ctx context.Context, email string) (uint, error) {
+	_, emailDomain, _ := strings.Cut(email, "@")
+	emailDomain = strings.ToLower(emailDomain)
+	if emailDomain == "" {
+		return 0, nil
+	}
+	tenant, err := s.tenantRepo.GetBySignUpDomain(ctx, emailDomain)
+	if err == nil {
+		return tenant.ID, nil
+	}
+	if !errors.Is(err, domain.ErrTenantNotFound) {
+		return 0, err
+	}
+	for _, allowed := range s.signUpDomains {
+		if emailDomain == allowed {
+			return domain.DefaultTenantID, nil
+		}
+	}
+	return 0, nil
+}
+
+// audit records an event; the audited change has already happened, so failures are only logged
+func (s *InvitationService) audit(ctx context.Context, event *entities.AuditEvent) {
+	if err := s.auditRepo.Record(ctx, event); err != nil {
+		log.Printf("Warning: failed to record audit event %s for %s: %v", event.Action, event.Email, err)
+	}
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type fakeUserRepo struct {
+	domain.UserRepository
+	users []*entities.User
+}
+
+func (f *fakeUserRepo) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+	for _, user := range f.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeUserRepo) CreateWithEntity(ctx context.Context, user *entities.User) error {
+	user.ID = uint(len(f.users) + 1)
+	f.users = append(f.users, user)
+	return nil
+}
+
+func (f *fakeUserRepo) Update(ctx context.Context, user *entities.User) error {
+	for i, stored := range f.users {
+		if stored.ID == user.ID {
+			updated := *user
+			f.users[i] = &updated
+		}
+	}
+	return nil
+}
+
+func (f *fakeUserRepo) LinkIdentity(ctx context.Context, id uint, provider, subject string) error {
+	for _, user := range f.users {
+		if user.ID == id {
+			if user.AuthSubject != nil {
+				return domain.ErrIdentityMismatch
+			}
+			user.AuthProvider, user.AuthSubject = &provider, &subject
+		}
+	}
+	return nil
+}
+
+type fakeInvitationRepo struct {
+	domain.InvitationRepository
+	users       *fakeUserRepo
+	invitations []*entities.Invitation
+}
+
+func (f *fakeInvitationRepo) Create(ctx context.Context, invitation *entities.Invitation) error {
+	invitation.ID = uint(len(f.invitations) + 1)
+	f.invitations = append(f.invitations, invitation)
+	return nil
+}
+
+func (f *fakeInvitationRepo) GetPending(ctx context.Context, email string, now time.Time) (*entities.Invitation, error) {
+	for _, invitation := range f.invitations {
+		if invitation.Email == email && invitation.AcceptedAt == nil && invitation.RevokedAt == nil && invitation.ExpiresAt.After(now) {
+			return invitation, nil
+		}
+	}
+	return nil, domain.ErrInvitationNotFound
+}
+
+func (f *fakeInvitationRepo) Accept(ctx context.Context, invitation *entities.Invitation, user *entities.User, now time.Time) error {
+	if err := f.users.CreateWithEntity(ctx, user); err != nil {
+		return err
+	}
+	invitation.AcceptedAt = &now
+	invitation.UserID = &user.ID
+	return nil
+}
+
+type fakeAuditRepo struct {
+	domain.AuditRepository
+	events []*entities.AuditEvent
+}
+
+func (f *fakeAuditRepo) Record(ctx context.Context, event *entities.AuditEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func TestInvitationProvisioning(t *testing.T) {
+	ctx := context.Background()
+	users := &fakeUserRepo{users: []*entities.User{
+		{ID: 1, Email: "manager@example.com", Role: string(models.RoleManager)},
+		{ID: 2, Email: "admin@example.com", Role: string(models.RoleAdmin)},
+	}}
+	invitations := &fakeInvitationRepo{users: users}
+	tenants := &fakeTenantRepo{tenants: []*entities.Tenant{{ID: 3, Slug: "acme", SignUpDomains: "acme.example,acme.test"}}}
+	audit := &fakeAuditRepo{}
+	service := NewInvitationService(invitations, users, tenants, audit, "partner.example")
+
+	if _, err := service.Invite(ctx, 1, &models.InviteRequest{Email: " New.Hire@Example.com", Role: models.RoleManager, SlackUserID: "U123"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.Invite(ctx, 1, &models.InviteRequest{Email: "new.hire@example.com"}); !errors.Is(err, domain.ErrInvitationExists) {
+		t.Errorf("second invitation: err = %v, want ErrInvitationExists", err)
+	}
+	if _, err := service.Invite(ctx, 1, &models.InviteRequest{Email: "someone@example.com", Role: "Owner"}); !errors.Is(err, domain.ErrInvalidInvitation) {
+		t.Errorf("unknown role: err = %v, want ErrInvalidInvitation", err)
+	}
+	if _, err := service.Invite(ctx, 1, &models.InviteRequest{Email: "someone@example.com", Role: models.RoleAdmin}); !errors.Is(err, domain.ErrAdminInvite) {
+		t.Errorf("manager inviting an admin: err = %v, want ErrAdminInvite", err)
+	}
+	if _, err := service.Invite(ctx, 2, &models.InviteRequest{Email: "someone@example.com", Role: models.RoleAdmin}); err != nil {
+		t.Errorf("admin inviting an admin: %v", err)
+	}
+
+	user, err := service.Provision(ctx, &domain.Identity{Provider: "google", Email: "new.hire@example.com", Name: "New Hire"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Role != string(models.RoleManager) || user.SlackUserID != "U123" {
+		t.Errorf("invited user = %s/%s, want the invitation's role and Slack ID", user.Role, user.SlackUserID)
+	}
+	if invitations.invitations[0].AcceptedAt == nil {
+		t.Error("invitation was not accepted")
+	}
+
+	user, err = service.Provision(ctx, &domain.Identity{Provider: "google", Email: "contractor@partner.example"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Role != string(models.RoleEmployee) || user.TenantID != domain.DefaultTenantID {
+		t.Errorf("allowed domain user = %s in tenant %d, want an Employee in the default tenant", user.Role, user.TenantID)
+	}
+
+	// A domain a tenant claims signs up to that tenant, not the default one
+	user, err = service.Provision(ctx, &domain.Identity{Provider: "google", Email: "dev@Acme.test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.TenantID != 3 {
+		t.Errorf("claimed domain user in tenant %d, want 3", user.TenantID)
+	}
+
+	if _, err := service.Provision(ctx, &domain.Identity{Provider: "google", Email: "stranger@gmail.com"}); !errors.Is(err, domain.ErrNotInvited) {
+		t.Errorf("uninvited sign-in: err = %v, want ErrNotInvited", err)
+	}
+
+	var actions []string
+	for _, event := range audit.events {
+		actions = append(actions, event.Action)
+	}
+	want := []string{entities.AuditUserInvited, entities.AuditUserInvited, entities.AuditUserProvisioned, entities.AuditUserProvisioned,
+		entities.AuditUserProvisioned, entities.AuditSignUpRejected}
+	if len(actions) != len(want) {
+		t.Fatalf("audit actions = %v, want %v", actions, want)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Errorf("audit actions = %v, want %v", actions, want)
+			break
+		}
+	}
+}
@@ -2,12 +2,16 @@ package services
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"log"
 	"strconv"
+	"time"
 
+	"github.com/talent-fit/backend/internal/config"
 	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
 	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/internal/redaction"
 	"github.com/talent-fit/backend/internal/utils"
 )
 
@@ -18,7 +22,9 @@ type MatchService struct {
 	allocationRepo   domain.ProjectAllocationRepository
 	profileRepo      domain.EmployeeProfileRepository
 	embeddingService domain.EmbeddingService
-	embeddingUtils   *utils.EmbeddingUtils
+	matchRunRepo     domain.MatchRunRepository
+	scorer           *CandidateScorer
+	tenants          domain.TenantSettingsProvider
 }
 
 // NewMatchService creates a new match service
@@ -28,6 +34,12 @@ func NewMatchService(
 	allocationRepo domain.ProjectAllocationRepository,
 	profileRepo domain.EmployeeProfileRepository,
 	embeddingService domain.EmbeddingService,
+	matchRunRepo domain.MatchRunRepository,
+	promptRegistry domain.PromptRegistry,
+	budget domain.AIBudget,
+	redactor *redaction.Redactor,
+	tenants domain.TenantSettingsProvider,
+	cfg *config.Config,
 ) domain.MatchService {
 	return &MatchService{
 		userRepo:         userRepo,
@@ -35,7 +47,9 @@ func NewMatchService(
 		allocationRepo:   allocationRepo,
 		profileRepo:      profileRepo,
 		embeddingService: embeddingService,
-		embeddingUtils:   utils.NewEmbeddingUtils(embeddingService),
+		matchRunRepo:     matchRunRepo,
+		scorer:           NewCandidateScorer(embeddingService, promptRegistry, budget, redactor, tenants, cfg),
+		tenants:          tenants,
 	}
 }
 
@@ -54,71 +68,159 @@ func (s *MatchService) GetEmployeeMatches(ctx context.Context, employeeID string
 	return nil
 }
 
+// candidateLimit is how many candidates vector search retrieves for scoring
+const candidateLimit = 20
+
 // GenerateMatchSuggestions generates AI-powered match suggestions
 func (s *MatchService) GenerateMatchSuggestions(ctx context.Context, projectID string) ([]*models.MatchSuggestion, error) {
-	// Convert projectID to int
-	projectIDInt, err := strconv.Atoi(projectID)
+	// 1-2. Get project details and similar available candidates
+	projectIDInt, project, candidates, err := s.retrieveCandidates(ctx, projectID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid project ID: %w", err)
+		return nil, err
 	}
 
-	// 1. Get project details
-	project, err := s.projectRepo.GetByID(ctx, projectIDInt)
+	if len(candidates) == 0 {
+		return []*models.MatchSuggestion{}, nil // Return empty array if no candidates
+	}
+
+	// 3. Score candidates with the AI model (validated, repaired and backfilled)
+	rules, err := s.scoringRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = domain.WithAIUsage(ctx, domain.FeatureMatchScoring, domain.EntityTypeProject, projectIDInt)
+	result, err := s.scorer.Score(ctx, project, candidates, rules)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project: %w", err)
+		return nil, err
+	}
+	s.recordRun(ctx, projectIDInt, candidates, result)
+
+	// 4. Combine scores with candidate profiles
+	candidateMap := make(map[int]*domain.SimilarityMatch)
+	for _, candidate := range candidates {
+		candidateMap[int(candidate.Profile.UserID)] = candidate
+	}
+
+	suggestions := make([]*models.MatchSuggestion, 0, len(result.Scores))
+	for _, score := range result.Scores {
+		suggestions = append(suggestions, newMatchSuggestion(candidateMap[score.CandidateID], score))
 	}
 
-	// 2. Get similar available candidates
-	candidates, err := s.profileRepo.GetSimilarAvailableProfilesWithUser(ctx, projectID, 20)
+	return suggestions, nil
+}
+
+// StreamMatchSuggestions generates match suggestions as a stream of events: the retrieved candidates
+// first, then each scored candidate as the model produces it, then a summary with the final ranking
+func (s *MatchService) StreamMatchSuggestions(ctx context.Context, projectID string, emit func(models.MatchStreamEvent)) error {
+	started := time.Now()
+	projectIDInt, project, candidates, err := s.retrieveCandidates(ctx, projectID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get candidates: %w", err)
+		return err
 	}
 
-	if len(candidates) == 0 {
-		return []*models.MatchSuggestion{}, nil // Return empty array if no candidates
+	candidateMap := make(map[int]*domain.SimilarityMatch, len(candidates))
+	retrieved := make([]models.RetrievedCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		candidateMap[int(candidate.Profile.UserID)] = candidate
+		profileModel := &models.EmployeeProfileModel{}
+		profileModel.FromEntity(candidate.Profile)
+		retrieved = append(retrieved, models.RetrievedCandidate{
+			CandidateID: int(candidate.Profile.UserID),
+			Similarity:  candidate.Similarity,
+			Status:      candidate.Status,
+			Profile:     profileModel,
+		})
 	}
+	emit(models.MatchStreamEvent{Name: models.MatchEventCandidates, Data: retrieved})
 
-	// 3. Generate matching prompt using default scoring rules
-	rules := utils.DefaultScoringRules()
-	prompt := s.embeddingUtils.GenerateMatchingPrompt(project.Summary, candidates, rules)
+	rules, err := s.scoringRules(ctx)
+	if err != nil {
+		return err
+	}
+	ctx = domain.WithAIUsage(ctx, domain.FeatureMatchScoring, domain.EntityTypeProject, projectIDInt)
+	result, err := s.scorer.ScoreStream(ctx, project, candidates, rules, func(score models.CandidateScore) {
+		emit(models.MatchStreamEvent{Name: models.MatchEventScore, Data: newMatchSuggestion(candidateMap[score.CandidateID], score)})
+	})
+	if err != nil {
+		return err
+	}
+	if len(candidates) > 0 {
+		s.recordRun(ctx, projectIDInt, candidates, result)
+	}
+
+	ranking := make([]int, 0, len(result.Scores))
+	for _, score := range result.Scores {
+		ranking = append(ranking, score.CandidateID)
+	}
+	emit(models.MatchStreamEvent{Name: models.MatchEventSummary, Data: models.MatchStreamSummary{
+		Count:          len(result.Scores),
+		Ranking:        ranking,
+		PromptVersion:  result.PromptVersion,
+		Model:          result.Model,
+		FallbackCount:  result.FallbackCount,
+		BudgetExceeded: result.BudgetExceeded,
+		DurationMs:     time.Since(started).Milliseconds(),
+	}})
+	return nil
+}
 
-	// 4. Get AI scoring
-	scoresJSON, err := s.embeddingService.GenerateMatchingScores(ctx, prompt)
+// scoringRules returns the context's tenant's scoring weights, or the defaults
+func (s *MatchService) scoringRules(ctx context.Context) (utils.ScoringRules, error) {
+	if s.tenants == nil {
+		return utils.DefaultScoringRules(), nil
+	}
+	tenant, err := s.tenants.Settings(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate AI scores: %w", err)
+		return utils.ScoringRules{}, fmt.Errorf("failed to load tenant scoring rules: %w", err)
+	}
+	if tenant == nil || tenant.ScoringRules == nil {
+		return utils.DefaultScoringRules(), nil
 	}
+	return utils.ScoringRules(*tenant.ScoringRules), nil
+}
 
-	// 5. Parse AI response
-	var candidateScores []models.CandidateScore
-	if err := json.Unmarshal([]byte(scoresJSON), &candidateScores); err != nil {
-		return nil, fmt.Errorf("failed to parse AI scores: %w", err)
+// retrieveCandidates loads the project and the most similar available candidates for it
+func (s *MatchService) retrieveCandidates(ctx context.Context, projectID string) (int, *entities.Project, []*domain.SimilarityMatch, error) {
+	// Convert projectID to int
+	projectIDInt, err := strconv.Atoi(projectID)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("invalid project ID: %w", err)
 	}
 
-	// 6. Combine scores with candidate profiles
-	var suggestions []*models.MatchSuggestion
-	candidateMap := make(map[int]*domain.SimilarityMatch)
-	
-	// Create map for quick lookup
-	for _, candidate := range candidates {
-		candidateMap[int(candidate.Profile.UserID)] = candidate
+	project, err := s.projectRepo.GetByID(ctx, projectIDInt)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
-	// Build suggestions with profile data
-	for _, score := range candidateScores {
-		if candidate, exists := candidateMap[score.CandidateID]; exists {
-			// Convert entity to model
-			profileModel := &models.EmployeeProfileModel{}
-			profileModel.FromEntity(candidate.Profile)
+	candidates, err := s.profileRepo.GetSimilarAvailableProfilesWithUser(ctx, projectID, candidateLimit)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to get candidates: %w", err)
+	}
+	return projectIDInt, project, candidates, nil
+}
 
-			suggestion := &models.MatchSuggestion{
-				CandidateScore: score,
-				Profile:        profileModel,
-			}
-			suggestions = append(suggestions, suggestion)
-		}
+// recordRun records a scoring run with the prompt version that produced it
+func (s *MatchService) recordRun(ctx context.Context, projectID int, candidates []*domain.SimilarityMatch, result *ScoringResult) {
+	run := &entities.MatchRun{
+		ProjectID:      projectID,
+		PromptVersion:  result.PromptVersion,
+		Model:          result.Model,
+		CandidateCount: len(candidates),
+		FallbackCount:  result.FallbackCount,
+	}
+	if _, err := s.matchRunRepo.Create(ctx, run); err != nil {
+		log.Printf("Warning: Failed to record match run for project %d: %v", projectID, err)
 	}
+}
 
-	return suggestions, nil
+// newMatchSuggestion combines a score with the candidate's profile
+func newMatchSuggestion(candidate *domain.SimilarityMatch, score models.CandidateScore) *models.MatchSuggestion {
+	profileModel := &models.EmployeeProfileModel{}
+	profileModel.FromEntity(candidate.Profile)
+	return &models.MatchSuggestion{
+		CandidateScore: score,
+		Profile:        profileModel,
+	}
 }
 
 // GetMatchExplanation gets AI explanation for a specific match
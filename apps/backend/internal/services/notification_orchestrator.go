@@ -7,17 +7,29 @@ import (
 	"github.com/talent-fit/backend/internal/domain"
 )
 
-// Simple orchestrator: always persists in-app, then attempts Slack
+// Simple orchestrator: always persists in-app, then attempts Slack. Messages without recipients
+// are routed by the resolver.
 type Orchestrator struct {
-    InApp  domain.Notifier
-    Slack  domain.Notifier
+    InApp    domain.Notifier
+    Slack    domain.Notifier
+    Resolver domain.RecipientResolver
 }
 
-func NewOrchestrator(inApp domain.Notifier, slack domain.Notifier) domain.NotificationOrchestrator {
-    return &Orchestrator{InApp: inApp, Slack: slack}
+func NewOrchestrator(inApp domain.Notifier, slack domain.Notifier, resolver domain.RecipientResolver) domain.NotificationOrchestrator {
+    return &Orchestrator{InApp: inApp, Slack: slack, Resolver: resolver}
 }
 
 func (o *Orchestrator) Dispatch(ctx context.Context, msg domain.NotificationMessage) error {
+    if len(msg.Recipients) == 0 && o.Resolver != nil {
+        recipients, err := o.Resolver.Resolve(ctx, msg)
+        if err != nil {
+            // Still alert someone: fall back to the default Slack channel
+            log.Printf("failed to resolve recipients for %s notification: %v", msg.Type, err)
+            recipients = []domain.Recipient{{}}
+        }
+        msg.Recipients = recipients
+    }
+
     // In-app is best-effort required (UI depends on it)
     if o.InApp != nil {
         if err := o.InApp.Send(ctx, msg); err != nil {
@@ -13,29 +13,41 @@ import (
 type SlackNotifier struct {
     client  *slack.Client
     cfg     *config.Config
+    // tenants gives tenants their own Slack workspace and default channel
+    tenants domain.TenantSettingsProvider
 }
 
-func NewSlackNotifier(cfg *config.Config) *SlackNotifier {
-    if cfg.Slack.BotToken == "" {
+// NewSlackNotifier creates a Slack notifier, or returns nil when there is no deployment bot token
+// and no tenant can have its own
+func NewSlackNotifier(cfg *config.Config, tenants domain.TenantSettingsProvider) *SlackNotifier {
+    if cfg.Slack.BotToken == "" && tenants == nil {
         return nil
     }
-    return &SlackNotifier{
-        client: slack.New(cfg.Slack.BotToken),
-        cfg:    cfg,
+    notifier := &SlackNotifier{cfg: cfg, tenants: tenants}
+    if cfg.Slack.BotToken != "" {
+        notifier.client = slack.New(cfg.Slack.BotToken)
     }
+    return notifier
 }
 
 func (s *SlackNotifier) Send(ctx context.Context, msg domain.NotificationMessage) error {
-    if s == nil || s.client == nil {
+    if s == nil {
+        return nil
+    }
+    client, defaultChannelID, err := s.workspace(ctx)
+    if err != nil {
+        return err
+    }
+    if client == nil {
         return nil
     }
 
     // Post message per recipient; DM if SlackID present, else fallback to default channel
     for _, r := range msg.Recipients {
-        channelID := s.cfg.Slack.DefaultChannelID
+        channelID := defaultChannelID
         if r.SlackID != "" {
             // Open IM channel
-            channel, _, _, err := s.client.OpenConversation(&slack.OpenConversationParameters{Users: []string{r.SlackID}, ReturnIM: true})
+            channel, _, _, err := client.OpenConversation(&slack.OpenConversationParameters{Users: []string{r.SlackID}, ReturnIM: true})
             if err != nil {
                 log.Printf("failed to open DM with %s: %v, using default channel", r.SlackID, err)
             } else {
@@ -48,7 +60,7 @@ func (s *SlackNotifier) Send(ctx context.Context, msg domain.NotificationMessage
         }
 
         text := fmt.Sprintf("%s\n%s", msg.Subject, msg.Body)
-        _, _, err := s.client.PostMessageContext(ctx, channelID, slack.MsgOptionText(text, false))
+        _, _, err := client.PostMessageContext(ctx, channelID, slack.MsgOptionText(text, false))
         if err != nil {
             log.Printf("failed to post slack message to %s: %v", channelID, err)
         }
@@ -56,4 +68,27 @@ func (s *SlackNotifier) Send(ctx context.Context, msg domain.NotificationMessage
     return nil
 }
 
-
+// workspace returns the Slack client and default channel of the context's tenant. A tenant with a
+// bot token posts to its own workspace. Others post nowhere, except the default tenant, which uses
+// the deployment's workspace, so one tenant's notifications never reach another's Slack.
+func (s *SlackNotifier) workspace(ctx context.Context) (*slack.Client, string, error) {
+    if s.tenants == nil {
+        return s.client, s.cfg.Slack.DefaultChannelID, nil
+    }
+    tenant, err := s.tenants.Settings(ctx)
+    if err != nil {
+        return nil, "", fmt.Errorf("failed to load tenant Slack settings: %w", err)
+    }
+    switch {
+    case tenant == nil:
+        return s.client, s.cfg.Slack.DefaultChannelID, nil
+    case tenant.SlackBotToken != "":
+        return slack.New(tenant.SlackBotToken), tenant.SlackDefaultChannelID, nil
+    case tenant.ID != domain.DefaultTenantID:
+        return nil, "", nil
+    case tenant.SlackDefaultChannelID != "":
+        return s.client, tenant.SlackDefaultChannelID, nil
+    default:
+        return s.client, s.cfg.Slack.DefaultChannelID, nil
+    }
+}
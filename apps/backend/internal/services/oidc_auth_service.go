@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+// OIDCAuthService implements domain.OIDCAuthService
+type OIDCAuthService struct {
+	verifier     domain.IdentityVerifier
+	userRepo     domain.UserRepository
+	invitations  domain.InvitationService
+	tokenService domain.TokenService
+}
+
+// NewOIDCAuthService creates a new OpenID Connect auth service
+func NewOIDCAuthService(verifier domain.IdentityVerifier, userRepo domain.UserRepository, invitations domain.InvitationService, tokenService domain.TokenService) domain.OIDCAuthService {
+	return &OIDCAuthService{
+		verifier:     verifier,
+		userRepo:     userRepo,
+		invitations:  invitations,
+		tokenService: tokenService,
+	}
+}
+
+// Providers returns the configured login providers
+func (s *OIDCAuthService) Providers() []domain.AuthProvider {
+	return s.verifier.Providers()
+}
+
+// Authenticate verifies an ID token, ensures the user exists with the role the provider maps
+// their groups to, if it maps roles, and issues the app's tokens. A user's first sign-in links
+// them to the provider account; sign-ins with their email from any other account are rejected.
+func (s *OIDCAuthService) Authenticate(ctx context.Context, provider, credential string) (*domain.AuthResponse, error) {
+	identity, err := s.verifier.Verify(ctx, provider, credential)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emails are unique across tenants, so the user's row tells which tenant they sign in to
+	user, err := s.userRepo.GetByEmail(domain.AllTenants(ctx), identity.Email)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// First sign-in: only invited users and allowed sign-up domains get an account
+		user, err = s.invitations.Provision(ctx, identity)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	case user.AuthSubject == nil:
+		// Users created before their first sign-in, e.g. by an admin, HR sync or CV import, are linked on it
+		if err := s.userRepo.LinkIdentity(domain.WithTenant(ctx, user.TenantID), user.ID, identity.Provider, identity.Subject); err != nil {
+			if errors.Is(err, domain.ErrIdentityMismatch) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to link sign-in identity: %w", err)
+		}
+	case user.AuthProvider == nil || *user.AuthProvider != identity.Provider || *user.AuthSubject != identity.Subject:
+		return nil, domain.ErrIdentityMismatch
+	}
+	ctx = domain.WithTenant(ctx, user.TenantID)
+
+	if identity.Role != "" && user.Role != identity.Role {
+		user.Role = identity.Role
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to update role: %w", err)
+		}
+	}
+
+	tokens, err := s.tokenService.Issue(ctx, user)
+	if errors.Is(err, domain.ErrAccountInactive) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.New("failed to generate jwt")
+	}
+	return &domain.AuthResponse{
+		Token:        tokens.AccessToken,
+		Name:         identity.Name,
+		Email:        identity.Email,
+		UserID:       user.ID,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// fakeVerifier accepts a credential naming the identity it stands for
+type fakeVerifier struct {
+	domain.IdentityVerifier
+	identities map[string]*domain.Identity
+}
+
+func (f *fakeVerifier) Verify(ctx context.Context, provider, credential string) (*domain.Identity, error) {
+	identity, ok := f.identities[credential]
+	if !ok || identity.Provider != provider {
+		return nil, errors.New("invalid id token")
+	}
+	return identity, nil
+}
+
+func TestAuthenticateLinksIdentity(t *testing.T) {
+	ctx := context.Background()
+	users := &fakeUserRepo{users: []*entities.User{{ID: 7, TenantID: 1, Email: "jane@example.com", Role: "Employee"}}}
+	invitations := NewInvitationService(&fakeInvitationRepo{users: users}, users, &fakeTenantRepo{}, &fakeAuditRepo{}, "example.com")
+	verifier := &fakeVerifier{identities: map[string]*domain.Identity{
+		"jane":      {Provider: "google", Subject: "g-1", Email: "jane@example.com"},
+		"jane-okta": {Provider: "okta", Subject: "g-1", Email: "jane@example.com"},
+		"imposter":  {Provider: "google", Subject: "g-2", Email: "jane@example.com"},
+		"new-hire":  {Provider: "okta", Subject: "o-1", Email: "new.hire@example.com"},
+	}}
+	_, _, tokens := newTestTokenService()
+	service := NewOIDCAuthService(verifier, users, invitations, tokens)
+
+	// An existing user is linked to the account they first sign in with
+	if _, err := service.Authenticate(ctx, "google", "jane"); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	jane := users.users[0]
+	if jane.AuthProvider == nil || *jane.AuthProvider != "google" || *jane.AuthSubject != "g-1" {
+		t.Fatalf("user linked to %v/%v, want google/g-1", jane.AuthProvider, jane.AuthSubject)
+	}
+	if _, err := service.Authenticate(ctx, "google", "jane"); err != nil {
+		t.Errorf("Authenticate() from the linked account error = %v", err)
+	}
+	for _, credential := range []string{"imposter", "jane-okta"} {
+		if _, err := service.Authenticate(ctx, verifier.identities[credential].Provider, credential); !errors.Is(err, domain.ErrIdentityMismatch) {
+			t.Errorf("Authenticate() as %s error = %v, want %v", credential, err, domain.ErrIdentityMismatch)
+		}
+	}
+
+	// Provisioned users are linked on creation
+	resp, err := service.Authenticate(ctx, "okta", "new-hire")
+	if err != nil {
+		t.Fatalf("Authenticate() of a new user error = %v", err)
+	}
+	created := users.users[len(users.users)-1]
+	if created.ID != resp.UserID || created.AuthProvider == nil || *created.AuthProvider != "okta" || *created.AuthSubject != "o-1" {
+		t.Errorf("provisioned user = %+v, want it linked to okta/o-1", created)
+	}
+}
@@ -15,24 +15,56 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
+	"github.com/openai/openai-go/shared"
 	"github.com/talent-fit/backend/internal/config"
 	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/utils"
 )
 
 // MultiProviderEmbeddingService implements the domain.EmbeddingService interface using multiple AI providers
 // Currently supports OpenAI (for embeddings) and Grok (for chat completions)
 type MultiProviderEmbeddingService struct {
-	openaiClient *openai.Client
-	grokClient   *openai.Client
-	config       *config.Config
+	openaiClient  *openai.Client
+	grokClient    *openai.Client
+	config        *config.Config
+	usageRecorder domain.AIUsageRecorder
+	// tenants overrides the chat provider and model per tenant
+	tenants domain.TenantSettingsProvider
+
+	// tenantClients holds the clients of tenants' own chat providers, by base URL and key
+	mu            sync.Mutex
+	tenantClients map[string]*openai.Client
+}
+
+// chatTarget is the provider, client and model a chat call goes to
+type chatTarget struct {
+	provider string
+	client   *openai.Client
+	model    string
 }
 
-// NewOpenAIEmbeddingService creates a new multi-provider embedding service
+// Providers and operations recorded in AI usage
+const (
+	providerOpenAI     = "openai"
+	providerGrok       = "grok"
+	operationEmbedding = "embedding"
+	operationSummarize = "summarize"
+	operationScore     = "score"
+	operationParse     = "parse_query"
+	operationExtract   = "extract_cv"
+)
+
+// NewOpenAIEmbeddingService creates a new multi-provider embedding service.
+// usageRecorder may be nil, in which case provider calls are not recorded, and tenants may be nil,
+// in which case every chat call uses the deployment's provider.
 // TODO: Consider renaming to NewMultiProviderEmbeddingService in future refactor
-func NewOpenAIEmbeddingService(cfg *config.Config) domain.EmbeddingService {
+func NewOpenAIEmbeddingService(cfg *config.Config, usageRecorder domain.AIUsageRecorder, tenants domain.TenantSettingsProvider) domain.EmbeddingService {
 	// Initialize OpenAI client
 	openaiClient := openai.NewClient(
 		option.WithAPIKey(cfg.AI.OpenAIAPIKey),
@@ -45,9 +77,12 @@ func NewOpenAIEmbeddingService(cfg *config.Config) domain.EmbeddingService {
 	)
 
 	return &MultiProviderEmbeddingService{
-		openaiClient: &openaiClient,
-		grokClient:   &grokClient,
-		config:       cfg,
+		openaiClient:  &openaiClient,
+		grokClient:    &grokClient,
+		config:        cfg,
+		usageRecorder: usageRecorder,
+		tenants:       tenants,
+		tenantClients: make(map[string]*openai.Client),
 	}
 }
 
@@ -58,12 +93,14 @@ func (s *MultiProviderEmbeddingService) GenerateEmbedding(ctx context.Context, t
 	}
 
 	// Create embedding request using OpenAI client
+	started := time.Now()
 	embedding, err := s.openaiClient.Embeddings.New(ctx, openai.EmbeddingNewParams{
 		Input: openai.EmbeddingNewParamsInputUnion{
 			OfString: openai.String(text),
 		},
 		Model: openai.EmbeddingModelTextEmbedding3Small,
 	})
+	s.recordEmbeddingUsage(ctx, started, embedding, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate embedding: %w", err)
 	}
@@ -102,12 +139,14 @@ func (s *MultiProviderEmbeddingService) GenerateBatchEmbeddings(ctx context.Cont
 	}
 
 	// Create embedding request using OpenAI client
+	started := time.Now()
 	embedding, err := s.openaiClient.Embeddings.New(ctx, openai.EmbeddingNewParams{
 		Input: openai.EmbeddingNewParamsInputUnion{
 			OfArrayOfStrings: validTexts,
 		},
 		Model: openai.EmbeddingModelTextEmbedding3Small,
 	})
+	s.recordEmbeddingUsage(ctx, started, embedding, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate batch embeddings: %w", err)
 	}
@@ -130,40 +169,22 @@ func (s *MultiProviderEmbeddingService) GenerateBatchEmbeddings(ctx context.Cont
 	return embeddings, nil
 }
 
-func (s *MultiProviderEmbeddingService) SummarizeProject(ctx context.Context, description string, seats map[string]int) (string, error) {
-	var roles []string
-	for role, count := range seats {
-		roles = append(roles, fmt.Sprintf("%d %s", count, strings.Title(role)))
-	}
-
-	prompt := fmt.Sprintf(`You are given a project description and role requirements.
-      1. Extract key skills grouped strictly by the role types provided (Backend, Frontend, AI, Project Manager).
-       Do not invent new role categories.
-    2. Keep only specific technical or management skills (languages, frameworks, tools, methodologies)
-        include cloude infra infer from description if not provided use aws as default. Also think dev ops skills as well.
-    3. Exclude generic soft skills like communication, leadership, teamwork, adaptability, fast learner.
-    4. For each role, limit to 5–7 skills maximum and remove duplicates across roles.
-    5. Identify years of experience, geo, and industry if mentioned.
-       - If geo is given as a timezone, map it to the most likely country or region. If adn only if no geo information found default to India
-         Examples: "MT timezone" → "United States"; "CET timezone" → "Europe"; "IST" → "India".
-       - Infer industry from description, If  is unclear, output "Unspecified".
-       - If experience is not mentioned infer by industry standard, if unable to do so then output "Unspecified".
-    6. Try to break down skill's required (example .net tech stack => c#, sql server, rest api, .net core etc)
-    7. Summarize in the format:
-	"Project requires: Skills: <skills>, Experience: <experience>, Location/Geo: <geo>, Industry: <industry>. Roles: <roles>."
-	Description: %s
-	Roles: %s`, description, strings.Join(roles, ", "))
-
-	resp, err := s.grokClient.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
-		Model: s.config.AI.GrokModel,
+// SummarizeProject summarises project requirements using a rendered summarize_project prompt
+func (s *MultiProviderEmbeddingService) SummarizeProject(ctx context.Context, prompt *domain.Prompt) (string, error) {
+	target, err := s.chat(ctx)
+	if err != nil {
+		return "", err
+	}
+	started := time.Now()
+	resp, err := target.client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+		Model: target.model,
 		Messages: []openai.ChatCompletionMessageParamUnion{
-			openai.SystemMessage(`You are an expert technical recruiter and project analyst.
-			Your role is to analyze project descriptions and extract structured technical requirements, skills,
-			and role specifications for talent matching purposes. Focus on identifying specific technical skills,
-			experience levels, normalize geographic info to countries/regions, and project requirements while filtering out generic soft skills.`),
-			openai.UserMessage(prompt),
+			openai.SystemMessage(prompt.System),
+			openai.UserMessage(prompt.User),
 		},
+		ResponseFormat: s.summaryResponseFormat(),
 	})
+	s.recordChatUsage(ctx, target, operationSummarize, started, resp, err)
 	if err != nil {
 		return "", fmt.Errorf("summarization failed: %w", err)
 	}
@@ -175,24 +196,22 @@ func (s *MultiProviderEmbeddingService) SummarizeProject(ctx context.Context, de
 	return strings.TrimSpace(resp.Choices[0].Message.Content), nil
 }
 
-// GenerateMatchingScores uses the generated prompt to score candidates using Grok AI
-// This method is specifically designed to work with prompts generated by utils.EmbeddingUtils.GenerateMatchingPrompt
-func (s *MultiProviderEmbeddingService) GenerateMatchingScores(ctx context.Context, matchingPrompt string) (string, error) {
-	resp, err := s.grokClient.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
-		Model: s.config.AI.GrokModel,
+// GenerateMatchingScores uses a rendered score_candidates prompt to score candidates using Grok AI
+func (s *MultiProviderEmbeddingService) GenerateMatchingScores(ctx context.Context, prompt *domain.Prompt) (string, error) {
+	target, err := s.chat(ctx)
+	if err != nil {
+		return "", err
+	}
+	started := time.Now()
+	resp, err := target.client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+		Model: target.model,
 		Messages: []openai.ChatCompletionMessageParamUnion{
-			openai.SystemMessage(`You are an expert AI recruiter and talent matching specialist with deep expertise in:
-			- Technical skill assessment and matching
-			- Geographic and cultural considerations for remote/distributed teams
-			- Experience level evaluation and role suitability
-			- Objective candidate scoring based on project requirements
-			
-			Your task is to analyze candidates against project requirements and provide accurate, unbiased scoring.
-			Always return valid JSON format as requested. Be consistent in your scoring methodology.
-			Consider both hard skills (technical) and soft factors (availability, location, experience) as specified in the scoring rules.`),
-			openai.UserMessage(matchingPrompt),
+			openai.SystemMessage(prompt.System),
+			openai.UserMessage(prompt.User),
 		},
+		ResponseFormat: s.scoringResponseFormat(),
 	})
+	s.recordChatUsage(ctx, target, operationScore, started, resp, err)
 	if err != nil {
 		return "", fmt.Errorf("matching score generation failed: %w", err)
 	}
@@ -204,6 +223,173 @@ func (s *MultiProviderEmbeddingService) GenerateMatchingScores(ctx context.Conte
 	return strings.TrimSpace(resp.Choices[0].Message.Content), nil
 }
 
+// StreamMatchingScores scores candidates like GenerateMatchingScores but streams the completion,
+// passing each content delta to onDelta as Grok produces it
+func (s *MultiProviderEmbeddingService) StreamMatchingScores(ctx context.Context, prompt *domain.Prompt, onDelta func(chunk string)) (string, error) {
+	target, err := s.chat(ctx)
+	if err != nil {
+		return "", err
+	}
+	started := time.Now()
+	stream := target.client.Chat.Completions.NewStreaming(ctx, openai.ChatCompletionNewParams{
+		Model: target.model,
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(prompt.System),
+			openai.UserMessage(prompt.User),
+		},
+		ResponseFormat: s.scoringResponseFormat(),
+		// Usage arrives in a final chunk so streamed calls are costed like the others
+		StreamOptions: openai.ChatCompletionStreamOptionsParam{
+			IncludeUsage: openai.Bool(true),
+		},
+	})
+	defer stream.Close()
+
+	var content strings.Builder
+	completion := &openai.ChatCompletion{}
+	for stream.Next() {
+		chunk := stream.Current()
+		if chunk.Usage.TotalTokens > 0 {
+			completion.Usage = chunk.Usage
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+		content.WriteString(chunk.Choices[0].Delta.Content)
+		onDelta(chunk.Choices[0].Delta.Content)
+	}
+
+	err = stream.Err()
+	s.recordChatUsage(ctx, target, operationScore, started, completion, err)
+	if err != nil {
+		return "", fmt.Errorf("matching score streaming failed: %w", err)
+	}
+	if content.Len() == 0 {
+		return "", fmt.Errorf("no matching scores returned")
+	}
+
+	return strings.TrimSpace(content.String()), nil
+}
+
+// ParseTalentQuery parses a search query into filters using a rendered parse_talent_query prompt
+func (s *MultiProviderEmbeddingService) ParseTalentQuery(ctx context.Context, prompt *domain.Prompt) (string, error) {
+	target, err := s.chat(ctx)
+	if err != nil {
+		return "", err
+	}
+	started := time.Now()
+	resp, err := target.client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+		Model: target.model,
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(prompt.System),
+			openai.UserMessage(prompt.User),
+		},
+		ResponseFormat: s.summaryResponseFormat(),
+	})
+	s.recordChatUsage(ctx, target, operationParse, started, resp, err)
+	if err != nil {
+		return "", fmt.Errorf("search query parsing failed: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("no search filters returned")
+	}
+
+	return strings.TrimSpace(resp.Choices[0].Message.Content), nil
+}
+
+// ExtractProfileFromCV proposes profile fields from a rendered extract_cv_profile prompt
+func (s *MultiProviderEmbeddingService) ExtractProfileFromCV(ctx context.Context, prompt *domain.Prompt) (string, error) {
+	target, err := s.chat(ctx)
+	if err != nil {
+		return "", err
+	}
+	started := time.Now()
+	resp, err := target.client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+		Model: target.model,
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(prompt.System),
+			openai.UserMessage(prompt.User),
+		},
+		ResponseFormat: s.summaryResponseFormat(),
+	})
+	s.recordChatUsage(ctx, target, operationExtract, started, resp, err)
+	if err != nil {
+		return "", fmt.Errorf("CV extraction failed: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("no profile fields returned")
+	}
+
+	return strings.TrimSpace(resp.Choices[0].Message.Content), nil
+}
+
+// chat returns where the context's tenant's chat calls go: the tenant's own OpenAI-compatible
+// provider when it has one, else Grok, with the tenant's model if it overrides it
+func (s *MultiProviderEmbeddingService) chat(ctx context.Context) (*chatTarget, error) {
+	target := &chatTarget{provider: providerGrok, client: s.grokClient, model: s.config.AI.GrokModel}
+	if s.tenants == nil {
+		return target, nil
+	}
+	tenant, err := s.tenants.Settings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant AI settings: %w", err)
+	}
+	if tenant == nil {
+		return target, nil
+	}
+	if tenant.AIBaseURL != "" {
+		target.provider = tenant.AIProvider
+		if target.provider == "" {
+			target.provider = tenant.AIBaseURL
+		}
+		target.client = s.tenantClient(tenant.AIBaseURL, tenant.AIAPIKey)
+	}
+	if tenant.AIModel != "" {
+		target.model = tenant.AIModel
+	}
+	return target, nil
+}
+
+// tenantClient returns a client for a tenant's provider, reusing it while its settings stay the same
+func (s *MultiProviderEmbeddingService) tenantClient(baseURL, apiKey string) *openai.Client {
+	key := baseURL + "\x00" + apiKey
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if client, ok := s.tenantClients[key]; ok {
+		return client
+	}
+	client := openai.NewClient(option.WithAPIKey(apiKey), option.WithBaseURL(baseURL))
+	s.tenantClients[key] = &client
+	return &client
+}
+
+// scoringResponseFormat selects structured output for scoring calls based on provider support.
+// "json_schema" enforces utils.CandidateScoreSchema, "json_object" only guarantees valid JSON,
+// and "text" leaves the response unconstrained for providers without JSON mode.
+func (s *MultiProviderEmbeddingService) scoringResponseFormat() openai.ChatCompletionNewParamsResponseFormatUnion {
+	switch s.config.AI.ScoringResponseFormat {
+	case "json_schema":
+		return openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONSchema: &shared.ResponseFormatJSONSchemaParam{
+				JSONSchema: shared.ResponseFormatJSONSchemaJSONSchemaParam{
+					Name:        "candidate_scores",
+					Description: openai.String("Scores for every candidate in the matching prompt"),
+					Schema:      utils.CandidateScoreSchema(),
+					Strict:      openai.Bool(true),
+				},
+			},
+		}
+	case "json_object":
+		return openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONObject: &shared.ResponseFormatJSONObjectParam{},
+		}
+	default:
+		return openai.ChatCompletionNewParamsResponseFormatUnion{}
+	}
+}
+
 // Helper methods for future extensibility
 
 // GetEmbeddingClient returns the client to use for embedding generation
@@ -225,3 +411,55 @@ func (s *MultiProviderEmbeddingService) GetEmbeddingModel() string {
 func (s *MultiProviderEmbeddingService) GetChatModel() string {
 	return s.config.AI.GrokModel
 }
+
+// summaryResponseFormat requests JSON output for project summaries unless the provider only supports text.
+// Requirements are parsed leniently, so plain JSON mode is enough here.
+func (s *MultiProviderEmbeddingService) summaryResponseFormat() openai.ChatCompletionNewParamsResponseFormatUnion {
+	if s.config.AI.ScoringResponseFormat == "text" {
+		return openai.ChatCompletionNewParamsResponseFormatUnion{}
+	}
+	return openai.ChatCompletionNewParamsResponseFormatUnion{
+		OfJSONObject: &shared.ResponseFormatJSONObjectParam{},
+	}
+}
+
+// recordEmbeddingUsage records an embedding call; resp is nil when the call failed
+func (s *MultiProviderEmbeddingService) recordEmbeddingUsage(ctx context.Context, started time.Time, resp *openai.CreateEmbeddingResponse, callErr error) {
+	usage := &entities.AIUsage{
+		Provider:  providerOpenAI,
+		Model:     string(openai.EmbeddingModelTextEmbedding3Small),
+		Operation: operationEmbedding,
+	}
+	if resp != nil {
+		usage.PromptTokens = resp.Usage.PromptTokens
+		usage.TotalTokens = resp.Usage.TotalTokens
+	}
+	s.recordUsage(ctx, usage, started, callErr)
+}
+
+// recordChatUsage records a chat completion call; resp is nil when the call failed
+func (s *MultiProviderEmbeddingService) recordChatUsage(ctx context.Context, target *chatTarget, operation string, started time.Time, resp *openai.ChatCompletion, callErr error) {
+	usage := &entities.AIUsage{
+		Provider:  target.provider,
+		Model:     target.model,
+		Operation: operation,
+	}
+	if resp != nil {
+		usage.PromptTokens = resp.Usage.PromptTokens
+		usage.CompletionTokens = resp.Usage.CompletionTokens
+		usage.TotalTokens = resp.Usage.TotalTokens
+	}
+	s.recordUsage(ctx, usage, started, callErr)
+}
+
+func (s *MultiProviderEmbeddingService) recordUsage(ctx context.Context, usage *entities.AIUsage, started time.Time, callErr error) {
+	if s.usageRecorder == nil {
+		return
+	}
+	usage.LatencyMs = time.Since(started).Milliseconds()
+	usage.Success = callErr == nil
+	if callErr != nil {
+		usage.Error = callErr.Error()
+	}
+	s.usageRecorder.Record(ctx, usage)
+}
@@ -16,7 +16,7 @@ func TestOpenAIEmbeddingService_GenerateEmbedding(t *testing.T) {
 		},
 	}
 
-	service := NewOpenAIEmbeddingService(cfg)
+	service := NewOpenAIEmbeddingService(cfg, nil, nil)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -77,7 +77,7 @@ func TestOpenAIEmbeddingService_GenerateBatchEmbeddings(t *testing.T) {
 		},
 	}
 
-	service := NewOpenAIEmbeddingService(cfg)
+	service := NewOpenAIEmbeddingService(cfg, nil, nil)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// orgUnitParentKinds maps each org unit kind to the kind its parent must be
+var orgUnitParentKinds = map[string]string{
+	entities.OrgUnitCompany:    "",
+	entities.OrgUnitPractice:   entities.OrgUnitCompany,
+	entities.OrgUnitDepartment: entities.OrgUnitPractice,
+}
+
+// OrgUnitService implements domain.OrgUnitService
+type OrgUnitService struct {
+	orgUnitRepo domain.OrgUnitRepository
+	userRepo    domain.UserRepository
+}
+
+// NewOrgUnitService creates a new org unit service
+func NewOrgUnitService(orgUnitRepo domain.OrgUnitRepository, userRepo domain.UserRepository) domain.OrgUnitService {
+	return &OrgUnitService{orgUnitRepo: orgUnitRepo, userRepo: userRepo}
+}
+
+// ListOrgUnits returns every org unit, companies first
+func (s *OrgUnitService) ListOrgUnits(ctx context.Context) ([]*models.OrgUnitModel, error) {
+	units, err := s.orgUnitRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list org units: %w", err)
+	}
+	result := make([]*models.OrgUnitModel, len(units))
+	for i, unit := range units {
+		result[i] = &models.OrgUnitModel{}
+		result[i].FromEntity(unit)
+	}
+	return result, nil
+}
+
+// CreateOrgUnit adds an org unit under its parent
+func (s *OrgUnitService) CreateOrgUnit(ctx context.Context, request *models.OrgUnitRequest) (*models.OrgUnitModel, error) {
+	unit := &entities.OrgUnit{}
+	if err := s.apply(ctx, unit, request); err != nil {
+		return nil, err
+	}
+	if err := s.orgUnitRepo.Create(ctx, unit); err != nil {
+		if errors.Is(err, domain.ErrOrgUnitExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create org unit: %w", err)
+	}
+	var model models.OrgUnitModel
+	model.FromEntity(unit)
+	return &model, nil
+}
+
+// UpdateOrgUnit renames, moves or changes the head of an org unit. Units with children keep
+// their kind, so the tree stays company > practice > department.
+func (s *OrgUnitService) UpdateOrgUnit(ctx context.Context, id uint, request *models.OrgUnitRequest) (*models.OrgUnitModel, error) {
+	unit, err := s.orgUnitRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Kind != unit.Kind {
+		subtree, err := s.orgUnitRepo.SubtreeIDs(ctx, []uint{id})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load org units: %w", err)
+		}
+		if len(subtree) > 1 {
+			return nil, fmt.Errorf("%w: move or delete its child units before changing its kind", domain.ErrInvalidOrgUnit)
+		}
+	}
+	if err := s.apply(ctx, unit, request); err != nil {
+		return nil, err
+	}
+	if err := s.orgUnitRepo.Update(ctx, unit); err != nil {
+		if errors.Is(err, domain.ErrOrgUnitExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update org unit: %w", err)
+	}
+	var model models.OrgUnitModel
+	model.FromEntity(unit)
+	return &model, nil
+}
+
+// DeleteOrgUnit removes an org unit nothing belongs to
+func (s *OrgUnitService) DeleteOrgUnit(ctx context.Context, id uint) error {
+	err := s.orgUnitRepo.Delete(ctx, id)
+	if err != nil && !errors.Is(err, domain.ErrOrgUnitNotFound) && !errors.Is(err, domain.ErrOrgUnitInUse) {
+		return fmt.Errorf("failed to delete org unit: %w", err)
+	}
+	return err
+}
+
+// Scope resolves an org_unit filter, or the user's default org units without one
+func (s *OrgUnitService) Scope(ctx context.Context, userID uint, filter string) ([]uint, error) {
+	filter = strings.TrimSpace(filter)
+	var roots []uint
+	switch {
+	case strings.EqualFold(filter, domain.OrgScopeAll):
+		return nil, nil
+	case filter != "":
+		id, err := strconv.ParseUint(filter, 10, 32)
+		if err != nil {
+			return nil, domain.ErrInvalidOrgScope
+		}
+		if _, err := s.orgUnitRepo.GetByID(ctx, uint(id)); err != nil {
+			return nil, err
+		}
+		roots = []uint{uint(id)}
+	case userID == 0:
+		// Service accounts have no org unit of their own
+		return nil, nil
+	default:
+		headed, err := s.orgUnitRepo.GetHeadedBy(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load headed org units: %w", err)
+		}
+		for _, unit := range headed {
+			roots = append(roots, unit.ID)
+		}
+		if len(roots) == 0 {
+			member, err := s.orgUnitRepo.GetMemberUnitID(ctx, userID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load org unit: %w", err)
+			}
+			if member == nil {
+				return nil, nil
+			}
+			roots = []uint{*member}
+		}
+	}
+
+	subtree, err := s.orgUnitRepo.SubtreeIDs(ctx, roots)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load org units: %w", err)
+	}
+	return subtree, nil
+}
+
+// apply validates a request against the tree and copies it onto the unit
+func (s *OrgUnitService) apply(ctx context.Context, unit *entities.OrgUnit, request *models.OrgUnitRequest) error {
+	name := strings.TrimSpace(request.Name)
+	kind := strings.ToLower(strings.TrimSpace(request.Kind))
+	parentKind, ok := orgUnitParentKinds[kind]
+	if name == "" || !ok {
+		return fmt.Errorf("%w: name is required and kind must be company, practice or department", domain.ErrInvalidOrgUnit)
+	}
+
+	if parentKind == "" {
+		if request.ParentID != nil {
+			return fmt.Errorf("%w: a company cannot have a parent", domain.ErrInvalidOrgUnit)
+		}
+	} else {
+		if request.ParentID == nil {
+			return fmt.Errorf("%w: a %s needs a parent %s", domain.ErrInvalidOrgUnit, kind, parentKind)
+		}
+		parent, err := s.orgUnitRepo.GetByID(ctx, *request.ParentID)
+		if errors.Is(err, domain.ErrOrgUnitNotFound) {
+			return fmt.Errorf("%w: parent %d not found", domain.ErrInvalidOrgUnit, *request.ParentID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load parent org unit: %w", err)
+		}
+		if parent.Kind != parentKind {
+			return fmt.Errorf("%w: a %s's parent must be a %s", domain.ErrInvalidOrgUnit, kind, parentKind)
+		}
+	}
+
+	if request.HeadID != nil {
+		_, err := s.userRepo.GetByID(ctx, strconv.FormatUint(uint64(*request.HeadID), 10))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: head %d not found", domain.ErrInvalidOrgUnit, *request.HeadID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load head: %w", err)
+		}
+	}
+
+	unit.Name = name
+	unit.Kind = kind
+	unit.ParentID = request.ParentID
+	unit.HeadID = request.HeadID
+	return nil
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+type fakeOrgUnitRepo struct {
+	domain.OrgUnitRepository
+	units   []*entities.OrgUnit
+	members map[uint]uint
+}
+
+func (f *fakeOrgUnitRepo) GetByID(ctx context.Context, id uint) (*entities.OrgUnit, error) {
+	for _, unit := range f.units {
+		if unit.ID == id {
+			return unit, nil
+		}
+	}
+	return nil, domain.ErrOrgUnitNotFound
+}
+
+func (f *fakeOrgUnitRepo) SubtreeIDs(ctx context.Context, ids []uint) ([]uint, error) {
+	in := make(map[uint]bool)
+	for _, id := range ids {
+		in[id] = true
+	}
+	var subtree []uint
+	for _, unit := range f.units {
+		// Parents come before their children in the fixture
+		if in[unit.ID] || (unit.ParentID != nil && in[*unit.ParentID]) {
+			in[unit.ID] = true
+			subtree = append(subtree, unit.ID)
+		}
+	}
+	return subtree, nil
+}
+
+func (f *fakeOrgUnitRepo) GetHeadedBy(ctx context.Context, userID uint) ([]*entities.OrgUnit, error) {
+	var headed []*entities.OrgUnit
+	for _, unit := range f.units {
+		if unit.HeadID != nil && *unit.HeadID == userID {
+			headed = append(headed, unit)
+		}
+	}
+	return headed, nil
+}
+
+func (f *fakeOrgUnitRepo) GetMemberUnitID(ctx context.Context, userID uint) (*uint, error) {
+	if id, ok := f.members[userID]; ok {
+		return &id, nil
+	}
+	return nil, nil
+}
+
+func TestOrgUnitScope(t *testing.T) {
+	ptr := func(id uint) *uint { return &id }
+	repo := &fakeOrgUnitRepo{
+		units: []*entities.OrgUnit{
+			{ID: 1, Kind: entities.OrgUnitCompany},
+			{ID: 2, Kind: entities.OrgUnitPractice, ParentID: ptr(1), HeadID: ptr(10)},
+			{ID: 3, Kind: entities.OrgUnitDepartment, ParentID: ptr(2)},
+			{ID: 4, Kind: entities.OrgUnitPractice, ParentID: ptr(1)},
+		},
+		members: map[uint]uint{10: 1, 11: 3},
+	}
+	service := NewOrgUnitService(repo, nil)
+
+	tests := []struct {
+		name    string
+		userID  uint
+		filter  string
+		want    []uint
+		wantErr error
+	}{
+		{name: "practice head sees their practice", userID: 10, want: []uint{2, 3}},
+		{name: "manager sees their own unit", userID: 11, want: []uint{3}},
+		{name: "user outside the tree sees everything", userID: 12, want: nil},
+		{name: "service account sees everything", userID: 0, want: nil},
+		{name: "filter selects a unit and below", userID: 11, filter: "1", want: []uint{1, 2, 3, 4}},
+		{name: "all overrides the default", userID: 10, filter: "all", want: nil},
+		{name: "unknown unit", userID: 10, filter: "99", wantErr: domain.ErrOrgUnitNotFound},
+		{name: "invalid filter", userID: 10, filter: "cloud", wantErr: domain.ErrInvalidOrgScope},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.Scope(context.Background(), tt.userID, tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Scope() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
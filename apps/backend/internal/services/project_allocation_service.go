@@ -103,7 +103,7 @@ func (s *ProjectAllocationService) CreateAllocation(ctx context.Context, allocat
 		model.FromEntity(createdAllocation)
 		result = append(result, model)
 
-        // Trigger: Allocation assigned -> notify employee
+        // Trigger: Allocation assigned -> notify employee and their managers
         subject := "Project allocation assigned"
         fullName := strings.TrimSpace(strings.Trim(createdAllocation.Employee.FirstName+" "+createdAllocation.Employee.LastName, " "))
         body := "You have been allocated to project " + createdAllocation.Project.Name
@@ -114,16 +114,11 @@ func (s *ProjectAllocationService) CreateAllocation(ctx context.Context, allocat
             Type:    domain.NotificationTypeAllocationAssigned,
             Subject: subject,
             Body:    body,
+            // Recipients: the employee, their line manager and the project's delivery manager
             Metadata: map[string]string{
-                "projectId": strconv.FormatInt(int64(createdAllocation.ProjectID), 10),
-                "employeeId": strconv.FormatInt(int64(createdAllocation.EmployeeID), 10),
+                domain.MetadataProjectID:  strconv.FormatInt(int64(createdAllocation.ProjectID), 10),
+                domain.MetadataEmployeeID: strconv.FormatInt(int64(createdAllocation.EmployeeID), 10),
             },
-            Recipients: []domain.Recipient{{
-                UserID:  uint(createdAllocation.EmployeeID),
-                Email:   createdAllocation.Employee.Email,
-                SlackID: createdAllocation.Employee.SlackUserID,
-                Role:    createdAllocation.Employee.Role,
-            }},
         }
         _ = s.orchestrator.Dispatch(ctx, msg)
 	}
@@ -9,32 +9,30 @@ import (
 	"github.com/talent-fit/backend/internal/domain"
 	"github.com/talent-fit/backend/internal/entities"
 	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/internal/prompts"
+	"github.com/talent-fit/backend/internal/redaction"
 	"github.com/talent-fit/backend/internal/utils"
 )
 
 // ProjectService implements the domain.ProjectService interface
 type ProjectService struct {
-	projectRepo      domain.ProjectRepository
-	embeddingService domain.EmbeddingService
-	embeddingUtils   *utils.EmbeddingEntityUtils
-	allocationRepo   domain.ProjectAllocationRepository
-	orchestrator     domain.NotificationOrchestrator
+	projectRepo  domain.ProjectRepository
+	orchestrator domain.NotificationOrchestrator
+	enrichment   domain.EnrichmentQueue
 }
 
 // NewProjectService creates a new project service
-func NewProjectService(projectRepo domain.ProjectRepository, embeddingService domain.EmbeddingService, allocationRepo domain.ProjectAllocationRepository, orchestrator domain.NotificationOrchestrator) domain.ProjectService {
+func NewProjectService(projectRepo domain.ProjectRepository, orchestrator domain.NotificationOrchestrator, enrichment domain.EnrichmentQueue) domain.ProjectService {
 	return &ProjectService{
-		projectRepo:      projectRepo,
-		embeddingService: embeddingService,
-		embeddingUtils:   utils.NewEmbeddingEntityUtils(embeddingService),
-		allocationRepo:   allocationRepo,
-		orchestrator:     orchestrator,
+		projectRepo:  projectRepo,
+		orchestrator: orchestrator,
+		enrichment:   enrichment,
 	}
 }
 
-// GetAllProjects retrieves all projects
-func (s *ProjectService) GetAllProjects(ctx context.Context) ([]*models.ProjectModel, error) {
-	entities, err := s.projectRepo.GetAll(ctx)
+// GetAllProjects retrieves the projects in the given org units, all when nil
+func (s *ProjectService) GetAllProjects(ctx context.Context, orgUnitIDs []uint) ([]*models.ProjectModel, error) {
+	entities, err := s.projectRepo.GetAll(ctx, orgUnitIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -58,31 +56,26 @@ func (s *ProjectService) GetProjectByID(ctx context.Context, id int) (*models.Pr
 	return model, nil
 }
 
-// CreateProject creates a new project
+// CreateProject creates a new project. The summary and embedding are generated by a queued
+// enrichment job; the project is matchable once its enrichment status is ready.
 func (s *ProjectService) CreateProject(ctx context.Context, project *models.ProjectModel) (*models.ProjectModel, error) {
 	entity := project.ToEntity()
+	entity.EnrichmentStatus = entities.EnrichmentStatusPending
 
-	// Generate project summary if description exists
-	if entity.Description != "" {
-		summary, err := s.embeddingService.SummarizeProject(ctx, entity.Description, entity.SeatsByType)
-		if err != nil {
-			log.Printf("Warning: Failed to generate project summary: %v", err)
-		} else {
-			entity.Summary = summary
-		}
-	}
-
-	// Generate embedding before creating
-	err := s.embeddingUtils.GenerateProjectEmbedding(ctx, entity)
-	if err != nil {
-		// Log the error but don't fail the creation - embedding generation is optional
-		log.Printf("Warning: Failed to generate embedding for project: %v", err)
+	// Requirements supplied by the manager take precedence over the summariser
+	summarize := false
+	if entity.Requirements != nil {
+		s.applyManagerRequirements(entity)
+	} else {
+		summarize = entity.Description != ""
 	}
 
 	createdEntity, err := s.projectRepo.Create(ctx, entity)
 	if err != nil {
 		return nil, err
 	}
+	s.enqueueEnrichment(ctx, createdEntity.ID, summarize)
+
 	model := &models.ProjectModel{}
 	model.FromEntity(createdEntity)
 	return model, nil
@@ -98,71 +91,152 @@ func (s *ProjectService) UpdateProject(ctx context.Context, id int, project *mod
 		return nil, fmt.Errorf("failed to get existing project: %w", err)
 	}
 
-	// Check if description or seats by type have changed
+	// Check if description, seats by type or requirements have changed
 	descriptionChanged := existingEntity.Description != entity.Description
 	seatsChanged := !compareSeatsByType(existingEntity.SeatsByType, entity.SeatsByType)
-
-	// Only regenerate summary and embedding if relevant fields changed
-	if (descriptionChanged || seatsChanged) && entity.Description != "" {
-		// Generate project summary
-		summary, err := s.embeddingService.SummarizeProject(ctx, entity.Description, entity.SeatsByType)
-		if err != nil {
-			log.Printf("Warning: Failed to generate project summary: %v", err)
-		} else {
-			entity.Summary = summary
-		}
-
-		if entity.Summary != "" {
-			// Generate embedding (force update since content changed)
-			err = s.embeddingUtils.UpdateProjectEmbedding(ctx, entity, true)
-			if err != nil {
-				log.Printf("Warning: Failed to generate embedding for project update: %v", err)
-			}
-		} else {
-			entity.Embedding = existingEntity.Embedding
-		}
+	requirementsEdited := entity.Requirements != nil && !sameRequirements(normalizedRequirements(entity), existingEntity.Requirements)
+
+	// Keep the current summary and embedding until the enrichment job replaces them
+	entity.Summary = existingEntity.Summary
+	entity.SummaryPromptVersion = existingEntity.SummaryPromptVersion
+	entity.Embedding = existingEntity.Embedding
+
+	enrich, summarize := false, false
+	if requirementsEdited {
+		// Manager edited the requirements: re-render the summary from them and re-embed
+		s.applyManagerRequirements(entity)
+		enrich = true
 	} else {
-		// Keep existing summary and embedding if no relevant changes
-		entity.Summary = existingEntity.Summary
-		entity.Embedding = existingEntity.Embedding
+		entity.Requirements = existingEntity.Requirements
+		if (descriptionChanged || seatsChanged) && entity.Description != "" {
+			// Only regenerate summary and embedding if relevant fields changed
+			enrich, summarize = true, true
+		}
+	}
+	if enrich {
+		entity.EnrichmentStatus = entities.EnrichmentStatusPending
 	}
 
 	updatedEntity, err := s.projectRepo.Update(ctx, id, entity)
 	if err != nil {
 		return nil, err
 	}
+	if enrich {
+		s.enqueueEnrichment(ctx, id, summarize)
+	}
 	model := &models.ProjectModel{}
 	model.FromEntity(updatedEntity)
 
-	// Trigger: Project ending date set/changed -> notify employees and manager (fallback channel)
+	// Trigger: Project ending date set/changed -> notify the allocated employees, owner and delivery manager
 	if !existingEntity.EndDate.Equal(entity.EndDate) {
-		// Gather recipients: all allocated employees (current allocations)
-		allocs, err := s.allocationRepo.GetByProjectID(ctx, strconv.Itoa(id))
-		if err == nil {
-			var recipients []domain.Recipient
-			for _, a := range allocs {
-				recipients = append(recipients, domain.Recipient{
-					UserID:  uint(a.EmployeeID),
-					Email:   a.Employee.Email,
-					SlackID: a.Employee.SlackUserID,
-					Role:    a.Employee.Role,
-				})
-			}
-			subject := "Project ending"
-			body := fmt.Sprintf("Project %s ends on %s", updatedEntity.Name, updatedEntity.EndDate.Format("2006-01-02"))
-			msg := domain.NotificationMessage{
-				Type:       domain.NotificationTypeProjectEnding,
-				Subject:    subject,
-				Body:       body,
-				Metadata:   map[string]string{"projectId": strconv.Itoa(id), "endDate": updatedEntity.EndDate.Format("2006-01-02")},
-				Recipients: recipients,
-			}
-			_ = s.orchestrator.Dispatch(ctx, msg)
+		msg := domain.NotificationMessage{
+			Type:     domain.NotificationTypeProjectEnding,
+			Subject:  "Project ending",
+			Body:     fmt.Sprintf("Project %s ends on %s", updatedEntity.Name, updatedEntity.EndDate.Format("2006-01-02")),
+			Metadata: map[string]string{domain.MetadataProjectID: strconv.Itoa(id), "endDate": updatedEntity.EndDate.Format("2006-01-02")},
 		}
+		_ = s.orchestrator.Dispatch(ctx, msg)
 	}
 	return model, nil
 }
 
+// enqueueEnrichment queues summarisation and embedding for a saved project. A failure is logged rather
+// than returned because the project itself was saved; the worker's sweep picks up projects left pending.
+func (s *ProjectService) enqueueEnrichment(ctx context.Context, projectID int, summarize bool) {
+	if err := s.enrichment.EnqueueProject(ctx, projectID, summarize); err != nil {
+		log.Printf("Warning: Failed to queue enrichment for project %d: %v", projectID, err)
+	}
+}
+
+// SummarizeProject summarises a project with the active summarize_project prompt.
+// Structured output is stored as Requirements with the summary rendered from it;
+// prose-only prompt versions still produce a text summary.
+func SummarizeProject(ctx context.Context, promptRegistry domain.PromptRegistry, embeddingService domain.EmbeddingService, redactor *redaction.Redactor, entity *entities.Project) error {
+	ctx = domain.WithAIUsage(ctx, domain.FeatureProjectSummary, domain.EntityTypeProject, entity.ID)
+
+	// Client names and contact details are tokenised before the description leaves the service
+	session := redactor.NewSession(prompts.SummarizeProject)
+	data := utils.NewSummaryPromptData(entity.Description, entity.SeatsByType)
+	session.MinimiseSummaryData(&data, entity.ClientName)
+
+	prompt, err := promptRegistry.Render(ctx, prompts.SummarizeProject, data)
+	if err != nil {
+		return err
+	}
+	summary, err := embeddingService.SummarizeProject(ctx, session.Seal(ctx, prompt))
+	if err != nil {
+		return err
+	}
+	summary = session.Restore(summary)
+	entity.SummaryPromptVersion = prompt.Version
+
+	requirements, err := utils.ParseProjectRequirements(summary)
+	if err != nil {
+		// Prose-only prompt versions (v1 or a text override) still produce a usable summary
+		log.Printf("Warning: Summary for prompt %s@%s is not structured, storing text only: %v", prompt.Name, prompt.Version, err)
+		entity.Summary = summary
+		entity.Requirements = nil
+		return nil
+	}
+
+	utils.NormalizeProjectRequirements(requirements, entity.SeatsByType)
+	entity.Requirements = requirements
+	entity.Summary = utils.RenderProjectSummary(requirements)
+	return nil
+}
+
+// EmbedProject embeds a project from a redacted copy: the description and the stored summary can name
+// the client, and the embeddings API is an external model like any other. The project keeps its real
+// text; only the embedding is set.
+func EmbedProject(ctx context.Context, embeddingUtils *utils.EmbeddingEntityUtils, redactor *redaction.Redactor, entity *entities.Project) error {
+	session := redactor.NewSession(domain.FeatureProjectEmbedding)
+	minimised := session.MinimiseEmbeddingProject(entity)
+	if err := embeddingUtils.GenerateProjectEmbedding(ctx, minimised); err != nil {
+		return err
+	}
+	entity.Embedding = minimised.Embedding
+	return nil
+}
+
+// applyManagerRequirements normalises manager-edited requirements and renders the summary from them
+func (s *ProjectService) applyManagerRequirements(entity *entities.Project) {
+	utils.NormalizeProjectRequirements(entity.Requirements, entity.SeatsByType)
+	entity.Requirements.Source = entities.RequirementsSourceManager
+	entity.Summary = utils.RenderProjectSummary(entity.Requirements)
+}
+
+// normalizedRequirements returns a copy of a project's requirements normalised as they would be
+// stored, so requirements echoed back in another order or spelling compare equal to the stored ones
+func normalizedRequirements(entity *entities.Project) *entities.ProjectRequirements {
+	requirements := *entity.Requirements
+	utils.NormalizeProjectRequirements(&requirements, entity.SeatsByType)
+	return &requirements
+}
+
+// sameRequirements reports whether two sets of requirements ask for the same roles, skills, experience,
+// geo and industry. Source and Confidence describe where requirements came from, so a client echoing
+// back AI requirements with a different source is not treated as an edit.
+func sameRequirements(a, b *entities.ProjectRequirements) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.MinExperienceYears != b.MinExperienceYears || a.Geo != b.Geo || a.Industry != b.Industry || len(a.Roles) != len(b.Roles) {
+		return false
+	}
+	for i, role := range a.Roles {
+		other := b.Roles[i]
+		if role.Role != other.Role || role.Seats != other.Seats || len(role.Skills) != len(other.Skills) {
+			return false
+		}
+		for j, skill := range role.Skills {
+			if skill != other.Skills[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // compareSeatsByType compares two SeatsByType maps for equality
 func compareSeatsByType(a, b entities.SeatsByType) bool {
 	if len(a) != len(b) {
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+func TestSameRequirements(t *testing.T) {
+	stored := func() *entities.ProjectRequirements {
+		return &entities.ProjectRequirements{
+			Roles:              []entities.RoleRequirement{{Role: "Backend", Seats: 2, Skills: []string{"Go", "PostgreSQL"}}},
+			MinExperienceYears: 3,
+			Geo:                "Europe",
+			Industry:           "Fintech",
+			Confidence:         0.8,
+			Source:             entities.RequirementsSourceAI,
+		}
+	}
+
+	tests := []struct {
+		name string
+		edit func(r *entities.ProjectRequirements)
+		want bool
+	}{
+		{"unchanged", func(r *entities.ProjectRequirements) {}, true},
+		{"source and confidence only", func(r *entities.ProjectRequirements) {
+			r.Source = entities.RequirementsSourceManager
+			r.Confidence = 0
+		}, true},
+		{"skill", func(r *entities.ProjectRequirements) { r.Roles[0].Skills[1] = "MySQL" }, false},
+		{"seats", func(r *entities.ProjectRequirements) { r.Roles[0].Seats = 3 }, false},
+		{"role added", func(r *entities.ProjectRequirements) {
+			r.Roles = append(r.Roles, entities.RoleRequirement{Role: "Frontend", Seats: 1})
+		}, false},
+		{"experience", func(r *entities.ProjectRequirements) { r.MinExperienceYears = 5 }, false},
+		{"geo", func(r *entities.ProjectRequirements) { r.Geo = "India" }, false},
+		{"industry", func(r *entities.ProjectRequirements) { r.Industry = "Retail" }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edited := stored()
+			tt.edit(edited)
+			if got := sameRequirements(edited, stored()); got != tt.want {
+				t.Errorf("sameRequirements() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if sameRequirements(stored(), nil) {
+		t.Error("sameRequirements() with no stored requirements = true, want false")
+	}
+}
+
+func TestNormalizedRequirementsMatchStored(t *testing.T) {
+	seats := entities.SeatsByType{"Backend": 2, "Frontend": 1}
+	stored := &entities.ProjectRequirements{
+		Roles: []entities.RoleRequirement{
+			{Role: "Backend", Seats: 2, Skills: []string{"Go"}},
+			{Role: "Frontend", Seats: 1, Skills: []string{"React"}},
+		},
+		Geo:      "United States",
+		Industry: "Unspecified",
+	}
+	// The client echoes the requirements back unsorted, in its own spelling and without an industry
+	echoed := &entities.Project{SeatsByType: seats, Requirements: &entities.ProjectRequirements{
+		Roles: []entities.RoleRequirement{
+			{Role: "frontend", Seats: 1, Skills: []string{" React "}},
+			{Role: "Backend", Seats: 2, Skills: []string{"Go", "go"}},
+		},
+		Geo: "USA",
+	}}
+
+	if !sameRequirements(normalizedRequirements(echoed), stored) {
+		t.Errorf("echoed requirements %+v differ from the stored ones", normalizedRequirements(echoed))
+	}
+	if echoed.Requirements.Roles[0].Role != "frontend" || echoed.Requirements.Geo != "USA" {
+		t.Errorf("normalizedRequirements() changed the project's own requirements: %+v", echoed.Requirements)
+	}
+}
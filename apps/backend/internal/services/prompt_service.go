@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/prompts"
+	"github.com/talent-fit/backend/internal/redaction"
+	"github.com/talent-fit/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+// previewCandidateLimit matches the candidate count used by match suggestions
+const previewCandidateLimit = 20
+
+// PromptService implements the domain.PromptService interface
+type PromptService struct {
+	registry     domain.PromptRegistry
+	templateRepo domain.PromptTemplateRepository
+	projectRepo  domain.ProjectRepository
+	profileRepo  domain.EmployeeProfileRepository
+	redactor     *redaction.Redactor
+}
+
+// NewPromptService creates a new prompt service
+func NewPromptService(registry domain.PromptRegistry, templateRepo domain.PromptTemplateRepository, projectRepo domain.ProjectRepository, profileRepo domain.EmployeeProfileRepository, redactor *redaction.Redactor) domain.PromptService {
+	return &PromptService{
+		registry:     registry,
+		templateRepo: templateRepo,
+		projectRepo:  projectRepo,
+		profileRepo:  profileRepo,
+		redactor:     redactor,
+	}
+}
+
+// ListVersions lists the embedded and database versions of a prompt template
+func (s *PromptService) ListVersions(ctx context.Context, name string) ([]domain.PromptVersion, error) {
+	return s.registry.Versions(ctx, name)
+}
+
+// SaveOverride validates and stores a database override, activating it when requested
+func (s *PromptService) SaveOverride(ctx context.Context, template *entities.PromptTemplate) (*entities.PromptTemplate, error) {
+	if _, err := s.registry.Versions(ctx, template.Name); err != nil {
+		return nil, err
+	}
+	if template.Version == "" {
+		return nil, fmt.Errorf("version is required")
+	}
+	if err := s.registry.Validate(template.Body); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	activate := template.Active
+	saved, err := s.templateRepo.Save(ctx, template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save prompt template: %w", err)
+	}
+
+	if activate {
+		if err := s.templateRepo.Activate(ctx, template.Name, template.Version); err != nil {
+			return nil, fmt.Errorf("failed to activate prompt template: %w", err)
+		}
+		saved.Active = true
+	}
+	s.registry.Invalidate(ctx, template.Name)
+	return saved, nil
+}
+
+// PreviewProjectPrompt renders a prompt for a project exactly as it would be sent to the model, after redaction
+func (s *PromptService) PreviewProjectPrompt(ctx context.Context, name string, version string, projectID int) (*domain.Prompt, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrPromptProjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	session := s.redactor.NewSession(name)
+	var data interface{}
+	switch name {
+	case prompts.SummarizeProject:
+		summaryData := utils.NewSummaryPromptData(project.Description, project.SeatsByType)
+		session.MinimiseSummaryData(&summaryData, project.ClientName)
+		data = summaryData
+	case prompts.ScoreCandidates:
+		candidates, err := s.profileRepo.GetSimilarAvailableProfilesWithUser(ctx, strconv.Itoa(projectID), previewCandidateLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get candidates: %w", err)
+		}
+		session.Register(redaction.KindClient, project.ClientName)
+		matchingData := utils.NewMatchingPromptData(project.Summary, candidates, utils.DefaultScoringRules())
+		session.MinimiseMatchingData(&matchingData)
+		data = matchingData
+	default:
+		return nil, fmt.Errorf("%w %q", domain.ErrUnknownPrompt, name)
+	}
+
+	var prompt *domain.Prompt
+	if version != "" {
+		prompt, err = s.registry.RenderVersion(ctx, name, version, data)
+	} else {
+		prompt, err = s.registry.Render(ctx, name, data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return session.Scrub(prompt), nil
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+// RecipientResolver routes notifications along reporting lines and project ownership
+type RecipientResolver struct {
+	userRepo       domain.UserRepository
+	projectRepo    domain.ProjectRepository
+	allocationRepo domain.ProjectAllocationRepository
+}
+
+// NewRecipientResolver creates a new recipient resolver
+func NewRecipientResolver(userRepo domain.UserRepository, projectRepo domain.ProjectRepository, allocationRepo domain.ProjectAllocationRepository) domain.RecipientResolver {
+	return &RecipientResolver{
+		userRepo:       userRepo,
+		projectRepo:    projectRepo,
+		allocationRepo: allocationRepo,
+	}
+}
+
+// Resolve returns the recipients for a notification:
+//   - roll-off alerts go to the employee's line manager and the delivery managers (or owners) of
+//     the projects they are currently allocated to
+//   - project-ending alerts go to the employees currently allocated, the project owner and the
+//     delivery manager
+//   - allocation alerts go to the employee, their line manager and the project's delivery manager
+//
+// Deactivated users are skipped. When nobody is found the message goes to the default Slack
+// channel only.
+func (r *RecipientResolver) Resolve(ctx context.Context, msg domain.NotificationMessage) ([]domain.Recipient, error) {
+	recipients := &recipientSet{seen: make(map[uint]bool)}
+	today := utils.DateOf(time.Now())
+
+	switch msg.Type {
+	case domain.NotificationTypeRolloffAlert:
+		employee, err := r.user(ctx, msg.Metadata[domain.MetadataEmployeeID])
+		if err != nil {
+			return nil, err
+		}
+		if employee == nil {
+			break
+		}
+		if err := r.addManager(ctx, recipients, employee); err != nil {
+			return nil, err
+		}
+		allocations, err := r.allocationRepo.GetByEmployeeID(ctx, strconv.FormatUint(uint64(employee.ID), 10))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load allocations: %w", err)
+		}
+		for _, allocation := range allocations {
+			if !allocationActive(allocation, today) {
+				continue
+			}
+			projectLead := allocation.Project.DeliveryManagerID
+			if projectLead == nil {
+				projectLead = allocation.Project.OwnerID
+			}
+			if err := r.addUserID(ctx, recipients, projectLead); err != nil {
+				return nil, err
+			}
+		}
+
+	case domain.NotificationTypeProjectEnding:
+		project, err := r.project(ctx, msg.Metadata[domain.MetadataProjectID])
+		if err != nil {
+			return nil, err
+		}
+		if project == nil {
+			break
+		}
+		allocations, err := r.allocationRepo.GetByProjectID(ctx, strconv.Itoa(project.ID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load allocations: %w", err)
+		}
+		for _, allocation := range allocations {
+			if allocationActive(allocation, today) {
+				recipients.add(&allocation.Employee)
+			}
+		}
+		if err := r.addUserID(ctx, recipients, project.OwnerID); err != nil {
+			return nil, err
+		}
+		if err := r.addUserID(ctx, recipients, project.DeliveryManagerID); err != nil {
+			return nil, err
+		}
+
+	case domain.NotificationTypeAllocationAssigned:
+		employee, err := r.user(ctx, msg.Metadata[domain.MetadataEmployeeID])
+		if err != nil {
+			return nil, err
+		}
+		if employee != nil {
+			recipients.add(employee)
+			if err := r.addManager(ctx, recipients, employee); err != nil {
+				return nil, err
+			}
+		}
+		project, err := r.project(ctx, msg.Metadata[domain.MetadataProjectID])
+		if err != nil {
+			return nil, err
+		}
+		if project != nil {
+			if err := r.addUserID(ctx, recipients, project.DeliveryManagerID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(recipients.list) == 0 {
+		return []domain.Recipient{{}}, nil
+	}
+	return recipients.list, nil
+}
+
+// addManager adds a user's line manager
+func (r *RecipientResolver) addManager(ctx context.Context, recipients *recipientSet, user *entities.User) error {
+	return r.addUserID(ctx, recipients, user.ManagerID)
+}
+
+// addUserID adds the user with the given ID, if set and still existing
+func (r *RecipientResolver) addUserID(ctx context.Context, recipients *recipientSet, id *uint) error {
+	if id == nil || recipients.seen[*id] {
+		return nil
+	}
+	user, err := r.user(ctx, strconv.FormatUint(uint64(*id), 10))
+	if err != nil {
+		return err
+	}
+	if user != nil {
+		recipients.add(user)
+	}
+	return nil
+}
+
+// user loads a user by ID, returning nil for a missing ID or user
+func (r *RecipientResolver) user(ctx context.Context, id string) (*entities.User, error) {
+	if id == "" {
+		return nil, nil
+	}
+	user, err := r.userRepo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user %s: %w", id, err)
+	}
+	return user, nil
+}
+
+// project loads a project by ID, returning nil for a missing ID or project
+func (r *RecipientResolver) project(ctx context.Context, id string) (*entities.Project, error) {
+	projectID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, nil
+	}
+	project, err := r.projectRepo.GetByID(ctx, projectID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project %s: %w", id, err)
+	}
+	return project, nil
+}
+
+// allocationActive reports whether an allocation has not ended before the given day
+func allocationActive(allocation *entities.ProjectAllocation, today time.Time) bool {
+	return allocation.EndDate == nil || !utils.DateOf(*allocation.EndDate).Before(today)
+}
+
+// recipientSet collects active users as recipients, each once
+type recipientSet struct {
+	list []domain.Recipient
+	seen map[uint]bool
+}
+
+func (s *recipientSet) add(user *entities.User) {
+	if user.ID == 0 || s.seen[user.ID] || user.DeactivatedAt != nil {
+		return
+	}
+	s.seen[user.ID] = true
+	s.list = append(s.list, domain.Recipient{
+		UserID:  user.ID,
+		Email:   user.Email,
+		SlackID: user.SlackUserID,
+		Role:    user.Role,
+	})
+}
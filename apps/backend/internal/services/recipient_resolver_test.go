@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// GetByID returns a copy, so only Update changes the stored user
+func (f *fakeUserRepo) GetByID(ctx context.Context, id string) (*entities.User, error) {
+	for _, user := range f.users {
+		if strconv.FormatUint(uint64(user.ID), 10) == id {
+			loaded := *user
+			return &loaded, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type fakeProjectRepo struct {
+	domain.ProjectRepository
+	projects []*entities.Project
+}
+
+func (f *fakeProjectRepo) GetByID(ctx context.Context, id int) (*entities.Project, error) {
+	for _, project := range f.projects {
+		if project.ID == id {
+			return project, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type fakeAllocationRepo struct {
+	domain.ProjectAllocationRepository
+	allocations []*entities.ProjectAllocation
+}
+
+func (f *fakeAllocationRepo) GetByProjectID(ctx context.Context, projectID string) ([]*entities.ProjectAllocation, error) {
+	var result []*entities.ProjectAllocation
+	for _, allocation := range f.allocations {
+		if strconv.Itoa(allocation.ProjectID) == projectID {
+			result = append(result, allocation)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeAllocationRepo) GetByEmployeeID(ctx context.Context, employeeID string) ([]*entities.ProjectAllocation, error) {
+	var result []*entities.ProjectAllocation
+	for _, allocation := range f.allocations {
+		if strconv.Itoa(allocation.EmployeeID) == employeeID {
+			result = append(result, allocation)
+		}
+	}
+	return result, nil
+}
+
+func TestRecipientResolver(t *testing.T) {
+	ctx := context.Background()
+	ptr := func(id uint) *uint { return &id }
+	ended := time.Now().AddDate(0, 0, -7)
+	deactivated := time.Now()
+
+	users := &fakeUserRepo{users: []*entities.User{
+		{ID: 1, Email: "line.manager@example.com"},
+		{ID: 2, Email: "delivery.manager@example.com"},
+		{ID: 3, Email: "owner@example.com"},
+		{ID: 4, Email: "dev@example.com", ManagerID: ptr(1)},
+		{ID: 5, Email: "former.dev@example.com"},
+		{ID: 6, Email: "left@example.com", DeactivatedAt: &deactivated},
+		{ID: 7, Email: "orphan@example.com", ManagerID: ptr(6)},
+	}}
+	project := &entities.Project{ID: 10, OwnerID: ptr(3), DeliveryManagerID: ptr(2)}
+	ownedOnly := &entities.Project{ID: 11, OwnerID: ptr(3)}
+	allocations := &fakeAllocationRepo{allocations: []*entities.ProjectAllocation{
+		{ProjectID: 10, EmployeeID: 4, Project: *project, Employee: *users.users[3]},
+		{ProjectID: 11, EmployeeID: 4, Project: *ownedOnly, Employee: *users.users[3]},
+		{ProjectID: 10, EmployeeID: 5, Project: *project, Employee: *users.users[4], EndDate: &ended},
+		{ProjectID: 10, EmployeeID: 2, Project: *project, Employee: *users.users[1]},
+	}}
+	resolver := NewRecipientResolver(users, &fakeProjectRepo{projects: []*entities.Project{project, ownedOnly}}, allocations)
+
+	tests := []struct {
+		name string
+		msg  domain.NotificationMessage
+		want []uint
+	}{
+		{
+			name: "roll-off goes to the line manager and project leads",
+			msg:  domain.NotificationMessage{Type: domain.NotificationTypeRolloffAlert, Metadata: map[string]string{domain.MetadataEmployeeID: "4"}},
+			want: []uint{1, 2, 3},
+		},
+		{
+			name: "roll-off without a reachable manager goes to the default channel",
+			msg:  domain.NotificationMessage{Type: domain.NotificationTypeRolloffAlert, Metadata: map[string]string{domain.MetadataEmployeeID: "7"}},
+			want: []uint{0},
+		},
+		{
+			name: "project ending goes to the current team, owner and delivery manager once each",
+			msg:  domain.NotificationMessage{Type: domain.NotificationTypeProjectEnding, Metadata: map[string]string{domain.MetadataProjectID: "10"}},
+			want: []uint{4, 2, 3},
+		},
+		{
+			name: "allocation goes to the employee, line manager and delivery manager",
+			msg:  domain.NotificationMessage{Type: domain.NotificationTypeAllocationAssigned, Metadata: map[string]string{domain.MetadataEmployeeID: "4", domain.MetadataProjectID: "10"}},
+			want: []uint{4, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recipients, err := resolver.Resolve(ctx, tt.msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []uint
+			for _, recipient := range recipients {
+				got = append(got, recipient.UserID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("recipients = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("recipients = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/pdf"
+	"github.com/talent-fit/backend/internal/tabular"
+	"github.com/talent-fit/backend/internal/utils"
+)
+
+// Report columns, in output order
+var (
+	benchColumns       = []string{"employee_id", "employee", "email", "type", "department", "geo", "experience_level", "skills", "available", "working_days", "unallocated_days", "bench_from", "end_date"}
+	allocationColumns  = []string{"allocation_id", "project_id", "project", "client", "employee_id", "employee", "email", "allocation_type", "start_date", "end_date", "days_in_period"}
+	utilisationColumns = []string{"employee_id", "employee", "email", "type", "department", "working_days", "allocated_days", "extra_days", "utilisation_pct"}
+	rolloffColumns     = []string{"date", "reason", "employee_id", "employee", "email", "type", "project", "client"}
+)
+
+// Columns shown for each table in the PDF summary, which has less room than a spreadsheet
+var (
+	summaryRolloffColumns     = []string{"date", "reason", "employee", "type", "project"}
+	summaryBenchColumns       = []string{"employee", "type", "geo", "skills", "unallocated_days", "bench_from", "end_date"}
+	summaryUtilisationColumns = []string{"employee", "type", "working_days", "allocated_days", "extra_days", "utilisation_pct"}
+)
+
+// Roll-off reasons
+const (
+	rolloffEmploymentEnd = "employment_end"
+	rolloffNotice        = "notice"
+	rolloffAllocationEnd = "allocation_end"
+)
+
+// ReportService implements domain.ReportService
+type ReportService struct {
+	reportRepo       domain.ReportRepository
+	dashboardService domain.DashboardService
+}
+
+// NewReportService creates a new report service
+func NewReportService(reportRepo domain.ReportRepository, dashboardService domain.DashboardService) domain.ReportService {
+	return &ReportService{
+		reportRepo:       reportRepo,
+		dashboardService: dashboardService,
+	}
+}
+
+// WriteReport streams one report to out. Allocations stream straight from the database; bench and
+// utilisation hold the period's allocations in memory and stream the profiles.
+func (s *ReportService) WriteReport(ctx context.Context, kind string, period domain.ReportPeriod, out tabular.Writer) error {
+	switch kind {
+	case domain.ReportBench:
+		return s.writeBench(ctx, period, out)
+	case domain.ReportAllocations:
+		return s.writeAllocations(ctx, period, out)
+	case domain.ReportUtilisation:
+		return s.writeUtilisation(ctx, period, out)
+	case domain.ReportRolloffs:
+		return s.writeRolloffs(ctx, period, out)
+	default:
+		return fmt.Errorf("%w: %q", domain.ErrUnknownReport, kind)
+	}
+}
+
+// WriteSummaryPDF renders today's dashboard metrics followed by the period's roll-offs, bench and utilisation
+func (s *ReportService) WriteSummaryPDF(ctx context.Context, period domain.ReportPeriod, w io.Writer) error {
+	metrics, err := s.dashboardService.GetManagerDashboardMetrics(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to compute dashboard metrics: %w", err)
+	}
+	tables := make(map[string]*collectedTable)
+	for _, kind := range []string{domain.ReportRolloffs, domain.ReportBench, domain.ReportUtilisation} {
+		table := &collectedTable{}
+		if err := s.WriteReport(ctx, kind, period, table); err != nil {
+			return err
+		}
+		tables[kind] = table
+	}
+
+	doc := pdf.New()
+	doc.Heading("Staffing summary")
+	doc.Text(fmt.Sprintf("Period %s to %s. Generated %s.",
+		period.From.Format(tabular.DateLayout), period.To.Format(tabular.DateLayout), time.Now().UTC().Format("2006-01-02 15:04 MST")))
+
+	doc.Heading("Dashboard today")
+	doc.Table([]string{"Metric", "Count"}, [][]string{
+		{"Available engineers", fmt.Sprint(metrics.AvailableEngineers)},
+		{"Allocated engineers", fmt.Sprint(metrics.AllocatedEngineers)},
+		{"Bench resources", fmt.Sprint(metrics.BenchResources)},
+		{"Rolling off soon", fmt.Sprint(metrics.RollingOffSoon)},
+		{"Active projects", fmt.Sprint(metrics.ActiveProjects)},
+	})
+
+	sections := []struct {
+		title   string
+		kind    string
+		columns []string
+	}{
+		{"Roll-offs", domain.ReportRolloffs, summaryRolloffColumns},
+		{"Bench", domain.ReportBench, summaryBenchColumns},
+		{"Utilisation", domain.ReportUtilisation, summaryUtilisationColumns},
+	}
+	for _, section := range sections {
+		table := tables[section.kind]
+		doc.Heading(fmt.Sprintf("%s (%d)", section.title, len(table.rows)))
+		if len(table.rows) == 0 {
+			doc.Text("None in this period.")
+			continue
+		}
+		doc.Table(section.columns, table.project(section.columns))
+	}
+
+	if _, err := doc.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
+}
+
+func (s *ReportService) writeBench(ctx context.Context, period domain.ReportPeriod, out tabular.Writer) error {
+	allocations, err := s.allocationsByEmployee(ctx, period)
+	if err != nil {
+		return err
+	}
+	if err := out.WriteHeader(benchColumns); err != nil {
+		return err
+	}
+	err = s.reportRepo.EachProfile(ctx, period, func(profile *entities.EmployeeProfile) error {
+		days := utils.ComputeStaffingDays(profile, allocations[profile.UserID], period)
+		if days.Unallocated == 0 {
+			return nil
+		}
+		return out.WriteRow([]interface{}{
+			profile.UserID, fullName(profile.User), profile.User.Email, profile.Type, profile.Department, profile.Geo,
+			profile.ExperienceLevel, strings.Join(profile.Skills, ", "), profile.AvailabilityFlag,
+			days.Working, days.Unallocated, days.FirstUnallocated, profile.EndDate,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write bench report: %w", err)
+	}
+	return out.Close()
+}
+
+func (s *ReportService) writeAllocations(ctx context.Context, period domain.ReportPeriod, out tabular.Writer) error {
+	if err := out.WriteHeader(allocationColumns); err != nil {
+		return err
+	}
+	err := s.reportRepo.EachAllocation(ctx, period, func(allocation *entities.ProjectAllocation) error {
+		return out.WriteRow([]interface{}{
+			allocation.ID, allocation.ProjectID, allocation.Project.Name, allocation.Project.ClientName,
+			allocation.EmployeeID, fullName(allocation.Employee), allocation.Employee.Email, allocation.AllocationType,
+			allocation.StartDate, allocation.EndDate, utils.AllocationDaysInPeriod(allocation, period),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write allocations report: %w", err)
+	}
+	return out.Close()
+}
+
+func (s *ReportService) writeUtilisation(ctx context.Context, period domain.ReportPeriod, out tabular.Writer) error {
+	allocations, err := s.allocationsByEmployee(ctx, period)
+	if err != nil {
+		return err
+	}
+	if err := out.WriteHeader(utilisationColumns); err != nil {
+		return err
+	}
+	err = s.reportRepo.EachProfile(ctx, period, func(profile *entities.EmployeeProfile) error {
+		days := utils.ComputeStaffingDays(profile, allocations[profile.UserID], period)
+		if days.Working == 0 {
+			return nil
+		}
+		return out.WriteRow([]interface{}{
+			profile.UserID, fullName(profile.User), profile.User.Email, profile.Type, profile.Department,
+			days.Working, days.Allocated, days.Extra, days.Utilisation(),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write utilisation report: %w", err)
+	}
+	return out.Close()
+}
+
+// writeRolloffs collects the period's end dates so they can be listed in date order; unlike the
+// other reports the rows are held in memory, but there are only as many as people leaving
+func (s *ReportService) writeRolloffs(ctx context.Context, period domain.ReportPeriod, out tabular.Writer) error {
+	type rolloff struct {
+		date     time.Time
+		employee string
+		reason   string
+		row      []interface{}
+	}
+	var rolloffs []rolloff
+
+	err := s.reportRepo.EachProfile(ctx, period, func(profile *entities.EmployeeProfile) error {
+		for reason, date := range map[string]*time.Time{rolloffEmploymentEnd: profile.EndDate, rolloffNotice: profile.NoticeDate} {
+			if utils.InPeriod(date, period) {
+				name := fullName(profile.User)
+				rolloffs = append(rolloffs, rolloff{*date, name, reason, []interface{}{
+					date, reason, profile.UserID, name, profile.User.Email, profile.Type, nil, nil,
+				}})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load employment end dates: %w", err)
+	}
+	err = s.reportRepo.EachAllocation(ctx, period, func(allocation *entities.ProjectAllocation) error {
+		if utils.InPeriod(allocation.EndDate, period) {
+			name := fullName(allocation.Employee)
+			rolloffs = append(rolloffs, rolloff{*allocation.EndDate, name, rolloffAllocationEnd, []interface{}{
+				allocation.EndDate, rolloffAllocationEnd, allocation.EmployeeID, name,
+				allocation.Employee.Email, allocation.AllocationType, allocation.Project.Name, allocation.Project.ClientName,
+			}})
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load allocation end dates: %w", err)
+	}
+
+	sort.SliceStable(rolloffs, func(i, j int) bool {
+		a, b := rolloffs[i], rolloffs[j]
+		if day, other := utils.DateOf(a.date), utils.DateOf(b.date); !day.Equal(other) {
+			return day.Before(other)
+		}
+		if a.employee != b.employee {
+			return a.employee < b.employee
+		}
+		return a.reason < b.reason
+	})
+	if err := out.WriteHeader(rolloffColumns); err != nil {
+		return err
+	}
+	for _, r := range rolloffs {
+		if err := out.WriteRow(r.row); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+// allocationsByEmployee loads the period's allocations grouped by employee
+func (s *ReportService) allocationsByEmployee(ctx context.Context, period domain.ReportPeriod) (map[uint][]*entities.ProjectAllocation, error) {
+	allocations := make(map[uint][]*entities.ProjectAllocation)
+	err := s.reportRepo.EachAllocation(ctx, period, func(allocation *entities.ProjectAllocation) error {
+		employeeID := uint(allocation.EmployeeID)
+		allocations[employeeID] = append(allocations[employeeID], allocation)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load allocations: %w", err)
+	}
+	return allocations, nil
+}
+
+func fullName(user entities.User) string {
+	return strings.TrimSpace(user.FirstName + " " + user.LastName)
+}
+
+// collectedTable is a tabular.Writer that keeps the rows as text for the PDF summary
+type collectedTable struct {
+	columns []string
+	rows    [][]string
+}
+
+func (t *collectedTable) WriteHeader(columns []string) error {
+	t.columns = columns
+	return nil
+}
+
+func (t *collectedTable) WriteRow(values []interface{}) error {
+	row := make([]string, len(values))
+	for i, value := range values {
+		row[i] = tabular.FormatValue(value)
+	}
+	t.rows = append(t.rows, row)
+	return nil
+}
+
+func (t *collectedTable) Close() error {
+	return nil
+}
+
+// project returns the rows cut down to the named columns
+func (t *collectedTable) project(columns []string) [][]string {
+	indexes := make([]int, len(columns))
+	for i, column := range columns {
+		indexes[i] = -1
+		for j, name := range t.columns {
+			if name == column {
+				indexes[i] = j
+			}
+		}
+	}
+	rows := make([][]string, len(t.rows))
+	for i, row := range t.rows {
+		rows[i] = make([]string, len(columns))
+		for j, index := range indexes {
+			if index >= 0 {
+				rows[i][j] = row[index]
+			}
+		}
+	}
+	return rows
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/internal/prompts"
+	"github.com/talent-fit/backend/internal/redaction"
+	"github.com/talent-fit/backend/internal/utils"
+)
+
+// Search result limits
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+)
+
+// TalentSearchService implements the domain.TalentSearchService interface.
+// Queries are parsed into filters by the chat model, or by built-in rules when the model is
+// unavailable, over budget or not allowed to see queries; the semantic remainder is embedded
+// to rank profiles that pass the filters.
+type TalentSearchService struct {
+	profileRepo      domain.EmployeeProfileRepository
+	embeddingService domain.EmbeddingService
+	prompts          domain.PromptRegistry
+	budget           domain.AIBudget
+	redactor         *redaction.Redactor
+}
+
+// NewTalentSearchService creates a new talent search service. budget may be nil, in which case spend is not capped.
+func NewTalentSearchService(profileRepo domain.EmployeeProfileRepository, embeddingService domain.EmbeddingService, promptRegistry domain.PromptRegistry, budget domain.AIBudget, redactor *redaction.Redactor) domain.TalentSearchService {
+	return &TalentSearchService{
+		profileRepo:      profileRepo,
+		embeddingService: embeddingService,
+		prompts:          promptRegistry,
+		budget:           budget,
+		redactor:         redactor,
+	}
+}
+
+// Search runs a natural-language talent search
+func (s *TalentSearchService) Search(ctx context.Context, request *models.EmployeeSearchRequest) (*models.EmployeeSearchResponse, error) {
+	text := strings.TrimSpace(request.Query)
+	if text == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	limit := request.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	ctx = domain.WithAIUsage(ctx, domain.FeatureTalentSearch, "", 0)
+	now := time.Now()
+	query, parser := s.parse(ctx, text, now)
+
+	search := &domain.ProfileSearch{
+		Skills:             utils.ExpandSkills(query.Filters.Skills),
+		Types:              utils.ExpandTypes(query.Filters.Types),
+		Geos:               utils.ExpandGeos(query.Filters.Geos),
+		Industries:         utils.ExpandIndustries(query.Filters.Industries),
+		MinExperienceYears: query.Filters.MinExperienceYears,
+		MaxExperienceYears: query.Filters.MaxExperienceYears,
+		AvailableBy:        query.Filters.AvailableBy,
+		Limit:              limit,
+	}
+	if query.SemanticQuery != "" {
+		// Without an embedding results are still filtered, just ranked by matched skills alone
+		embedding, err := s.embeddingService.GenerateEmbedding(ctx, query.SemanticQuery)
+		if err != nil {
+			log.Printf("Warning: Failed to embed search query, ranking by filters only: %v", err)
+		} else {
+			search.Embedding = embedding
+		}
+	}
+
+	matches, err := s.profileRepo.Search(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*models.EmployeeSearchResult, 0, len(matches))
+	for _, match := range matches {
+		profile := &models.EmployeeProfileModel{}
+		profile.FromEntity(match.Profile)
+		results = append(results, &models.EmployeeSearchResult{
+			Profile:       profile,
+			Similarity:    match.Similarity,
+			AvailableFrom: match.AvailableFrom,
+			Highlights:    utils.HighlightProfile(&query.Filters, match.Profile, match.AvailableFrom, now),
+		})
+	}
+
+	return &models.EmployeeSearchResponse{
+		Query:         text,
+		Parser:        parser,
+		Filters:       query.Filters,
+		SemanticQuery: query.SemanticQuery,
+		Results:       results,
+	}, nil
+}
+
+// parse turns the query into filters with the model, falling back to the rules parser
+func (s *TalentSearchService) parse(ctx context.Context, text string, now time.Time) (*utils.TalentQuery, string) {
+	if !s.redactor.Allowed(prompts.ParseTalentQuery, "query") || s.budgetExceeded(ctx) {
+		return utils.ParseTalentQueryRules(text, now), models.SearchParserRules
+	}
+
+	query, err := s.parseWithModel(ctx, text, now)
+	if err != nil {
+		log.Printf("Warning: Failed to parse search query with the model, using rules: %v", err)
+		return utils.ParseTalentQueryRules(text, now), models.SearchParserRules
+	}
+	return query, models.SearchParserModel
+}
+
+func (s *TalentSearchService) parseWithModel(ctx context.Context, text string, now time.Time) (*utils.TalentQuery, error) {
+	session := s.redactor.NewSession(prompts.ParseTalentQuery)
+	data := utils.NewTalentQueryPromptData(text, now)
+	session.MinimiseSearchData(&data)
+
+	prompt, err := s.prompts.Render(ctx, prompts.ParseTalentQuery, data)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.embeddingService.ParseTalentQuery(ctx, session.Seal(ctx, prompt))
+	if err != nil {
+		return nil, err
+	}
+	return utils.ParseTalentQueryResponse(session.Restore(response), now)
+}
+
+// budgetExceeded checks the monthly AI budget; a failed check does not block the model
+func (s *TalentSearchService) budgetExceeded(ctx context.Context) bool {
+	if s.budget == nil {
+		return false
+	}
+	exceeded, err := s.budget.BudgetExceeded(ctx)
+	if err != nil {
+		log.Printf("Warning: failed to check AI budget: %v", err)
+		return false
+	}
+	return exceeded
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// tenantSlugPattern is a lowercase DNS label, e.g. "acme-uk"
+var tenantSlugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// TenantService implements the domain.TenantService interface
+type TenantService struct {
+	tenantRepo domain.TenantRepository
+	userRepo   domain.UserRepository
+	auditRepo  domain.AuditRepository
+}
+
+// NewTenantService creates a new tenant service
+func NewTenantService(tenantRepo domain.TenantRepository, userRepo domain.UserRepository, auditRepo domain.AuditRepository) domain.TenantService {
+	return &TenantService{
+		tenantRepo: tenantRepo,
+		userRepo:   userRepo,
+		auditRepo:  auditRepo,
+	}
+}
+
+// Settings returns the context's tenant, or nil without one
+func (s *TenantService) Settings(ctx context.Context) (*entities.Tenant, error) {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	return s.tenantRepo.GetByID(ctx, tenantID)
+}
+
+// CreateTenant creates a tenant and its first admin
+func (s *TenantService) CreateTenant(ctx context.Context, request *models.CreateTenantRequest) (*models.TenantModel, error) {
+	name := strings.TrimSpace(request.Name)
+	slug := strings.ToLower(strings.TrimSpace(request.Slug))
+	if name == "" || !tenantSlugPattern.MatchString(slug) {
+		return nil, domain.ErrInvalidTenantSettings
+	}
+	email := strings.ToLower(strings.TrimSpace(request.AdminEmail))
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return nil, domain.ErrInvalidUser
+	}
+	// Emails are unique across tenants, so look the admin up in all of them
+	if _, err := s.userRepo.GetByEmail(domain.AllTenants(ctx), email); err == nil {
+		return nil, domain.ErrTenantAdminEmailExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check user: %w", err)
+	}
+
+	// Sign-up domains are not verified, so they are only set here, by operators, and never shared
+	signUpDomains := parseEmailDomains(request.SignUpDomains)
+	for _, emailDomain := range signUpDomains {
+		claimedBy, err := s.tenantRepo.GetBySignUpDomain(ctx, emailDomain)
+		if err == nil {
+			return nil, fmt.Errorf("%w: sign-up domain %s belongs to tenant %s", domain.ErrInvalidTenantSettings, emailDomain, claimedBy.Slug)
+		}
+		if !errors.Is(err, domain.ErrTenantNotFound) {
+			return nil, fmt.Errorf("failed to check sign-up domains: %w", err)
+		}
+	}
+
+	tenant := &entities.Tenant{Name: name, Slug: slug, SignUpDomains: strings.Join(signUpDomains, ",")}
+	if err := s.tenantRepo.Create(ctx, tenant); err != nil {
+		if errors.Is(err, domain.ErrTenantExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create tenant: %w", err)
+	}
+
+	tenantCtx := domain.WithTenant(ctx, tenant.ID)
+	admin := &entities.User{
+		FirstName: strings.TrimSpace(request.AdminFirstName),
+		LastName:  strings.TrimSpace(request.AdminLastName),
+		Email:     email,
+		Role:      string(models.RoleAdmin),
+	}
+	if err := s.userRepo.CreateWithEntity(tenantCtx, admin); err != nil {
+		return nil, fmt.Errorf("failed to create tenant admin: %w", err)
+	}
+	s.audit(tenantCtx, nil, &admin.ID, email, entities.AuditTenantCreated, "tenant "+slug)
+
+	var model models.TenantModel
+	model.FromEntity(tenant)
+	return &model, nil
+}
+
+// ListTenantIDs returns every tenant's ID
+func (s *TenantService) ListTenantIDs(ctx context.Context) ([]uint, error) {
+	tenants, err := s.tenantRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	ids := make([]uint, len(tenants))
+	for i, tenant := range tenants {
+		ids[i] = tenant.ID
+	}
+	return ids, nil
+}
+
+// GetCurrent returns the context's tenant
+func (s *TenantService) GetCurrent(ctx context.Context) (*models.TenantModel, error) {
+	tenant, err := s.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var model models.TenantModel
+	model.FromEntity(tenant)
+	return &model, nil
+}
+
+// UpdateSettings replaces the context's tenant's settings, keeping omitted secrets
+func (s *TenantService) UpdateSettings(ctx context.Context, updatedBy uint, request *models.TenantSettingsRequest) (*models.TenantModel, error) {
+	tenant, err := s.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant.Name = strings.TrimSpace(request.Name)
+	tenant.SlackDefaultChannelID = strings.TrimSpace(request.SlackDefaultChannelID)
+	tenant.AIProvider = strings.TrimSpace(request.AIProvider)
+	tenant.AIModel = strings.TrimSpace(request.AIModel)
+	tenant.ScoringRules = request.ScoringRules
+	if request.SlackBotToken != nil {
+		tenant.SlackBotToken = strings.TrimSpace(*request.SlackBotToken)
+	}
+	// The stored key is only kept for the host it was given for, so it is never sent to another one
+	if baseURL := strings.TrimSpace(request.AIBaseURL); baseURL != tenant.AIBaseURL {
+		if baseURL != "" && request.AIAPIKey == nil {
+			return nil, fmt.Errorf("%w: changing ai_base_url needs ai_api_key", domain.ErrInvalidTenantSettings)
+		}
+		tenant.AIBaseURL = baseURL
+		tenant.AIAPIKey = ""
+	}
+	if request.AIAPIKey != nil {
+		tenant.AIAPIKey = strings.TrimSpace(*request.AIAPIKey)
+	}
+	if err := validateTenantSettings(tenant); err != nil {
+		return nil, err
+	}
+
+	if err := s.tenantRepo.Update(ctx, tenant); err != nil {
+		return nil, fmt.Errorf("failed to update tenant: %w", err)
+	}
+	s.audit(ctx, &updatedBy, nil, "", entities.AuditTenantSettingsUpdated, "tenant "+tenant.Slug)
+
+	var model models.TenantModel
+	model.FromEntity(tenant)
+	return &model, nil
+}
+
+// current loads the context's tenant; requests without one act on the default tenant
+func (s *TenantService) current(ctx context.Context) (*entities.Tenant, error) {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		tenantID = domain.DefaultTenantID
+	}
+	return s.tenantRepo.GetByID(ctx, tenantID)
+}
+
+// validateTenantSettings checks a tenant's settings before they are saved
+func validateTenantSettings(tenant *entities.Tenant) error {
+	if tenant.Name == "" {
+		return fmt.Errorf("%w: name is required", domain.ErrInvalidTenantSettings)
+	}
+	// A custom provider needs its own key: the deployment's key is never sent to another host
+	if (tenant.AIBaseURL == "") != (tenant.AIAPIKey == "") {
+		return fmt.Errorf("%w: ai_base_url and ai_api_key must be set together", domain.ErrInvalidTenantSettings)
+	}
+	if tenant.AIBaseURL != "" {
+		u, err := url.Parse(tenant.AIBaseURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("%w: ai_base_url must be an http(s) URL", domain.ErrInvalidTenantSettings)
+		}
+	}
+	if tenant.AIProvider != "" && tenant.AIBaseURL == "" {
+		return fmt.Errorf("%w: ai_provider needs ai_base_url", domain.ErrInvalidTenantSettings)
+	}
+	if w := tenant.ScoringRules; w != nil {
+		if w.SkillsWeight < 0 || w.GeoWeight < 0 || w.ExperienceWeight < 0 || w.StatusWeight < 0 ||
+			w.SkillsWeight+w.GeoWeight+w.ExperienceWeight+w.StatusWeight != 100 {
+			return fmt.Errorf("%w: scoring weights must be non-negative and add up to 100", domain.ErrInvalidTenantSettings)
+		}
+	}
+	return nil
+}
+
+// audit records a tenant event, logging rather than failing the change when it cannot be stored
+func (s *TenantService) audit(ctx context.Context, actorID, userID *uint, email, action, detail string) {
+	event := &entities.AuditEvent{Action: action, ActorID: actorID, UserID: userID, Email: email, Detail: detail}
+	if err := s.auditRepo.Record(ctx, event); err != nil {
+		log.Printf("Warning: failed to record audit event %s: %v", action, err)
+	}
+}
+
+// ForEachTenant runs fn once per tenant with a context scoped to it, for jobs such as the metrics
+// snapshot that work on every tenant's data. It runs every tenant and returns the first error.
+func ForEachTenant(ctx context.Context, tenants domain.TenantService, fn func(ctx context.Context) error) error {
+	ids, err := tenants.ListTenantIDs(ctx)
+	if err != nil {
+		return err
+	}
+	var first error
+	for _, id := range ids {
+		if err := fn(domain.WithTenant(ctx, id)); err != nil {
+			log.Printf("Warning: tenant %d: %v", id, err)
+			if first == nil {
+				first = fmt.Errorf("tenant %d: %w", id, err)
+			}
+		}
+	}
+	return first
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+type fakeTenantRepo struct {
+	domain.TenantRepository
+	tenants []*entities.Tenant
+}
+
+func (f *fakeTenantRepo) GetByID(ctx context.Context, id uint) (*entities.Tenant, error) {
+	for _, tenant := range f.tenants {
+		if tenant.ID == id {
+			loaded := *tenant
+			return &loaded, nil
+		}
+	}
+	return nil, domain.ErrTenantNotFound
+}
+
+func (f *fakeTenantRepo) GetBySignUpDomain(ctx context.Context, emailDomain string) (*entities.Tenant, error) {
+	for _, tenant := range f.tenants {
+		for _, claimed := range strings.Split(tenant.SignUpDomains, ",") {
+			if claimed == emailDomain {
+				return tenant, nil
+			}
+		}
+	}
+	return nil, domain.ErrTenantNotFound
+}
+
+func (f *fakeTenantRepo) Update(ctx context.Context, tenant *entities.Tenant) error {
+	for i, stored := range f.tenants {
+		if stored.ID == tenant.ID {
+			updated := *tenant
+			f.tenants[i] = &updated
+		}
+	}
+	return nil
+}
+
+func TestUpdateSettingsKeepsAIKeyForItsHost(t *testing.T) {
+	tenants := &fakeTenantRepo{tenants: []*entities.Tenant{
+		{ID: 2, Name: "Acme", Slug: "acme", AIBaseURL: "https://llm.acme.example/v1", AIAPIKey: "acme-key"},
+	}}
+	service := NewTenantService(tenants, &fakeUserRepo{}, &fakeAuditRepo{})
+	ctx := domain.WithTenant(context.Background(), 2)
+
+	if _, err := service.UpdateSettings(ctx, 1, &models.TenantSettingsRequest{Name: "Acme Ltd", AIBaseURL: "https://llm.acme.example/v1"}); err != nil {
+		t.Fatalf("same host without a key: %v", err)
+	}
+	if tenants.tenants[0].AIAPIKey != "acme-key" {
+		t.Errorf("key = %q after keeping the host, want it kept", tenants.tenants[0].AIAPIKey)
+	}
+
+	if _, err := service.UpdateSettings(ctx, 1, &models.TenantSettingsRequest{Name: "Acme Ltd", AIBaseURL: "https://attacker.example/v1"}); !errors.Is(err, domain.ErrInvalidTenantSettings) {
+		t.Errorf("new host without a key: err = %v, want ErrInvalidTenantSettings", err)
+	}
+	if tenants.tenants[0].AIBaseURL != "https://llm.acme.example/v1" {
+		t.Errorf("base URL = %q, want the rejected change not saved", tenants.tenants[0].AIBaseURL)
+	}
+
+	key := "new-key"
+	if _, err := service.UpdateSettings(ctx, 1, &models.TenantSettingsRequest{Name: "Acme Ltd", AIBaseURL: "https://llm2.acme.example/v1", AIAPIKey: &key}); err != nil {
+		t.Fatalf("new host with a key: %v", err)
+	}
+	if _, err := service.UpdateSettings(ctx, 1, &models.TenantSettingsRequest{Name: "Acme Ltd"}); err != nil {
+		t.Fatalf("clearing the host: %v", err)
+	}
+	if stored := tenants.tenants[0]; stored.AIBaseURL != "" || stored.AIAPIKey != "" {
+		t.Errorf("stored %q/%q after clearing the host, want both cleared", stored.AIBaseURL, stored.AIAPIKey)
+	}
+}
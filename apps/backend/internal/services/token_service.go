@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/utils"
+	"github.com/talent-fit/backend/pkg/middleware"
+	"gorm.io/gorm"
+)
+
+// TokenService implements domain.TokenService
+type TokenService struct {
+	tokenRepo   domain.AuthTokenRepository
+	userRepo    domain.UserRepository
+	profileRepo domain.EmployeeProfileRepository
+	cfg         *config.Config
+}
+
+// NewTokenService creates a new token service
+func NewTokenService(tokenRepo domain.AuthTokenRepository, userRepo domain.UserRepository, profileRepo domain.EmployeeProfileRepository, cfg *config.Config) domain.TokenService {
+	return &TokenService{
+		tokenRepo:   tokenRepo,
+		userRepo:    userRepo,
+		profileRepo: profileRepo,
+		cfg:         cfg,
+	}
+}
+
+// Issue starts a new session in the user's tenant, purging expired tokens on the way
+func (s *TokenService) Issue(ctx context.Context, user *entities.User) (*domain.TokenPair, error) {
+	ctx = domain.WithTenant(ctx, user.TenantID)
+	if err := s.checkActive(ctx, user); err != nil {
+		return nil, err
+	}
+	if err := s.tokenRepo.DeleteExpired(ctx, time.Now()); err != nil {
+		log.Printf("Warning: failed to purge expired tokens: %v", err)
+	}
+
+	familyID, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	raw, refreshToken, err := s.newRefreshToken(user.ID, familyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tokenRepo.CreateRefreshToken(ctx, refreshToken); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+	return s.pair(user, raw)
+}
+
+// Refresh rotates a refresh token. A token presented after it was rotated or revoked means it
+// leaked, or the client replayed it, so the whole session is revoked.
+func (s *TokenService) Refresh(ctx context.Context, refreshToken string) (*domain.TokenPair, error) {
+	// The token is looked up across tenants; everything after acts in its tenant
+	current, err := s.tokenRepo.GetRefreshToken(domain.AllTenants(ctx), hashToken(refreshToken))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load refresh token: %w", err)
+	}
+	ctx = domain.WithTenant(ctx, current.TenantID)
+	if current.RevokedAt != nil {
+		log.Printf("Warning: revoked refresh token reused for user %d, revoking its session", current.UserID)
+		if err := s.tokenRepo.RevokeRefreshTokenFamily(ctx, current.FamilyID); err != nil {
+			return nil, fmt.Errorf("failed to revoke session: %w", err)
+		}
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, strconv.FormatUint(uint64(current.UserID), 10))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	if err := s.checkActive(ctx, user); err != nil {
+		return nil, err
+	}
+
+	raw, next, err := s.newRefreshToken(user.ID, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tokenRepo.RotateRefreshToken(ctx, current, next); err != nil {
+		if errors.Is(err, domain.ErrInvalidRefreshToken) {
+			// Rotated concurrently: the same token was used twice
+			if revokeErr := s.tokenRepo.RevokeRefreshTokenFamily(ctx, current.FamilyID); revokeErr != nil {
+				log.Printf("Warning: failed to revoke session: %v", revokeErr)
+			}
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	return s.pair(user, raw)
+}
+
+// RevokeRefreshToken ends the session of a refresh token
+func (s *TokenService) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	current, err := s.tokenRepo.GetRefreshToken(domain.AllTenants(ctx), hashToken(refreshToken))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load refresh token: %w", err)
+	}
+	if err := s.tokenRepo.RevokeRefreshTokenFamily(domain.WithTenant(ctx, current.TenantID), current.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+// RevokeAccessToken puts an access token on the revocation list until it expires
+func (s *TokenService) RevokeAccessToken(ctx context.Context, jti string, userID uint, expiresAt time.Time) error {
+	err := s.tokenRepo.RevokeAccessToken(ctx, &entities.RevokedToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+		RevokedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+	return nil
+}
+
+// IsAccessTokenRevoked reports whether an access token is on the revocation list
+func (s *TokenService) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	return s.tokenRepo.IsAccessTokenRevoked(ctx, jti)
+}
+
+// RevokeAllSessions revokes every refresh and access token the user holds
+func (s *TokenService) RevokeAllSessions(ctx context.Context, userID uint) error {
+	return s.tokenRepo.RevokeUserSessions(ctx, userID, time.Now())
+}
+
+// checkActive rejects deactivated users and users whose employment end date has passed
+func (s *TokenService) checkActive(ctx context.Context, user *entities.User) error {
+	if user.DeactivatedAt != nil {
+		return domain.ErrAccountInactive
+	}
+	profile, err := s.profileRepo.GetByUserID(ctx, strconv.FormatUint(uint64(user.ID), 10))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load profile: %w", err)
+	}
+	if profile.EndDate != nil && utils.DateOf(*profile.EndDate).Before(utils.DateOf(time.Now())) {
+		return domain.ErrAccountInactive
+	}
+	return nil
+}
+
+// newRefreshToken returns a random refresh token and its record in a session
+func (s *TokenService) newRefreshToken(userID uint, familyID string) (string, *entities.RefreshToken, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	raw := base64.RawURLEncoding.EncodeToString(random)
+	now := time.Now()
+	return raw, &entities.RefreshToken{
+		UserID:    userID,
+		TokenHash: hashToken(raw),
+		FamilyID:  familyID,
+		ExpiresAt: now.Add(s.cfg.Auth.RefreshTokenTTL()),
+		CreatedAt: now,
+	}, nil
+}
+
+// pair issues an access token to go with a refresh token
+func (s *TokenService) pair(user *entities.User, refreshToken string) (*domain.TokenPair, error) {
+	accessToken, expiresAt, err := middleware.GenerateJWTToken(s.cfg, user.ID, user.TenantID, user.Email, user.Role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate jwt: %w", err)
+	}
+	return &domain.TokenPair{AccessToken: accessToken, RefreshToken: refreshToken, ExpiresAt: expiresAt}, nil
+}
+
+// hashToken returns the hex SHA-256 of a refresh token, the form it is stored and looked up in
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomHex returns n random bytes hex-encoded
+func randomHex(n int) (string, error) {
+	random := make([]byte, n)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(random), nil
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"gorm.io/gorm"
+)
+
+// fakeTokenRepo stores refresh tokens by hash; beforeRotate runs once before the next rotation,
+// standing in for a request that rotates the same token concurrently
+type fakeTokenRepo struct {
+	domain.AuthTokenRepository
+	tokens       map[string]*entities.RefreshToken
+	beforeRotate func()
+}
+
+func (f *fakeTokenRepo) DeleteExpired(ctx context.Context, before time.Time) error {
+	return nil
+}
+
+func (f *fakeTokenRepo) CreateRefreshToken(ctx context.Context, token *entities.RefreshToken) error {
+	f.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (f *fakeTokenRepo) GetRefreshToken(ctx context.Context, tokenHash string) (*entities.RefreshToken, error) {
+	token, ok := f.tokens[tokenHash]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	loaded := *token
+	return &loaded, nil
+}
+
+func (f *fakeTokenRepo) RotateRefreshToken(ctx context.Context, old *entities.RefreshToken, next *entities.RefreshToken) error {
+	if hook := f.beforeRotate; hook != nil {
+		f.beforeRotate = nil
+		hook()
+	}
+	stored := f.tokens[old.TokenHash]
+	if stored.RevokedAt != nil {
+		return domain.ErrInvalidRefreshToken
+	}
+	now := time.Now()
+	stored.RevokedAt = &now
+	f.tokens[next.TokenHash] = next
+	return nil
+}
+
+func (f *fakeTokenRepo) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	now := time.Now()
+	for _, token := range f.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+type fakeTokenUserRepo struct {
+	domain.UserRepository
+	user *entities.User
+}
+
+func (f *fakeTokenUserRepo) GetByID(ctx context.Context, id string) (*entities.User, error) {
+	if strconv.Itoa(int(f.user.ID)) != id {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return f.user, nil
+}
+
+type noProfilesRepo struct {
+	domain.EmployeeProfileRepository
+}
+
+func (noProfilesRepo) GetByUserID(ctx context.Context, userID string) (*entities.EmployeeProfile, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func newTestTokenService() (*fakeTokenRepo, *entities.User, domain.TokenService) {
+	cfg := &config.Config{}
+	cfg.Auth.JWTSecret = "test-secret"
+	tokens := &fakeTokenRepo{tokens: make(map[string]*entities.RefreshToken)}
+	user := &entities.User{ID: 7, TenantID: 1, Email: "jane@example.com", Role: "Employee"}
+	return tokens, user, NewTokenService(tokens, &fakeTokenUserRepo{user: user}, noProfilesRepo{}, cfg)
+}
+
+// sessionRevoked reports whether every refresh token of a family is revoked
+func sessionRevoked(tokens *fakeTokenRepo, familyID string) bool {
+	for _, token := range tokens.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRefreshRotatesTokens(t *testing.T) {
+	ctx := context.Background()
+	tokens, user, service := newTestTokenService()
+
+	issued, err := service.Issue(ctx, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refreshed, err := service.Refresh(ctx, issued.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if refreshed.RefreshToken == issued.RefreshToken || refreshed.AccessToken == "" {
+		t.Error("Refresh() did not issue a new pair")
+	}
+	if tokens.tokens[hashToken(issued.RefreshToken)].RevokedAt == nil {
+		t.Error("the presented refresh token was not revoked")
+	}
+	if _, err := service.Refresh(ctx, "unknown"); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Errorf("Refresh() of an unknown token error = %v, want %v", err, domain.ErrInvalidRefreshToken)
+	}
+}
+
+func TestRefreshReuseRevokesSession(t *testing.T) {
+	ctx := context.Background()
+	tokens, user, service := newTestTokenService()
+
+	issued, _ := service.Issue(ctx, user)
+	refreshed, err := service.Refresh(ctx, issued.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Replaying the rotated token ends the session, including the token it was rotated to
+	if _, err := service.Refresh(ctx, issued.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Fatalf("Refresh() of a rotated token error = %v, want %v", err, domain.ErrInvalidRefreshToken)
+	}
+	if familyID := tokens.tokens[hashToken(issued.RefreshToken)].FamilyID; !sessionRevoked(tokens, familyID) {
+		t.Error("reusing a rotated token left its session active")
+	}
+	if _, err := service.Refresh(ctx, refreshed.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Errorf("Refresh() with the successor of a reused token error = %v, want %v", err, domain.ErrInvalidRefreshToken)
+	}
+}
+
+func TestRefreshConcurrentRotationRevokesSession(t *testing.T) {
+	ctx := context.Background()
+	tokens, user, service := newTestTokenService()
+
+	issued, _ := service.Issue(ctx, user)
+	current := tokens.tokens[hashToken(issued.RefreshToken)]
+	// Another request rotates the same token between this one loading and rotating it
+	tokens.beforeRotate = func() {
+		now := time.Now()
+		current.RevokedAt = &now
+		tokens.tokens["racing"] = &entities.RefreshToken{TokenHash: "racing", FamilyID: current.FamilyID, UserID: user.ID}
+	}
+
+	if _, err := service.Refresh(ctx, issued.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Fatalf("Refresh() racing another rotation error = %v, want %v", err, domain.ErrInvalidRefreshToken)
+	}
+	if !sessionRevoked(tokens, current.FamilyID) {
+		t.Error("a concurrent rotation left the session active, including the racing request's token")
+	}
+}
+
+func TestRefreshRejectsInactiveSessions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("expired", func(t *testing.T) {
+		tokens, user, service := newTestTokenService()
+		issued, _ := service.Issue(ctx, user)
+		tokens.tokens[hashToken(issued.RefreshToken)].ExpiresAt = time.Now().Add(-time.Minute)
+		if _, err := service.Refresh(ctx, issued.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+			t.Errorf("Refresh() of an expired token error = %v, want %v", err, domain.ErrInvalidRefreshToken)
+		}
+	})
+
+	t.Run("deactivated user", func(t *testing.T) {
+		_, user, service := newTestTokenService()
+		issued, _ := service.Issue(ctx, user)
+		now := time.Now()
+		user.DeactivatedAt = &now
+		if _, err := service.Refresh(ctx, issued.RefreshToken); !errors.Is(err, domain.ErrAccountInactive) {
+			t.Errorf("Refresh() for a deactivated user error = %v, want %v", err, domain.ErrAccountInactive)
+		}
+	})
+}
@@ -2,61 +2,247 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Page size bounds for the user list
+const (
+	defaultUserLimit = 50
+	maxUserLimit     = 200
 )
 
 // UserService implements the domain.UserService interface
 type UserService struct {
-	userRepo domain.UserRepository
+	userRepo     domain.UserRepository
+	tokenService domain.TokenService
+	auditRepo    domain.AuditRepository
 }
 
 // NewUserService creates a new user service
-func NewUserService(userRepo domain.UserRepository) domain.UserService {
+func NewUserService(userRepo domain.UserRepository, tokenService domain.TokenService, auditRepo domain.AuditRepository) domain.UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:     userRepo,
+		tokenService: tokenService,
+		auditRepo:    auditRepo,
 	}
 }
 
-// GetAllUsers retrieves all users
-func (s *UserService) GetAllUsers(ctx context.Context) error {
-	// TODO: Implement business logic for getting all users
-	// TODO: Call userRepo.GetAll(ctx)
-	// TODO: Apply any business rules, filtering, etc.
-	return nil
+// ListUsers returns a page of users matching the filter
+func (s *UserService) ListUsers(ctx context.Context, filter *domain.UserFilter) (*models.UserPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultUserLimit
+	}
+	if filter.Limit > maxUserLimit {
+		filter.Limit = maxUserLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	users, total, err := s.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	page := &models.UserPage{Users: make([]*models.UserModel, len(users)), Total: total, Limit: filter.Limit, Offset: filter.Offset}
+	for i, user := range users {
+		page.Users[i] = &models.UserModel{}
+		page.Users[i].FromEntity(user)
+	}
+	return page, nil
 }
 
-// GetUserByID retrieves a user by ID
-func (s *UserService) GetUserByID(ctx context.Context, id string) error {
-	// TODO: Implement business logic for getting user by ID
-	// TODO: Validate ID format
-	// TODO: Call userRepo.GetByID(ctx, id)
-	// TODO: Apply any business rules
-	return nil
+// GetUser retrieves a user by ID
+func (s *UserService) GetUser(ctx context.Context, id uint) (*models.UserModel, error) {
+	user, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	var model models.UserModel
+	model.FromEntity(user)
+	return &model, nil
 }
 
-// UpdateUser updates a user
-func (s *UserService) UpdateUser(ctx context.Context, id string) error {
-	// TODO: Implement business logic for updating user
-	// TODO: Validate input data
-	// TODO: Check permissions
-	// TODO: Call userRepo.Update(ctx, id)
-	return nil
+// CreateUser creates a user, who is signed in to this account by any provider with their email
+func (s *UserService) CreateUser(ctx context.Context, createdBy uint, request *models.CreateUserRequest) (*models.UserModel, error) {
+	email := strings.ToLower(strings.TrimSpace(request.Email))
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return nil, domain.ErrInvalidUser
+	}
+	role := request.Role
+	if role == "" {
+		role = models.RoleEmployee
+	}
+	if !role.IsValid() {
+		return nil, domain.ErrInvalidUser
+	}
+	// Emails are unique across tenants, so check them all
+	if _, err := s.userRepo.GetByEmail(domain.AllTenants(ctx), email); err == nil {
+		return nil, domain.ErrUserAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check user: %w", err)
+	}
+	if err := s.checkManager(ctx, 0, request.ManagerID); err != nil {
+		return nil, err
+	}
+
+	user := &entities.User{
+		FirstName:   strings.TrimSpace(request.FirstName),
+		LastName:    strings.TrimSpace(request.LastName),
+		Email:       email,
+		Role:        string(role),
+		SlackUserID: strings.TrimSpace(request.SlackUserID),
+		ManagerID:   request.ManagerID,
+	}
+	if err := s.userRepo.CreateWithEntity(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	s.audit(ctx, createdBy, user, entities.AuditUserCreated, "as "+user.Role)
+
+	var model models.UserModel
+	model.FromEntity(user)
+	return &model, nil
 }
 
-// CreateUser creates a new user
-func (s *UserService) CreateUser(ctx context.Context) error {
-	// TODO: Implement business logic for creating user
-	// TODO: Validate input data
-	// TODO: Hash passwords, generate IDs, etc.
-	// TODO: Call userRepo.Create(ctx)
-	return nil
+// UpdateUser updates the fields set in the request
+func (s *UserService) UpdateUser(ctx context.Context, updatedBy uint, id uint, request *models.UpdateUserRequest) (*models.UserModel, error) {
+	user, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	if request.FirstName != nil {
+		user.FirstName = strings.TrimSpace(*request.FirstName)
+	}
+	if request.LastName != nil {
+		user.LastName = strings.TrimSpace(*request.LastName)
+	}
+	if request.Role != nil && string(*request.Role) != user.Role {
+		if !request.Role.IsValid() {
+			return nil, domain.ErrInvalidUser
+		}
+		if id == updatedBy {
+			return nil, domain.ErrSelfModification
+		}
+		changes = append(changes, fmt.Sprintf("role %s -> %s", user.Role, *request.Role))
+		user.Role = string(*request.Role)
+	}
+	if request.SlackUserID != nil && strings.TrimSpace(*request.SlackUserID) != user.SlackUserID {
+		user.SlackUserID = strings.TrimSpace(*request.SlackUserID)
+		changes = append(changes, "slack user ID "+user.SlackUserID)
+	}
+	if request.ManagerID != nil {
+		// 0 removes the manager
+		user.ManagerID = nil
+		if *request.ManagerID != 0 {
+			if err := s.checkManager(ctx, id, request.ManagerID); err != nil {
+				return nil, err
+			}
+			user.ManagerID = request.ManagerID
+		}
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	if len(changes) > 0 {
+		s.audit(ctx, updatedBy, user, entities.AuditUserUpdated, strings.Join(changes, ", "))
+	}
+
+	var model models.UserModel
+	model.FromEntity(user)
+	return &model, nil
+}
+
+// DeactivateUser deactivates a user and signs them out everywhere
+func (s *UserService) DeactivateUser(ctx context.Context, deactivatedBy uint, id uint) (*models.UserModel, error) {
+	if id == deactivatedBy {
+		return nil, domain.ErrSelfModification
+	}
+	if err := s.userRepo.Deactivate(ctx, id, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to deactivate user: %w", err)
+	}
+	if err := s.tokenService.RevokeAllSessions(ctx, id); err != nil {
+		// Deactivated users are rejected by the auth middleware regardless
+		log.Printf("Warning: failed to revoke sessions of deactivated user %d: %v", id, err)
+	}
+
+	user, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.audit(ctx, deactivatedBy, user, entities.AuditUserDeactivated, "")
+	var model models.UserModel
+	model.FromEntity(user)
+	return &model, nil
 }
 
-// DeleteUser deletes a user
-func (s *UserService) DeleteUser(ctx context.Context, id string) error {
-	// TODO: Implement business logic for deleting user
-	// TODO: Check if user can be deleted (no active projects, etc.)
-	// TODO: Call userRepo.Delete(ctx, id)
+// ReactivateUser lets a deactivated user sign in again
+func (s *UserService) ReactivateUser(ctx context.Context, reactivatedBy uint, id uint) (*models.UserModel, error) {
+	if err := s.userRepo.Reactivate(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to reactivate user: %w", err)
+	}
+
+	user, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.audit(ctx, reactivatedBy, user, entities.AuditUserReactivated, "")
+	var model models.UserModel
+	model.FromEntity(user)
+	return &model, nil
+}
+
+// load retrieves a user, mapping a missing one to domain.ErrUserNotFound
+func (s *UserService) load(ctx context.Context, id uint) (*entities.User, error) {
+	user, err := s.userRepo.GetByID(ctx, strconv.FormatUint(uint64(id), 10))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	return user, nil
+}
+
+// checkManager checks a manager exists and is not the user themselves
+func (s *UserService) checkManager(ctx context.Context, userID uint, managerID *uint) error {
+	if managerID == nil {
+		return nil
+	}
+	if *managerID == userID {
+		return fmt.Errorf("%w: a user cannot be their own manager", domain.ErrInvalidUser)
+	}
+	if _, err := s.load(ctx, *managerID); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return fmt.Errorf("%w: manager %d not found", domain.ErrInvalidUser, *managerID)
+		}
+		return err
+	}
 	return nil
 }
+
+// audit records an event; the audited change has already happened, so failures are only logged
+func (s *UserService) audit(ctx context.Context, actorID uint, user *entities.User, action, detail string) {
+	event := &entities.AuditEvent{Action: action, ActorID: &actorID, UserID: &user.ID, Email: user.Email, Detail: detail}
+	if err := s.auditRepo.Record(ctx, event); err != nil {
+		log.Printf("Warning: failed to record audit event %s for %s: %v", action, user.Email, err)
+	}
+}
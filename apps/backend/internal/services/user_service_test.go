@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+func TestUpdateUserClearsFields(t *testing.T) {
+	ctx := context.Background()
+	managerID := uint(8)
+	users := &fakeUserRepo{users: []*entities.User{
+		{ID: 7, FirstName: "Jane", LastName: "Doe", Email: "jane@example.com", Role: "Employee", SlackUserID: "U123", ManagerID: &managerID},
+		{ID: 8, FirstName: "Sam", Email: "sam@example.com", Role: "Manager"},
+	}}
+	service := NewUserService(users, nil, &fakeAuditRepo{})
+
+	empty, noManager := "", uint(0)
+	updated, err := service.UpdateUser(ctx, 1, 7, &models.UpdateUserRequest{
+		FirstName: &empty, LastName: &empty, SlackUserID: &empty, ManagerID: &noManager,
+	})
+	if err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+	if updated.FirstName != "" || updated.LastName != "" || updated.SlackUserID != "" || updated.ManagerID != nil {
+		t.Errorf("UpdateUser() = %+v, want names, Slack user ID and manager cleared", updated)
+	}
+	stored := users.users[0]
+	if stored.FirstName != "" || stored.LastName != "" || stored.SlackUserID != "" || stored.ManagerID != nil {
+		t.Errorf("stored user = %+v, want names, Slack user ID and manager cleared", stored)
+	}
+
+	// Omitted fields are left unchanged
+	if _, err := service.UpdateUser(ctx, 1, 7, &models.UpdateUserRequest{ManagerID: &managerID}); err != nil {
+		t.Fatal(err)
+	}
+	if stored := users.users[0]; stored.ManagerID == nil || *stored.ManagerID != managerID || stored.Role != "Employee" {
+		t.Errorf("stored user = %+v, want manager %d and the role unchanged", stored, managerID)
+	}
+}
@@ -0,0 +1,159 @@
+// Package tabular reads CSV and XLSX files into rows of strings for bulk imports, and streams
+// report rows out as CSV, XLSX or JSON.
+//
+// XLSX support reads the first worksheet with the standard library only. Cells are returned
+// as displayed text where the file stores it; dates stored as spreadsheet serial numbers are
+// left as numbers for the caller to interpret, see ParseDate. Written workbooks hold a single
+// sheet with inline strings, so rows never need to be held in memory.
+package tabular
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// File formats
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// ErrUnsupportedFormat is returned for files that are neither CSV nor XLSX
+var ErrUnsupportedFormat = errors.New("unsupported file format; upload a CSV or XLSX file")
+
+// Table is a header row and the data rows below it
+type Table struct {
+	Header []string
+	Rows   []Row
+}
+
+// Row is one data row with its line number in the file, counting the header as line 1
+type Row struct {
+	Line   int
+	Values []string
+}
+
+// Read parses a CSV or XLSX file, detecting the format from its content and file name.
+// Blank rows are skipped; header cells are trimmed.
+func Read(filename string, data []byte) (*Table, error) {
+	var records [][]string
+	var err error
+	switch DetectFormat(filename, data) {
+	case FormatXLSX:
+		records, err = readXLSX(data)
+	case FormatCSV:
+		records, err = readCSV(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	table := &Table{}
+	for i, record := range records {
+		if isBlank(record) {
+			continue
+		}
+		if table.Header == nil {
+			for _, cell := range record {
+				table.Header = append(table.Header, strings.TrimSpace(cell))
+			}
+			continue
+		}
+		table.Rows = append(table.Rows, Row{Line: i + 1, Values: record})
+	}
+	if table.Header == nil {
+		return nil, fmt.Errorf("file has no header row")
+	}
+	return table, nil
+}
+
+// DetectFormat returns the file format, trusting content signatures over the file extension
+func DetectFormat(filename string, data []byte) string {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		if bytes.Contains(data, []byte("xl/workbook.xml")) {
+			return FormatXLSX
+		}
+		return ""
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".xlsx", ".xls", ".ods", ".numbers":
+		return ""
+	}
+	if utf8.Valid(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))) && bytes.IndexByte(data, 0) < 0 {
+		return FormatCSV
+	}
+	return ""
+}
+
+// readCSV reads comma, semicolon or tab separated values, picking the delimiter from the header line
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = sniffDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func sniffDelimiter(data []byte) rune {
+	line := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		line = data[:i]
+	}
+	delimiter, best := ',', bytes.Count(line, []byte(","))
+	for _, candidate := range []rune{';', '\t'} {
+		if n := bytes.Count(line, []byte(string(candidate))); n > best {
+			delimiter, best = candidate, n
+		}
+	}
+	return delimiter
+}
+
+func isBlank(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// spreadsheetEpoch is day zero of spreadsheet serial dates, accounting for the 1900 leap year bug
+var spreadsheetEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+// ParseDate reads a date written as YYYY-MM-DD, an RFC 3339 timestamp or a spreadsheet serial number
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial > 0 && serial < 2958466 {
+		return spreadsheetEpoch.AddDate(0, 0, int(serial)), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+}
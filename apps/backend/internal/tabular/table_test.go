@@ -0,0 +1,157 @@
+package tabular
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRead(t *testing.T) {
+	csvData := []byte("\xef\xbb\xbfemail;first_name;skills\njane@example.com;Jane;\"Go;Kafka\"\n;;\nbob@example.com;Bob;\n")
+	xlsxData := buildXLSX(t, map[string]string{
+		"xl/workbook.xml":            `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="People" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" Target="worksheets/people.xml"/></Relationships>`,
+		"xl/sharedStrings.xml":       `<sst><si><t>email</t></si><si><t>first_name</t></si><si><r><t>Go;</t></r><r><t>Kafka</t></r></si></sst>`,
+		"xl/worksheets/people.xml": `<worksheet><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="inlineStr"><is><t>skills</t></is></c></row>` +
+			`<row r="2"><c r="A2" t="inlineStr"><is><t>jane@example.com</t></is></c><c r="B2" t="inlineStr"><is><t>Jane</t></is></c><c r="C2" t="s"><v>2</v></c></row>` +
+			`<row r="4"><c r="A4" t="inlineStr"><is><t>bob@example.com</t></is></c><c r="B4" t="inlineStr"><is><t>Bob</t></is></c></row>` +
+			`</sheetData></worksheet>`,
+	})
+
+	for name, data := range map[string][]byte{"people.csv": csvData, "people.xlsx": xlsxData} {
+		table, err := Read(name, data)
+		if err != nil {
+			t.Fatalf("Read(%s) error = %v", name, err)
+		}
+		if got := strings.Join(table.Header, ","); got != "email,first_name,skills" {
+			t.Errorf("%s: Header = %q", name, got)
+		}
+		if len(table.Rows) != 2 {
+			t.Fatalf("%s: %d rows, expected blank rows skipped", name, len(table.Rows))
+		}
+		if got := strings.Join(table.Rows[0].Values, ","); got != "jane@example.com,Jane,Go;Kafka" {
+			t.Errorf("%s: first row = %q", name, got)
+		}
+		if table.Rows[1].Line != 4 {
+			t.Errorf("%s: second row line = %d, expected 4", name, table.Rows[1].Line)
+		}
+	}
+
+	if _, err := Read("people.xlsx", []byte("email\njane@example.com")); err != ErrUnsupportedFormat {
+		t.Errorf("Read() error = %v, expected ErrUnsupportedFormat for a mislabelled file", err)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	want := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	for _, value := range []string{"2026-03-02", "2026-03-02T00:00:00Z", "46083"} {
+		got, err := ParseDate(value)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseDate(%q) = %v, %v", value, got, err)
+		}
+	}
+	if _, err := ParseDate("02/03/2026"); err == nil {
+		t.Errorf("ParseDate() expected error for an ambiguous date")
+	}
+}
+
+func TestWriter(t *testing.T) {
+	end := time.Date(2026, time.November, 30, 0, 0, 0, 0, time.UTC)
+	var noDate *time.Time
+	rows := [][]interface{}{
+		{"Jane <Doe>", 12, 87.5, true, end},
+		{" Bob & co", nil, 0.0, false, noDate},
+	}
+	want := []string{"name,days,utilisation,flag,end", "Jane <Doe>,12,87.5,true,2026-11-30", " Bob & co,,0,false,"}
+
+	for _, format := range []string{FormatCSV, FormatXLSX} {
+		var buf bytes.Buffer
+		w, err := NewWriter(format, &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(strings.Split(want[0], ","))
+		for _, row := range rows {
+			if err := w.WriteRow(row); err != nil {
+				t.Fatalf("%s: WriteRow() error = %v", format, err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("%s: Close() error = %v", format, err)
+		}
+
+		table, err := Read("report."+format, buf.Bytes())
+		if err != nil {
+			t.Fatalf("%s: Read() error = %v", format, err)
+		}
+		got := []string{strings.Join(table.Header, ",")}
+		for _, row := range table.Rows {
+			values := append(row.Values, make([]string, len(table.Header)-len(row.Values))...)
+			got = append(got, strings.Join(values, ","))
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("%s: read back %q, expected %q", format, got, want)
+		}
+	}
+
+	var buf bytes.Buffer
+	w, _ := NewWriter(FormatJSON, &buf)
+	w.WriteHeader([]string{"name", "end"})
+	w.WriteRow([]interface{}{"Jane", noDate})
+	w.Close()
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || len(decoded) != 1 || decoded[0]["end"] != nil {
+		t.Errorf("JSON output = %s, %v", buf.String(), err)
+	}
+
+	if _, err := NewWriter("pdf", &buf); err == nil {
+		t.Error("NewWriter() expected unsupported formats rejected")
+	}
+}
+
+func TestWriterEscapesFormulas(t *testing.T) {
+	row := []interface{}{"=HYPERLINK(\"http://x\")", "+1", "-2", "@SUM(A1)", "\tcmd", "\rcmd", "Jane", -3}
+	want := []string{`'=HYPERLINK("http://x")`, "'+1", "'-2", "'@SUM(A1)", "'\tcmd", "'\rcmd", "Jane", "-3"}
+	header := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+
+	for _, format := range []string{FormatCSV, FormatXLSX} {
+		var buf bytes.Buffer
+		w, _ := NewWriter(format, &buf)
+		w.WriteHeader(header)
+		w.WriteRow(row)
+		if err := w.Close(); err != nil {
+			t.Fatalf("%s: Close() error = %v", format, err)
+		}
+
+		table, err := Read("report."+format, buf.Bytes())
+		if err != nil {
+			t.Fatalf("%s: Read() error = %v", format, err)
+		}
+		if got := table.Rows[0].Values; strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("%s: read back %q, expected %q", format, got, want)
+		}
+	}
+}
+
+func buildXLSX(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,172 @@
+package tabular
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatJSON writes a JSON array of objects keyed by column name
+const FormatJSON = "json"
+
+// DateLayout is the layout dates are written in
+const DateLayout = "2006-01-02"
+
+// Writer streams a header and rows to CSV, XLSX or JSON without holding the rows in memory
+type Writer interface {
+	// WriteHeader writes the column names; it must be called once before any row
+	WriteHeader(columns []string) error
+	// WriteRow writes one row. Values may be strings, integers, float64s, bools, time.Time,
+	// *time.Time or nil; dates are written as YYYY-MM-DD.
+	WriteRow(values []interface{}) error
+	// Close finishes the file; it does not close the underlying writer
+	Close() error
+}
+
+// NewWriter creates a writer for format, one of FormatCSV, FormatXLSX or FormatJSON
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w), nil
+	case FormatJSON:
+		return &jsonWriter{w: bufio.NewWriter(w)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q; use csv, xlsx or json", format)
+	}
+}
+
+// formulaPrefixes are the first characters that make a spreadsheet treat a cell as a formula
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeFormula prefixes text a spreadsheet would evaluate with an apostrophe, so values users
+// entered, such as names and skills, are shown as written rather than run as formulas
+func escapeFormula(text string) string {
+	if text != "" && strings.IndexByte(formulaPrefixes, text[0]) >= 0 {
+		return "'" + text
+	}
+	return text
+}
+
+// ContentType returns the MIME type for a writer format
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// FormatValue renders a cell value as text, the way the CSV writer does
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return v.Format(DateLayout)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(DateLayout)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (cw *csvWriter) WriteHeader(columns []string) error {
+	return cw.w.Write(columns)
+}
+
+func (cw *csvWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = FormatValue(value)
+		if _, ok := value.(string); ok {
+			record[i] = escapeFormula(record[i])
+		}
+	}
+	return cw.w.Write(record)
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+type jsonWriter struct {
+	w       *bufio.Writer
+	keys    [][]byte
+	started bool
+}
+
+func (jw *jsonWriter) WriteHeader(columns []string) error {
+	jw.keys = make([][]byte, len(columns))
+	for i, column := range columns {
+		key, err := json.Marshal(column)
+		if err != nil {
+			return err
+		}
+		jw.keys[i] = key
+	}
+	_, err := jw.w.WriteString("[")
+	return err
+}
+
+func (jw *jsonWriter) WriteRow(values []interface{}) error {
+	if jw.started {
+		jw.w.WriteString(",")
+	}
+	jw.started = true
+	jw.w.WriteString("\n{")
+	for i, value := range values {
+		if i >= len(jw.keys) {
+			break
+		}
+		if i > 0 {
+			jw.w.WriteString(",")
+		}
+		switch v := value.(type) {
+		case time.Time, *time.Time:
+			value = FormatValue(v)
+			if value == "" {
+				value = nil
+			}
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		jw.w.Write(jw.keys[i])
+		jw.w.WriteString(":")
+		jw.w.Write(encoded)
+	}
+	_, err := jw.w.WriteString("}")
+	return err
+}
+
+func (jw *jsonWriter) Close() error {
+	if _, err := jw.w.WriteString("\n]\n"); err != nil {
+		return err
+	}
+	return jw.w.Flush()
+}
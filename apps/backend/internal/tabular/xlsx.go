@@ -0,0 +1,188 @@
+package tabular
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// maxXLSXPart bounds the decompressed size of one part of an XLSX package
+const maxXLSXPart = 50 << 20
+
+// readXLSX reads the cells of the first worksheet, keeping row numbers so blank rows stay blank
+func readXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open XLSX: %w", err)
+	}
+	parts := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		parts[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(parts)
+	if err != nil {
+		return nil, err
+	}
+	sharedStrings, err := readSharedStrings(parts["xl/sharedStrings.xml"])
+	if err != nil {
+		return nil, err
+	}
+
+	var sheet struct {
+		Rows []struct {
+			Number int `xml:"r,attr"`
+			Cells  []struct {
+				Ref    string `xml:"r,attr"`
+				Type   string `xml:"t,attr"`
+				Value  string `xml:"v"`
+				Inline struct {
+					Text string `xml:"t"`
+					Runs []struct {
+						Text string `xml:"t"`
+					} `xml:"r"`
+				} `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodePart(parts[sheetPath], &sheet); err != nil {
+		return nil, err
+	}
+
+	var records [][]string
+	for i, row := range sheet.Rows {
+		number := row.Number
+		if number == 0 {
+			number = i + 1
+		}
+		for len(records) < number {
+			records = append(records, nil)
+		}
+		var record []string
+		for j, cell := range row.Cells {
+			column := j
+			if index := columnIndex(cell.Ref); index >= 0 {
+				column = index
+			}
+			for len(record) <= column {
+				record = append(record, "")
+			}
+			switch cell.Type {
+			case "s":
+				var index int
+				if _, err := fmt.Sscan(cell.Value, &index); err == nil && index >= 0 && index < len(sharedStrings) {
+					record[column] = sharedStrings[index]
+				}
+			case "inlineStr":
+				text := cell.Inline.Text
+				for _, run := range cell.Inline.Runs {
+					text += run.Text
+				}
+				record[column] = text
+			case "b":
+				record[column] = map[string]string{"1": "true", "0": "false"}[cell.Value]
+			default:
+				record[column] = cell.Value
+			}
+		}
+		records[number-1] = record
+	}
+	return records, nil
+}
+
+// firstSheetPath resolves the first worksheet listed in the workbook
+func firstSheetPath(parts map[string]*zip.File) (string, error) {
+	var workbook struct {
+		Sheets []struct {
+			RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodePart(parts["xl/workbook.xml"], &workbook); err != nil {
+		return "", err
+	}
+	if f := parts["xl/_rels/workbook.xml.rels"]; f != nil && len(workbook.Sheets) > 0 {
+		if err := decodePart(f, &rels); err != nil {
+			return "", err
+		}
+		for _, rel := range rels.Relationships {
+			if rel.ID != workbook.Sheets[0].RelID {
+				continue
+			}
+			target := strings.TrimPrefix(rel.Target, "/")
+			if !strings.HasPrefix(target, "xl/") {
+				target = path.Join("xl", target)
+			}
+			if parts[target] != nil {
+				return target, nil
+			}
+		}
+	}
+	if parts["xl/worksheets/sheet1.xml"] != nil {
+		return "xl/worksheets/sheet1.xml", nil
+	}
+	return "", fmt.Errorf("XLSX has no worksheet")
+}
+
+// readSharedStrings reads the workbook's string table; rich text runs are concatenated
+func readSharedStrings(f *zip.File) ([]string, error) {
+	if f == nil {
+		return nil, nil
+	}
+	var table struct {
+		Items []struct {
+			Text string `xml:"t"`
+			Runs []struct {
+				Text string `xml:"t"`
+			} `xml:"r"`
+		} `xml:"si"`
+	}
+	if err := decodePart(f, &table); err != nil {
+		return nil, err
+	}
+	values := make([]string, len(table.Items))
+	for i, item := range table.Items {
+		text := item.Text
+		for _, run := range item.Runs {
+			text += run.Text
+		}
+		values[i] = text
+	}
+	return values, nil
+}
+
+func decodePart(f *zip.File, v interface{}) error {
+	if f == nil {
+		return fmt.Errorf("XLSX is missing a required part")
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to read XLSX: %w", err)
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(io.LimitReader(rc, maxXLSXPart)).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse XLSX %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// columnIndex converts the letters of a cell reference such as "AB12" to a zero-based column
+func columnIndex(ref string) int {
+	index := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A'+1)
+	}
+	return index - 1
+}
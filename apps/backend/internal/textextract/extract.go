@@ -0,0 +1,151 @@
+// Package textextract pulls plain text out of uploaded documents such as CVs.
+//
+// Extraction runs locally with the standard library only, so document contents never
+// leave the service before redaction. PDF support covers text-based PDFs with standard
+// font encodings; scanned PDFs have no text layer and are reported as ErrNoText.
+package textextract
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Document formats
+const (
+	FormatPDF  = "pdf"
+	FormatDOCX = "docx"
+	FormatText = "text"
+)
+
+// minLetters is the least amount of text worth sending for extraction
+const minLetters = 20
+
+var (
+	// ErrUnsupportedFormat is returned for files that are not PDF, DOCX or plain text
+	ErrUnsupportedFormat = errors.New("unsupported document format; upload a PDF, DOCX or plain-text file")
+	// ErrNoText is returned when a document has no extractable text, e.g. a scanned PDF
+	ErrNoText = errors.New("no text could be extracted from the document")
+)
+
+// Document is the text extracted from an uploaded file
+type Document struct {
+	Format string
+	Text   string
+}
+
+// Extract detects the format of data from its content and file name and extracts its text
+func Extract(filename string, data []byte) (*Document, error) {
+	format := DetectFormat(filename, data)
+
+	var text string
+	var err error
+	switch format {
+	case FormatPDF:
+		text, err = extractPDF(data)
+	case FormatDOCX:
+		text, err = extractDOCX(data)
+	case FormatText:
+		text = decodeText(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	text = normalizeWhitespace(text)
+	if countLetters(text) < minLetters {
+		return nil, ErrNoText
+	}
+	return &Document{Format: format, Text: text}, nil
+}
+
+// DetectFormat returns the document format, trusting content signatures over the file extension
+func DetectFormat(filename string, data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("%PDF-")):
+		return FormatPDF
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		if bytes.Contains(data, []byte("word/document.xml")) {
+			return FormatDOCX
+		}
+		return ""
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".pdf", ".docx", ".doc", ".rtf", ".odt":
+		// The extension promises a binary format the content does not match
+		return ""
+	}
+	if looksLikeText(data) {
+		return FormatText
+	}
+	return ""
+}
+
+// looksLikeText reports whether data is mostly printable text
+func looksLikeText(data []byte) bool {
+	if len(data) == 0 || bytes.IndexByte(data, 0) >= 0 {
+		return false
+	}
+	sample := data
+	if len(sample) > 4096 {
+		sample = sample[:4096]
+	}
+	control := 0
+	for _, b := range sample {
+		if b < 0x20 && b != '\n' && b != '\r' && b != '\t' && b != '\f' {
+			control++
+		}
+	}
+	return control*100 < len(sample)
+}
+
+// decodeText returns UTF-8 text, reading invalid UTF-8 as Latin-1
+func decodeText(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+// normalizeWhitespace collapses runs of spaces and keeps at most one blank line between paragraphs
+func normalizeWhitespace(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var out []string
+	blank := false
+	for _, line := range lines {
+		line = strings.Join(strings.FieldsFunc(line, func(r rune) bool {
+			return unicode.IsSpace(r) || !unicode.IsPrint(r)
+		}), " ")
+		if line == "" {
+			if !blank && len(out) > 0 {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		out = append(out, line)
+		blank = false
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+func countLetters(text string) int {
+	n := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			n++
+		}
+	}
+	return n
+}
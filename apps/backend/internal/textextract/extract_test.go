@@ -0,0 +1,73 @@
+package textextract
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	docx := buildDOCX(t, `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`+
+		`<w:p><w:r><w:t>Jane Doe</w:t></w:r></w:p>`+
+		`<w:p><w:r><w:t>Skills:</w:t><w:tab/><w:t xml:space="preserve">Go, Kafka &amp; PostgreSQL</w:t></w:r></w:p>`+
+		`</w:body></w:document>`)
+
+	content := "BT /F1 12 Tf 72 720 Td (Senior Backend Engineer) Tj 0 -14 Td [(Seven years in)-300(fintech \\(payments\\))] TJ ET"
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write([]byte(content))
+	zw.Close()
+	pdf := fmt.Sprintf("%%PDF-1.4\n4 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream\nendobj\n%%%%EOF\n",
+		compressed.Len(), compressed.String())
+
+	tests := []struct {
+		name     string
+		filename string
+		data     []byte
+		format   string
+		text     string
+	}{
+		{"docx", "cv.docx", docx, FormatDOCX, "Jane Doe\nSkills: Go, Kafka & PostgreSQL"},
+		{"pdf", "cv.pdf", []byte(pdf), FormatPDF, "Senior Backend Engineer\nSeven years in fintech (payments)"},
+		{"text", "cv.txt", []byte("\xef\xbb\xbfQA lead,   ten years\r\n\r\n\r\nCypress and Selenium"), FormatText, "QA lead, ten years\n\nCypress and Selenium"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Extract(tt.filename, tt.data)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if doc.Format != tt.format || doc.Text != tt.text {
+				t.Errorf("Extract() = %s %q, expected %s %q", doc.Format, doc.Text, tt.format, tt.text)
+			}
+		})
+	}
+
+	if _, err := Extract("cv.pdf", []byte("not really a PDF, just some text pretending to be one")); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Extract() error = %v, expected ErrUnsupportedFormat for a mislabelled file", err)
+	}
+	if _, err := Extract("scan.pdf", []byte("%PDF-1.4\n1 0 obj\n<< /Subtype /Image /Length 3 >>\nstream\nabc\nendstream\nendobj\n")); !errors.Is(err, ErrNoText) {
+		t.Errorf("Extract() error = %v, expected ErrNoText for a scanned PDF", err)
+	}
+}
+
+func buildDOCX(t *testing.T, documentXML string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("word/document.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte(strings.TrimSpace(documentXML))); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,384 @@
+package textextract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// maxStreamSize bounds the decompressed size of a single PDF stream
+const maxStreamSize = 20 << 20
+
+// winAnsiPunctuation maps the WinAnsi code points PDFs commonly use for typographic punctuation
+var winAnsiPunctuation = map[byte]rune{
+	0x85: '…', 0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—',
+}
+
+// extractPDF extracts text from the content streams of a text-based PDF.
+// Text drawn with fonts that need a ToUnicode map (e.g. subset CID fonts) is skipped.
+func extractPDF(data []byte) (string, error) {
+	var text strings.Builder
+	for _, stream := range pdfStreams(data) {
+		if !bytes.Contains(stream, []byte("BT")) || !bytes.Contains(stream, []byte("ET")) {
+			continue
+		}
+		text.WriteString(contentText(stream))
+		text.WriteByte('\n')
+	}
+	return text.String(), nil
+}
+
+// pdfStreams returns the decoded streams of a PDF that may hold page content
+func pdfStreams(data []byte) [][]byte {
+	var streams [][]byte
+	for pos := 0; pos < len(data); {
+		i := bytes.Index(data[pos:], []byte("stream"))
+		if i < 0 {
+			break
+		}
+		keyword := pos + i
+		pos = keyword + len("stream")
+		if keyword >= 3 && string(data[keyword-3:keyword]) == "end" {
+			continue
+		}
+
+		start := pos
+		if start < len(data) && data[start] == '\r' {
+			start++
+		}
+		if start < len(data) && data[start] == '\n' {
+			start++
+		}
+		end := bytes.Index(data[start:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		end += start
+		pos = end + len("endstream")
+
+		dictStart := bytes.LastIndex(data[:keyword], []byte(" obj"))
+		if dictStart < 0 {
+			dictStart = 0
+		}
+		dict := data[dictStart:keyword]
+		if bytes.Contains(dict, []byte("/Image")) || bytes.Contains(dict, []byte("/Length1")) ||
+			bytes.Contains(dict, []byte("/FontFile")) || bytes.Contains(dict, []byte("/ObjStm")) {
+			continue
+		}
+
+		raw := bytes.TrimRight(data[start:end], "\r\n")
+		switch {
+		case bytes.Contains(dict, []byte("/FlateDecode")):
+			decoded, ok := inflate(raw)
+			if !ok {
+				continue
+			}
+			streams = append(streams, decoded)
+		case bytes.Contains(dict, []byte("/Filter")):
+			// Image and other encodings never hold page text worth the effort
+			continue
+		default:
+			streams = append(streams, raw)
+		}
+	}
+	return streams
+}
+
+// inflate decompresses a FlateDecode stream, keeping whatever decodes before a corrupt tail
+func inflate(raw []byte) ([]byte, bool) {
+	reader, err := zlib.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, false
+	}
+	defer reader.Close()
+	decoded, err := io.ReadAll(io.LimitReader(reader, maxStreamSize))
+	if err != nil && len(decoded) == 0 {
+		return nil, false
+	}
+	return decoded, true
+}
+
+// pdfToken is one lexical token of a content stream
+type pdfToken struct {
+	kind  byte // 's' string, 'n' number, 'o' operator, '[' and ']' array bounds, 'x' anything else
+	text  string
+	value float64
+}
+
+// contentText interprets the text operators of a content stream
+func contentText(content []byte) string {
+	var text strings.Builder
+	var operands []pdfToken
+	inText := false
+	lastY := 0.0
+
+	lexer := &pdfLexer{data: content}
+	for {
+		token, ok := lexer.next()
+		if !ok {
+			break
+		}
+		if token.kind != 'o' {
+			operands = append(operands, token)
+			continue
+		}
+
+		switch token.text {
+		case "BT":
+			inText = true
+		case "ET":
+			inText = false
+			text.WriteByte('\n')
+		case "Tj":
+			if inText {
+				text.WriteString(lastString(operands))
+			}
+		case "'", "\"":
+			if inText {
+				text.WriteByte('\n')
+				text.WriteString(lastString(operands))
+			}
+		case "TJ":
+			if inText {
+				for _, operand := range operands {
+					switch {
+					case operand.kind == 's':
+						text.WriteString(operand.text)
+					case operand.kind == 'n' && operand.value < -250:
+						// A wide negative kern is how many PDFs encode a space
+						text.WriteByte(' ')
+					}
+				}
+			}
+		case "Td", "TD":
+			if inText && len(operands) >= 2 {
+				if operands[len(operands)-1].value != 0 {
+					text.WriteByte('\n')
+				} else {
+					text.WriteByte(' ')
+				}
+			}
+		case "T*":
+			if inText {
+				text.WriteByte('\n')
+			}
+		case "Tm":
+			if inText && len(operands) >= 6 {
+				y := operands[len(operands)-1].value
+				if y != lastY {
+					text.WriteByte('\n')
+				} else {
+					text.WriteByte(' ')
+				}
+				lastY = y
+			}
+		case "ID":
+			lexer.skipInlineImage()
+		}
+		operands = operands[:0]
+	}
+	return text.String()
+}
+
+func lastString(operands []pdfToken) string {
+	for i := len(operands) - 1; i >= 0; i-- {
+		if operands[i].kind == 's' {
+			return operands[i].text
+		}
+	}
+	return ""
+}
+
+// pdfLexer splits a content stream into tokens
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func (l *pdfLexer) next() (pdfToken, bool) {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case isPDFSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		case c == '(':
+			return pdfToken{kind: 's', text: decodePDFString(l.literalString())}, true
+		case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+			l.pos += 2
+			return pdfToken{kind: 'x', text: "<<"}, true
+		case c == '>' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '>':
+			l.pos += 2
+			return pdfToken{kind: 'x', text: ">>"}, true
+		case c == '<':
+			return pdfToken{kind: 's', text: decodePDFString(l.hexString())}, true
+		case c == '[' || c == ']':
+			l.pos++
+			return pdfToken{kind: c, text: string(c)}, true
+		case c == '/' || c == '{' || c == '}' || c == ')' || c == '>':
+			l.pos++
+			name := l.regular()
+			return pdfToken{kind: 'x', text: string(c) + name}, true
+		default:
+			word := l.regular()
+			if word == "" {
+				l.pos++
+				continue
+			}
+			if value, err := strconv.ParseFloat(word, 64); err == nil {
+				return pdfToken{kind: 'n', text: word, value: value}, true
+			}
+			return pdfToken{kind: 'o', text: word}, true
+		}
+	}
+	return pdfToken{}, false
+}
+
+// regular reads a run of regular characters
+func (l *pdfLexer) regular() string {
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	return string(l.data[start:l.pos])
+}
+
+// literalString reads a (string) with balanced parentheses and escapes
+func (l *pdfLexer) literalString() []byte {
+	l.pos++ // opening parenthesis
+	var out []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '\\':
+			if l.pos >= len(l.data) {
+				return out
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b', 'f':
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+			case '\n':
+			default:
+				if e >= '0' && e <= '7' {
+					value := int(e - '0')
+					for n := 0; n < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; n++ {
+						value = value*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					out = append(out, byte(value))
+				} else {
+					out = append(out, e)
+				}
+			}
+		case '(':
+			depth++
+			out = append(out, c)
+		case ')':
+			depth--
+			if depth == 0 {
+				return out
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// hexString reads a <hex string>
+func (l *pdfLexer) hexString() []byte {
+	l.pos++ // opening angle bracket
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; isHexDigit(c) {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	l.pos++ // closing angle bracket
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, len(digits)/2)
+	for i := range out {
+		value, _ := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		out[i] = byte(value)
+	}
+	return out
+}
+
+// skipInlineImage skips binary inline image data up to the EI operator
+func (l *pdfLexer) skipInlineImage() {
+	for l.pos+2 < len(l.data) {
+		if l.data[l.pos] == 'E' && l.data[l.pos+1] == 'I' && isPDFSpace(l.data[l.pos-1]) &&
+			(l.pos+2 == len(l.data) || isPDFSpace(l.data[l.pos+2])) {
+			l.pos += 2
+			return
+		}
+		l.pos++
+	}
+	l.pos = len(l.data)
+}
+
+// decodePDFString decodes UTF-16 strings with a byte order mark and reads anything else as
+// WinAnsi. Strings that decode to control characters use a font-specific encoding and are dropped.
+func decodePDFString(raw []byte) string {
+	if len(raw) >= 2 && raw[0] == 0xfe && raw[1] == 0xff {
+		units := make([]uint16, 0, len(raw)/2)
+		for i := 2; i+1 < len(raw); i += 2 {
+			units = append(units, uint16(raw[i])<<8|uint16(raw[i+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+
+	var out strings.Builder
+	control := 0
+	for _, b := range raw {
+		switch {
+		case b == '\n' || b == '\r' || b == '\t':
+			out.WriteByte(' ')
+		case b < 0x20:
+			control++
+		case winAnsiPunctuation[b] != 0:
+			out.WriteRune(winAnsiPunctuation[b])
+		default:
+			out.WriteRune(rune(b))
+		}
+	}
+	if control*4 > len(raw) {
+		return ""
+	}
+	return out.String()
+}
+
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
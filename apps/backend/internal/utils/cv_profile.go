@@ -0,0 +1,248 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// maxCVPromptChars bounds the CV text sent to the model; the first pages carry the summary and recent roles
+const maxCVPromptChars = 20000
+
+// maxExperienceYears rejects implausible experience read from a CV
+const maxExperienceYears = 50
+
+var (
+	statedExperience = regexp.MustCompile(`(\d{1,2})\s*\+?\s*(?:years|yrs)(?:\s+of)?(?:\s+[a-z/-]+){0,3}?\s+experience`)
+	employmentRange  = regexp.MustCompile(`\b((?:19|20)\d{2})\s*(?:-|–|—|to|until)\s*(?:present|current|now|today|(?:19|20)\d{2})\b`)
+)
+
+// CVProfilePromptData is the data rendered into the extract_cv_profile prompt template
+type CVProfilePromptData struct {
+	CV    string
+	Today string
+	Types string
+}
+
+// NewCVProfilePromptData builds CV extraction prompt data, truncating long CVs
+func NewCVProfilePromptData(text string, now time.Time) CVProfilePromptData {
+	text = strings.TrimSpace(text)
+	if len(text) > maxCVPromptChars {
+		cut := maxCVPromptChars
+		for cut > 0 && !isRuneStart(text[cut]) {
+			cut--
+		}
+		text = text[:cut]
+	}
+	return CVProfilePromptData{
+		CV:    text,
+		Today: now.Format(dateLayout),
+		Types: NewTalentQueryPromptData("", now).Types,
+	}
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xc0 != 0x80
+}
+
+// ParseCVProfileResponse extracts proposed profile fields from an extract_cv_profile response.
+// Like ParseTalentQueryResponse it tolerates markdown fences, prose and quoted numbers.
+func ParseCVProfileResponse(raw string) (*entities.ProfileProposal, error) {
+	text := stripCodeFences(raw)
+
+	start := strings.Index(text, "{")
+	if start < 0 {
+		return nil, fmt.Errorf("no JSON object found in response")
+	}
+
+	var fields struct {
+		Skills            []string     `json:"skills"`
+		YearsOfExperience *json.Number `json:"years_of_experience"`
+		Industries        []string     `json:"industries"`
+		Type              string       `json:"type"`
+	}
+	decoder := json.NewDecoder(strings.NewReader(text[start:]))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("invalid CV profile JSON: %w", err)
+	}
+
+	proposal := &entities.ProfileProposal{
+		Skills:   fields.Skills,
+		Industry: fields.Industries,
+		Type:     fields.Type,
+	}
+	if fields.YearsOfExperience != nil && strings.Trim(string(*fields.YearsOfExperience), `" `) != "" {
+		years := int(math.Round(numberOrZero(*fields.YearsOfExperience)))
+		proposal.YearsOfExperience = &years
+	}
+	normalizeProposal(proposal)
+	return proposal, nil
+}
+
+// ExtractCVProfileRules reads profile fields from CV text without a model, using the built-in
+// vocabulary for skills, role and industries. Experience is taken from a stated number of years
+// or, failing that, from the earliest employment date range.
+func ExtractCVProfileRules(text string, now time.Time) *entities.ProfileProposal {
+	q := newQueryScanner(text)
+	proposal := &entities.ProfileProposal{
+		Skills:   q.terms(skillVocabulary),
+		Industry: q.terms(industryVocabulary),
+	}
+	// CVs open with the current title, so the first role mentioned is the best guess
+	if types := q.terms(typeVocabulary); len(types) > 0 {
+		proposal.Type = types[0]
+	}
+
+	years := 0
+	for _, match := range statedExperience.FindAllStringSubmatch(q.lower, -1) {
+		if n, _ := strconv.Atoi(match[1]); n > years {
+			years = n
+		}
+	}
+	if years == 0 {
+		earliest := 0
+		for _, match := range employmentRange.FindAllStringSubmatch(q.lower, -1) {
+			if year, _ := strconv.Atoi(match[1]); earliest == 0 || year < earliest {
+				earliest = year
+			}
+		}
+		if earliest > 0 {
+			years = now.Year() - earliest
+		}
+	}
+	if years > 0 {
+		proposal.YearsOfExperience = &years
+	}
+
+	normalizeProposal(proposal)
+	return proposal
+}
+
+// normalizeProposal maps values onto canonical names, drops unknown employee types and
+// discards implausible experience
+func normalizeProposal(proposal *entities.ProfileProposal) {
+	proposal.Skills = canonicalTerms(skillVocabulary, proposal.Skills, true)
+	proposal.Industry = canonicalTerms(industryVocabulary, proposal.Industry, true)
+	if types := canonicalTerms(typeVocabulary, []string{proposal.Type}, false); len(types) > 0 {
+		proposal.Type = types[0]
+	} else {
+		proposal.Type = ""
+	}
+	if years := proposal.YearsOfExperience; years != nil && (*years <= 0 || *years > maxExperienceYears) {
+		proposal.YearsOfExperience = nil
+	}
+}
+
+// MergeProfileProposal returns the values a CV proposes for a profile. Skills and industries
+// are added to the current ones rather than replacing them; current may be nil.
+func MergeProfileProposal(current *entities.EmployeeProfile, extracted *entities.ProfileProposal) entities.ProfileProposal {
+	merged := entities.ProfileProposal{
+		YearsOfExperience: extracted.YearsOfExperience,
+		Type:              extracted.Type,
+	}
+	var skills, industries []string
+	if current != nil {
+		skills = current.Skills
+		industries = splitIndustry(current.Industry)
+	}
+	merged.Skills = unionTerms(skillVocabulary, skills, extracted.Skills)
+	merged.Industry = unionTerms(industryVocabulary, industries, extracted.Industry)
+	return merged
+}
+
+// ProfileDraftChanges lists the fields where a merged proposal differs from the current profile
+func ProfileDraftChanges(current *entities.EmployeeProfile, proposed *entities.ProfileProposal) []models.ProfileFieldChange {
+	if current == nil {
+		current = &entities.EmployeeProfile{}
+	}
+	changes := []models.ProfileFieldChange{}
+
+	skills := []string(current.Skills)
+	if added := addedTerms(skillVocabulary, skills, proposed.Skills); len(added) > 0 {
+		changes = append(changes, models.ProfileFieldChange{
+			Field: models.DraftFieldSkills, Current: nonNil(skills), Proposed: proposed.Skills, Added: added,
+		})
+	}
+	if years := proposed.YearsOfExperience; years != nil && *years != current.YearsOfExperience {
+		changes = append(changes, models.ProfileFieldChange{
+			Field: models.DraftFieldYearsOfExperience, Current: current.YearsOfExperience, Proposed: *years,
+		})
+	}
+	industries := splitIndustry(current.Industry)
+	if added := addedTerms(industryVocabulary, industries, proposed.Industry); len(added) > 0 {
+		changes = append(changes, models.ProfileFieldChange{
+			Field: models.DraftFieldIndustry, Current: nonNil(industries), Proposed: proposed.Industry, Added: added,
+		})
+	}
+	if proposed.Type != "" && !strings.EqualFold(proposed.Type, current.Type) {
+		changes = append(changes, models.ProfileFieldChange{
+			Field: models.DraftFieldType, Current: current.Type, Proposed: proposed.Type,
+		})
+	}
+	return changes
+}
+
+// unionTerms appends new values to the current ones, treating spellings of one term as the same value
+func unionTerms(v vocabulary, current []string, extra []string) []string {
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, value := range append(append([]string{}, current...), extra...) {
+		value = strings.TrimSpace(value)
+		key := termKey(v, value)
+		if value == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, value)
+	}
+	return result
+}
+
+// addedTerms returns the proposed values that are not already among the current ones
+func addedTerms(v vocabulary, current []string, proposed []string) []string {
+	existing := make(map[string]bool, len(current))
+	for _, value := range current {
+		existing[termKey(v, value)] = true
+	}
+	var added []string
+	for _, value := range proposed {
+		if !existing[termKey(v, value)] {
+			added = append(added, value)
+		}
+	}
+	return added
+}
+
+// termKey identifies a value by its canonical term, so "Golang" and "Go" compare equal
+func termKey(v vocabulary, value string) string {
+	if term, ok := v.lookup(value); ok {
+		return strings.ToLower(term.Name)
+	}
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// splitIndustry splits the colon-separated industry column
+func splitIndustry(industry string) []string {
+	var industries []string
+	for _, value := range strings.Split(industry, ":") {
+		if value = strings.TrimSpace(value); value != "" {
+			industries = append(industries, value)
+		}
+	}
+	return industries
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
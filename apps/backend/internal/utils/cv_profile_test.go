@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+func TestExtractCVProfileRules(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 15, 0, 0, 0, time.UTC)
+	cv := `Jane Doe
+Senior Backend Engineer
+
+Experience
+Acme Payments, 2019 – present: Golang services on Kubernetes, Kafka and Postgres for a banking client.
+Initech, 2014 - 2019: Java and Spring Boot.`
+
+	proposal := ExtractCVProfileRules(cv, now)
+	if got := strings.Join(proposal.Skills, ","); got != "Go,Kubernetes,Kafka,PostgreSQL,Java,Spring" {
+		t.Errorf("Skills = %q", got)
+	}
+	if proposal.Type != "Backend Dev" {
+		t.Errorf("Type = %q", proposal.Type)
+	}
+	if got := strings.Join(proposal.Industry, ","); got != "Fintech" {
+		t.Errorf("Industry = %q", got)
+	}
+	if proposal.YearsOfExperience == nil || *proposal.YearsOfExperience != 12 {
+		t.Errorf("YearsOfExperience = %v, expected years since the earliest role", proposal.YearsOfExperience)
+	}
+
+	// A stated figure wins over the employment history
+	proposal = ExtractCVProfileRules("QA engineer with 7 years of professional experience, 2022 - present", now)
+	if proposal.YearsOfExperience == nil || *proposal.YearsOfExperience != 7 {
+		t.Errorf("YearsOfExperience = %v, expected the stated 7 years", proposal.YearsOfExperience)
+	}
+}
+
+func TestProfileDraftChanges(t *testing.T) {
+	raw := "```json\n" + `{"skills": ["golang", "Terraform"], "years_of_experience": "9", "industries": ["banking", "Retail"], "type": "Wizard"}` + "\n```"
+	extracted, err := ParseCVProfileResponse(raw)
+	if err != nil {
+		t.Fatalf("ParseCVProfileResponse() error = %v", err)
+	}
+	if extracted.Type != "" {
+		t.Errorf("Type = %q, expected unknown types dropped", extracted.Type)
+	}
+
+	current := &entities.EmployeeProfile{
+		Type:              "Backend Dev",
+		Skills:            entities.Skills{"Golang", "Kafka"},
+		Industry:          "Fintech",
+		YearsOfExperience: 9,
+	}
+	proposed := MergeProfileProposal(current, extracted)
+	if got := strings.Join(proposed.Skills, ","); got != "Golang,Kafka,Terraform" {
+		t.Errorf("merged Skills = %q, expected current spellings kept", got)
+	}
+
+	changes := ProfileDraftChanges(current, &proposed)
+	if len(changes) != 2 {
+		t.Fatalf("changes = %+v, expected skills and industry only", changes)
+	}
+	if changes[0].Field != models.DraftFieldSkills || strings.Join(changes[0].Added, ",") != "Terraform" {
+		t.Errorf("skills change = %+v", changes[0])
+	}
+	if changes[1].Field != models.DraftFieldIndustry || strings.Join(changes[1].Added, ",") != "Retail" {
+		t.Errorf("industry change = %+v", changes[1])
+	}
+
+	// Without a profile every extracted value is a change
+	proposed = MergeProfileProposal(nil, extracted)
+	if changes := ProfileDraftChanges(nil, &proposed); len(changes) != 3 {
+		t.Errorf("changes = %+v, expected skills, experience and industry", changes)
+	}
+}
@@ -26,6 +26,7 @@ func (u *EmbeddingEntityUtils) GenerateEmployeeProfileEmbedding(ctx context.Cont
   if profile == nil {
     return fmt.Errorf("profile cannot be nil")
   }
+  ctx = withDefaultAIUsage(ctx, domain.FeatureProfileEmbedding, domain.EntityTypeProfile, int(profile.UserID))
 
   // Create profile data map
   profileData := map[string]interface{}{
@@ -53,6 +54,7 @@ func (u *EmbeddingEntityUtils) GenerateProjectEmbedding(ctx context.Context, pro
   if project == nil {
     return fmt.Errorf("project cannot be nil")
   }
+  ctx = withDefaultAIUsage(ctx, domain.FeatureProjectEmbedding, domain.EntityTypeProject, project.ID)
 
   // Create project data map
   // Convert SeatsByType (map[string]int) to map[string]interface{}
@@ -105,6 +107,7 @@ func (u *EmbeddingEntityUtils) BatchGenerateEmployeeProfileEmbeddings(ctx contex
   if len(profiles) == 0 {
     return nil
   }
+  ctx = withDefaultAIUsage(ctx, domain.FeatureProfileEmbedding, domain.EntityTypeProfile, 0)
 
   // Prepare texts for batch processing
   var texts []string
@@ -174,6 +177,7 @@ func (u *EmbeddingEntityUtils) BatchGenerateProjectEmbeddings(ctx context.Contex
   if len(projects) == 0 {
     return nil
   }
+  ctx = withDefaultAIUsage(ctx, domain.FeatureProjectEmbedding, domain.EntityTypeProject, 0)
 
   // Prepare texts for batch processing
   var texts []string
@@ -230,3 +234,11 @@ func (u *EmbeddingEntityUtils) BatchGenerateProjectEmbeddings(ctx context.Contex
 
   return nil
 }
+
+// withDefaultAIUsage attributes embedding calls unless the caller already attributed the context
+func withDefaultAIUsage(ctx context.Context, feature string, entityType string, entityID int) context.Context {
+  if _, ok := domain.AIUsageFromContext(ctx); ok {
+    return ctx
+  }
+  return domain.WithAIUsage(ctx, feature, entityType, entityID)
+}
@@ -0,0 +1,468 @@
+package utils
+
+import (
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/internal/tabular"
+)
+
+// maxImportExperienceYears rejects implausible experience in imported profiles
+const maxImportExperienceYears = 60
+
+// importColumn is a column an import file may have
+type importColumn struct {
+	Name     string
+	Required bool
+}
+
+// importColumns lists the columns of each import kind; headers are matched case-insensitively
+// with spaces and dashes read as underscores
+var importColumns = map[models.ImportKind][]importColumn{
+	models.ImportUsers: {
+		{Name: "email", Required: true},
+		{Name: "first_name", Required: true},
+		{Name: "last_name", Required: true},
+		{Name: "role"},
+		{Name: "external_id"},
+		{Name: "slack_user_id"},
+	},
+	models.ImportProfiles: {
+		{Name: "email", Required: true},
+		{Name: "type"},
+		{Name: "skills"},
+		{Name: "years_of_experience"},
+		{Name: "experience_level"},
+		{Name: "industry"},
+		{Name: "geo"},
+		{Name: "availability_flag"},
+		{Name: "date_of_joining"},
+		{Name: "end_date"},
+		{Name: "notice_date"},
+		{Name: "department"},
+		{Name: "employment_type"},
+	},
+	models.ImportProjects: {
+		{Name: "external_id", Required: true},
+		{Name: "name", Required: true},
+		{Name: "start_date", Required: true},
+		{Name: "end_date", Required: true},
+		{Name: "description"},
+		{Name: "client_name"},
+		{Name: "industry"},
+		{Name: "geo_preference"},
+		{Name: "priority"},
+		{Name: "budget"},
+		{Name: "required_seats"},
+		{Name: "seats_by_type"},
+		{Name: "status"},
+	},
+	models.ImportAllocations: {
+		{Name: "employee_email", Required: true},
+		{Name: "project_external_id", Required: true},
+		{Name: "allocation_type", Required: true},
+		{Name: "start_date", Required: true},
+		{Name: "end_date"},
+	},
+}
+
+// ParseImportTable validates the rows of one import file and adds the valid ones to the batch.
+// Header problems are reported on line 1 and stop the file from being read.
+func ParseImportTable(kind models.ImportKind, file string, table *tabular.Table, batch *domain.ImportBatch) []models.ImportRowError {
+	columns, ok := importColumns[kind]
+	if !ok {
+		return []models.ImportRowError{{File: file, Line: 1, Message: fmt.Sprintf("unknown import kind %q", kind)}}
+	}
+
+	var errs []models.ImportRowError
+	header := make([]string, len(table.Header))
+	present := make(map[string]bool, len(table.Header))
+	for i, name := range table.Header {
+		name = normalizeImportHeader(name)
+		header[i] = name
+		switch {
+		case name == "":
+			errs = append(errs, models.ImportRowError{File: file, Line: 1, Message: fmt.Sprintf("column %d has no header", i+1)})
+		case present[name]:
+			errs = append(errs, models.ImportRowError{File: file, Line: 1, Column: name, Message: "duplicate column"})
+		case !knownImportColumn(columns, name):
+			errs = append(errs, models.ImportRowError{File: file, Line: 1, Column: name, Message: "unknown column"})
+		}
+		present[name] = true
+	}
+	for _, column := range columns {
+		if column.Required && !present[column.Name] {
+			errs = append(errs, models.ImportRowError{File: file, Line: 1, Column: column.Name, Message: "required column is missing"})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	parser := importParser{file: file, header: header, present: present, seen: make(map[string]int)}
+	for _, row := range table.Rows {
+		r := parser.row(row)
+		switch kind {
+		case models.ImportUsers:
+			if user, ok := parser.user(r); ok {
+				batch.Users = append(batch.Users, user)
+			}
+		case models.ImportProfiles:
+			if profile, ok := parser.profile(r); ok {
+				batch.Profiles = append(batch.Profiles, profile)
+			}
+		case models.ImportProjects:
+			if project, ok := parser.project(r); ok {
+				batch.Projects = append(batch.Projects, project)
+			}
+		case models.ImportAllocations:
+			if allocation, ok := parser.allocation(r); ok {
+				batch.Allocations = append(batch.Allocations, allocation)
+			}
+		}
+		errs = append(errs, r.errs...)
+	}
+	return errs
+}
+
+// importParser converts the rows of one file
+type importParser struct {
+	file    string
+	header  []string
+	present map[string]bool
+	// seen maps row keys, e.g. emails, to the line they first appeared on
+	seen map[string]int
+}
+
+// importRow is one row's values by column, collecting its errors
+type importRow struct {
+	source domain.ImportSource
+	values map[string]string
+	errs   []models.ImportRowError
+}
+
+func (p *importParser) row(row tabular.Row) *importRow {
+	values := make(map[string]string, len(p.header))
+	for i, name := range p.header {
+		if i < len(row.Values) {
+			values[name] = strings.TrimSpace(row.Values[i])
+		}
+	}
+	return &importRow{source: domain.ImportSource{File: p.file, Line: row.Line}, values: values}
+}
+
+// columns returns the given column names that the file has
+func (p *importParser) columns(names ...string) []string {
+	var columns []string
+	for _, name := range names {
+		if p.present[name] {
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
+
+// unique reports an error when a key was already used by an earlier row of the file
+func (p *importParser) unique(r *importRow, column, key string) {
+	if key == "" {
+		return
+	}
+	id := column + "\x00" + strings.ToLower(key)
+	if line, ok := p.seen[id]; ok {
+		r.fail(column, "duplicate of line %d", line)
+		return
+	}
+	p.seen[id] = r.source.Line
+}
+
+func (p *importParser) user(r *importRow) (domain.ImportUser, bool) {
+	user := &entities.User{
+		Email:       r.email("email"),
+		FirstName:   r.required("first_name"),
+		LastName:    r.required("last_name"),
+		Role:        string(models.RoleEmployee),
+		SlackUserID: r.text("slack_user_id"),
+		ExternalID:  optionalText(r.text("external_id")),
+	}
+	if role := r.text("role"); role != "" {
+		switch {
+		case strings.EqualFold(role, string(models.RoleEmployee)):
+			user.Role = string(models.RoleEmployee)
+		case strings.EqualFold(role, string(models.RoleManager)):
+			user.Role = string(models.RoleManager)
+		default:
+			r.fail("role", "must be %s or %s", models.RoleEmployee, models.RoleManager)
+		}
+	}
+	p.unique(r, "email", user.Email)
+	p.unique(r, "external_id", r.text("external_id"))
+
+	columns := append([]string{"email"}, p.columns("first_name", "last_name", "role", "external_id", "slack_user_id")...)
+	return domain.ImportUser{ImportSource: r.source, User: user, Columns: columns}, len(r.errs) == 0
+}
+
+func (p *importParser) profile(r *importRow) (domain.ImportProfile, bool) {
+	email := r.email("email")
+	profile := &entities.EmployeeProfile{
+		Geo:              r.text("geo"),
+		ExperienceLevel:  r.text("experience_level"),
+		Department:       r.text("department"),
+		EmploymentType:   r.text("employment_type"),
+		Skills:           unionTerms(skillVocabulary, nil, splitImportList(r.text("skills"))),
+		Industry:         strings.Join(unionTerms(industryVocabulary, nil, splitImportList(r.text("industry"))), ":"),
+		DateOfJoining:    r.optionalDate("date_of_joining"),
+		EndDate:          r.optionalDate("end_date"),
+		NoticeDate:       r.optionalDate("notice_date"),
+		AvailabilityFlag: r.boolean("availability_flag"),
+	}
+	if value := r.text("type"); value != "" {
+		if term, ok := typeVocabulary.lookup(value); ok {
+			profile.Type = term.Name
+		} else {
+			r.fail("type", "unknown employee type %q", value)
+		}
+	}
+	if years, ok := r.integer("years_of_experience"); ok {
+		if years > maxImportExperienceYears {
+			r.fail("years_of_experience", "must be at most %d", maxImportExperienceYears)
+		}
+		profile.YearsOfExperience = years
+	}
+	p.unique(r, "email", email)
+
+	columns := p.columns("type", "skills", "years_of_experience", "experience_level", "industry", "geo",
+		"availability_flag", "date_of_joining", "end_date", "notice_date", "department", "employment_type")
+	return domain.ImportProfile{ImportSource: r.source, Email: email, Profile: profile, Columns: columns}, len(r.errs) == 0
+}
+
+func (p *importParser) project(r *importRow) (domain.ImportProject, bool) {
+	externalID := r.required("external_id")
+	project := &entities.Project{
+		ExternalID:    optionalText(externalID),
+		Name:          r.required("name"),
+		Description:   r.text("description"),
+		ClientName:    r.text("client_name"),
+		Industry:      r.text("industry"),
+		GeoPreference: r.text("geo_preference"),
+		Priority:      r.text("priority"),
+		Status:        string(models.StatusOpen),
+		SeatsByType:   entities.SeatsByType{},
+	}
+	if start, ok := r.date("start_date"); ok {
+		project.StartDate = start
+	}
+	if end, ok := r.date("end_date"); ok {
+		project.EndDate = end
+		if !project.StartDate.IsZero() && end.Before(project.StartDate) {
+			r.fail("end_date", "must not be before start_date")
+		}
+	}
+	if value := r.text("budget"); value != "" {
+		budget, err := strconv.ParseFloat(value, 64)
+		if err != nil || budget < 0 {
+			r.fail("budget", "must be a non-negative number")
+		}
+		project.Budget = budget
+	}
+	if value := r.text("status"); value != "" {
+		switch {
+		case strings.EqualFold(value, string(models.StatusOpen)):
+			project.Status = string(models.StatusOpen)
+		case strings.EqualFold(value, string(models.StatusClosed)):
+			project.Status = string(models.StatusClosed)
+		default:
+			r.fail("status", "must be %s or %s", models.StatusOpen, models.StatusClosed)
+		}
+	}
+
+	seatTotal := 0
+	for _, entry := range splitImportList(r.text("seats_by_type")) {
+		name, count, found := strings.Cut(entry, "=")
+		seats, err := strconv.Atoi(strings.TrimSpace(count))
+		if !found || strings.TrimSpace(name) == "" || err != nil || seats < 0 {
+			r.fail("seats_by_type", "expected entries like \"Backend Dev=2\", got %q", entry)
+			continue
+		}
+		name = strings.TrimSpace(name)
+		if term, ok := typeVocabulary.lookup(name); ok {
+			name = term.Name
+		}
+		project.SeatsByType[name] += seats
+		seatTotal += seats
+	}
+	columns := p.columns("name", "description", "client_name", "industry", "geo_preference", "priority",
+		"budget", "required_seats", "seats_by_type", "status", "start_date", "end_date")
+	if seats, ok := r.integer("required_seats"); ok {
+		project.RequiredSeats = seats
+	} else if !p.present["required_seats"] && p.present["seats_by_type"] {
+		// Without an explicit total the seats by type add up to it
+		project.RequiredSeats = seatTotal
+		columns = append(columns, "required_seats")
+	}
+	p.unique(r, "external_id", externalID)
+
+	return domain.ImportProject{ImportSource: r.source, Project: project, Columns: columns}, len(r.errs) == 0
+}
+
+func (p *importParser) allocation(r *importRow) (domain.ImportAllocation, bool) {
+	allocation := &entities.ProjectAllocation{EndDate: r.optionalDate("end_date")}
+	email := r.email("employee_email")
+	projectID := r.required("project_external_id")
+
+	value := r.required("allocation_type")
+	if allocationType, ok := parseAllocationType(value); ok {
+		allocation.AllocationType = string(allocationType)
+	} else if value != "" {
+		r.fail("allocation_type", "must be %s, %s or %s", models.AllocationFullTime, models.AllocationPartTime, models.AllocationExtra)
+	}
+	if start, ok := r.date("start_date"); ok {
+		allocation.StartDate = start
+		if allocation.EndDate != nil && allocation.EndDate.Before(start) {
+			r.fail("end_date", "must not be before start_date")
+		}
+	}
+	p.unique(r, "start_date", email+"\x00"+projectID+"\x00"+r.text("start_date"))
+
+	return domain.ImportAllocation{
+		ImportSource:      r.source,
+		EmployeeEmail:     email,
+		ProjectExternalID: projectID,
+		Allocation:        allocation,
+	}, len(r.errs) == 0
+}
+
+func (r *importRow) fail(column, format string, args ...interface{}) {
+	r.errs = append(r.errs, models.ImportRowError{
+		File:    r.source.File,
+		Line:    r.source.Line,
+		Column:  column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (r *importRow) text(column string) string {
+	return r.values[column]
+}
+
+func (r *importRow) required(column string) string {
+	value := r.values[column]
+	if value == "" {
+		r.fail(column, "is required")
+	}
+	return value
+}
+
+// email reads a required email address, lower-cased so it matches stored emails
+func (r *importRow) email(column string) string {
+	value := r.required(column)
+	if value == "" {
+		return ""
+	}
+	if address, err := mail.ParseAddress(value); err != nil || address.Address != value {
+		r.fail(column, "invalid email address %q", value)
+	}
+	return strings.ToLower(value)
+}
+
+// date reads a required date
+func (r *importRow) date(column string) (time.Time, bool) {
+	value := r.required(column)
+	if value == "" {
+		return time.Time{}, false
+	}
+	t, err := tabular.ParseDate(value)
+	if err != nil {
+		r.fail(column, "%v", err)
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// optionalDate reads a date that may be left empty
+func (r *importRow) optionalDate(column string) *time.Time {
+	value := r.values[column]
+	if value == "" {
+		return nil
+	}
+	t, err := tabular.ParseDate(value)
+	if err != nil {
+		r.fail(column, "%v", err)
+		return nil
+	}
+	return &t
+}
+
+// integer reads a non-negative whole number; ok is false when the cell is empty or invalid
+func (r *importRow) integer(column string) (int, bool) {
+	value := r.values[column]
+	if value == "" {
+		return 0, false
+	}
+	// Spreadsheets store every number as a float
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 || n != float64(int(n)) {
+		r.fail(column, "must be a non-negative whole number")
+		return 0, false
+	}
+	return int(n), true
+}
+
+func (r *importRow) boolean(column string) bool {
+	switch strings.ToLower(r.values[column]) {
+	case "", "false", "no", "n", "0":
+		return false
+	case "true", "yes", "y", "1":
+		return true
+	default:
+		r.fail(column, "must be true or false")
+		return false
+	}
+}
+
+// parseAllocationType matches an allocation type regardless of case, spaces or dashes
+func parseAllocationType(value string) (models.AllocationType, bool) {
+	key := strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(value))
+	for _, allocationType := range []models.AllocationType{models.AllocationFullTime, models.AllocationPartTime, models.AllocationExtra} {
+		if key == strings.ReplaceAll(strings.ToLower(string(allocationType)), "-", "") {
+			return allocationType, true
+		}
+	}
+	return "", false
+}
+
+// splitImportList splits a cell holding several values separated by semicolons, commas or pipes
+func splitImportList(value string) []string {
+	var values []string
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == ',' || r == '|' || r == '\n' }) {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+func normalizeImportHeader(name string) string {
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+func knownImportColumn(columns []importColumn, name string) bool {
+	for _, column := range columns {
+		if column.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func optionalText(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/models"
+	"github.com/talent-fit/backend/internal/tabular"
+)
+
+func readImportTable(t *testing.T, csv string) *tabular.Table {
+	t.Helper()
+	table, err := tabular.Read("rows.csv", []byte(csv))
+	if err != nil {
+		t.Fatalf("tabular.Read() error = %v", err)
+	}
+	return table
+}
+
+func TestParseImportTable(t *testing.T) {
+	batch := &domain.ImportBatch{}
+	table := readImportTable(t, `Email,First Name,Last Name,Role,External-ID
+jane@example.com,Jane,Doe,manager,E-1
+bob@example.com,Bob,,Owner,
+JANE@example.com,Jane,Again,,
+`)
+	errs := ParseImportTable(models.ImportUsers, "users.csv", table, batch)
+	if len(batch.Users) != 1 {
+		t.Fatalf("Users = %+v, expected only the first row", batch.Users)
+	}
+	user := batch.Users[0]
+	if user.User.Role != string(models.RoleManager) || *user.User.ExternalID != "E-1" {
+		t.Errorf("User = %+v", user.User)
+	}
+	if got := strings.Join(user.Columns, ","); got != "email,first_name,last_name,role,external_id" {
+		t.Errorf("Columns = %q", got)
+	}
+	var got []string
+	for _, err := range errs {
+		got = append(got, strings.Join([]string{err.Column, err.Message}, ":"))
+	}
+	if want := "last_name:is required,role:must be Employee or Manager,email:duplicate of line 2"; strings.Join(got, ",") != want {
+		t.Errorf("errors = %q, expected %q", got, want)
+	}
+	if errs[0].Line != 3 || errs[2].Line != 4 {
+		t.Errorf("errors = %+v, expected file line numbers", errs)
+	}
+
+	// Header problems stop the file before any row is read
+	table = readImportTable(t, "employee_email,project,allocation_type\nbob@example.com,P-1,Extra\n")
+	errs = ParseImportTable(models.ImportAllocations, "allocations.csv", table, batch)
+	if len(errs) != 3 || errs[0].Column != "project" || errs[0].Line != 1 {
+		t.Errorf("errors = %+v, expected the unknown and two missing columns", errs)
+	}
+}
+
+func TestParseImportTableValues(t *testing.T) {
+	batch := &domain.ImportBatch{}
+	table := readImportTable(t, `external_id,name,start_date,end_date,seats_by_type,status
+P-1,Payments,2026-01-05,2026-06-30,backend=2; UI=1,closed
+P-2,Portal,2026-03-01,2026-02-01,,
+`)
+	errs := ParseImportTable(models.ImportProjects, "projects.csv", table, batch)
+	if len(errs) != 1 || errs[0].Column != "end_date" {
+		t.Errorf("errors = %+v, expected the end date before the start", errs)
+	}
+	project := batch.Projects[0].Project
+	if project.RequiredSeats != 3 || project.SeatsByType["Backend Dev"] != 2 || project.Status != "Closed" {
+		t.Errorf("Project = %+v", project)
+	}
+
+	table = readImportTable(t, `email,type,skills,industry,years_of_experience,availability_flag
+ann@example.com,fullstack,Golang; React | Go,Banking,7,yes
+`)
+	if errs := ParseImportTable(models.ImportProfiles, "profiles.csv", table, batch); len(errs) > 0 {
+		t.Fatalf("errors = %+v", errs)
+	}
+	profile := batch.Profiles[0].Profile
+	if profile.Type != "Fullstack Dev" || strings.Join(profile.Skills, ",") != "Golang,React" ||
+		profile.YearsOfExperience != 7 || !profile.AvailabilityFlag {
+		t.Errorf("Profile = %+v", profile)
+	}
+
+	table = readImportTable(t, "employee_email,project_external_id,allocation_type,start_date\nann@example.com,P-1,part time,45292\n")
+	if errs := ParseImportTable(models.ImportAllocations, "allocations.csv", table, batch); len(errs) > 0 {
+		t.Fatalf("errors = %+v", errs)
+	}
+	allocation := batch.Allocations[0].Allocation
+	if allocation.AllocationType != string(models.AllocationPartTime) || allocation.StartDate.Format("2006-01-02") != "2024-01-01" {
+		t.Errorf("Allocation = %+v", allocation)
+	}
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// ScoreStreamParser extracts candidate score entries from scoring output as it streams in.
+// It accepts the same shapes as ParseCandidateScores, a bare array or {"scores": [...]},
+// and yields each entry once its object is complete. Entries that do not decode are skipped;
+// the complete response is still parsed and validated when the stream ends.
+type ScoreStreamParser struct {
+	buf        strings.Builder
+	depth      int
+	arrayDepth int // depth inside the scores array, 0 until it opens
+	entryStart int // offset of the entry being read, -1 between entries
+	inString   bool
+	escaped    bool
+}
+
+// NewScoreStreamParser creates a parser for one streamed scoring response
+func NewScoreStreamParser() *ScoreStreamParser {
+	return &ScoreStreamParser{entryStart: -1}
+}
+
+// Write consumes the next chunk of the response and returns the entries it completed
+func (p *ScoreStreamParser) Write(chunk string) []models.CandidateScore {
+	offset := p.buf.Len()
+	p.buf.WriteString(chunk)
+	text := p.buf.String()
+
+	var scores []models.CandidateScore
+	for i := offset; i < len(text); i++ {
+		c := text[i]
+		if p.inString {
+			switch {
+			case p.escaped:
+				p.escaped = false
+			case c == '\\':
+				p.escaped = true
+			case c == '"':
+				p.inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			p.inString = true
+		case '[':
+			p.depth++
+			if p.arrayDepth == 0 {
+				p.arrayDepth = p.depth
+			}
+		case '{':
+			if p.arrayDepth > 0 && p.depth == p.arrayDepth {
+				p.entryStart = i
+			}
+			p.depth++
+		case '}':
+			p.depth--
+			if p.entryStart >= 0 && p.depth == p.arrayDepth {
+				if score, err := parseCandidateScoreEntry(json.RawMessage(text[p.entryStart : i+1])); err == nil {
+					scores = append(scores, score)
+				}
+				p.entryStart = -1
+			}
+		case ']':
+			p.depth--
+		}
+	}
+	return scores
+}
+
+// String returns the response received so far
+func (p *ScoreStreamParser) String() string {
+	return p.buf.String()
+}
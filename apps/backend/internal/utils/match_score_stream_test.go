@@ -0,0 +1,35 @@
+package utils
+
+import "testing"
+
+func TestScoreStreamParser(t *testing.T) {
+	response := "```json\n" + `{"scores": [{"candidate_id": 1, "score": 80, "reason": "Go and {Postgres} \"expert\""}, {"candidate_id": "2", "score": 55.6, "reason": "Partial [match]"}, {"candidate_id": 3, "score": 40, "reason": "tru`
+
+	parser := NewScoreStreamParser()
+	var ids []int
+	// Feed the response in small chunks, splitting entries, strings and escapes
+	for i := 0; i < len(response); i += 7 {
+		end := i + 7
+		if end > len(response) {
+			end = len(response)
+		}
+		for _, score := range parser.Write(response[i:end]) {
+			ids = append(ids, score.CandidateID)
+			if score.CandidateID == 2 && score.Score != 56 {
+				t.Errorf("expected rounded score 56 for candidate 2, got %d", score.Score)
+			}
+		}
+	}
+
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("expected candidates [1 2] from the completed entries, got %v", ids)
+	}
+	if parser.String() != response {
+		t.Error("parser did not keep the full response")
+	}
+
+	bare := NewScoreStreamParser()
+	if scores := bare.Write(`[{"candidate_id": 7, "score": 90, "reason": "ok"}]`); len(scores) != 1 || scores[0].CandidateID != 7 {
+		t.Errorf("expected one score for a bare array, got %v", scores)
+	}
+}
@@ -0,0 +1,264 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// Score sources recorded on every CandidateScore returned to clients
+const (
+	ScoreSourceAI       = "ai"
+	ScoreSourceFallback = "fallback"
+)
+
+// CandidateScoreSchema returns the JSON schema the scoring model must follow.
+// The root is an object because structured output modes do not accept bare arrays.
+func CandidateScoreSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"scores": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"candidate_id": map[string]interface{}{"type": "integer"},
+						"score":        map[string]interface{}{"type": "integer", "minimum": 0, "maximum": 100},
+						"reason":       map[string]interface{}{"type": "string", "minLength": 1},
+					},
+					"required":             []string{"candidate_id", "score", "reason"},
+					"additionalProperties": false,
+				},
+			},
+		},
+		"required":             []string{"scores"},
+		"additionalProperties": false,
+	}
+}
+
+// ParseCandidateScores extracts candidate scores from a model response.
+// It tolerates markdown fences, leading prose and trailing comments, and accepts
+// either a bare array or an object with a "scores" array. Malformed entries are skipped and
+// reported as problems; an error means the response held no scores array at all.
+func ParseCandidateScores(raw string) ([]models.CandidateScore, []string, error) {
+	text := stripCodeFences(raw)
+
+	start := strings.IndexAny(text, "[{")
+	if start < 0 {
+		return nil, nil, fmt.Errorf("no JSON found in response")
+	}
+
+	// Decode only the first JSON value; anything after it is ignored
+	var value json.RawMessage
+	decoder := json.NewDecoder(strings.NewReader(text[start:]))
+	if err := decoder.Decode(&value); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var entries []json.RawMessage
+	if bytes.HasPrefix(bytes.TrimSpace(value), []byte("[")) {
+		if err := json.Unmarshal(value, &entries); err != nil {
+			return nil, nil, fmt.Errorf("invalid scores array: %w", err)
+		}
+	} else {
+		var wrapper struct {
+			Scores []json.RawMessage `json:"scores"`
+		}
+		if err := json.Unmarshal(value, &wrapper); err != nil {
+			return nil, nil, fmt.Errorf("invalid scores object: %w", err)
+		}
+		if wrapper.Scores == nil {
+			return nil, nil, fmt.Errorf(`response object has no "scores" array`)
+		}
+		entries = wrapper.Scores
+	}
+
+	scores := make([]models.CandidateScore, 0, len(entries))
+	var problems []string
+	for i, entry := range entries {
+		score, err := parseCandidateScoreEntry(entry)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("entry %d: %v", i, err))
+			continue
+		}
+		scores = append(scores, score)
+	}
+	return scores, problems, nil
+}
+
+// parseCandidateScoreEntry decodes a single entry, accepting numbers encoded as strings or floats
+func parseCandidateScoreEntry(entry json.RawMessage) (models.CandidateScore, error) {
+	var fields struct {
+		CandidateID json.Number `json:"candidate_id"`
+		Score       json.Number `json:"score"`
+		Reason      string      `json:"reason"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(entry))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		// Retry with string-encoded numbers, e.g. {"candidate_id": "12"}
+		var loose struct {
+			CandidateID string `json:"candidate_id"`
+			Score       string `json:"score"`
+			Reason      string `json:"reason"`
+		}
+		if looseErr := json.Unmarshal(entry, &loose); looseErr != nil {
+			return models.CandidateScore{}, err
+		}
+		fields.CandidateID = json.Number(strings.TrimSpace(loose.CandidateID))
+		fields.Score = json.Number(strings.TrimSpace(loose.Score))
+		fields.Reason = loose.Reason
+	}
+
+	if fields.CandidateID == "" {
+		return models.CandidateScore{}, fmt.Errorf("missing candidate_id")
+	}
+	if fields.Score == "" {
+		return models.CandidateScore{}, fmt.Errorf("missing score")
+	}
+
+	id, err := fields.CandidateID.Float64()
+	if err != nil {
+		return models.CandidateScore{}, fmt.Errorf("invalid candidate_id %q", fields.CandidateID)
+	}
+	score, err := fields.Score.Float64()
+	if err != nil {
+		return models.CandidateScore{}, fmt.Errorf("invalid score %q", fields.Score)
+	}
+
+	return models.CandidateScore{
+		CandidateID: int(id),
+		Score:       int(math.Round(score)),
+		Reason:      strings.TrimSpace(fields.Reason),
+	}, nil
+}
+
+// stripCodeFences removes markdown code fences the model sometimes wraps JSON in
+func stripCodeFences(raw string) string {
+	text := strings.TrimSpace(raw)
+	if !strings.Contains(text, "```") {
+		return text
+	}
+
+	var kept []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// ValidateCandidateScores checks parsed scores against the candidates that were sent to the model.
+// Valid entries are returned in model order; every rejected entry and missing candidate is reported as a problem.
+func ValidateCandidateScores(scores []models.CandidateScore, candidates []*domain.SimilarityMatch) ([]models.CandidateScore, []int, []string) {
+	known := make(map[int]bool, len(candidates))
+	for _, candidate := range candidates {
+		known[int(candidate.Profile.UserID)] = true
+	}
+
+	var valid []models.CandidateScore
+	var problems []string
+	seen := make(map[int]bool, len(scores))
+	for _, score := range scores {
+		switch {
+		case !known[score.CandidateID]:
+			problems = append(problems, fmt.Sprintf("candidate_id %d is not one of the provided candidates", score.CandidateID))
+		case seen[score.CandidateID]:
+			problems = append(problems, fmt.Sprintf("candidate_id %d was scored more than once", score.CandidateID))
+		case score.Score < 0 || score.Score > 100:
+			problems = append(problems, fmt.Sprintf("candidate_id %d has score %d outside 0-100", score.CandidateID, score.Score))
+		case score.Reason == "":
+			problems = append(problems, fmt.Sprintf("candidate_id %d has an empty reason", score.CandidateID))
+		default:
+			seen[score.CandidateID] = true
+			score.Source = ScoreSourceAI
+			valid = append(valid, score)
+		}
+	}
+
+	var missing []int
+	for _, candidate := range candidates {
+		id := int(candidate.Profile.UserID)
+		if !seen[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("missing scores for candidate_ids %s", joinInts(missing)))
+	}
+
+	return valid, missing, problems
+}
+
+// GenerateScoringRepairPrompt asks the model to fix its previous answer, scoring only the
+// candidates still missing a valid score
+func GenerateScoringRepairPrompt(previousResponse string, problems []string, missing []int) string {
+	return fmt.Sprintf(`Your previous answer could not be accepted.
+
+Previous answer:
+%s
+
+Problems:
+- %s
+
+Return ONLY the corrected JSON object, with no markdown and no commentary:
+{ "scores": [ { "candidate_id": <id>, "score": <int 0-100>, "reason": "<non-empty string>" } ] }
+The other candidates are already scored: include exactly one entry for each of candidate_ids %s and no others.`,
+		previousResponse, strings.Join(problems, "\n- "), joinInts(missing))
+}
+
+// FallbackCandidateScore scores a candidate without the model, using vector similarity
+// and bench status weighted by the same rules the prompt uses
+func FallbackCandidateScore(match *domain.SimilarityMatch, rules ScoringRules) models.CandidateScore {
+	similarity := math.Max(0, math.Min(1, match.Similarity))
+	matchWeight := rules.SkillsWeight + rules.GeoWeight + rules.ExperienceWeight
+
+	score := similarity * float64(matchWeight)
+	reason := fmt.Sprintf("Scored from profile similarity (%.0f%%) without an AI review.", similarity*100)
+	if match.Status == "onBench" {
+		score += float64(rules.StatusWeight)
+		reason += " Candidate is currently on bench."
+	} else {
+		score += float64(rules.StatusWeight) / 2
+	}
+
+	total := matchWeight + rules.StatusWeight
+	if total > 0 && total != 100 {
+		score = score * 100 / float64(total)
+	}
+
+	return models.CandidateScore{
+		CandidateID: int(match.Profile.UserID),
+		Score:       int(math.Round(math.Max(0, math.Min(100, score)))),
+		Reason:      reason,
+		Source:      ScoreSourceFallback,
+	}
+}
+
+// SortCandidateScores orders scores from best to worst, keeping ties stable by candidate ID
+func SortCandidateScores(scores []models.CandidateScore) {
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].CandidateID < scores[j].CandidateID
+	})
+}
+
+// joinInts formats a list of IDs for prompts and logs
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprintf("%d", v)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+func TestParseCandidateScores(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		want     int
+		problems int
+		wantErr  bool
+	}{
+		{
+			name: "bare array",
+			raw:  `[{"candidate_id": 1, "score": 80, "reason": "Strong Go skills"}]`,
+			want: 1,
+		},
+		{
+			name: "scores object",
+			raw:  `{"scores": [{"candidate_id": 1, "score": 80, "reason": "ok"}, {"candidate_id": 2, "score": 40, "reason": "ok"}]}`,
+			want: 2,
+		},
+		{
+			name: "markdown fence and trailing comment",
+			raw:  "```json\n[{\"candidate_id\": 1, \"score\": 80, \"reason\": \"ok\"}]\n```\n// scores above",
+			want: 1,
+		},
+		{
+			name: "string encoded numbers",
+			raw:  `[{"candidate_id": "3", "score": "72.6", "reason": "ok"}]`,
+			want: 1,
+		},
+		{
+			name:     "missing score",
+			raw:      `[{"candidate_id": 1, "reason": "ok"}]`,
+			want:     0,
+			problems: 1,
+		},
+		{
+			name:     "malformed entry among valid ones",
+			raw:      `{"scores": [{"candidate_id": 1, "score": 80, "reason": "ok"}, {"candidate_id": "two", "score": 40, "reason": "ok"}, {"candidate_id": 3, "score": 55, "reason": "ok"}]}`,
+			want:     2,
+			problems: 1,
+		},
+		{
+			name:    "no json",
+			raw:     "I cannot score these candidates.",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores, problems, err := ParseCandidateScores(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseCandidateScores() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCandidateScores() error = %v", err)
+			}
+			if len(scores) != tt.want {
+				t.Errorf("ParseCandidateScores() returned %d scores, expected %d", len(scores), tt.want)
+			}
+			if len(problems) != tt.problems {
+				t.Errorf("ParseCandidateScores() reported problems %v, expected %d", problems, tt.problems)
+			}
+		})
+	}
+}
+
+func TestValidateCandidateScores(t *testing.T) {
+	candidates := []*domain.SimilarityMatch{
+		{Profile: &entities.EmployeeProfile{UserID: 1}, Similarity: 0.9},
+		{Profile: &entities.EmployeeProfile{UserID: 2}, Similarity: 0.5, Status: "onBench"},
+		{Profile: &entities.EmployeeProfile{UserID: 3}, Similarity: 0.4},
+	}
+
+	scores, _, err := ParseCandidateScores(`[
+		{"candidate_id": 1, "score": 85, "reason": "Good fit"},
+		{"candidate_id": 1, "score": 90, "reason": "Duplicate"},
+		{"candidate_id": 2, "score": 140, "reason": "Out of range"},
+		{"candidate_id": 99, "score": 70, "reason": "Invented"},
+		{"candidate_id": 3, "score": 50, "reason": ""}
+	]`)
+	if err != nil {
+		t.Fatalf("ParseCandidateScores() error = %v", err)
+	}
+
+	valid, missing, problems := ValidateCandidateScores(scores, candidates)
+	if len(valid) != 1 || valid[0].CandidateID != 1 || valid[0].Score != 85 {
+		t.Errorf("ValidateCandidateScores() valid = %+v, expected only candidate 1 with score 85", valid)
+	}
+	if len(missing) != 2 || missing[0] != 2 || missing[1] != 3 {
+		t.Errorf("ValidateCandidateScores() missing = %v, expected [2 3]", missing)
+	}
+	// duplicate, out of range, unknown, empty reason and the missing summary
+	if len(problems) != 5 {
+		t.Errorf("ValidateCandidateScores() reported %d problems, expected 5: %v", len(problems), problems)
+	}
+
+	fallback := FallbackCandidateScore(candidates[1], DefaultScoringRules())
+	if fallback.Source != ScoreSourceFallback || fallback.Score < 0 || fallback.Score > 100 || fallback.Reason == "" {
+		t.Errorf("FallbackCandidateScore() = %+v, expected a valid fallback score", fallback)
+	}
+}
+
+func TestPartialScoresRepairOnlyMissing(t *testing.T) {
+	candidates := []*domain.SimilarityMatch{
+		{Profile: &entities.EmployeeProfile{UserID: 1}},
+		{Profile: &entities.EmployeeProfile{UserID: 2}},
+		{Profile: &entities.EmployeeProfile{UserID: 3}},
+	}
+	response := `[
+		{"candidate_id": 1, "score": 85, "reason": "Good fit"},
+		{"candidate_id": 2, "score": "high", "reason": "Malformed"},
+		{"candidate_id": 3, "score": 60, "reason": "Partial fit"}
+	]`
+
+	scores, problems, err := ParseCandidateScores(response)
+	if err != nil {
+		t.Fatalf("ParseCandidateScores() error = %v", err)
+	}
+	valid, missing, invalid := ValidateCandidateScores(scores, candidates)
+	if len(valid) != 2 || valid[0].CandidateID != 1 || valid[1].CandidateID != 3 {
+		t.Errorf("valid = %+v, want the well-formed scores of candidates 1 and 3 kept", valid)
+	}
+	if len(missing) != 1 || missing[0] != 2 {
+		t.Errorf("missing = %v, want [2]", missing)
+	}
+	problems = append(problems, invalid...)
+	if len(problems) != 2 || !strings.HasPrefix(problems[0], "entry 1:") {
+		t.Errorf("problems = %v, want the malformed entry and the missing candidate", problems)
+	}
+
+	repair := GenerateScoringRepairPrompt(response, problems, missing)
+	if !strings.Contains(repair, "each of candidate_ids 2 and no others") {
+		t.Errorf("repair prompt does not ask for candidate 2 only:\n%s", repair)
+	}
+}
@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/talent-fit/backend/internal/entities"
+)
+
+// maxSkillsPerRole mirrors the limit given to the summariser
+const maxSkillsPerRole = 7
+
+// ParseProjectRequirements extracts structured requirements from a summariser response.
+// It tolerates markdown fences and surrounding prose, and accepts numbers encoded as strings.
+func ParseProjectRequirements(raw string) (*entities.ProjectRequirements, error) {
+	text := stripCodeFences(raw)
+
+	start := strings.Index(text, "{")
+	if start < 0 {
+		return nil, fmt.Errorf("no JSON object found in response")
+	}
+
+	var fields struct {
+		Roles []struct {
+			Role   string      `json:"role"`
+			Seats  json.Number `json:"seats"`
+			Skills []string    `json:"skills"`
+		} `json:"roles"`
+		MinExperienceYears json.Number `json:"min_experience_years"`
+		Geo                string      `json:"geo"`
+		Industry           string      `json:"industry"`
+		Confidence         json.Number `json:"confidence"`
+	}
+	decoder := json.NewDecoder(strings.NewReader(text[start:]))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("invalid requirements JSON: %w", err)
+	}
+	if len(fields.Roles) == 0 {
+		return nil, fmt.Errorf("requirements contain no roles")
+	}
+
+	requirements := &entities.ProjectRequirements{
+		MinExperienceYears: int(math.Round(numberOrZero(fields.MinExperienceYears))),
+		Geo:                fields.Geo,
+		Industry:           fields.Industry,
+		Confidence:         numberOrZero(fields.Confidence),
+		Source:             entities.RequirementsSourceAI,
+	}
+	for _, role := range fields.Roles {
+		requirements.Roles = append(requirements.Roles, entities.RoleRequirement{
+			Role:   role.Role,
+			Seats:  int(math.Round(numberOrZero(role.Seats))),
+			Skills: role.Skills,
+		})
+	}
+	return requirements, nil
+}
+
+// numberOrZero converts a JSON number, possibly quoted by the model, to a float
+func numberOrZero(n json.Number) float64 {
+	value, err := json.Number(strings.Trim(string(n), `" `)).Float64()
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// NormalizeProjectRequirements cleans requirements from the model or a manager edit.
+// Skills are trimmed and de-duplicated within each role, seats come from the project's seat plan
+// when known, geo uses the search vocabulary's canonical names, and confidence is clamped to 0-1.
+func NormalizeProjectRequirements(requirements *entities.ProjectRequirements, seats map[string]int) {
+	if requirements == nil {
+		return
+	}
+
+	seatRoles := make(map[string]string, len(seats))
+	for role := range seats {
+		seatRoles[strings.ToLower(role)] = role
+	}
+
+	roles := make([]entities.RoleRequirement, 0, len(requirements.Roles))
+	for _, role := range requirements.Roles {
+		role.Role = strings.TrimSpace(role.Role)
+		if role.Role == "" {
+			continue
+		}
+		// Use the seat plan's spelling and count for roles it knows
+		if seatRole, ok := seatRoles[strings.ToLower(role.Role)]; ok {
+			role.Role = seatRole
+			role.Seats = seats[seatRole]
+		}
+
+		// Roles may share skills, e.g. Go for both backend and platform engineers
+		seen := make(map[string]bool)
+		skills := make([]string, 0, len(role.Skills))
+		for _, skill := range role.Skills {
+			skill = strings.TrimSpace(skill)
+			key := strings.ToLower(skill)
+			if skill == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			skills = append(skills, skill)
+			if len(skills) == maxSkillsPerRole {
+				break
+			}
+		}
+		role.Skills = skills
+		roles = append(roles, role)
+	}
+	sort.SliceStable(roles, func(i, j int) bool { return roles[i].Role < roles[j].Role })
+	requirements.Roles = roles
+
+	requirements.Geo = CanonicalGeo(requirements.Geo)
+	requirements.Industry = strings.TrimSpace(requirements.Industry)
+	if requirements.Industry == "" {
+		requirements.Industry = "Unspecified"
+	}
+	if requirements.MinExperienceYears < 0 {
+		requirements.MinExperienceYears = 0
+	}
+	requirements.Confidence = math.Max(0, math.Min(1, requirements.Confidence))
+}
+
+// RenderProjectSummary renders requirements into the summary text used for embeddings and scoring prompts
+func RenderProjectSummary(requirements *entities.ProjectRequirements) string {
+	experience := "Unspecified"
+	if requirements.MinExperienceYears > 0 {
+		experience = fmt.Sprintf("%d+ years", requirements.MinExperienceYears)
+	}
+	geo := requirements.Geo
+	if geo == "" {
+		geo = "Unspecified"
+	}
+
+	roles := make([]string, 0, len(requirements.Roles))
+	for _, role := range requirements.Roles {
+		roles = append(roles, fmt.Sprintf("%d %s (%s)", role.Seats, role.Role, strings.Join(role.Skills, ", ")))
+	}
+
+	return fmt.Sprintf("Project requires: Skills: %s, Experience: %s, Location/Geo: %s, Industry: %s. Roles: %s.",
+		strings.Join(requirements.Skills(), ", "), experience, geo, requirements.Industry, strings.Join(roles, "; "))
+}
+
+// MatchingSkills returns the required skills a candidate has, compared case-insensitively
+func MatchingSkills(requirements *entities.ProjectRequirements, candidateSkills []string) []string {
+	if requirements == nil {
+		return nil
+	}
+
+	has := make(map[string]bool, len(candidateSkills))
+	for _, skill := range candidateSkills {
+		has[strings.ToLower(strings.TrimSpace(skill))] = true
+	}
+
+	var matched []string
+	for _, skill := range requirements.Skills() {
+		if has[strings.ToLower(skill)] {
+			matched = append(matched, skill)
+		}
+	}
+	return matched
+}
+
+// ExplainSkillMatch describes how a candidate's skills cover the required skills
+func ExplainSkillMatch(requirements *entities.ProjectRequirements, candidateSkills []string) string {
+	required := requirements.Skills()
+	if len(required) == 0 {
+		return "No required skills recorded for the project."
+	}
+	matched := MatchingSkills(requirements, candidateSkills)
+	if len(matched) == 0 {
+		return fmt.Sprintf("Matches none of the %d required skills.", len(required))
+	}
+	return fmt.Sprintf("Matches %d of %d required skills (%s).", len(matched), len(required), strings.Join(matched, ", "))
+}
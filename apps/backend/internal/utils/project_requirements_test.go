@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseProjectRequirements(t *testing.T) {
+	raw := "```json\n" + `{
+		"roles": [
+			{"role": "backend", "seats": "1", "skills": ["Go", " PostgreSQL ", "go", ""]},
+			{"role": "Frontend", "seats": 1, "skills": ["React", "PostgreSQL"]}
+		],
+		"min_experience_years": 5,
+		"geo": " USA ",
+		"industry": "",
+		"confidence": 1.4
+	}` + "\n```"
+
+	requirements, err := ParseProjectRequirements(raw)
+	if err != nil {
+		t.Fatalf("ParseProjectRequirements() error = %v", err)
+	}
+	NormalizeProjectRequirements(requirements, map[string]int{"Backend": 2, "Frontend": 1})
+
+	if len(requirements.Roles) != 2 || requirements.Roles[0].Role != "Backend" || requirements.Roles[0].Seats != 2 {
+		t.Errorf("NormalizeProjectRequirements() roles = %+v", requirements.Roles)
+	}
+	if got := strings.Join(requirements.Roles[0].Skills, ","); got != "Go,PostgreSQL" {
+		t.Errorf("backend skills = %q, expected duplicates within the role removed", got)
+	}
+	// A skill both roles need stays on each
+	if got := strings.Join(requirements.Roles[1].Skills, ","); got != "React,PostgreSQL" {
+		t.Errorf("frontend skills = %q, want React,PostgreSQL", got)
+	}
+	if got := strings.Join(requirements.Skills(), ","); got != "Go,PostgreSQL,React" {
+		t.Errorf("Skills() = %q, expected duplicates across roles removed", got)
+	}
+	if requirements.Confidence != 1 || requirements.Industry != "Unspecified" || requirements.Geo != "United States" {
+		t.Errorf("NormalizeProjectRequirements() = %+v", requirements)
+	}
+
+	summary := RenderProjectSummary(requirements)
+	if !strings.HasPrefix(summary, "Project requires: Skills: Go, PostgreSQL, React, Experience: 5+ years") {
+		t.Errorf("RenderProjectSummary() = %q", summary)
+	}
+
+	if _, err := ParseProjectRequirements("Project requires: Skills: Go"); err == nil {
+		t.Errorf("ParseProjectRequirements() expected error for prose summary")
+	}
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/talent-fit/backend/internal/domain"
+)
+
+// SummaryPromptData is the data rendered into the summarize_project prompt template
+type SummaryPromptData struct {
+	Description string
+	Roles       string
+}
+
+// NewSummaryPromptData builds summarisation prompt data with roles in a stable order
+func NewSummaryPromptData(description string, seats map[string]int) SummaryPromptData {
+	roleTypes := make([]string, 0, len(seats))
+	for role := range seats {
+		roleTypes = append(roleTypes, role)
+	}
+	sort.Strings(roleTypes)
+
+	roles := make([]string, 0, len(roleTypes))
+	for _, role := range roleTypes {
+		roles = append(roles, fmt.Sprintf("%d %s", seats[role], strings.Title(role)))
+	}
+
+	return SummaryPromptData{
+		Description: description,
+		Roles:       strings.Join(roles, ", "),
+	}
+}
+
+// MatchingPromptCandidate is one candidate as rendered into the score_candidates prompt template
+type MatchingPromptCandidate struct {
+	Number       int
+	ID           uint
+	Name         string
+	Skills       string
+	Geo          string
+	Experience   string
+	Industry     string
+	Availability string
+	Status       string
+	Similarity   float64 // percentage, 0-100
+}
+
+// MatchingPromptData is the data rendered into the score_candidates prompt template
+type MatchingPromptData struct {
+	ProjectSummary string
+	Candidates     []MatchingPromptCandidate
+	Rules          ScoringRules
+}
+
+// NewMatchingPromptData builds scoring prompt data for the given candidates
+func NewMatchingPromptData(projectSummary string, candidates []*domain.SimilarityMatch, rules ScoringRules) MatchingPromptData {
+	data := MatchingPromptData{
+		ProjectSummary: projectSummary,
+		Candidates:     make([]MatchingPromptCandidate, 0, len(candidates)),
+		Rules:          rules,
+	}
+
+	for i, match := range candidates {
+		profile := match.Profile
+
+		// Format skills as comma-separated string
+		skillsStr := "None specified"
+		if len(profile.Skills) > 0 {
+			skillsStr = strings.Join(profile.Skills, ", ")
+		}
+
+		// Get user info if available
+		name := "Unknown"
+		if profile.User.FirstName != "" || profile.User.LastName != "" {
+			name = strings.TrimSpace(profile.User.FirstName + " " + profile.User.LastName)
+		}
+
+		data.Candidates = append(data.Candidates, MatchingPromptCandidate{
+			Number:       i + 1,
+			ID:           profile.UserID,
+			Name:         name,
+			Skills:       skillsStr,
+			Geo:          profile.Geo,
+			Experience:   fmt.Sprintf("%d years", profile.YearsOfExperience),
+			Industry:     profile.Industry,
+			Availability: fmt.Sprintf("%t", profile.AvailabilityFlag),
+			Status:       match.Status,
+			Similarity:   match.Similarity * 100,
+		})
+	}
+
+	return data
+}
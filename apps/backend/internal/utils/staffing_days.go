@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"math"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// allocationWeights is the share of a working day each allocation type takes. Extra allocations
+// sit on top of an employee's normal load and do not count towards utilisation.
+var allocationWeights = map[string]float64{
+	string(models.AllocationFullTime): 1,
+	string(models.AllocationPartTime): 0.5,
+}
+
+// AllocationWeight is the share of a working day an allocation of the given type takes
+func AllocationWeight(allocationType string) float64 {
+	return allocationWeights[allocationType]
+}
+
+// StaffingDays is how an employee's working days in a report period were staffed. Working days
+// are weekdays between the employee's joining and end dates.
+type StaffingDays struct {
+	Working int
+	// Allocated counts a day covered by a full-time allocation as 1 and by a part-time one as 0.5;
+	// overlapping allocations add up, so it can exceed Working
+	Allocated float64
+	// Extra is the working days covered by an extra allocation
+	Extra int
+	// Unallocated is the working days not covered by any allocation
+	Unallocated int
+	// FirstUnallocated is the first unallocated working day, nil when there is none
+	FirstUnallocated *time.Time
+}
+
+// Utilisation is the allocated share of working days as a percentage rounded to one decimal
+func (d StaffingDays) Utilisation() float64 {
+	if d.Working == 0 {
+		return 0
+	}
+	return math.Round(d.Allocated/float64(d.Working)*1000) / 10
+}
+
+// ComputeStaffingDays walks the period a day at a time, checking the employee's allocations
+func ComputeStaffingDays(profile *entities.EmployeeProfile, allocations []*entities.ProjectAllocation, period domain.ReportPeriod) StaffingDays {
+	var days StaffingDays
+	last := DateOf(period.To)
+	for day := DateOf(period.From); !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !isWeekday(day) || !coversDay(profile.DateOfJoining, profile.EndDate, day) {
+			continue
+		}
+		days.Working++
+
+		covered, extra := false, false
+		for _, allocation := range allocations {
+			start := allocation.StartDate
+			if !coversDay(&start, allocation.EndDate, day) {
+				continue
+			}
+			covered = true
+			if allocation.AllocationType == string(models.AllocationExtra) {
+				extra = true
+			}
+			days.Allocated += AllocationWeight(allocation.AllocationType)
+		}
+		if extra {
+			days.Extra++
+		}
+		if !covered {
+			days.Unallocated++
+			if days.FirstUnallocated == nil {
+				first := day
+				days.FirstUnallocated = &first
+			}
+		}
+	}
+	return days
+}
+
+// AllocationDaysInPeriod counts the weekdays of the period an allocation covers
+func AllocationDaysInPeriod(allocation *entities.ProjectAllocation, period domain.ReportPeriod) int {
+	count := 0
+	start := allocation.StartDate
+	last := DateOf(period.To)
+	for day := DateOf(period.From); !day.After(last); day = day.AddDate(0, 0, 1) {
+		if isWeekday(day) && coversDay(&start, allocation.EndDate, day) {
+			count++
+		}
+	}
+	return count
+}
+
+// DateOf truncates t to midnight UTC on its UTC date
+func DateOf(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// InPeriod reports whether t falls on one of the period's dates
+func InPeriod(t *time.Time, period domain.ReportPeriod) bool {
+	if t == nil {
+		return false
+	}
+	day := DateOf(*t)
+	return !day.Before(DateOf(period.From)) && !day.After(DateOf(period.To))
+}
+
+// coversDay reports whether day lies between start and end, both included; nil bounds are open
+func coversDay(start, end *time.Time, day time.Time) bool {
+	if start != nil && DateOf(*start).After(day) {
+		return false
+	}
+	return end == nil || !DateOf(*end).Before(day)
+}
+
+func isWeekday(day time.Time) bool {
+	return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+func TestComputeStaffingDays(t *testing.T) {
+	date := func(day int) time.Time { return time.Date(2026, time.October, day, 0, 0, 0, 0, time.UTC) }
+	at := func(day int) *time.Time { d := date(day); return &d }
+
+	// Monday 5th to Sunday 18th: ten weekdays
+	period := domain.ReportPeriod{From: date(5), To: date(18)}
+	profile := &entities.EmployeeProfile{EndDate: at(15)}
+	allocations := []*entities.ProjectAllocation{
+		{AllocationType: string(models.AllocationFullTime), StartDate: date(1), EndDate: at(7)},
+		{AllocationType: string(models.AllocationPartTime), StartDate: time.Date(2026, time.October, 12, 9, 30, 0, 0, time.UTC)},
+		{AllocationType: string(models.AllocationExtra), StartDate: date(15), EndDate: at(15)},
+	}
+
+	days := ComputeStaffingDays(profile, allocations, period)
+	if days.Working != 9 {
+		t.Errorf("Working = %d, expected the weekdays up to the end date", days.Working)
+	}
+	if days.Allocated != 3+4*0.5 || days.Extra != 1 {
+		t.Errorf("Allocated = %v, Extra = %d", days.Allocated, days.Extra)
+	}
+	if days.Unallocated != 2 || days.FirstUnallocated == nil || !days.FirstUnallocated.Equal(date(8)) {
+		t.Errorf("Unallocated = %d from %v, expected the 8th and 9th", days.Unallocated, days.FirstUnallocated)
+	}
+	if got := days.Utilisation(); got != 55.6 {
+		t.Errorf("Utilisation() = %v, expected 55.6", got)
+	}
+
+	if got := AllocationDaysInPeriod(allocations[1], period); got != 5 {
+		t.Errorf("AllocationDaysInPeriod() = %d, expected 5", got)
+	}
+	if !InPeriod(at(18), period) || InPeriod(at(19), period) || InPeriod(nil, period) {
+		t.Error("InPeriod() expected both ends of the period included")
+	}
+}
@@ -0,0 +1,516 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+// dateLayout is the date format used in search prompts and responses
+const dateLayout = "2006-01-02"
+
+var (
+	minimumYears   = regexp.MustCompile(`(?:at least\s+)?(\d+)\s*\+?\s*(?:years|yrs)`)
+	relativeDate   = regexp.MustCompile(`\bin\s+(\d+|a|an|one|two|three|four|six)\s+(day|week|month)s?\b`)
+	monthDate      = regexp.MustCompile(`\b(?:by|from|in|starting)\s+(january|february|march|april|may|june|july|august|september|october|november|december|jan|feb|mar|apr|jun|jul|aug|sep|sept|oct|nov|dec)\b`)
+	availabilityRe = regexp.MustCompile(`\b(?:free|available|availability|starting|start|on the bench|bench)\b`)
+)
+
+// TalentQuery is a natural-language search split into structured filters and a semantic remainder
+type TalentQuery struct {
+	Filters models.EmployeeSearchFilters
+	// SemanticQuery is what the filters do not capture, e.g. "engineer"; it is embedded to rank results
+	SemanticQuery string
+}
+
+// TalentQueryPromptData is the data rendered into the parse_talent_query prompt template
+type TalentQueryPromptData struct {
+	Query string
+	Today string
+	Types string
+}
+
+// NewTalentQueryPromptData builds query parsing prompt data with the employee types the filters may use
+func NewTalentQueryPromptData(query string, now time.Time) TalentQueryPromptData {
+	types := make([]string, 0, len(typeVocabulary))
+	for _, term := range typeVocabulary {
+		types = append(types, term.Name)
+	}
+	return TalentQueryPromptData{
+		Query: strings.TrimSpace(query),
+		Today: now.Format(dateLayout),
+		Types: strings.Join(types, ", "),
+	}
+}
+
+// ParseTalentQueryResponse extracts search filters from a parse_talent_query response.
+// Like ParseProjectRequirements it tolerates markdown fences, prose and quoted numbers.
+func ParseTalentQueryResponse(raw string, now time.Time) (*TalentQuery, error) {
+	text := stripCodeFences(raw)
+
+	start := strings.Index(text, "{")
+	if start < 0 {
+		return nil, fmt.Errorf("no JSON object found in response")
+	}
+
+	var fields struct {
+		Skills             []string     `json:"skills"`
+		Types              []string     `json:"types"`
+		Geos               []string     `json:"geos"`
+		Industries         []string     `json:"industries"`
+		MinExperienceYears *json.Number `json:"min_experience_years"`
+		MaxExperienceYears *json.Number `json:"max_experience_years"`
+		AvailableBy        *string      `json:"available_by"`
+		SemanticQuery      string       `json:"semantic_query"`
+	}
+	decoder := json.NewDecoder(strings.NewReader(text[start:]))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("invalid search filters JSON: %w", err)
+	}
+
+	query := &TalentQuery{
+		Filters: models.EmployeeSearchFilters{
+			Skills:             fields.Skills,
+			Types:              fields.Types,
+			Geos:               fields.Geos,
+			Industries:         fields.Industries,
+			MinExperienceYears: yearsOrNil(fields.MinExperienceYears),
+			MaxExperienceYears: yearsOrNil(fields.MaxExperienceYears),
+		},
+		SemanticQuery: strings.TrimSpace(fields.SemanticQuery),
+	}
+	if fields.AvailableBy != nil {
+		if date, err := time.Parse(dateLayout, strings.TrimSpace(*fields.AvailableBy)); err == nil {
+			query.Filters.AvailableBy = &date
+		}
+	}
+	NormalizeSearchFilters(&query.Filters, now)
+	return query, nil
+}
+
+// yearsOrNil converts an optional JSON number of years, possibly quoted, to a whole number
+func yearsOrNil(n *json.Number) *int {
+	if n == nil || strings.Trim(string(*n), `" `) == "" {
+		return nil
+	}
+	years := int(math.Round(numberOrZero(*n)))
+	return &years
+}
+
+// ParseTalentQueryRules parses a search query without a model, using the built-in vocabulary of
+// skills, roles, places and industries plus seniority and availability phrases.
+// Anything it does not recognise is left in the semantic remainder.
+func ParseTalentQueryRules(text string, now time.Time) *TalentQuery {
+	q := newQueryScanner(text)
+	filters := models.EmployeeSearchFilters{
+		Types:      q.terms(typeVocabulary),
+		Skills:     q.terms(skillVocabulary),
+		Geos:       q.terms(geoVocabulary),
+		Industries: q.terms(industryVocabulary),
+	}
+
+	// Seniority words first: explicit years win when both are given
+	for word, years := range seniorityYears {
+		if !q.consume(word) {
+			continue
+		}
+		if years < 0 {
+			max := -years
+			filters.MaxExperienceYears = &max
+		} else if filters.MinExperienceYears == nil || *filters.MinExperienceYears < years {
+			min := years
+			filters.MinExperienceYears = &min
+		}
+	}
+	if match := q.consumeRegexp(minimumYears); match != nil {
+		years, _ := strconv.Atoi(match[1])
+		filters.MinExperienceYears = &years
+	}
+
+	filters.AvailableBy = q.availableBy(now)
+
+	NormalizeSearchFilters(&filters, now)
+	return &TalentQuery{Filters: filters, SemanticQuery: q.remainder()}
+}
+
+// NormalizeSearchFilters maps filter values onto the vocabulary's canonical names, drops unknown
+// employee types and duplicates, and keeps experience and availability within sensible bounds
+func NormalizeSearchFilters(filters *models.EmployeeSearchFilters, now time.Time) {
+	filters.Skills = canonicalTerms(skillVocabulary, filters.Skills, true)
+	filters.Types = canonicalTerms(typeVocabulary, filters.Types, false)
+	filters.Geos = canonicalTerms(geoVocabulary, filters.Geos, true)
+	filters.Industries = canonicalTerms(industryVocabulary, filters.Industries, true)
+
+	if filters.MinExperienceYears != nil && *filters.MinExperienceYears <= 0 {
+		filters.MinExperienceYears = nil
+	}
+	if filters.MaxExperienceYears != nil && *filters.MaxExperienceYears < 0 {
+		filters.MaxExperienceYears = nil
+	}
+	if filters.MinExperienceYears != nil && filters.MaxExperienceYears != nil && *filters.MaxExperienceYears < *filters.MinExperienceYears {
+		filters.MaxExperienceYears = nil
+	}
+
+	if filters.AvailableBy != nil {
+		today := startOfDay(now)
+		date := startOfDay(*filters.AvailableBy)
+		if date.Before(today) {
+			date = today
+		}
+		filters.AvailableBy = &date
+	}
+}
+
+// canonicalTerms replaces known values with their canonical names and removes duplicates.
+// Unknown values are kept as written when keepUnknown is set.
+func canonicalTerms(v vocabulary, values []string, keepUnknown bool) []string {
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if term, ok := v.lookup(value); ok {
+			value = term.Name
+		} else if !keepUnknown {
+			continue
+		}
+		key := strings.ToLower(value)
+		if value == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, value)
+	}
+	return result
+}
+
+// ExpandSkills returns the lower-case skill spellings that satisfy the given skills
+func ExpandSkills(skills []string) []string {
+	return expandTerms(skillVocabulary, skills)
+}
+
+// ExpandTypes returns the lower-case employee types that satisfy the given types
+func ExpandTypes(types []string) []string {
+	return expandTerms(typeVocabulary, types)
+}
+
+// ExpandGeos returns the lower-case geo values that satisfy the given places
+func ExpandGeos(geos []string) []string {
+	return expandTerms(geoVocabulary, geos)
+}
+
+// CanonicalGeo returns the vocabulary's name for a place, e.g. "United States" for "USA", so
+// project requirements use the same spelling as search filters; unknown places are only trimmed
+func CanonicalGeo(geo string) string {
+	geo = strings.TrimSpace(geo)
+	if term, ok := geoVocabulary.lookup(geo); ok {
+		return term.Name
+	}
+	return geo
+}
+
+// ExpandIndustries returns lower-case substrings of the stored industries that satisfy the given industries
+func ExpandIndustries(industries []string) []string {
+	return expandTerms(industryVocabulary, industries)
+}
+
+func expandTerms(v vocabulary, values []string) []string {
+	var expanded []string
+	seen := make(map[string]bool)
+	add := func(value string) {
+		if value != "" && !seen[value] {
+			seen[value] = true
+			expanded = append(expanded, value)
+		}
+	}
+	for _, value := range values {
+		if term, ok := v.lookup(value); ok {
+			for _, stored := range term.stored() {
+				add(stored)
+			}
+			continue
+		}
+		add(strings.ToLower(strings.TrimSpace(value)))
+	}
+	return expanded
+}
+
+// HighlightProfile lists, per profile field, the values that matched the search filters
+func HighlightProfile(filters *models.EmployeeSearchFilters, profile *entities.EmployeeProfile, availableFrom *time.Time, now time.Time) map[string][]string {
+	highlights := make(map[string][]string)
+
+	if len(filters.Skills) > 0 {
+		wanted := stringSet(ExpandSkills(filters.Skills))
+		for _, skill := range profile.Skills {
+			if wanted[strings.ToLower(strings.TrimSpace(skill))] {
+				highlights[models.HighlightSkills] = append(highlights[models.HighlightSkills], skill)
+			}
+		}
+	}
+	if len(filters.Types) > 0 && stringSet(ExpandTypes(filters.Types))[strings.ToLower(profile.Type)] {
+		highlights[models.HighlightType] = []string{profile.Type}
+	}
+	if len(filters.Geos) > 0 && stringSet(ExpandGeos(filters.Geos))[strings.ToLower(profile.Geo)] {
+		highlights[models.HighlightGeo] = []string{profile.Geo}
+	}
+	if len(filters.Industries) > 0 {
+		patterns := ExpandIndustries(filters.Industries)
+		for _, industry := range strings.Split(profile.Industry, ":") {
+			for _, pattern := range patterns {
+				if strings.Contains(strings.ToLower(industry), pattern) {
+					highlights[models.HighlightIndustry] = append(highlights[models.HighlightIndustry], industry)
+					break
+				}
+			}
+		}
+	}
+	if filters.MinExperienceYears != nil || filters.MaxExperienceYears != nil {
+		highlights[models.HighlightExperience] = []string{fmt.Sprintf("%d years", profile.YearsOfExperience)}
+	}
+	if filters.AvailableBy != nil {
+		switch {
+		case availableFrom != nil && !availableFrom.After(now):
+			highlights[models.HighlightAvailability] = []string{"available now"}
+		case availableFrom != nil:
+			highlights[models.HighlightAvailability] = []string{"free from " + availableFrom.Format(dateLayout)}
+		case profile.AvailabilityFlag:
+			highlights[models.HighlightAvailability] = []string{"available for extra work"}
+		}
+	}
+	return highlights
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// queryScanner finds phrases in a query and remembers which parts were consumed by filters
+type queryScanner struct {
+	text     string
+	lower    string
+	consumed []bool
+}
+
+func newQueryScanner(text string) *queryScanner {
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// Case folding changed byte offsets; exact matches are skipped but phrases still work
+		text = lower
+	}
+	return &queryScanner{text: text, lower: lower, consumed: make([]bool, len(lower))}
+}
+
+// terms returns the canonical names of the vocabulary terms found in the query, in query order
+func (q *queryScanner) terms(v vocabulary) []string {
+	positions := make(map[string]int)
+	for _, alias := range v.aliasesByLength() {
+		text := q.lower
+		if alias.exact {
+			text = q.text
+		}
+		if at := q.consumeIn(text, alias.alias); at >= 0 {
+			if first, seen := positions[alias.term]; !seen || at < first {
+				positions[alias.term] = at
+			}
+		}
+	}
+
+	found := make([]string, 0, len(positions))
+	for term := range positions {
+		found = append(found, term)
+	}
+	sort.Slice(found, func(i, j int) bool { return positions[found[i]] < positions[found[j]] })
+	return found
+}
+
+// consume marks every unconsumed whole-word occurrence of a lower-case phrase
+func (q *queryScanner) consume(phrase string) bool {
+	return q.consumeIn(q.lower, phrase) >= 0
+}
+
+// consumeIn marks every unconsumed whole-word occurrence of phrase in text and returns the
+// offset of the first one, or -1
+func (q *queryScanner) consumeIn(text string, phrase string) int {
+	first := -1
+	for offset := 0; offset < len(text); {
+		i := strings.Index(text[offset:], phrase)
+		if i < 0 {
+			break
+		}
+		start, end := offset+i, offset+i+len(phrase)
+		offset = start + 1
+		if !isBoundary(text, start-1) || !isBoundary(text, end) || q.isConsumed(start, end) {
+			continue
+		}
+		q.mark(start, end)
+		if first < 0 {
+			first = start
+		}
+	}
+	return first
+}
+
+// consumeRegexp consumes the first match of a pattern in the lower-case query
+func (q *queryScanner) consumeRegexp(pattern *regexp.Regexp) []string {
+	for _, loc := range pattern.FindAllStringSubmatchIndex(q.lower, -1) {
+		if q.isConsumed(loc[0], loc[1]) {
+			continue
+		}
+		q.mark(loc[0], loc[1])
+		match := make([]string, 0, len(loc)/2)
+		for i := 0; i < len(loc); i += 2 {
+			if loc[i] < 0 {
+				match = append(match, "")
+				continue
+			}
+			match = append(match, q.lower[loc[i]:loc[i+1]])
+		}
+		return match
+	}
+	return nil
+}
+
+// availableBy reads an availability date such as "free next month" or "available in 2 weeks".
+// An availability word without a date means available now.
+func (q *queryScanner) availableBy(now time.Time) *time.Time {
+	today := startOfDay(now)
+	var date *time.Time
+	set := func(t time.Time) { date = &t }
+
+	switch {
+	case q.consume("next week"):
+		set(today.AddDate(0, 0, 7))
+	case q.consume("next month"):
+		set(time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()))
+	case q.consume("this month") || q.consume("end of month"):
+		set(time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, today.Location()))
+	case q.consume("right away") || q.consume("immediately") || q.consume("asap") || q.consume("today") || q.consume("now"):
+		set(today)
+	}
+	if date == nil {
+		if match := q.consumeRegexp(relativeDate); match != nil {
+			n := countWord(match[1])
+			switch match[2] {
+			case "day":
+				set(today.AddDate(0, 0, n))
+			case "week":
+				set(today.AddDate(0, 0, 7*n))
+			default:
+				set(today.AddDate(0, n, 0))
+			}
+		} else if match := q.consumeRegexp(monthDate); match != nil {
+			month := monthNumber(match[1])
+			year := today.Year()
+			if month < today.Month() {
+				year++
+			}
+			first := time.Date(year, month, 1, 0, 0, 0, 0, today.Location())
+			if first.Before(today) {
+				first = today
+			}
+			set(first)
+		}
+	}
+
+	mentioned := false
+	for q.consumeRegexp(availabilityRe) != nil {
+		mentioned = true
+	}
+	if mentioned && date == nil {
+		set(today)
+	}
+	return date
+}
+
+// remainder returns the unconsumed words of the query without stopwords
+func (q *queryScanner) remainder() string {
+	var kept strings.Builder
+	for i := 0; i < len(q.lower); i++ {
+		if q.consumed[i] {
+			kept.WriteByte(' ')
+		} else {
+			kept.WriteByte(q.lower[i])
+		}
+	}
+
+	words := strings.FieldsFunc(kept.String(), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '#' && r != '+' && r != '-' && r != '.'
+	})
+	var semantic []string
+	for _, word := range words {
+		word = strings.Trim(word, ".-")
+		if word == "" || searchStopwords[word] {
+			continue
+		}
+		semantic = append(semantic, word)
+	}
+	return strings.Join(semantic, " ")
+}
+
+func (q *queryScanner) isConsumed(start, end int) bool {
+	for i := start; i < end; i++ {
+		if q.consumed[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *queryScanner) mark(start, end int) {
+	for i := start; i < end; i++ {
+		q.consumed[i] = true
+	}
+}
+
+// isBoundary reports whether the byte at i does not continue a word; "c" must not match "c#"
+func isBoundary(text string, i int) bool {
+	if i < 0 || i >= len(text) {
+		return true
+	}
+	b := text[i]
+	return !(b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '#' || b == '+' || b >= 0x80)
+}
+
+func countWord(word string) int {
+	switch word {
+	case "a", "an", "one":
+		return 1
+	case "two":
+		return 2
+	case "three":
+		return 3
+	case "four":
+		return 4
+	case "six":
+		return 6
+	}
+	n, _ := strconv.Atoi(word)
+	return n
+}
+
+func monthNumber(name string) time.Month {
+	for month := time.January; month <= time.December; month++ {
+		if strings.HasPrefix(strings.ToLower(month.String()), name[:3]) {
+			return month
+		}
+	}
+	return time.January
+}
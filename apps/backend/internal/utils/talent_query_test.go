@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+)
+
+func TestParseTalentQueryRules(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 15, 0, 0, 0, time.UTC)
+	query := ParseTalentQueryRules("senior Go engineer in Europe with fintech and Kafka, free next month", now)
+	filters := query.Filters
+
+	if got := strings.Join(filters.Skills, ","); got != "Go,Kafka" {
+		t.Errorf("Skills = %q", got)
+	}
+	if got := strings.Join(filters.Geos, ","); got != "Europe" {
+		t.Errorf("Geos = %q", got)
+	}
+	if got := strings.Join(filters.Industries, ","); got != "Fintech" {
+		t.Errorf("Industries = %q", got)
+	}
+	if filters.MinExperienceYears == nil || *filters.MinExperienceYears != 5 || filters.MaxExperienceYears != nil {
+		t.Errorf("experience = %v..%v, expected senior to mean at least 5 years", filters.MinExperienceYears, filters.MaxExperienceYears)
+	}
+	if filters.AvailableBy == nil || !filters.AvailableBy.Equal(time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("AvailableBy = %v, expected the first of next month", filters.AvailableBy)
+	}
+	if query.SemanticQuery != "engineer" {
+		t.Errorf("SemanticQuery = %q", query.SemanticQuery)
+	}
+
+	// "go" in ordinary English is not the language, and "us" is not a place
+	query = ParseTalentQueryRules("find us a QA lead ready to go, 3+ years", now)
+	if len(query.Filters.Skills) != 0 || len(query.Filters.Geos) != 0 {
+		t.Errorf("filters = %+v, expected no skills or geos", query.Filters)
+	}
+	if got := strings.Join(query.Filters.Types, ","); got != "Tester" {
+		t.Errorf("Types = %q", got)
+	}
+	if query.Filters.MinExperienceYears == nil || *query.Filters.MinExperienceYears != 3 {
+		t.Errorf("MinExperienceYears = %v, expected explicit years to win over lead", query.Filters.MinExperienceYears)
+	}
+}
+
+func TestParseTalentQueryResponse(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 15, 0, 0, 0, time.UTC)
+	raw := "```json\n" + `{
+		"skills": ["golang", "Kafka", "kafka"],
+		"types": ["backend", "Wizard"],
+		"geos": ["EU"],
+		"industries": ["banking"],
+		"min_experience_years": "5",
+		"max_experience_years": null,
+		"available_by": "2020-01-01",
+		"semantic_query": " engineer "
+	}` + "\n```"
+
+	query, err := ParseTalentQueryResponse(raw, now)
+	if err != nil {
+		t.Fatalf("ParseTalentQueryResponse() error = %v", err)
+	}
+	filters := query.Filters
+	if got := strings.Join(filters.Skills, ","); got != "Go,Kafka" {
+		t.Errorf("Skills = %q", got)
+	}
+	if got := strings.Join(filters.Types, ","); got != "Backend Dev" {
+		t.Errorf("Types = %q, expected unknown types dropped", got)
+	}
+	if filters.Geos[0] != "Europe" || filters.Industries[0] != "Fintech" {
+		t.Errorf("filters = %+v, expected canonical names", filters)
+	}
+	if filters.MinExperienceYears == nil || *filters.MinExperienceYears != 5 {
+		t.Errorf("MinExperienceYears = %v", filters.MinExperienceYears)
+	}
+	if filters.AvailableBy == nil || !filters.AvailableBy.Equal(time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("AvailableBy = %v, expected past dates moved to today", filters.AvailableBy)
+	}
+	if query.SemanticQuery != "engineer" {
+		t.Errorf("SemanticQuery = %q", query.SemanticQuery)
+	}
+
+	profile := &entities.EmployeeProfile{
+		Type:              "Backend Dev",
+		Geo:               "EU",
+		Skills:            entities.Skills{"Golang", "PostgreSQL", "Kafka"},
+		Industry:          "Technology:Finance",
+		YearsOfExperience: 7,
+	}
+	availableFrom := now.AddDate(0, 0, 10)
+	highlights := HighlightProfile(&filters, profile, &availableFrom, now)
+	if got := strings.Join(highlights[models.HighlightSkills], ","); got != "Golang,Kafka" {
+		t.Errorf("skills highlight = %q", got)
+	}
+	if got := strings.Join(highlights[models.HighlightIndustry], ","); got != "Finance" {
+		t.Errorf("industry highlight = %q", got)
+	}
+	if got := highlights[models.HighlightAvailability]; len(got) != 1 || got[0] != "free from 2026-10-29" {
+		t.Errorf("availability highlight = %v", got)
+	}
+
+	if _, err := ParseTalentQueryResponse("no filters here", now); err == nil {
+		t.Errorf("ParseTalentQueryResponse() expected error without JSON")
+	}
+}
@@ -0,0 +1,235 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// vocabularyTerm maps the ways a search term is written in queries to one canonical name
+// and to the values stored on profiles that satisfy it
+type vocabularyTerm struct {
+	Name string
+	// Aliases are lower-case spellings recognised in queries; the name counts as one unless it is Exact
+	Aliases []string
+	// Exact are case-sensitive spellings for words that are also common English, e.g. "Go" or "US"
+	Exact []string
+	// Stored are lower-case profile values that satisfy the term; aliases are used when empty
+	Stored []string
+}
+
+// vocabulary is a list of terms with lookups by alias
+type vocabulary []vocabularyTerm
+
+// lookup returns the term a value names, matching the name or an alias case-insensitively
+func (v vocabulary) lookup(value string) (vocabularyTerm, bool) {
+	key := strings.ToLower(strings.TrimSpace(value))
+	for _, term := range v {
+		if strings.ToLower(term.Name) == key {
+			return term, true
+		}
+		for _, alias := range term.Aliases {
+			if alias == key {
+				return term, true
+			}
+		}
+		for _, exact := range term.Exact {
+			if strings.ToLower(exact) == key {
+				return term, true
+			}
+		}
+	}
+	return vocabularyTerm{}, false
+}
+
+// stored returns the lower-case profile values that satisfy a term
+func (t vocabularyTerm) stored() []string {
+	values := t.Stored
+	if len(values) == 0 {
+		values = append([]string{strings.ToLower(t.Name)}, t.Aliases...)
+		for _, exact := range t.Exact {
+			values = append(values, strings.ToLower(exact))
+		}
+	}
+	return values
+}
+
+// aliasesByLength lists every alias with its term, longest first, so "react native"
+// is recognised before "react"
+func (v vocabulary) aliasesByLength() []termAlias {
+	var aliases []termAlias
+	for _, term := range v {
+		if !term.isExact(term.Name) {
+			aliases = append(aliases, termAlias{term: term.Name, alias: strings.ToLower(term.Name)})
+		}
+		for _, alias := range term.Aliases {
+			aliases = append(aliases, termAlias{term: term.Name, alias: alias})
+		}
+		for _, exact := range term.Exact {
+			aliases = append(aliases, termAlias{term: term.Name, alias: exact, exact: true})
+		}
+	}
+	sort.SliceStable(aliases, func(i, j int) bool { return len(aliases[i].alias) > len(aliases[j].alias) })
+	return aliases
+}
+
+// isExact reports whether a spelling only counts with its exact case
+func (t vocabularyTerm) isExact(spelling string) bool {
+	for _, exact := range t.Exact {
+		if exact == spelling {
+			return true
+		}
+	}
+	return false
+}
+
+type termAlias struct {
+	term  string
+	alias string
+	exact bool
+}
+
+// skillVocabulary covers common skills and the spellings profiles use for them
+var skillVocabulary = vocabulary{
+	{Name: "Go", Aliases: []string{"golang"}, Exact: []string{"Go"}},
+	{Name: "Java"},
+	{Name: "Kotlin"},
+	{Name: "Scala"},
+	{Name: "Python"},
+	{Name: "Ruby on Rails", Aliases: []string{"rails", "ror"}},
+	{Name: "Ruby"},
+	{Name: "PHP"},
+	{Name: "Rust"},
+	{Name: "C#", Aliases: []string{"csharp"}},
+	{Name: ".NET", Aliases: []string{"dotnet", ".net core", "asp.net"}},
+	{Name: "C++", Aliases: []string{"cpp"}},
+	{Name: "JavaScript", Aliases: []string{"js"}},
+	{Name: "TypeScript"},
+	{Name: "React Native"},
+	{Name: "React", Aliases: []string{"react.js", "reactjs"}},
+	{Name: "Angular", Aliases: []string{"angularjs"}},
+	{Name: "Vue", Aliases: []string{"vue.js", "vuejs"}},
+	{Name: "Node.js", Aliases: []string{"node", "nodejs"}},
+	{Name: "Next.js", Aliases: []string{"nextjs"}},
+	{Name: "Django"},
+	{Name: "Flask"},
+	{Name: "Spring", Aliases: []string{"spring boot"}},
+	{Name: "Kafka", Aliases: []string{"apache kafka"}},
+	{Name: "RabbitMQ"},
+	{Name: "Kubernetes", Aliases: []string{"k8s"}},
+	{Name: "Docker"},
+	{Name: "Terraform"},
+	{Name: "AWS", Aliases: []string{"amazon web services"}},
+	{Name: "Azure"},
+	{Name: "GCP", Aliases: []string{"google cloud"}},
+	{Name: "PostgreSQL", Aliases: []string{"postgres"}},
+	{Name: "MySQL"},
+	{Name: "MongoDB", Aliases: []string{"mongo"}},
+	{Name: "Redis"},
+	{Name: "Elasticsearch"},
+	{Name: "GraphQL"},
+	{Name: "SQL"},
+	{Name: "Spark", Aliases: []string{"apache spark"}},
+	{Name: "Airflow"},
+	{Name: "Snowflake"},
+	{Name: "TensorFlow"},
+	{Name: "PyTorch"},
+	{Name: "ML", Aliases: []string{"machine learning"}},
+	{Name: "NLP"},
+	{Name: "LLM", Aliases: []string{"llms", "genai", "generative ai"}},
+	{Name: "Flutter"},
+	{Name: "Swift"},
+	{Name: "iOS"},
+	{Name: "Android"},
+	{Name: "Figma"},
+	{Name: "Selenium"},
+	{Name: "Cypress"},
+	{Name: "Playwright"},
+	{Name: "CI/CD", Aliases: []string{"cicd"}},
+	{Name: "Microservices"},
+	{Name: "Scrum"},
+	{Name: "Agile"},
+	{Name: "JIRA"},
+}
+
+// typeVocabulary maps role words to the employee types in models.UserType.
+// Stored includes the variants found in seeded data, e.g. "Automation Tester".
+var typeVocabulary = vocabulary{
+	{Name: "Frontend Dev", Aliases: []string{"frontend", "front-end", "front end"}, Stored: []string{"frontend dev"}},
+	{Name: "Backend Dev", Aliases: []string{"backend", "back-end", "back end"}, Stored: []string{"backend dev"}},
+	{Name: "Fullstack Dev", Aliases: []string{"fullstack", "full-stack", "full stack"}, Stored: []string{"fullstack dev"}},
+	{Name: "AI", Aliases: []string{"ai engineer", "ml engineer", "machine learning engineer", "data scientist"}, Stored: []string{"ai", "ai engineer"}},
+	{Name: "UI", Aliases: []string{"ui designer", "visual designer"}, Stored: []string{"ui"}},
+	{Name: "UX", Aliases: []string{"ux designer", "ux researcher"}, Stored: []string{"ux"}},
+	{Name: "Tester", Aliases: []string{"testers", "qa", "quality assurance", "test engineer"}, Stored: []string{"tester", "manual tester", "automation tester", "qa"}},
+	{Name: "Manager", Aliases: []string{"project manager", "delivery manager", "engineering manager"}, Stored: []string{"manager"}},
+	{Name: "Architect", Aliases: []string{"solution architect", "solutions architect"}, Stored: []string{"architect"}},
+	{Name: "Scrum Master", Stored: []string{"scrum master"}},
+}
+
+// geoVocabulary maps places and timezones to the geo values stored on profiles, e.g. "EU" or "US-East"
+var geoVocabulary = vocabulary{
+	{Name: "Europe", Aliases: []string{"european", "eu", "emea", "cet", "cest"}, Stored: []string{
+		"eu", "europe", "emea", "germany", "france", "spain", "italy", "netherlands", "poland",
+		"portugal", "romania", "ireland", "sweden", "uk", "united kingdom",
+	}},
+	{Name: "United Kingdom", Aliases: []string{"uk", "britain", "great britain", "england"}, Stored: []string{"uk", "united kingdom", "gb"}},
+	{Name: "United States", Aliases: []string{"usa", "u.s.", "america", "est", "pst"}, Exact: []string{"US"}, Stored: []string{
+		"us", "usa", "united states", "us-east", "us-west",
+	}},
+	{Name: "Canada", Stored: []string{"canada", "ca"}},
+	{Name: "India", Aliases: []string{"ist"}, Stored: []string{"in", "india"}},
+	{Name: "APAC", Aliases: []string{"asia", "asia pacific", "asia-pacific"}, Stored: []string{"apac", "asia", "singapore", "australia", "japan"}},
+	{Name: "Latin America", Aliases: []string{"latam", "south america"}, Stored: []string{"latam", "brazil", "mexico", "argentina", "colombia"}},
+	{Name: "Germany", Stored: []string{"germany", "de"}},
+	{Name: "France", Stored: []string{"france", "fr"}},
+	{Name: "Spain", Stored: []string{"spain", "es"}},
+	{Name: "Netherlands", Stored: []string{"netherlands", "nl"}},
+	{Name: "Poland", Stored: []string{"poland", "pl"}},
+	{Name: "Portugal", Stored: []string{"portugal", "pt"}},
+	{Name: "Romania", Stored: []string{"romania", "ro"}},
+}
+
+// industryVocabulary maps domains to substrings of the industries stored on profiles
+var industryVocabulary = vocabulary{
+	{Name: "Fintech", Aliases: []string{"financial services", "finance", "banking", "bank", "payments"}, Stored: []string{"fintech", "financ", "bank", "payment"}},
+	{Name: "Healthcare", Aliases: []string{"health care", "health", "healthtech", "medical", "pharma", "life sciences"}, Stored: []string{"health", "medical", "pharma", "life science"}},
+	{Name: "Insurance", Aliases: []string{"insurtech"}, Stored: []string{"insur"}},
+	{Name: "Retail", Aliases: []string{"e-commerce", "ecommerce"}, Stored: []string{"retail", "commerce"}},
+	{Name: "Telecom", Aliases: []string{"telecommunications", "telco"}, Stored: []string{"telecom", "telco"}},
+	{Name: "Automotive", Stored: []string{"automotive"}},
+	{Name: "Media", Aliases: []string{"entertainment", "publishing"}, Stored: []string{"media", "entertainment", "publishing"}},
+	{Name: "Logistics", Aliases: []string{"supply chain", "shipping"}, Stored: []string{"logistic", "supply chain", "shipping"}},
+	{Name: "Energy", Aliases: []string{"utilities", "oil and gas"}, Stored: []string{"energy", "utilit", "oil"}},
+	{Name: "Education", Aliases: []string{"edtech"}, Stored: []string{"educat", "edtech"}},
+	{Name: "Gaming", Aliases: []string{"games"}, Stored: []string{"gaming", "game"}},
+	{Name: "Public Sector", Aliases: []string{"government"}, Stored: []string{"public sector", "government"}},
+}
+
+// seniorityYears maps seniority words to minimum (positive) or maximum (negative) years of experience
+var seniorityYears = map[string]int{
+	"junior":       -2,
+	"entry-level":  -2,
+	"entry level":  -2,
+	"graduate":     -2,
+	"mid-level":    3,
+	"mid level":    3,
+	"intermediate": 3,
+	"senior":       5,
+	"sr":           5,
+	"lead":         8,
+	"staff":        8,
+	"principal":    8,
+}
+
+// searchStopwords are dropped from the semantic remainder of a query
+var searchStopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "in": true, "on": true, "at": true, "by": true, "for": true,
+	"from": true, "with": true, "and": true, "or": true, "of": true, "to": true, "who": true, "that": true,
+	"which": true, "is": true, "are": true, "be": true, "has": true, "have": true, "having": true,
+	"someone": true, "somebody": true, "anyone": true, "people": true, "person": true, "candidate": true,
+	"candidates": true, "me": true, "find": true, "need": true, "needs": true, "looking": true, "search": true,
+	"want": true, "show": true, "get": true, "experience": true, "experienced": true, "year": true,
+	"years": true, "yrs": true, "plus": true, "knowledge": true, "skills": true, "skilled": true,
+	"background": true, "based": true, "located": true, "some": true, "any": true, "level": true,
+	"know": true, "knows": true, "ready": true,
+}
@@ -0,0 +1,38 @@
+-- Migration: 004_prompt_templates_and_match_runs.sql
+-- Description: Database overrides for prompt templates and prompt version tracking
+
+CREATE TABLE IF NOT EXISTS prompt_templates (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    version VARCHAR(50) NOT NULL,
+    body TEXT NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT idx_prompt_templates_name_version UNIQUE (name, version)
+);
+
+-- At most one active override per template
+CREATE UNIQUE INDEX IF NOT EXISTS idx_prompt_templates_active ON prompt_templates(name) WHERE active;
+
+CREATE TRIGGER update_prompt_templates_updated_at
+    BEFORE UPDATE ON prompt_templates
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Prompt version used to generate projects.summary
+ALTER TABLE projects
+    ADD COLUMN IF NOT EXISTS summary_prompt_version VARCHAR(50);
+
+-- One row per AI scoring run
+CREATE TABLE IF NOT EXISTS match_runs (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    prompt_version VARCHAR(50) NOT NULL,
+    model VARCHAR(100),
+    candidate_count INTEGER NOT NULL DEFAULT 0,
+    fallback_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_match_runs_project_id ON match_runs(project_id);
@@ -0,0 +1,9 @@
+-- Migration: 005_project_requirements.sql
+-- Description: Structured project requirements extracted by the summariser or edited by managers
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS requirements JSONB;
+
+-- Supports containment queries such as requirements @> '{"industry": "Fintech"}'
+CREATE INDEX IF NOT EXISTS idx_projects_requirements ON projects USING GIN (requirements);
+
+COMMENT ON COLUMN projects.requirements IS 'Typed requirements (roles with skills, min experience, geo, industry, confidence); summary is rendered from this';
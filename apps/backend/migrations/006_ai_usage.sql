@@ -0,0 +1,26 @@
+-- Migration: 006_ai_usage.sql
+-- Description: Token usage, latency and cost of every AI provider call
+
+CREATE TABLE IF NOT EXISTS ai_usage (
+    id SERIAL PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    model VARCHAR(100) NOT NULL,
+    operation VARCHAR(50) NOT NULL,
+    feature VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(50),
+    entity_id INTEGER,
+    prompt_tokens BIGINT NOT NULL DEFAULT 0,
+    completion_tokens BIGINT NOT NULL DEFAULT 0,
+    total_tokens BIGINT NOT NULL DEFAULT 0,
+    latency_ms BIGINT NOT NULL DEFAULT 0,
+    success BOOLEAN NOT NULL,
+    error TEXT,
+    cost_usd NUMERIC(12, 6) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_usage_created_at ON ai_usage(created_at);
+CREATE INDEX IF NOT EXISTS idx_ai_usage_feature_created_at ON ai_usage(feature, created_at);
+CREATE INDEX IF NOT EXISTS idx_ai_usage_entity ON ai_usage(entity_type, entity_id);
+
+COMMENT ON TABLE ai_usage IS 'One row per AI provider call; cost_usd is priced at call time';
@@ -0,0 +1,22 @@
+-- Migration: 007_prompt_audit_logs.sql
+-- Description: Audit trail of redacted prompts sent to external language models
+
+CREATE TABLE IF NOT EXISTS prompt_audit_logs (
+    id SERIAL PRIMARY KEY,
+    prompt_name VARCHAR(100) NOT NULL,
+    prompt_version VARCHAR(50) NOT NULL,
+    feature VARCHAR(100),
+    entity_type VARCHAR(50),
+    entity_id INTEGER,
+    fields_sent TEXT,
+    redactions JSONB DEFAULT '{}',
+    prompt_sha256 CHAR(64) NOT NULL,
+    sent_prompt TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_prompt_audit_logs_prompt_name ON prompt_audit_logs(prompt_name);
+CREATE INDEX IF NOT EXISTS idx_prompt_audit_logs_created_at ON prompt_audit_logs(created_at);
+CREATE INDEX IF NOT EXISTS idx_prompt_audit_logs_entity ON prompt_audit_logs(entity_type, entity_id);
+
+COMMENT ON TABLE prompt_audit_logs IS 'Redacted prompts as sent to external models; pseudonym mappings are never stored';
@@ -0,0 +1,54 @@
+-- Migration: 008_enrichment_jobs.sql
+-- Description: Durable queue for summarise and embed jobs, and enrichment status on projects and profiles
+
+CREATE TABLE IF NOT EXISTS enrichment_jobs (
+    id SERIAL PRIMARY KEY,
+    job_type VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 5,
+    last_error TEXT,
+    run_after TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    locked_at TIMESTAMP WITH TIME ZONE,
+    locked_by VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Workers claim the oldest due job with FOR UPDATE SKIP LOCKED
+CREATE INDEX IF NOT EXISTS idx_enrichment_jobs_due ON enrichment_jobs(run_after, id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_enrichment_jobs_status ON enrichment_jobs(status, updated_at);
+CREATE INDEX IF NOT EXISTS idx_enrichment_jobs_entity ON enrichment_jobs(entity_type, entity_id);
+
+-- At most one queued job per entity and type; a running job may still have a newer one queued behind it
+CREATE UNIQUE INDEX IF NOT EXISTS idx_enrichment_jobs_one_pending ON enrichment_jobs(job_type, entity_type, entity_id) WHERE status = 'pending';
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS enrichment_status VARCHAR(20) NOT NULL DEFAULT 'pending'
+    CHECK (enrichment_status IN ('pending', 'ready', 'failed'));
+ALTER TABLE employee_profiles ADD COLUMN IF NOT EXISTS enrichment_status VARCHAR(20) NOT NULL DEFAULT 'pending'
+    CHECK (enrichment_status IN ('pending', 'ready', 'failed'));
+
+UPDATE projects SET enrichment_status = 'ready' WHERE embedding IS NOT NULL;
+UPDATE employee_profiles SET enrichment_status = 'ready' WHERE embedding IS NOT NULL;
+
+-- Records saved without an embedding were silently excluded from matching; queue them now
+INSERT INTO enrichment_jobs (job_type, entity_type, entity_id)
+SELECT CASE WHEN COALESCE(description, '') <> '' AND COALESCE(summary, '') = '' THEN 'summarize_project' ELSE 'embed_project' END, 'project', id
+FROM projects
+WHERE embedding IS NULL AND deleted_at IS NULL
+ON CONFLICT DO NOTHING;
+
+INSERT INTO enrichment_jobs (job_type, entity_type, entity_id)
+SELECT 'embed_profile', 'profile', user_id
+FROM employee_profiles
+WHERE embedding IS NULL AND deleted_at IS NULL
+ON CONFLICT DO NOTHING;
+
+CREATE INDEX IF NOT EXISTS idx_projects_enrichment_status ON projects(enrichment_status) WHERE enrichment_status <> 'ready';
+CREATE INDEX IF NOT EXISTS idx_employee_profiles_enrichment_status ON employee_profiles(enrichment_status) WHERE enrichment_status <> 'ready';
+
+COMMENT ON TABLE enrichment_jobs IS 'Summarise and embed jobs for projects and profiles, processed by workers with retries';
+COMMENT ON COLUMN projects.enrichment_status IS 'pending until the summary and embedding are generated, failed once retries are exhausted';
+COMMENT ON COLUMN employee_profiles.enrichment_status IS 'pending until the embedding is generated, failed once retries are exhausted';
@@ -0,0 +1,22 @@
+-- Migration: 009_profile_drafts.sql
+-- Description: Profile updates proposed from uploaded CVs, pending confirmation by the employee
+
+CREATE TABLE IF NOT EXISTS profile_drafts (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'discarded')),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('model', 'rules')),
+    prompt_version VARCHAR(50),
+    filename VARCHAR(255),
+    format VARCHAR(20),
+    blob_key VARCHAR(255),
+    extracted JSONB NOT NULL DEFAULT '{}',
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_profile_drafts_user_id ON profile_drafts(user_id, created_at DESC);
+
+COMMENT ON TABLE profile_drafts IS 'Skills, experience, industry and type proposed from a CV; applied to the profile only once confirmed';
+COMMENT ON COLUMN profile_drafts.blob_key IS 'Key of the original file in the blob store, set only when the employee chose to keep it';
@@ -0,0 +1,11 @@
+-- Migration: 010_external_ids.sql
+-- Description: External IDs on users and projects so bulk imports and HR systems can upsert records
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_external_id ON users(external_id) WHERE external_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_projects_external_id ON projects(external_id) WHERE external_id IS NOT NULL;
+
+COMMENT ON COLUMN users.external_id IS 'ID of the person in an import file or HR system; imports match on it before email';
+COMMENT ON COLUMN projects.external_id IS 'ID of the project in an import file or external system; imports upsert projects by it';
@@ -0,0 +1,40 @@
+-- Migration: 011_hr_sync.sql
+-- Description: Log of employee records synced from the HR system, with per-record changes and conflicts
+
+CREATE TABLE IF NOT EXISTS hr_sync_runs (
+    id SERIAL PRIMARY KEY,
+    source VARCHAR(50) NOT NULL,
+    reference VARCHAR(255),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('succeeded', 'conflicts', 'failed')),
+    received INTEGER NOT NULL DEFAULT 0,
+    created INTEGER NOT NULL DEFAULT 0,
+    updated INTEGER NOT NULL DEFAULT 0,
+    unchanged INTEGER NOT NULL DEFAULT 0,
+    leavers INTEGER NOT NULL DEFAULT 0,
+    conflicts INTEGER NOT NULL DEFAULT 0,
+    rejected INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_hr_sync_runs_reference ON hr_sync_runs(source, reference);
+CREATE INDEX IF NOT EXISTS idx_hr_sync_runs_started_at ON hr_sync_runs(started_at DESC);
+
+CREATE TABLE IF NOT EXISTS hr_sync_entries (
+    id SERIAL PRIMARY KEY,
+    run_id INTEGER NOT NULL REFERENCES hr_sync_runs(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('created', 'updated', 'leaver', 'conflict', 'rejected', 'skipped')),
+    external_id VARCHAR(255),
+    email VARCHAR(255),
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    field VARCHAR(50),
+    detail TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_hr_sync_entries_run_id ON hr_sync_entries(run_id);
+CREATE INDEX IF NOT EXISTS idx_hr_sync_entries_user_id ON hr_sync_entries(user_id) WHERE user_id IS NOT NULL;
+
+COMMENT ON TABLE hr_sync_runs IS 'One batch of employee records from an HR connector (signed webhook or file drop)';
+COMMENT ON TABLE hr_sync_entries IS 'Fields changed, leavers detected and conflicts left for a person to resolve, per synced record';
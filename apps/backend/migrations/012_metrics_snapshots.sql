@@ -0,0 +1,19 @@
+-- Migration: 012_metrics_snapshots.sql
+-- Description: Daily manager dashboard metrics, organisation-wide and per department and geo, for trend charts
+
+CREATE TABLE IF NOT EXISTS metrics_snapshots (
+    id SERIAL PRIMARY KEY,
+    date DATE NOT NULL,
+    group_by VARCHAR(20) NOT NULL CHECK (group_by IN ('all', 'department', 'geo')),
+    group_value VARCHAR(255) NOT NULL DEFAULT '',
+    headcount INTEGER NOT NULL DEFAULT 0,
+    available_engineers INTEGER NOT NULL DEFAULT 0,
+    active_projects INTEGER NOT NULL DEFAULT 0,
+    rolling_off_soon INTEGER NOT NULL DEFAULT 0,
+    bench_resources INTEGER NOT NULL DEFAULT 0,
+    allocated_engineers INTEGER NOT NULL DEFAULT 0,
+    utilisation_pct NUMERIC(6, 1) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_metrics_snapshots_key ON metrics_snapshots(date, group_by, group_value);
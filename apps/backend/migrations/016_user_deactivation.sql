@@ -0,0 +1,5 @@
+-- Migration: 016_user_deactivation.sql
+-- Description: Deactivated users, who cannot sign in and are left out of matching and metrics
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_users_deactivated_at ON users(deactivated_at);
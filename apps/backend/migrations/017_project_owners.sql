@@ -0,0 +1,7 @@
+-- Migration: 017_project_owners.sql
+-- Description: Project owners and delivery managers, who receive the project's notifications
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS owner_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS delivery_manager_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_projects_owner_id ON projects(owner_id);
+CREATE INDEX IF NOT EXISTS idx_projects_delivery_manager_id ON projects(delivery_manager_id);
@@ -0,0 +1,22 @@
+-- Migration: 018_org_units.sql
+-- Description: Org unit tree (company > practice > department) that employees and projects belong to
+
+CREATE TABLE IF NOT EXISTS org_units (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('company', 'practice', 'department')),
+    parent_id INTEGER REFERENCES org_units(id),
+    head_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_org_units_parent_name ON org_units(name, parent_id);
+CREATE INDEX IF NOT EXISTS idx_org_units_parent_id ON org_units(parent_id);
+CREATE INDEX IF NOT EXISTS idx_org_units_head_id ON org_units(head_id);
+
+ALTER TABLE employee_profiles ADD COLUMN IF NOT EXISTS org_unit_id INTEGER REFERENCES org_units(id);
+CREATE INDEX IF NOT EXISTS idx_employee_profiles_org_unit_id ON employee_profiles(org_unit_id);
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS org_unit_id INTEGER REFERENCES org_units(id);
+CREATE INDEX IF NOT EXISTS idx_projects_org_unit_id ON projects(org_unit_id);
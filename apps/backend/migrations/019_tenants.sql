@@ -0,0 +1,54 @@
+-- Migration: 019_tenants.sql
+-- Description: Tenants hosted by one deployment, with their Slack, AI provider and scoring settings,
+-- and a tenant_id on every table. Existing rows belong to the default tenant 1.
+
+CREATE TABLE IF NOT EXISTS tenants (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(63) NOT NULL UNIQUE,
+    slack_bot_token TEXT,
+    slack_default_channel_id VARCHAR(50),
+    ai_provider VARCHAR(50),
+    ai_base_url TEXT,
+    ai_api_key TEXT,
+    ai_model VARCHAR(100),
+    scoring_rules JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+INSERT INTO tenants (id, name, slug) VALUES (1, 'Default', 'default') ON CONFLICT (id) DO NOTHING;
+SELECT setval(pg_get_serial_sequence('tenants', 'id'), GREATEST((SELECT MAX(id) FROM tenants), 1));
+
+-- Prompt templates get a nullable tenant_id in 023_tenant_prompt_templates.sql
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'users', 'employee_profiles', 'projects', 'project_allocations', 'notifications',
+        'match_runs', 'ai_usage', 'prompt_audit_logs', 'enrichment_jobs', 'profile_drafts',
+        'hr_sync_runs', 'hr_sync_entries', 'metrics_snapshots', 'refresh_tokens', 'revoked_tokens',
+        'invitations', 'audit_events', 'service_accounts', 'api_keys', 'org_units'
+    ] LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN IF NOT EXISTS tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id)', t);
+        EXECUTE format('CREATE INDEX IF NOT EXISTS %I ON %I(tenant_id)', 'idx_' || t || '_tenant_id', t);
+    END LOOP;
+END $$;
+
+-- Keys that were unique deployment-wide are unique per tenant. Emails stay unique across tenants,
+-- so sign-in finds the user's tenant from their email.
+DROP INDEX IF EXISTS idx_users_external_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_tenant_external_id ON users(tenant_id, external_id) WHERE external_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_projects_external_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_projects_tenant_external_id ON projects(tenant_id, external_id) WHERE external_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_metrics_snapshots_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_metrics_snapshots_key ON metrics_snapshots(tenant_id, date, group_by, group_value);
+
+DROP INDEX IF EXISTS idx_org_units_parent_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_org_units_parent_name ON org_units(tenant_id, name, parent_id);
+
+ALTER TABLE service_accounts DROP CONSTRAINT IF EXISTS service_accounts_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_service_accounts_tenant_name ON service_accounts(tenant_id, name);
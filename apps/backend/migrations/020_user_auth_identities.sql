@@ -0,0 +1,9 @@
+-- Migration: 020_user_auth_identities.sql
+-- Description: The provider and subject a user signs in with, recorded on first sign-in so a
+-- later ID token with the same email but another identity is rejected. Existing users are
+-- linked on their next sign-in.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS auth_provider VARCHAR(100);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS auth_subject VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_auth_identity ON users(auth_provider, auth_subject) WHERE auth_subject IS NOT NULL;
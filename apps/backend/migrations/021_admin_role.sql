@@ -0,0 +1,18 @@
+-- Migration: 021_admin_role.sql
+-- Description: An Admin role holding the admin permission, which Managers no longer have. The
+-- earliest active Manager of each tenant, normally the one the tenant was created with, becomes
+-- its Admin; promote others through PATCH /api/v1/admin/users/:id.
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('Employee', 'Manager', 'Admin'));
+
+ALTER TABLE invitations DROP CONSTRAINT IF EXISTS invitations_role_check;
+ALTER TABLE invitations ADD CONSTRAINT invitations_role_check CHECK (role IN ('Employee', 'Manager', 'Admin'));
+
+UPDATE users SET role = 'Admin'
+WHERE id IN (
+    SELECT DISTINCT ON (tenant_id) id
+    FROM users
+    WHERE role = 'Manager' AND deactivated_at IS NULL AND deleted_at IS NULL
+    ORDER BY tenant_id, created_at, id
+);
@@ -0,0 +1,7 @@
+-- Migration: 022_tenant_signup_domains.sql
+-- Description: The email domains whose people may sign up to each tenant without an invitation,
+-- comma-separated. A first sign-in from a domain no tenant claims needs an invitation;
+-- SIGNUP_ALLOWED_DOMAINS still lists the default tenant's. Set them for an existing tenant with e.g.
+--   UPDATE tenants SET signup_domains = 'acme.co.uk,acme.com' WHERE slug = 'acme-uk';
+
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS signup_domains TEXT NOT NULL DEFAULT '';
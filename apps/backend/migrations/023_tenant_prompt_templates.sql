@@ -0,0 +1,14 @@
+-- Migration: 023_tenant_prompt_templates.sql
+-- Description: Prompt template overrides per tenant. A NULL tenant_id marks a deployment default,
+-- which every tenant uses until it activates a version of its own; tenant admins only write their
+-- tenant's versions. Existing overrides become the defaults.
+
+ALTER TABLE prompt_templates ADD COLUMN IF NOT EXISTS tenant_id INTEGER REFERENCES tenants(id);
+
+ALTER TABLE prompt_templates DROP CONSTRAINT IF EXISTS idx_prompt_templates_name_version;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_prompt_templates_tenant_version ON prompt_templates(tenant_id, name, version) WHERE tenant_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_prompt_templates_default_version ON prompt_templates(name, version) WHERE tenant_id IS NULL;
+
+-- At most one active override per template for each tenant, and one default
+DROP INDEX IF EXISTS idx_prompt_templates_active;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_prompt_templates_active ON prompt_templates(COALESCE(tenant_id, 0), name) WHERE active;
@@ -0,0 +1,33 @@
+-- Migration: optional/tenant_rls.sql
+-- Description: Row-level security backing the repository layer's tenant isolation. Not run by the
+-- migration runner, which only reads the top-level directory; apply it by hand after 019_tenants.sql:
+--
+--     psql "$DATABASE_URL" -f migrations/optional/tenant_rls.sql
+--
+-- A session that sets app.tenant_id only sees and writes that tenant's rows. The vector searches set it
+-- for the request's tenant. Sessions that leave it unset, such as sign-in and background workers before
+-- they know whose record they handle, are not restricted.
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'users', 'employee_profiles', 'projects', 'project_allocations', 'notifications',
+        'match_runs', 'ai_usage', 'prompt_audit_logs', 'enrichment_jobs', 'profile_drafts',
+        'hr_sync_runs', 'hr_sync_entries', 'metrics_snapshots', 'refresh_tokens', 'revoked_tokens',
+        'invitations', 'audit_events', 'service_accounts', 'api_keys', 'org_units'
+    ] LOOP
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        -- The application usually connects as the tables' owner, which policies skip unless forced
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format($policy$
+            CREATE POLICY tenant_isolation ON %I
+            USING (NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+                   OR tenant_id = current_setting('app.tenant_id', true)::integer)
+            WITH CHECK (NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+                        OR tenant_id = current_setting('app.tenant_id', true)::integer)
+        $policy$, t);
+    END LOOP;
+END $$;
@@ -1,34 +1,140 @@
 package middleware
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"gorm.io/gorm"
 )
 
-// GenerateJWTToken creates a signed JWT containing the user email and role
-func GenerateJWTToken(cfg *config.Config, email string, role string) (string, error) {
+// RevocationList reports access tokens revoked before they expire, see domain.TokenService
+type RevocationList interface {
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+// UserLookup finds the user a token was issued to, see domain.UserRepository
+type UserLookup interface {
+	GetByEmail(ctx context.Context, email string) (*entities.User, error)
+}
+
+// APIKeyAuthenticator authenticates service accounts' API keys, see domain.APIKeyService
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, rawKey string) (*entities.APIKey, error)
+}
+
+// AuditRecorder records audit events, see domain.AuditRepository
+type AuditRecorder interface {
+	Record(ctx context.Context, event *entities.AuditEvent) error
+}
+
+// GenerateJWTToken creates a signed access token for a user that expires after cfg.Auth.JWTExpiry.
+// Its jti claim identifies it on the revocation list, and its tid claim the user's tenant.
+func GenerateJWTToken(cfg *config.Config, userID uint, tenantID uint, email string, role string) (string, time.Time, error) {
+	return signAccessToken(cfg, userID, tenantID, email, role, nil)
+}
+
+// GenerateImpersonationToken creates an access token for a user on behalf of an admin acting as
+// them. Its act claim names the admin (RFC 8693), so requests made with it can be attributed.
+func GenerateImpersonationToken(cfg *config.Config, user *entities.User, actor *entities.User) (string, time.Time, error) {
+	return signAccessToken(cfg, user.ID, user.TenantID, user.Email, user.Role, jwt.MapClaims{
+		"sub": actor.Email,
+		"uid": actor.ID,
+	})
+}
+
+// signAccessToken signs an access token, with an act claim if actor is set
+func signAccessToken(cfg *config.Config, userID uint, tenantID uint, email string, role string, actor jwt.MapClaims) (string, time.Time, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", time.Time{}, err
+	}
+	now := time.Now()
+	expiresAt := now.Add(cfg.Auth.AccessTokenTTL())
 	claims := jwt.MapClaims{
 		"sub":   email,
+		"uid":   userID,
+		"tid":   tenantID,
 		"role":  role,
-		"exp":   time.Now().Add(24 * time.Hour).Unix(),
-		"iat":   time.Now().Unix(),
+		"jti":   hex.EncodeToString(random),
+		"exp":   expiresAt.Unix(),
+		"iat":   now.Unix(),
 		"iss":   "talent-fit",
 		"scope": "api",
 	}
+	if actor != nil {
+		claims["act"] = actor
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(cfg.Auth.JWTSecret))
+	signed, err := token.SignedString([]byte(cfg.Auth.JWTSecret))
+	return signed, expiresAt, err
+}
+
+// ParseJWTToken verifies an access token's signature and expiry and returns its claims
+func ParseJWTToken(cfg *config.Config, raw string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid signing method")
+		}
+		return []byte(cfg.Auth.JWTSecret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// AuthenticateAPIKey authenticates requests whose bearer token is an API key, injecting the
+// service account and the key's scopes in place of a user; the JWT middlewares after it let these
+// requests through. Permissions are then checked against the scopes rather than a role.
+func AuthenticateAPIKey(keys APIKeyAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !strings.HasPrefix(raw, domain.APIKeyPrefix) {
+			c.Next()
+			return
+		}
+
+		key, err := keys.Authenticate(c.Request.Context(), raw)
+		if errors.Is(err, domain.ErrInvalidAPIKey) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to check API key"})
+			return
+		}
+
+		c.Set("serviceAccountID", key.ServiceAccountID)
+		c.Set("apiKeyScopes", models.ParseScopes(key.Scopes))
+		setTenant(c, key.TenantID)
+		c.Next()
+	}
 }
 
 // AuthMiddlewareWithConfig validates JWT tokens using provided config
 // and injects user identity and claims into Gin context.
 func AuthMiddlewareWithConfig(cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if IsServiceAccount(c) {
+			c.Next()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
@@ -37,13 +143,7 @@ func AuthMiddlewareWithConfig(cfg *config.Config) gin.HandlerFunc {
 
 		raw := strings.TrimPrefix(authHeader, "Bearer ")
 
-		claims := jwt.MapClaims{}
-		_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, errors.New("invalid signing method")
-			}
-			return []byte(cfg.Auth.JWTSecret), nil
-		})
+		claims, err := ParseJWTToken(cfg, raw)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			return
@@ -59,11 +159,189 @@ func AuthMiddlewareWithConfig(cfg *config.Config) gin.HandlerFunc {
 		// Inject into context for downstream handlers
 		c.Set("userEmail", sub)
 		c.Set("authClaims", claims)
+		setTenant(c, TenantFromClaims(claims))
+
+		c.Next()
+	}
+}
+
+// RejectRevokedTokens rejects access tokens on the revocation list, and tokens issued before
+// revocation existed, which have no jti. It must run after AuthMiddlewareWithConfig.
+func RejectRevokedTokens(revoked RevocationList) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if IsServiceAccount(c) {
+			c.Next()
+			return
+		}
+
+		claims, _ := GetAuthClaims(c)
+		jti, _ := claims["jti"].(string)
+		if jti == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token has no jti, sign in again"})
+			return
+		}
+
+		isRevoked, err := revoked.IsAccessTokenRevoked(c.Request.Context(), jti)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to check token revocation"})
+			return
+		}
+		if isRevoked {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token has been revoked"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// LoadCurrentUser looks up the token's user and injects their ID and current role, so a role
+// changed, a user removed or their sessions revoked since the token was issued takes effect immediately.
+// It must run after AuthMiddlewareWithConfig.
+func LoadCurrentUser(users UserLookup) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if IsServiceAccount(c) {
+			c.Next()
+			return
+		}
+
+		email, ok := GetUserEmail(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing auth claims"})
+			return
+		}
+
+		user, err := users.GetByEmail(c.Request.Context(), email)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "user not found"})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to load user"})
+			return
+		}
+
+		if user.DeactivatedAt != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "account is deactivated"})
+			return
+		}
+
+		// "Sign out all sessions" invalidates every token issued up to then
+		if user.SessionsRevokedAt != nil {
+			claims, _ := GetAuthClaims(c)
+			if issuedAt, err := claims.GetIssuedAt(); err != nil || issuedAt == nil || !issuedAt.After(*user.SessionsRevokedAt) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "session has been revoked"})
+				return
+			}
+		}
+
+		c.Set("userID", user.ID)
+		c.Set("userRole", models.UserRole(user.Role))
+		if actorID, ok := impersonatorID(c); ok {
+			c.Set("impersonatorID", actorID)
+		}
+		c.Next()
+	}
+}
+
+// RecordImpersonation records every request that changes something while an admin acts as
+// another user, after it completes. It must run after LoadCurrentUser.
+func RecordImpersonation(recorder AuditRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID, ok := GetImpersonatorID(c)
+		if !ok || c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead || c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+
+		c.Next()
+
+		userID, _ := GetUserID(c)
+		email, _ := GetUserEmail(c)
+		event := &entities.AuditEvent{
+			Action:  entities.AuditImpersonatedRequest,
+			ActorID: &actorID,
+			UserID:  &userID,
+			Email:   email,
+			Detail:  fmt.Sprintf("%s %s -> %d", c.Request.Method, c.Request.URL.Path, c.Writer.Status()),
+		}
+		if err := recorder.Record(c.Request.Context(), event); err != nil {
+			log.Printf("Warning: failed to record impersonated request %s: %v", event.Detail, err)
+		}
+	}
+}
+
+// RequirePermission rejects requests from users whose role, or API keys whose scopes, lack any of
+// the given permissions. It must run after LoadCurrentUser.
+func RequirePermission(permissions ...models.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, permission := range permissions {
+			granted, authenticated := hasPermission(c, permission)
+			if !authenticated {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing current user"})
+				return
+			}
+			if !granted {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+				return
+			}
+		}
+		c.Next()
+	}
+}
 
+// RequireSelfOrPermission lets users act on their own records, identified by the user ID in the
+// given path parameter, and anyone else only with the permission. It must run after LoadCurrentUser.
+func RequireSelfOrPermission(param string, permission models.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		granted, authenticated := hasPermission(c, permission)
+		if !authenticated {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing current user"})
+			return
+		}
+
+		if granted {
+			c.Next()
+			return
+		}
+		if userID, ok := GetUserID(c); ok {
+			if id, err := strconv.ParseUint(c.Param(param), 10, 64); err == nil && uint(id) == userID {
+				c.Next()
+				return
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "you may only access your own records"})
+	}
+}
+
+// RequireUser rejects API keys on routes that act as the current user rather than on a permission
+func RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := GetUserID(c); !ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "this endpoint is only available to signed-in users"})
+			return
+		}
 		c.Next()
 	}
 }
 
+// hasPermission reports whether the current user's role, or the current API key's scopes, grant a
+// permission, and whether the request was authenticated at all
+func hasPermission(c *gin.Context, permission models.Permission) (granted, authenticated bool) {
+	if scopes, ok := GetAPIKeyScopes(c); ok {
+		for _, scope := range scopes {
+			if scope.Grants(permission) {
+				return true, true
+			}
+		}
+		return false, true
+	}
+	role, ok := GetUserRole(c)
+	if !ok {
+		return false, false
+	}
+	return role.Can(permission), true
+}
+
 // Helper to get user email from context
 func GetUserEmail(c *gin.Context) (string, bool) {
 	v, ok := c.Get("userEmail")
@@ -82,4 +360,103 @@ func GetAuthClaims(c *gin.Context) (jwt.MapClaims, bool) {
 	}
 	claims, ok := v.(jwt.MapClaims)
 	return claims, ok
-}
\ No newline at end of file
+}
+
+// GetUserID returns the current user's ID, set by LoadCurrentUser
+func GetUserID(c *gin.Context) (uint, bool) {
+	v, ok := c.Get("userID")
+	if !ok {
+		return 0, false
+	}
+	id, ok := v.(uint)
+	return id, ok
+}
+
+// GetUserRole returns the current user's role as stored, set by LoadCurrentUser
+func GetUserRole(c *gin.Context) (models.UserRole, bool) {
+	v, ok := c.Get("userRole")
+	if !ok {
+		return "", false
+	}
+	role, ok := v.(models.UserRole)
+	return role, ok
+}
+
+// IsServiceAccount reports whether the request was authenticated with an API key
+func IsServiceAccount(c *gin.Context) bool {
+	_, ok := GetServiceAccountID(c)
+	return ok
+}
+
+// GetServiceAccountID returns the ID of the service account whose API key authenticated the
+// request, set by AuthenticateAPIKey
+func GetServiceAccountID(c *gin.Context) (uint, bool) {
+	v, ok := c.Get("serviceAccountID")
+	if !ok {
+		return 0, false
+	}
+	id, ok := v.(uint)
+	return id, ok
+}
+
+// GetAPIKeyScopes returns the scopes of the API key that authenticated the request, set by
+// AuthenticateAPIKey
+func GetAPIKeyScopes(c *gin.Context) ([]models.APIKeyScope, bool) {
+	v, ok := c.Get("apiKeyScopes")
+	if !ok {
+		return nil, false
+	}
+	scopes, ok := v.([]models.APIKeyScope)
+	return scopes, ok
+}
+
+// GetImpersonatorID returns the ID of the admin acting as the current user, if they are, set by
+// LoadCurrentUser
+func GetImpersonatorID(c *gin.Context) (uint, bool) {
+	v, ok := c.Get("impersonatorID")
+	if !ok {
+		return 0, false
+	}
+	id, ok := v.(uint)
+	return id, ok
+}
+
+// GetTenantID returns the tenant of the current user or API key, set by AuthMiddlewareWithConfig or
+// AuthenticateAPIKey. The request's context is scoped to it as well, see domain.WithTenant.
+func GetTenantID(c *gin.Context) (uint, bool) {
+	v, ok := c.Get("tenantID")
+	if !ok {
+		return 0, false
+	}
+	id, ok := v.(uint)
+	return id, ok
+}
+
+// setTenant scopes the request, and so every repository it reaches, to a tenant
+func setTenant(c *gin.Context, tenantID uint) {
+	c.Set("tenantID", tenantID)
+	c.Request = c.Request.WithContext(domain.WithTenant(c.Request.Context(), tenantID))
+}
+
+// TenantFromClaims reads the token's tid claim; tokens issued before tenants belong to the default one
+func TenantFromClaims(claims jwt.MapClaims) uint {
+	tid, ok := claims["tid"].(float64)
+	if !ok || tid <= 0 {
+		return domain.DefaultTenantID
+	}
+	return uint(tid)
+}
+
+// impersonatorID reads the admin's user ID from the token's act claim
+func impersonatorID(c *gin.Context) (uint, bool) {
+	claims, _ := GetAuthClaims(c)
+	act, ok := claims["act"].(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	uid, ok := act["uid"].(float64)
+	if !ok || uid <= 0 {
+		return 0, false
+	}
+	return uint(uid), true
+}
@@ -0,0 +1,314 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/talent-fit/backend/internal/config"
+	"github.com/talent-fit/backend/internal/domain"
+	"github.com/talent-fit/backend/internal/entities"
+	"github.com/talent-fit/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type fakeRevocations map[string]bool
+
+func (f fakeRevocations) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	return f[jti], nil
+}
+
+type fakeUsers map[string]*entities.User
+
+func (f fakeUsers) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+	if user, ok := f[email]; ok {
+		return user, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type fakeKeys map[string]*entities.APIKey
+
+func (f fakeKeys) Authenticate(ctx context.Context, rawKey string) (*entities.APIKey, error) {
+	if key, ok := f[rawKey]; ok {
+		return key, nil
+	}
+	return nil, domain.ErrInvalidAPIKey
+}
+
+func TestAuthorization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	cfg.Auth.JWTSecret = "test-secret"
+	users := fakeUsers{
+		"employee@example.com": {ID: 7, Email: "employee@example.com", Role: string(models.RoleEmployee)},
+		"manager@example.com":  {ID: 8, Email: "manager@example.com", Role: string(models.RoleManager)},
+		"admin@example.com":    {ID: 9, Email: "admin@example.com", Role: string(models.RoleAdmin)},
+	}
+
+	revoked := fakeRevocations{}
+	router := gin.New()
+	api := router.Group("", AuthMiddlewareWithConfig(cfg), RejectRevokedTokens(revoked), LoadCurrentUser(users))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	api.POST("/projects", RequirePermission(models.PermProjectsWrite), ok)
+	api.PATCH("/employee/:id", RequireSelfOrPermission("id", models.PermEmployeesWrite), ok)
+	api.GET("/admin/users", RequirePermission(models.PermAdmin), ok)
+
+	tests := []struct {
+		name      string
+		email     string
+		tokenRole string
+		method    string
+		path      string
+		want      int
+	}{
+		{"employee cannot create projects", "employee@example.com", "Employee", http.MethodPost, "/projects", http.StatusForbidden},
+		{"manager can create projects", "manager@example.com", "Manager", http.MethodPost, "/projects", http.StatusOK},
+		{"role claim is not trusted", "employee@example.com", "Manager", http.MethodPost, "/projects", http.StatusForbidden},
+		{"employee can edit own profile", "employee@example.com", "Employee", http.MethodPatch, "/employee/7", http.StatusOK},
+		{"employee cannot edit another profile", "employee@example.com", "Employee", http.MethodPatch, "/employee/8", http.StatusForbidden},
+		{"manager can edit another profile", "manager@example.com", "Manager", http.MethodPatch, "/employee/7", http.StatusOK},
+		{"removed user is rejected", "gone@example.com", "Manager", http.MethodPost, "/projects", http.StatusUnauthorized},
+		{"manager cannot administer", "manager@example.com", "Manager", http.MethodGet, "/admin/users", http.StatusForbidden},
+		{"admin can administer", "admin@example.com", "Admin", http.MethodGet, "/admin/users", http.StatusOK},
+		{"admin can create projects", "admin@example.com", "Admin", http.MethodPost, "/projects", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, _, err := GenerateJWTToken(cfg, 0, 1, tt.email, tt.tokenRole)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("%s %s = %d, want %d", tt.method, tt.path, rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenRevocation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	cfg.Auth.JWTSecret = "test-secret"
+	signedOut := time.Now().Add(-time.Minute)
+	users := fakeUsers{
+		"employee@example.com":    {ID: 7, Email: "employee@example.com", Role: string(models.RoleEmployee)},
+		"signed-out@example.com":  {ID: 9, Email: "signed-out@example.com", Role: string(models.RoleEmployee), SessionsRevokedAt: &signedOut},
+		"deactivated@example.com": {ID: 11, Email: "deactivated@example.com", Role: string(models.RoleEmployee), DeactivatedAt: &signedOut},
+	}
+	revoked := fakeRevocations{}
+	router := gin.New()
+	router.GET("/me", AuthMiddlewareWithConfig(cfg), RejectRevokedTokens(revoked), LoadCurrentUser(users), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	get := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	token, _, err := GenerateJWTToken(cfg, 7, 1, "employee@example.com", "Employee")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code := get(token); code != http.StatusOK {
+		t.Fatalf("valid token = %d, want 200", code)
+	}
+	claims, err := ParseJWTToken(cfg, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revoked[claims["jti"].(string)] = true
+	if code := get(token); code != http.StatusUnauthorized {
+		t.Errorf("revoked token = %d, want 401", code)
+	}
+
+	old := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "signed-out@example.com", "jti": "old", "iat": signedOut.Add(-time.Hour).Unix(), "exp": time.Now().Add(time.Hour).Unix(),
+	})
+	oldToken, _ := old.SignedString([]byte(cfg.Auth.JWTSecret))
+	if code := get(oldToken); code != http.StatusUnauthorized {
+		t.Errorf("token issued before sign-out = %d, want 401", code)
+	}
+	fresh, _, _ := GenerateJWTToken(cfg, 9, 1, "signed-out@example.com", "Employee")
+	if code := get(fresh); code != http.StatusOK {
+		t.Errorf("token issued after sign-out = %d, want 200", code)
+	}
+	deactivated, _, _ := GenerateJWTToken(cfg, 11, 1, "deactivated@example.com", "Employee")
+	if code := get(deactivated); code != http.StatusUnauthorized {
+		t.Errorf("deactivated user = %d, want 401", code)
+	}
+
+	legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "employee@example.com", "exp": time.Now().Add(time.Hour).Unix()})
+	legacyToken, _ := legacy.SignedString([]byte(cfg.Auth.JWTSecret))
+	if code := get(legacyToken); code != http.StatusUnauthorized {
+		t.Errorf("token without jti = %d, want 401", code)
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	cfg.Auth.JWTSecret = "test-secret"
+	users := fakeUsers{"employee@example.com": {ID: 7, Email: "employee@example.com", Role: string(models.RoleEmployee)}}
+	keys := fakeKeys{"tfk_reports": {ID: 1, ServiceAccountID: 3, Scopes: "reports:read profiles:read"}}
+
+	router := gin.New()
+	api := router.Group("", AuthenticateAPIKey(keys), AuthMiddlewareWithConfig(cfg), RejectRevokedTokens(fakeRevocations{}), LoadCurrentUser(users))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	api.GET("/reports", RequirePermission(models.PermReportsRead), ok)
+	api.POST("/projects", RequirePermission(models.PermProjectsWrite), ok)
+	api.GET("/employee/:id/projects", RequireSelfOrPermission("id", models.PermAllocationsRead), ok)
+	api.GET("/employee/me", RequireUser(), ok)
+
+	employeeToken, _, err := GenerateJWTToken(cfg, 7, 1, "employee@example.com", "Employee")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		token  string
+		method string
+		path   string
+		want   int
+	}{
+		{"key with scope", "tfk_reports", http.MethodGet, "/reports", http.StatusOK},
+		{"key without scope", "tfk_reports", http.MethodPost, "/projects", http.StatusForbidden},
+		{"key is nobody's self", "tfk_reports", http.MethodGet, "/employee/3/projects", http.StatusForbidden},
+		{"key on a user-only route", "tfk_reports", http.MethodGet, "/employee/me", http.StatusForbidden},
+		{"unknown key", "tfk_unknown", http.MethodGet, "/reports", http.StatusUnauthorized},
+		{"user token still works", employeeToken, http.MethodGet, "/employee/7/projects", http.StatusOK},
+		{"user token without permission", employeeToken, http.MethodGet, "/reports", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("%s %s = %d, want %d", tt.method, tt.path, rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+type fakeAudit []*entities.AuditEvent
+
+func (f *fakeAudit) Record(ctx context.Context, event *entities.AuditEvent) error {
+	*f = append(*f, event)
+	return nil
+}
+
+func TestImpersonation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	cfg.Auth.JWTSecret = "test-secret"
+	employee := &entities.User{ID: 7, Email: "employee@example.com", Role: string(models.RoleEmployee)}
+	admin := &entities.User{ID: 9, Email: "admin@example.com", Role: string(models.RoleAdmin)}
+	users := fakeUsers{employee.Email: employee, admin.Email: admin}
+	audit := &fakeAudit{}
+
+	router := gin.New()
+	api := router.Group("", AuthMiddlewareWithConfig(cfg), RejectRevokedTokens(fakeRevocations{}), LoadCurrentUser(users), RecordImpersonation(audit))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	api.GET("/projects", RequirePermission(models.PermProjectsRead), ok)
+	api.POST("/projects", RequirePermission(models.PermProjectsWrite), ok)
+	api.PATCH("/employee/:id", RequireSelfOrPermission("id", models.PermEmployeesWrite), ok)
+
+	token, _, err := GenerateImpersonationToken(cfg, employee, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	do := func(method, path string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// The admin gets the employee's permissions, not their own
+	if code := do(http.MethodPost, "/projects"); code != http.StatusForbidden {
+		t.Errorf("POST /projects as employee = %d, want 403", code)
+	}
+	if code := do(http.MethodPatch, "/employee/7"); code != http.StatusOK {
+		t.Errorf("PATCH /employee/7 as employee = %d, want 200", code)
+	}
+	if code := do(http.MethodGet, "/projects"); code != http.StatusOK {
+		t.Errorf("GET /projects as employee = %d, want 200", code)
+	}
+
+	// Changes are recorded against the admin; reads are not
+	if len(*audit) != 2 {
+		t.Fatalf("recorded %d impersonated requests, want 2", len(*audit))
+	}
+	last := (*audit)[1]
+	if last.ActorID == nil || *last.ActorID != admin.ID || last.UserID == nil || *last.UserID != employee.ID {
+		t.Errorf("recorded actor %v and user %v, want %d acting as %d", last.ActorID, last.UserID, admin.ID, employee.ID)
+	}
+	if last.Detail != "PATCH /employee/7 -> 200" {
+		t.Errorf("recorded detail %q", last.Detail)
+	}
+}
+
+func TestTenantResolution(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	cfg.Auth.JWTSecret = "test-secret"
+	keys := fakeKeys{"tfk_other": {ServiceAccountID: 5, TenantID: 4, Scopes: string(models.ScopeReportsRead)}}
+
+	router := gin.New()
+	router.GET("/tenant", AuthenticateAPIKey(keys), AuthMiddlewareWithConfig(cfg), func(c *gin.Context) {
+		fromGin, _ := GetTenantID(c)
+		fromContext, _ := domain.TenantFromContext(c.Request.Context())
+		c.JSON(http.StatusOK, gin.H{"gin": fromGin, "context": fromContext})
+	})
+	get := func(token string) string {
+		req := httptest.NewRequest(http.MethodGet, "/tenant", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Body.String()
+	}
+
+	tenantToken, _, err := GenerateJWTToken(cfg, 7, 3, "employee@example.com", "Employee")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Tokens issued before tenants existed have no tid claim
+	legacyToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "employee@example.com",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}).SignedString([]byte(cfg.Auth.JWTSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{"token's tenant", tenantToken, `{"context":3,"gin":3}`},
+		{"token without tid", legacyToken, `{"context":1,"gin":1}`},
+		{"API key's tenant", "tfk_other", `{"context":4,"gin":4}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := get(tt.token); got != tt.want {
+				t.Errorf("tenant = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
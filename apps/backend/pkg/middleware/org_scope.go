@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/talent-fit/backend/internal/domain"
+)
+
+// OrgScoper resolves the org units a view covers, see domain.OrgUnitService
+type OrgScoper interface {
+	Scope(ctx context.Context, userID uint, filter string) ([]uint, error)
+}
+
+// ScopeToOrgUnit resolves the ?org_unit= filter, or the current user's default org units, for
+// GetOrgUnitIDs. It must run after LoadCurrentUser.
+func ScopeToOrgUnit(scoper OrgScoper) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := GetUserID(c)
+		ids, err := scoper.Scope(c.Request.Context(), userID, c.Query("org_unit"))
+		switch {
+		case errors.Is(err, domain.ErrInvalidOrgScope):
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, domain.ErrOrgUnitNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve org unit"})
+			return
+		}
+
+		c.Set("orgUnitIDs", ids)
+		c.Next()
+	}
+}
+
+// GetOrgUnitIDs returns the org unit IDs the request is scoped to, set by ScopeToOrgUnit; nil
+// means the whole organisation
+func GetOrgUnitIDs(c *gin.Context) []uint {
+	ids, _ := c.Get("orgUnitIDs")
+	scope, _ := ids.([]uint)
+	return scope
+}
@@ -1,11 +1,23 @@
 import { AlertCircle, User } from 'lucide-react';
-import { useEffect, useState } from 'react';
+import { FormEvent, useEffect, useState } from 'react';
 import { useAuth } from '../context/AuthContext';
 
 export function LoginPage() {
   const [error, setError] = useState('');
   const [isLoading, setIsLoading] = useState(false);
-  const { loginWithGoogleCredential } = useAuth();
+  const [devEmail, setDevEmail] = useState('');
+  const { loginWithGoogleCredential, loginAsDevUser } = useAuth();
+  const devLogin = (import.meta as any).env.VITE_DEV_LOGIN === 'true';
+
+  const handleDevLogin = async (e: FormEvent) => {
+    e.preventDefault();
+    if (!devEmail) return;
+    setError('');
+    setIsLoading(true);
+    const ok = await loginAsDevUser(devEmail);
+    if (!ok) setError('Dev sign-in failed. Is the backend running with DEV_LOGIN=true?');
+    setIsLoading(false);
+  };
 
   useEffect(() => {
     const scriptId = 'google-identity';
@@ -73,6 +85,25 @@ export function LoginPage() {
               data-logo_alignment="left"
               data-width="320"
             />
+            {devLogin && (
+              <form onSubmit={handleDevLogin} className="w-full pt-4 border-t border-gray-200 space-y-2">
+                <p className="text-xs text-gray-500 text-center">Development: sign in as a seeded user</p>
+                <input
+                  type="email"
+                  value={devEmail}
+                  onChange={(e) => setDevEmail(e.target.value)}
+                  placeholder="user@example.com"
+                  className="w-full px-3 py-2 border border-gray-300 rounded-lg text-sm"
+                />
+                <button
+                  type="submit"
+                  disabled={isLoading || !devEmail}
+                  className="w-full px-3 py-2 bg-gray-800 text-white rounded-lg text-sm disabled:opacity-50"
+                >
+                  Dev sign-in
+                </button>
+              </form>
+            )}
             {isLoading && (
               <div className="w-full flex items-center justify-center">
                 <div className="w-5 h-5 border-2 border-gray-400 border-t-transparent rounded-full animate-spin" />
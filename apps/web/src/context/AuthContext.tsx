@@ -23,6 +23,7 @@ interface AuthContextType {
   user: AuthUser | null;
   profileStatus: 'loading' | 'exists' | 'needs_creation' | 'error';
   loginWithGoogleCredential: (credential: string) => Promise<boolean>;
+  loginAsDevUser: (email: string) => Promise<boolean>;
   logout: () => void;
   updateProfile: (updates: Partial<AuthUser>) => void;
   refreshingToken: boolean;
@@ -56,6 +57,34 @@ export function AuthProvider({ children }: { children: React.ReactNode }) {
     }
   }, []);
 
+  // Start a UI session from a backend login response
+  const startSession = async (resp: LoginResponse) => {
+    const { token, email, name, userId, refreshToken } = resp;
+
+    // Persist JWT for API calls, and the refresh token that renews it
+    localStorage.setItem('authToken', token);
+    localStorage.setItem('refreshToken', refreshToken);
+
+    // Create a lightweight session for UI using response data directly
+    // Role will be determined from /me API later
+    const session: AuthUser = {
+      id: userId, // Use userId from response instead of parsing JWT
+      name: name || email,
+      email,
+      role: UserRole.EMPLOYEE, // Temporary role, will be updated from /me API
+      avatar: `https://ui-avatars.com/api/?name=${encodeURIComponent(name || email)}`,
+      accessToken: token,
+      tokenExpiry: Date.now() + TOKEN_LIFETIME_MS,
+      photoUrl: `https://ui-avatars.com/api/?name=${encodeURIComponent(name || email)}`,
+    };
+
+    setUser(session);
+    localStorage.setItem('user', JSON.stringify(session));
+
+    // Check profile status after successful login
+    await checkProfileStatus(session);
+  };
+
   // Real Google login using backend exchange. Expects Google ID token (credential).
   const loginWithGoogleCredential = async (credential: string): Promise<boolean> => {
     try {
@@ -64,30 +93,7 @@ export function AuthProvider({ children }: { children: React.ReactNode }) {
         '/auth/google/login',
         { credential }
       );
-
-      const { token, email, name, userId } = resp;
-
-      // Persist JWT for API calls
-      localStorage.setItem('authToken', token);
-
-      // Create a lightweight session for UI using response data directly
-      // Role will be determined from /me API later
-      const session: AuthUser = {
-        id: userId, // Use userId from response instead of parsing JWT
-        name: name || email,
-        email,
-        role: UserRole.EMPLOYEE, // Temporary role, will be updated from /me API
-        avatar: `https://ui-avatars.com/api/?name=${encodeURIComponent(name || email)}`,
-        accessToken: token,
-        tokenExpiry: Date.now() + TOKEN_LIFETIME_MS,
-        photoUrl: `https://ui-avatars.com/api/?name=${encodeURIComponent(name || email)}`,
-      };
-
-      setUser(session);
-      localStorage.setItem('user', JSON.stringify(session));
-      
-      // Check profile status after successful login
-      await checkProfileStatus(session);
+      await startSession(resp);
       return true;
     } catch (error: any) {
       console.error('Google login failed:', error);
@@ -101,10 +107,28 @@ export function AuthProvider({ children }: { children: React.ReactNode }) {
     }
   };
 
+  // Local development only: sign in as a seeded user when the backend runs with DEV_LOGIN=true
+  const loginAsDevUser = async (email: string): Promise<boolean> => {
+    try {
+      const resp = await apiService.post<LoginResponse>('/auth/dev/login', { email });
+      await startSession(resp);
+      return true;
+    } catch (error: any) {
+      console.error('Dev login failed:', error);
+      return false;
+    }
+  };
+
   const logout = () => {
+    // Revoke the session server-side; the local session is cleared either way
+    const token = localStorage.getItem('authToken');
+    apiService
+      .post('/auth/logout', { refreshToken: localStorage.getItem('refreshToken') || '' }, token ? { headers: { Authorization: `Bearer ${token}` } } : undefined)
+      .catch(() => {});
     setUser(null);
     localStorage.removeItem('user');
     localStorage.removeItem('authToken');
+    localStorage.removeItem('refreshToken');
     localStorage.removeItem('employeeProfile');
     // Navigate to home page
     window.location.href = '/';
@@ -135,9 +159,9 @@ export function AuthProvider({ children }: { children: React.ReactNode }) {
       
       // Extract role from the /me API response and update user session
       if (response && response.user && response.user.role) {
-        // Handle case-insensitive role comparison
+        // Handle case-insensitive role comparison; admins get the manager portal
         const apiRoleString = response.user.role.toLowerCase();
-        const apiRole = apiRoleString === 'manager' ? UserRole.MANAGER : UserRole.EMPLOYEE;
+        const apiRole = apiRoleString === 'manager' || apiRoleString === 'admin' ? UserRole.MANAGER : UserRole.EMPLOYEE;
         
         if (apiRole !== user.role) {
           const updatedUser = { ...user, role: apiRole };
@@ -178,6 +202,7 @@ export function AuthProvider({ children }: { children: React.ReactNode }) {
       user,
       profileStatus,
       loginWithGoogleCredential,
+      loginAsDevUser,
       logout,
       updateProfile,
       refreshingToken,
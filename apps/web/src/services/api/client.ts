@@ -31,10 +31,45 @@ apiClient.interceptors.request.use(
   }
 );
 
+// Access tokens are short-lived; one refresh is shared by all requests that fail meanwhile
+const REFRESH_URL = '/auth/refresh-token';
+let refreshing: Promise<string | null> | null = null;
+
+const refreshAccessToken = (): Promise<string | null> => {
+  const refreshToken = localStorage.getItem('refreshToken');
+  if (!refreshToken) return Promise.resolve(null);
+  if (!refreshing) {
+    refreshing = apiClient
+      .post<{ token: string; refreshToken: string }>(REFRESH_URL, { refreshToken })
+      .then(({ data }) => {
+        localStorage.setItem('authToken', data.token);
+        localStorage.setItem('refreshToken', data.refreshToken);
+        return data.token;
+      })
+      .catch(() => {
+        localStorage.removeItem('refreshToken');
+        return null;
+      })
+      .finally(() => {
+        refreshing = null;
+      });
+  }
+  return refreshing;
+};
+
 // Response interceptor - handle common errors
 apiClient.interceptors.response.use(
   (response: AxiosResponse) => response,
-  (error) => {
+  async (error) => {
+    const original = error.config as (AxiosRequestConfig & { _retried?: boolean }) | undefined;
+    if (error.response?.status === 401 && original && !original._retried && original.url !== REFRESH_URL) {
+      original._retried = true;
+      // The request interceptor picks up the new token
+      if (await refreshAccessToken()) {
+        return apiClient(original);
+      }
+    }
+
     if (error.response) {
       const { status, data } = error.response;
       if (status === 500 && data?.error?.includes('record not found')) {
@@ -43,6 +78,8 @@ apiClient.interceptors.response.use(
       }
       switch (status) {
         case 401:
+          // The request that triggered the refresh reports the expired session
+          if (original?.url === REFRESH_URL) break;
           localStorage.removeItem('authToken');
           toast.error('Session expired. Please login again.');
           break;
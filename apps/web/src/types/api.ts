@@ -6,6 +6,8 @@ export interface LoginResponse {
   name: string;
   email: string;
   userId: number;
+  refreshToken: string;
+  expiresAt: string;
 }
 
 export interface BackendUser {
@@ -3,7 +3,7 @@ export interface User {
   first_name: string;
   last_name: string;
   email: string;
-  role: 'Employee' | 'Manager';
+  role: 'Employee' | 'Manager' | 'Admin';
   created_at: string;
   updated_at: string;
   name?: string; // Computed full name